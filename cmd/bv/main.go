@@ -86,6 +86,8 @@ func main() {
 	suggestType := flag.String("suggest-type", "", "Filter suggestions by type: duplicate, dependency, label, cycle")
 	suggestConfidence := flag.Float64("suggest-confidence", 0.0, "Minimum confidence for suggestions (0.0-1.0)")
 	suggestBead := flag.String("suggest-bead", "", "Filter suggestions for specific bead ID")
+	suggestMethod := flag.String("suggest-method", "jaccard", "Duplicate detection method: jaccard (keyword overlap) or semantic (embedding similarity)")
+	suggestThreshold := flag.Float64("suggest-threshold", 0, "Similarity threshold for --suggest-method semantic (0 = calibrate from the corpus)")
 	// Graph export (bv-136)
 	robotGraph := flag.Bool("robot-graph", false, "Output dependency graph as JSON/DOT/Mermaid for AI agents")
 	graphFormat := flag.String("graph-format", "json", "Graph output format: json, dot, mermaid")
@@ -108,13 +110,25 @@ func main() {
 	recipeName := flag.StringP("recipe", "r", "", "Apply named recipe (e.g., triage, actionable, high-impact)")
 	semanticQuery := flag.String("search", "", "Semantic search query (vector-based; builds/updates index on first run)")
 	robotSearch := flag.Bool("robot-search", false, "Output semantic search results as JSON for AI agents (use with --search)")
-	searchLimit := flag.Int("search-limit", 10, "Max results for --search/--robot-search")
+	searchLimit := flag.Int("search-limit", 10, "Max results for --search/--robot-search/--robot-similar")
+	robotSimilar := flag.String("robot-similar", "", "Output the issues most similar to <id> (semantic nearest neighbours) as JSON")
+	robotSearchAlerts := flag.Bool("robot-search-alerts", false, "Evaluate saved searches (.bv/searches.yaml) and output issues new to their top-N as JSON")
+	searchAlertsSince := flag.String("search-alerts-since", "24h", "Alert window for --robot-search-alerts (duration like 24h, relative like 7d, or date)")
 	searchMode := flag.String("search-mode", "", "Search ranking mode: text or hybrid (default: BV_SEARCH_MODE or text)")
 	searchPreset := flag.String("search-preset", "", "Hybrid preset name (default: BV_SEARCH_PRESET or default)")
 	searchWeights := flag.String("search-weights", "", "Hybrid weights JSON (overrides preset; keys: text,pagerank,status,impact,priority,recency)")
+	searchHistory := flag.String("search-history", "", "Search commit messages and past issue text from git history, including text since edited or compacted away")
+	searchFilter := flag.String("search-filter", "", "Refine --search results by facet: status=open,label=api,priority=P1 (repeat a field to OR values)")
+	searchAggregate := flag.Bool("search-aggregate", false, "With --search, output only facet counts (status/type/priority/label/assignee/repo) over the matches")
+	searchAccept := flag.String("search-accept", "", "Record <id> as the accepted result for --search/--robot-search (click feedback for --robot-search-learn)")
+	robotSearchLearn := flag.Bool("robot-search-learn", false, "Fit the 'learned' hybrid preset from search click feedback and output an NDCG report as JSON")
 	diffSince := flag.String("diff-since", "", "Show changes since historical point (commit SHA, branch, tag, or date)")
+	releaseNotes := flag.String("release-notes", "", "Generate release notes for beads closed between two refs (e.g., v1.2.0..v1.3.0; a single ref means <ref> to the current beads)")
+	releaseNotesFormat := flag.String("release-notes-format", "markdown", "Release notes format: markdown, json, or changelog (Keep a Changelog)")
+	releaseNotesGroup := flag.String("release-notes-group", "type", "Group release notes sections by type or label")
 	asOf := flag.String("as-of", "", "View state at point in time (commit SHA, branch, tag, or date)")
 	forceFullAnalysis := flag.Bool("force-full-analysis", false, "Compute all metrics regardless of graph size (may be slow for large graphs)")
+	streamLoad := flag.Bool("stream-load", false, "Stream beads data loading only graph fields (skips descriptions/comments) to bound memory on very large archives")
 	profileStartup := flag.Bool("profile-startup", false, "Output detailed startup timing profile for diagnostics")
 	profileJSON := flag.Bool("profile-json", false, "Output profile in JSON format (use with --profile-startup)")
 	noHooks := flag.Bool("no-hooks", false, "Skip running hooks during export")
@@ -128,6 +142,7 @@ func main() {
 	beadHistory := flag.String("bead-history", "", "Show history for specific bead ID")
 	historySince := flag.String("history-since", "", "Limit history to commits after this date/ref (e.g., '30 days ago', '2024-01-01')")
 	historyLimit := flag.Int("history-limit", 500, "Max commits to analyze (0 = unlimited)")
+	historyDB := flag.Bool("history-db", false, "Keep git history correlations in .bv/history.db, updated incrementally (or BV_HISTORY_DB=1)")
 	minConfidence := flag.Float64("min-confidence", 0.0, "Filter correlations by minimum confidence (0.0-1.0)")
 	// Correlation audit flags (bv-e1u6)
 	robotExplainCorrelation := flag.String("robot-explain-correlation", "", "Explain why a commit is linked to a bead (format: SHA:beadID)")
@@ -135,14 +150,29 @@ func main() {
 	robotRejectCorrelation := flag.String("robot-reject-correlation", "", "Reject an incorrect correlation (format: SHA:beadID)")
 	correlationFeedbackBy := flag.String("correlation-by", "", "Agent/user identifier for correlation feedback")
 	correlationFeedbackReason := flag.String("correlation-reason", "", "Reason for correlation feedback")
-	robotCorrelationStats := flag.Bool("robot-correlation-stats", false, "Output correlation feedback statistics as JSON")
+	robotCorrelationStats := flag.Bool("robot-correlation-stats", false, "Output correlation feedback statistics, learned confidence model and calibration curves as JSON")
+	robotResetCorrelationModel := flag.Bool("robot-reset-correlation-model", false, "Discard the learned correlation confidence model and retrain only from new feedback")
+	robotExportCorrelationModel := flag.Bool("robot-export-correlation-model", false, "Output the learned correlation confidence model weights as JSON")
 	// Orphan commit detection flags (bv-jdop)
 	robotOrphans := flag.Bool("robot-orphans", false, "Output orphan commit candidates (commits that should be linked but aren't) as JSON")
 	orphansMinScore := flag.Int("orphans-min-score", 30, "Minimum suspicion score for orphan candidates (0-100)")
+	// Delivery metrics flags
+	robotDeliveryMetrics := flag.Bool("robot-delivery-metrics", false, "Output DORA-style delivery metrics (lead time, deployment frequency, change failure rate, time to restore) as JSON")
+	deliveryWeeks := flag.Int("delivery-weeks", 12, "Weeks of delivery metrics to report (use with --robot-delivery-metrics)")
+	deliveryTagPattern := flag.String("delivery-tag-pattern", correlation.DefaultReleaseTagPattern, "Glob for release tags counted as deployments (use with --robot-delivery-metrics)")
+	deliveryFailureDays := flag.Int("delivery-failure-days", 14, "Days after close a discovered-from bug counts as a failed change (use with --robot-delivery-metrics)")
 	// File-bead index flags (bv-hmib)
 	robotFileBeads := flag.String("robot-file-beads", "", "Output beads that touched a file path as JSON")
 	fileBeadsLimit := flag.Int("file-beads-limit", 20, "Max closed beads to show (use with --robot-file-beads)")
+	robotBlame := flag.String("robot-blame", "", "Output the beads that explain each current line of a file as JSON (path[:start-end])")
 	fileHotspots := flag.Bool("robot-file-hotspots", false, "Output files touched by most beads as JSON")
+	robotExperts := flag.Bool("robot-experts", false, "Output who knows a label or path best, from bead history, as JSON (use with --label or --file)")
+	expertsFile := flag.String("file", "", "File or directory to find experts for (use with --robot-experts)")
+	expertsLimit := flag.Int("experts-limit", 5, "Max experts per label or directory (use with --robot-experts)")
+	robotBusFactor := flag.Bool("robot-bus-factor", false, "Output labels, epics and hot files dominated by one contributor, with their open work, as JSON")
+	busFactorThreshold := flag.Float64("bus-factor-threshold", 0.5, "Top contributor commit share above which an area is a silo (use with --robot-bus-factor)")
+	robotChurn := flag.Bool("robot-churn", false, "Output reopen rates by label/type/assignee, churny beads, reopen-prone files and the reopen trend as JSON")
+	churnWindow := flag.Int("churn-window", 30, "Days in the recent reopen-rate window (use with --robot-churn, --robot-alerts)")
 	hotspotsLimit := flag.Int("hotspots-limit", 10, "Max hotspots to show (use with --robot-file-hotspots)")
 	// Impact analysis flag (bv-19pq)
 	robotImpact := flag.String("robot-impact", "", "Analyze impact of modifying files (comma-separated paths)")
@@ -280,10 +310,17 @@ func main() {
 		*robotSuggest ||
 		*robotGraph ||
 		*robotSearch ||
+		*robotSimilar != "" ||
+		*robotSearchAlerts ||
+		*robotSearchLearn ||
 		*robotDriftCheck ||
 		*robotHistory ||
 		*robotFileBeads != "" ||
+		*robotBlame != "" ||
 		*fileHotspots ||
+		*robotExperts ||
+		*robotBusFactor ||
+		*robotChurn ||
 		*robotImpact != "" ||
 		*robotFileRelations != "" ||
 		*robotRelatedWork != "" ||
@@ -298,6 +335,7 @@ func main() {
 		*robotByAssignee != "" ||
 		*robotCapacity ||
 		*robotDocs != "" ||
+		(*releaseNotes != "" && *releaseNotesFormat == "json") ||
 		// When stdout is non-TTY, --diff-since auto-enables JSON output. Mark this
 		// as robot mode early so parsers keep stdout JSON clean.
 		(*diffSince != "" && !stdoutIsTTY)
@@ -308,6 +346,10 @@ func main() {
 		envRobot = true
 	}
 
+	// History consumers (robot commands and the TUI) read through the
+	// persistent history database when requested.
+	useHistoryDB = *historyDB || correlation.HistoryStoreEnabled()
+
 	// Structured output format for --robot-* commands.
 	robotOutputFormat = resolveRobotOutputFormat(*outputFormat)
 	robotToonEncodeOptions = resolveToonEncodeOptionsFromEnv()
@@ -391,6 +433,12 @@ func main() {
 		fmt.Println("      - --search-preset=default|bug-hunting|sprint-planning|impact-first|text-only")
 		fmt.Println("      - --search-weights='{\"text\":0.4,\"pagerank\":0.2,\"status\":0.15,\"impact\":0.1,\"priority\":0.1,\"recency\":0.05}'")
 		fmt.Println("")
+		fmt.Println("  --stream-load")
+		fmt.Println("      Stream the beads file decoding only graph fields (ids, status, labels, deps).")
+		fmt.Println("      Descriptions and comments are skipped, bounding memory on 100k+ issue archives.")
+		fmt.Println("      Intended for --robot-triage/--robot-next/--robot-plan/--robot-insights.")
+		fmt.Println("      Example: bv --robot-triage --stream-load")
+		fmt.Println("")
 		fmt.Println("  --emit-script [--script-limit=N]")
 		fmt.Println("      Emits a shell script for top-N recommendations (default: 5).")
 		fmt.Println("      Includes hash/config header for deterministic ordering.")
@@ -404,16 +452,29 @@ func main() {
 		fmt.Println("      Tracks which code changes relate to which beads via git history analysis.")
 		fmt.Println("      Key sections:")
 		fmt.Println("      - stats: Summary (total beads, beads with commits, avg cycle time)")
-		fmt.Println("      - histories: Per-bead data (events, commits, milestones, cycle_time)")
+		fmt.Println("      - histories: Per-bead data (events, commits, pull_requests, milestones, cycle_time)")
 		fmt.Println("      - commit_index: Reverse lookup from commit SHA to bead IDs")
 		fmt.Println("      Flags:")
 		fmt.Println("      - --bead-history <id>: Filter to single bead")
 		fmt.Println("      - --history-since <ref>: Limit to recent commits")
 		fmt.Println("      - --history-limit <n>: Max commits to analyze (default: 500)")
+		fmt.Println("      - --history-db: Read from .bv/history.db, indexing only commits added")
+		fmt.Println("        since the last run (makes --history-limit 0 cheap on large repos)")
 		fmt.Println("      - --min-confidence <0.0-1.0>: Filter by minimum confidence score")
 		fmt.Println("      Example: bv --robot-history --history-since '30 days ago'")
 		fmt.Println("      Example: bv --robot-history --min-confidence 0.7")
 		fmt.Println("")
+		fmt.Println("  --robot-delivery-metrics")
+		fmt.Println("      Outputs DORA-style delivery metrics as weekly series (oldest first).")
+		fmt.Println("      - Deployment frequency: release tags per week (--delivery-tag-pattern, default v*)")
+		fmt.Println("      - Lead time: bead created -> first commit -> first release containing it")
+		fmt.Println("        (created -> closed when no release tags match)")
+		fmt.Println("      - Change failure rate: closed beads a bug was discovered-from within")
+		fmt.Println("        --delivery-failure-days (default 14)")
+		fmt.Println("      - Time to restore: P0 bugs, created -> closed")
+		fmt.Println("      Key sections: summary, weeks, releases")
+		fmt.Println("      Example: bv --robot-delivery-metrics --delivery-weeks 26 --delivery-tag-pattern 'release-*'")
+		fmt.Println("")
 		fmt.Println("  --robot-file-beads <path>")
 		fmt.Println("      Outputs beads that have touched a file path as JSON.")
 		fmt.Println("      Answers: 'What beads have touched this file, and why?'")
@@ -428,6 +489,17 @@ func main() {
 		fmt.Println("      Example: bv --robot-file-beads pkg/auth/token.go")
 		fmt.Println("      Example: bv --robot-file-beads pkg/auth")
 		fmt.Println("")
+		fmt.Println("  --robot-blame <path>[:start-end]")
+		fmt.Println("      Runs git blame and maps each surviving line's commit to beads.")
+		fmt.Println("      Answers: 'Which bead explains these lines?'")
+		fmt.Println("      Key sections:")
+		fmt.Println("      - ranges: Consecutive lines from one commit, with sha, author, summary,")
+		fmt.Println("        and beads [{id, title, status, confidence}] (empty if unexplained)")
+		fmt.Println("      - beads: Beads ranked by the number of lines they explain")
+		fmt.Println("      - stats: total_lines, attributed_lines, uncommitted_lines, attribution_ratio")
+		fmt.Println("      Commits older than --history-limit are not correlated.")
+		fmt.Println("      Example: bv --robot-blame pkg/auth/token.go:40-80")
+		fmt.Println("")
 		fmt.Println("  --robot-file-hotspots")
 		fmt.Println("      Outputs files touched by the most beads as JSON.")
 		fmt.Println("      Identifies potential conflict zones or high-churn areas.")
@@ -438,6 +510,43 @@ func main() {
 		fmt.Println("      - --hotspots-limit <n>: Max hotspots to show (default: 10)")
 		fmt.Println("      Example: bv --robot-file-hotspots")
 		fmt.Println("")
+		fmt.Println("  --robot-experts [--label <label> | --file <path>]")
+		fmt.Println("      Outputs who knows a label or a directory best, from bead history.")
+		fmt.Println("      Answers: 'Who should I ask about this?'")
+		fmt.Println("      Commits count toward the bead's labels and every directory they touched,")
+		fmt.Println("      weighted by correlation confidence and halving every 90 days.")
+		fmt.Println("      Key sections:")
+		fmt.Println("      - experts: [{author, email, score, share, commits, beads, last_active}]")
+		fmt.Println("      - directory: Directory a --file path resolved to")
+		fmt.Println("      - labels / directories: Full map when neither --label nor --file is given")
+		fmt.Println("      Flags:")
+		fmt.Println("      - --experts-limit <n>: Max experts per label or directory (default: 5)")
+		fmt.Println("      Example: bv --robot-experts --label api")
+		fmt.Println("      Example: bv --robot-experts --file pkg/auth/token.go")
+		fmt.Println("")
+		fmt.Println("  --robot-bus-factor")
+		fmt.Println("      Finds knowledge silos: labels, epics and hot files where one contributor")
+		fmt.Println("      authored most of the linked commits, weighted by open work in the area.")
+		fmt.Println("      Key sections:")
+		fmt.Println("      - areas: [{kind, key, top_author, top_share, bus_factor, open_count,")
+		fmt.Println("        critical_open, open_issues, risk}], riskiest first")
+		fmt.Println("      - stats: areas_analyzed, silos, silos_with_open_work, by_kind")
+		fmt.Println("      Flags:")
+		fmt.Println("      - --bus-factor-threshold <0.0-1.0>: Top share that makes a silo (default: 0.5)")
+		fmt.Println("      - --hotspots-limit <n>: Hot files to examine")
+		fmt.Println("      Example: bv --robot-bus-factor")
+		fmt.Println("")
+		fmt.Println("  --robot-churn")
+		fmt.Println("      Reopen and churn analytics from bead lifecycle history.")
+		fmt.Println("      Key sections:")
+		fmt.Println("      - overall, by_label, by_type, by_assignee: {closed, reopened, reopens, rate}")
+		fmt.Println("      - beads: reopened or churny beads {reopens, priority_flips, title_rewrites, hours_lost}")
+		fmt.Println("      - files: files touched by reopened beads {beads, reopened_beads, rate, lift}")
+		fmt.Println("      - trend: recent_rate vs baseline_rate (also a reopen_spike alert in --robot-alerts)")
+		fmt.Println("      Flags:")
+		fmt.Println("      - --churn-window <days>: Recent window for the trend (default: 30)")
+		fmt.Println("      Example: bv --robot-churn --churn-window 14")
+		fmt.Println("")
 		fmt.Println("  --robot-impact <files>")
 		fmt.Println("      Analyzes impact of modifying files - what beads might be affected?")
 		fmt.Println("      Critical for agents: check before making changes to avoid conflicts.")
@@ -576,6 +685,14 @@ func main() {
 		fmt.Println("      - resolved_cycles: Circular dependencies fixed")
 		fmt.Println("      - summary.health_trend: 'improving', 'degrading', or 'stable'")
 		fmt.Println("")
+		fmt.Println("  --release-notes <from>..<to>")
+		fmt.Println("      Release notes for beads closed between two refs, with their correlated")
+		fmt.Println("      commits and contributors. Also lists commits with no bead (with orphan")
+		fmt.Println("      detector guesses) and beads closed without any commit in the range.")
+		fmt.Println("      --release-notes-format markdown|json|changelog (Keep a Changelog)")
+		fmt.Println("      --release-notes-group type|label (default: type)")
+		fmt.Println("      Example: bv --release-notes v1.2.0..v1.3.0 --release-notes-format changelog")
+		fmt.Println("")
 		fmt.Println("  --as-of <commit|date>")
 		fmt.Println("      View issue state at a point in time (works with all robot commands).")
 		fmt.Println("      Useful for historical analysis without modifying the working tree.")
@@ -1289,6 +1406,8 @@ func main() {
 	var beadsPath string
 	var workspaceInfo *workspace.LoadSummary
 	var asOfResolved string // Resolved commit SHA when using --as-of (for robot output metadata)
+	var snapshotCache *ui.SnapshotCache
+	var snapshotCacheErr error
 
 	if *asOf != "" {
 		// Time-travel mode: load historical issues from git
@@ -1348,7 +1467,25 @@ func main() {
 	} else {
 		// Load from single repo (original behavior)
 		var err error
-		issues, err = datasource.LoadIssues("")
+		// Snapshot cache: reuse the issues persisted by the last TUI session when
+		// the source files are unchanged, skipping the parse entirely. The TUI
+		// re-validates them against the source in the background.
+		if !robotMode && !*streamLoad && activeRecipe == nil && *repoFilter == "" && *labelScope == "" && ui.SnapshotCacheEnabled() {
+			if cacheBeadsDir, dirErr := loader.GetBeadsDir(""); dirErr == nil {
+				if cacheBeadsPath, pathErr := loader.FindJSONLPath(cacheBeadsDir); pathErr == nil {
+					snapshotCache, snapshotCacheErr = ui.OpenSnapshotCache(cacheBeadsPath, ui.SnapshotConfigHash(nil))
+				}
+			}
+		}
+		if snapshotCache != nil {
+			issues = snapshotCache.Issues()
+		} else if *streamLoad {
+			// Graph-only load for very large archives: long-form text and
+			// comments are never decoded, which keeps --robot-triage bounded.
+			issues, _, err = datasource.LoadIssuesSlim("")
+		} else {
+			issues, err = datasource.LoadIssues("")
+		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
 			fmt.Fprintln(os.Stderr, "Make sure you are in a project initialized with 'bd init'.")
@@ -1412,64 +1549,273 @@ func main() {
 	// dataHash uses pre-filtered issues for stability.
 	if activeRecipe != nil && (*robotTriage || *robotNext || *robotTriageByTrack || *robotTriageByLabel || *robotPriority || *robotInsights || *robotPlan) {
 		issues = applyRecipeFilters(issues, activeRecipe)
-		issues = applyRecipeSort(issues, activeRecipe)
+		issues = applyRecipeSort(issues, activeRecipe, customEnumValues(beadsPath))
 	}
 
-	// Handle semantic search CLI (bv-9gf.3)
-	if *robotSearch && *semanticQuery == "" {
-		fmt.Fprintln(os.Stderr, "Error: --robot-search requires --search \"query\"")
-		os.Exit(1)
-	}
-	if *semanticQuery != "" {
-		embedCfg := search.EmbeddingConfigFromEnv()
-		searchCfg, err := search.SearchConfigFromEnv()
+	// Handle --robot-similar: nearest neighbours of one issue
+	if *robotSimilar != "" {
+		var target *model.Issue
+		for i := range issuesForSearch {
+			if issuesForSearch[i].ID == *robotSimilar {
+				target = &issuesForSearch[i]
+				break
+			}
+		}
+		if target == nil {
+			fmt.Fprintf(os.Stderr, "Error: issue %q not found\n", *robotSimilar)
+			os.Exit(1)
+		}
+		si, err := openSemanticIndex(issuesForSearch, nil)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-		searchCfg, err = applySearchConfigOverrides(searchCfg, *searchMode, *searchPreset, *searchWeights)
+		limit := *searchLimit
+		if limit <= 0 {
+			limit = 10
+		}
+		similar, err := search.SimilarIssues(si.Index, si.Chunks, target.ID, limit)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
+		titleByID := make(map[string]string, len(issuesForSearch))
+		for _, iss := range issuesForSearch {
+			titleByID[iss.ID] = iss.Title
+		}
+		out := robotSimilarOutput{
+			GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+			DataHash:    dataHash,
+			IssueID:     target.ID,
+			Title:       target.Title,
+			Provider:    si.Config.Provider,
+			Model:       si.Config.Model,
+			Dim:         si.Embedder.Dim(),
+			IndexPath:   si.Path,
+			Index:       si.Stats,
+			Limit:       limit,
+			Results:     make([]robotSearchResult, 0, len(similar)),
+			UsageHints: []string{
+				"jq '.results[] | {issue_id, score, title}' - Neighbours by similarity",
+				"jq '.results[] | select(.score >= 0.85)' - Likely duplicates",
+				"--suggest-type duplicate --suggest-method semantic - Duplicate groups across all issues",
+			},
+		}
+		for _, r := range similar {
+			out.Results = append(out.Results, robotSearchResult{
+				IssueID: r.IssueID,
+				Score:   r.Score,
+				Title:   titleByID[r.IssueID],
+			})
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(out); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding robot-similar: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
 
-		embedder, err := search.NewEmbedderFromConfig(embedCfg)
+	// Handle --robot-search-alerts: saved searches whose top-N gained issues
+	if *robotSearchAlerts {
+		now := time.Now()
+		since, err := parseSearchAlertsSince(*searchAlertsSince, now)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-
 		projectDir, err := os.Getwd()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-		indexPath := search.DefaultIndexPath(projectDir, embedCfg)
-		idx, loaded, err := search.LoadOrNewVectorIndex(indexPath, embedder.Dim())
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		results, err := search.CheckSavedSearches(ctx, projectDir, issuesForSearch, now, since)
+		cancel()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-
-		docs := search.DocumentsFromIssues(issuesForSearch)
-		if !*robotSearch && !loaded {
-			fmt.Fprintf(os.Stderr, "Building semantic index (%d issues)...\n", len(docs))
+		out := newRobotSearchAlertsOutput(results, dataHash, projectDir, now, since)
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(out); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding robot-search-alerts: %v\n", err)
+			os.Exit(1)
 		}
+		os.Exit(0)
+	}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
+	// Handle --robot-search-learn: fit the learned preset from click feedback
+	if *robotSearchLearn {
+		searchCfg, err := search.SearchConfigFromEnv()
+		if err == nil {
+			searchCfg, err = applySearchConfigOverrides(searchCfg, *searchMode, *searchPreset, *searchWeights)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		si, err := openSemanticIndex(issuesForSearch, nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		baseline, baselinePreset, err := resolveSearchWeights(searchCfg, si.ProjectDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		events, malformed, err := search.LoadSearchFeedback(si.ProjectDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading search feedback: %v\n", err)
+			os.Exit(1)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		learned, err := search.LearnWeights(ctx, search.NewSearchEngine(si, issuesForSearch), events, baseline, baselinePreset, search.DefaultLearnConfig(), time.Now())
+		cancel()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		learned.Skipped += malformed
+		if err := learned.Save(si.ProjectDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving learned weights: %v\n", err)
+			os.Exit(1)
+		}
+		out := robotSearchLearnOutput{
+			GeneratedAt:    time.Now().UTC().Format(time.RFC3339),
+			DataHash:       dataHash,
+			FeedbackPath:   search.SearchFeedbackPath(si.ProjectDir),
+			WeightsPath:    search.LearnedWeightsPath(si.ProjectDir),
+			LearnedWeights: learned,
+			NDCGCutoff:     search.LearnedNDCGCutoff,
+			UsageHints: []string{
+				"jq '{before: .ndcg_before, after: .ndcg_after}' - Ranking quality on the logged queries",
+				"jq '.weights' - Fitted hybrid weights",
+				"--search-mode hybrid --search-preset learned - Search with the learned weights",
+				"--search \"query\" --search-accept <id> - Log more feedback",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(out); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding robot-search-learn: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
 
-		syncStats, err := search.SyncVectorIndex(ctx, idx, embedder, docs, 64)
+	// Handle --search-history: commit messages and historical issue text
+	if *searchHistory != "" {
+		cwd, err := os.Getwd()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error building semantic index: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error getting working directory: %v\n", err)
 			os.Exit(1)
 		}
-		if !loaded || syncStats.Changed() {
-			if err := idx.Save(indexPath); err != nil {
-				fmt.Fprintf(os.Stderr, "Error saving semantic index: %v\n", err)
+		opts := search.HistoryOptions{MaxCommits: *historyLimit, MaxRevisions: *historyLimit}
+		corpus, stats, err := search.OpenHistoryCorpus(cwd, issuesForSearch, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error indexing git history: %v\n", err)
+			os.Exit(1)
+		}
+		limit := *searchLimit
+		if limit <= 0 {
+			limit = 10
+		}
+		hits := search.SearchHistory(corpus, *searchHistory, issuesForSearch, limit)
+
+		if *robotSearch {
+			out := robotSearchHistoryOutput{
+				GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+				DataHash:    dataHash,
+				Query:       *searchHistory,
+				IndexPath:   search.HistoryCorpusPath(cwd),
+				Index:       stats,
+				Docs:        len(corpus.Docs),
+				Limit:       limit,
+				Results:     hits,
+				UsageHints: []string{
+					"jq '.results[] | {sha: .commit_sha, beads: .issue_ids, snippet: .match.snippet}' - Where it was discussed",
+					"jq '.results[] | select(.current == false)' - Text since edited or compacted away",
+					"git show <commit_sha> - Inspect the commit",
+				},
+			}
+			if out.Results == nil {
+				out.Results = []search.HistoryHit{}
+			}
+			encoder := newRobotEncoder(os.Stdout)
+			if err := encoder.Encode(out); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding search-history: %v\n", err)
 				os.Exit(1)
 			}
+			os.Exit(0)
+		}
+
+		if stats.Commits > 0 || stats.Revisions > 0 {
+			fmt.Fprintf(os.Stderr, "History index: +%d commits, +%d beads revisions (%d docs) → %s\n", stats.Commits, stats.Revisions, len(corpus.Docs), search.HistoryCorpusPath(cwd))
+		}
+		hlOpen, hlClose := "", ""
+		if stdoutIsTTY {
+			hlOpen, hlClose = "\x1b[1m", "\x1b[0m"
+		}
+		for _, h := range hits {
+			beads := strings.Join(h.IssueIDs, ",")
+			if beads == "" {
+				beads = "-"
+			}
+			fmt.Printf("%.4f\t%.12s\t%s\t%s\t%s\n", h.Score, h.CommitSHA, h.Timestamp.Format("2006-01-02"), beads, h.Title)
+			label := h.Match.Label()
+			if !h.Current {
+				label += " (since changed)"
+			}
+			fmt.Printf("\t  ↳ %s: %s\n", label, h.Match.Highlighted(hlOpen, hlClose))
+		}
+		os.Exit(0)
+	}
+
+	// Handle semantic search CLI (bv-9gf.3)
+	if *robotSearch && *semanticQuery == "" {
+		fmt.Fprintln(os.Stderr, "Error: --robot-search requires --search \"query\"")
+		os.Exit(1)
+	}
+	if *searchAccept != "" && *semanticQuery == "" {
+		fmt.Fprintln(os.Stderr, "Error: --search-accept requires --search \"query\"")
+		os.Exit(1)
+	}
+	if (*searchFilter != "" || *searchAggregate) && *semanticQuery == "" {
+		fmt.Fprintln(os.Stderr, "Error: --search-filter and --search-aggregate require --search \"query\"")
+		os.Exit(1)
+	}
+	if *semanticQuery != "" {
+		searchCfg, err := search.SearchConfigFromEnv()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		searchCfg, err = applySearchConfigOverrides(searchCfg, *searchMode, *searchPreset, *searchWeights)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		facetFilter, err := search.ParseFacetFilter(*searchFilter)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --search-filter: %v\n", err)
+			os.Exit(1)
+		}
+
+		var progress io.Writer
+		if !*robotSearch {
+			progress = os.Stderr
+		}
+		si, err := openSemanticIndex(issuesForSearch, progress)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
+		embedCfg, embedder, idx, chunks := si.Config, si.Embedder, si.Index, si.Chunks
+		indexPath, loaded, syncStats := si.Path, si.Loaded, si.Stats
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
 
 		qvecs, err := embedder.Embed(ctx, []string{*semanticQuery})
 		if err != nil || len(qvecs) != 1 {
@@ -1484,16 +1830,73 @@ func main() {
 		if limit <= 0 {
 			limit = 10
 		}
-		fetchLimit := limit
-		if searchCfg.Mode == search.SearchModeHybrid {
-			fetchLimit = search.HybridCandidateLimit(limit, len(issuesForSearch), *semanticQuery)
+		// Both rankers over-fetch so reciprocal rank fusion sees items that
+		// only one of them ranks highly.
+		fetchLimit := search.HybridCandidateLimit(limit, len(issuesForSearch), *semanticQuery)
+		issueByID := make(map[string]*model.Issue, len(issuesForSearch))
+		for i := range issuesForSearch {
+			issueByID[issuesForSearch[i].ID] = &issuesForSearch[i]
+		}
+		// A facet filter is applied after retrieval, so retrieve from the
+		// whole corpus and keep a candidate pool sized for what matches it.
+		retrieveLimit := fetchLimit
+		if !facetFilter.Empty() {
+			retrieveLimit = len(issuesForSearch)
+			matching := 0
+			for i := range issuesForSearch {
+				if facetFilter.Match(&issuesForSearch[i]) {
+					matching++
+				}
+			}
+			fetchLimit = search.HybridCandidateLimit(limit, matching, *semanticQuery)
 		}
-		results, err := idx.SearchTopK(qvecs[0], fetchLimit)
+		vectorResults, bestChunk, err := search.SearchChunks(idx, qvecs[0], retrieveLimit)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error searching index: %v\n", err)
 			os.Exit(1)
 		}
-		results = search.ApplyShortQueryLexicalBoost(results, *semanticQuery, docs)
+		lexical := search.NewLexicalIndex(issuesForSearch)
+		vectorResults = search.FilterResults(vectorResults, issueByID, facetFilter)
+		lexicalResults := search.FilterResults(lexical.Search(*semanticQuery, retrieveLimit), issueByID, facetFilter)
+		if len(vectorResults) > fetchLimit {
+			vectorResults = vectorResults[:fetchLimit]
+		}
+		if len(lexicalResults) > fetchLimit {
+			lexicalResults = lexicalResults[:fetchLimit]
+		}
+		results := search.FuseRRF(search.DefaultRRFK, vectorResults, lexicalResults)
+		// Facets count the whole candidate pool, not just the returned page.
+		facets := search.ResultFacets(results, issueByID)
+		candidates := len(results)
+
+		if *searchAggregate {
+			out := robotSearchAggregateOutput{
+				GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+				DataHash:    dataHash,
+				Query:       *semanticQuery,
+				Filter:      facetFilter.String(),
+				Candidates:  candidates,
+				Facets:      facets,
+			}
+			if *robotSearch {
+				out.UsageHints = []string{
+					"jq '.facets.status' - Status distribution of the matches",
+					"jq '.facets.label[:5]' - Most common labels among the matches",
+					"jq '.facets | map_values(.[0].value)' - Dominant value per facet",
+				}
+				if err := newRobotEncoder(os.Stdout).Encode(out); err != nil {
+					fmt.Fprintf(os.Stderr, "Error encoding search aggregate: %v\n", err)
+					os.Exit(1)
+				}
+				os.Exit(0)
+			}
+			printSearchFacets(os.Stdout, out.Facets, candidates, 0)
+			os.Exit(0)
+		}
+
+		if searchCfg.Mode != search.SearchModeHybrid && len(results) > limit {
+			results = results[:limit]
+		}
 		if isLikelyIssueID(*semanticQuery) {
 			results = promoteExactSearchResult(*semanticQuery, results)
 		}
@@ -1502,12 +1905,15 @@ func main() {
 		for _, iss := range issuesForSearch {
 			titleByID[iss.ID] = iss.Title
 		}
+		matchFor := func(id string) *search.ChunkMatch {
+			return chunks.MatchFor(id, bestChunk[id], *semanticQuery)
+		}
 
 		var hybridResults []search.HybridScore
 		var resolvedPreset search.PresetName
 		var resolvedWeights *search.Weights
 		if searchCfg.Mode == search.SearchModeHybrid {
-			weights, presetName, err := resolveSearchWeights(searchCfg)
+			weights, presetName, err := resolveSearchWeights(searchCfg, si.ProjectDir)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				os.Exit(1)
@@ -1537,6 +1943,34 @@ func main() {
 			}
 		}
 
+		// Click feedback for learning-to-rank: the shown results and the one accepted.
+		var feedbackRecorded string
+		if *searchAccept != "" {
+			shown := make([]string, 0, limit)
+			if searchCfg.Mode == search.SearchModeHybrid {
+				for _, r := range hybridResults {
+					shown = append(shown, r.IssueID)
+				}
+			} else {
+				for _, r := range results {
+					shown = append(shown, r.IssueID)
+				}
+			}
+			source := search.FeedbackSourceCLI
+			if *robotSearch {
+				source = search.FeedbackSourceRobot
+			}
+			ev := search.NewSearchFeedbackEvent(source, *semanticQuery, searchCfg.Mode, shown, *searchAccept, time.Now())
+			if err := search.AppendSearchFeedback(si.ProjectDir, ev); err != nil {
+				fmt.Fprintf(os.Stderr, "Error recording search feedback: %v\n", err)
+				os.Exit(1)
+			}
+			feedbackRecorded = *searchAccept
+			if !*robotSearch {
+				fmt.Fprintf(os.Stderr, "Recorded %s as accepted for %q\n", *searchAccept, *semanticQuery)
+			}
+		}
+
 		if *robotSearch {
 			out := robotSearchOutput{
 				GeneratedAt: time.Now().UTC().Format(time.RFC3339),
@@ -1550,6 +1984,10 @@ func main() {
 				Loaded:      loaded,
 				Limit:       limit,
 				Mode:        searchCfg.Mode,
+				Filter:      facetFilter.String(),
+				Candidates:  candidates,
+				Facets:      facets,
+				Accepted:    feedbackRecorded,
 			}
 			if searchCfg.Mode == search.SearchModeHybrid {
 				out.Preset = resolvedPreset
@@ -1564,11 +2002,14 @@ func main() {
 						TextScore:       r.TextScore,
 						Title:           titleByID[r.IssueID],
 						ComponentScores: r.ComponentScores,
+						Match:           matchFor(r.IssueID),
 					})
 				}
 				out.UsageHints = []string{
 					"jq '.results[] | {id: .issue_id, score: .score, text: .text_score}' - Extract scores",
 					"jq '.results[] | {id: .issue_id, components: .component_scores}' - Hybrid breakdown",
+					"jq '.results[] | {id: .issue_id, field: .match.field, snippet: .match.snippet}' - Which chunk matched",
+					"jq '.facets.status' - Status counts over all candidates (refine with --search-filter)",
 					"jq '.index' - Index update stats (added/updated/removed/embedded)",
 				}
 			} else {
@@ -1577,10 +2018,13 @@ func main() {
 						IssueID: r.IssueID,
 						Score:   r.Score,
 						Title:   titleByID[r.IssueID],
+						Match:   matchFor(r.IssueID),
 					})
 				}
 				out.UsageHints = []string{
 					"jq '.results[] | {id: .issue_id, score: .score, title: .title}' - Extract results",
+					"jq '.results[] | {id: .issue_id, field: .match.field, snippet: .match.snippet}' - Which chunk matched",
+					"jq '.facets.status' - Status counts over all candidates (refine with --search-filter)",
 					"jq '.index' - Index update stats (added/updated/removed/embedded)",
 				}
 			}
@@ -1596,13 +2040,24 @@ func main() {
 		if !loaded || syncStats.Changed() {
 			fmt.Fprintf(os.Stderr, "Index: +%d ~%d -%d (%d total) → %s\n", syncStats.Added, syncStats.Updated, syncStats.Removed, idx.Size(), indexPath)
 		}
+		hlOpen, hlClose := "", ""
+		if stdoutIsTTY {
+			hlOpen, hlClose = "\x1b[1m", "\x1b[0m"
+		}
+		printMatch := func(id string) {
+			if m := matchFor(id); m != nil && m.Field != search.ChunkFieldHead {
+				fmt.Printf("\t  ↳ %s: %s\n", m.Label(), m.Highlighted(hlOpen, hlClose))
+			}
+		}
 		if searchCfg.Mode == search.SearchModeHybrid {
 			for _, r := range hybridResults {
 				fmt.Printf("%.4f\t%s\t%s\n", r.FinalScore, r.IssueID, titleByID[r.IssueID])
+				printMatch(r.IssueID)
 			}
 		} else {
 			for _, r := range results {
 				fmt.Printf("%.4f\t%s\t%s\n", r.Score, r.IssueID, titleByID[r.IssueID])
+				printMatch(r.IssueID)
 			}
 		}
 		os.Exit(0)
@@ -1779,7 +2234,6 @@ func main() {
 
 			// Collect all issues.jsonl files to watch
 			var watchFiles []string
-			var watchers []*watcher.Watcher
 
 			if *workspaceConfig != "" {
 				// Workspace mode: watch all repos' issues.jsonl files (bv-79)
@@ -1818,22 +2272,13 @@ func main() {
 				watchFiles = append(watchFiles, issuesFile)
 			}
 
-			// Print watched files
-			for _, f := range watchFiles {
-				fmt.Printf("  → Watching: %s\n", f)
-			}
-			fmt.Println("  → Press Ctrl+C to stop")
-			fmt.Println("")
-			fmt.Println("To preview with auto-refresh, run in another terminal:")
-			fmt.Printf("  bv --preview-pages %s\n", *exportPages)
-
-			// Create a merged change channel for all watchers
-			mergedChangeCh := make(chan struct{}, 1)
-
-			// Create file watchers with 500ms debounce for each file
+			// Group the beads files with their SQLite WAL and beads git refs so a
+			// `git pull` triggers one export describing everything that moved.
+			group := watcher.NewGroup(500 * time.Millisecond)
+			seenGitDirs := make(map[string]bool)
 			for _, watchFile := range watchFiles {
 				w, err := watcher.NewWatcher(watchFile,
-					watcher.WithDebounceDuration(500*time.Millisecond),
+					watcher.WithDebounceDuration(watcher.SourceDebounce),
 					watcher.WithOnError(func(err error) {
 						fmt.Printf("  → Watch error: %v\n", err)
 					}),
@@ -1842,31 +2287,31 @@ func main() {
 					fmt.Fprintf(os.Stderr, "Error creating watcher for %s: %v\n", watchFile, err)
 					os.Exit(1)
 				}
-
-				if err := w.Start(); err != nil {
-					fmt.Fprintf(os.Stderr, "Error starting watcher for %s: %v\n", watchFile, err)
-					os.Exit(1)
-				}
-				watchers = append(watchers, w)
-
-				// Forward changes to merged channel
-				go func(ch <-chan struct{}) {
-					for range ch {
-						select {
-						case mergedChangeCh <- struct{}{}:
-						default:
-							// Already a change pending, skip
+				group.Add(watcher.WrapWatcher(watcher.ChangeFile, w))
+				fmt.Printf("  → Watching: %s\n", watchFile)
+
+				for _, src := range watcher.AuxiliarySources(watchFile) {
+					if refs, ok := src.(*watcher.GitRefSource); ok {
+						// Workspace repos often share one git directory.
+						if seenGitDirs[refs.GitDir()] {
+							continue
 						}
+						seenGitDirs[refs.GitDir()] = true
 					}
-				}(w.Changed())
+					group.Add(src)
+					fmt.Printf("  → Watching: %s\n", src.Name())
+				}
 			}
+			fmt.Println("  → Press Ctrl+C to stop")
+			fmt.Println("")
+			fmt.Println("To preview with auto-refresh, run in another terminal:")
+			fmt.Printf("  bv --preview-pages %s\n", *exportPages)
 
-			// Cleanup all watchers on exit
-			defer func() {
-				for _, w := range watchers {
-					w.Stop()
-				}
-			}()
+			if err := group.Start(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error starting watchers: %v\n", err)
+				os.Exit(1)
+			}
+			defer group.Stop()
 
 			// Set up signal handling for graceful shutdown
 			sigCh := make(chan os.Signal, 1)
@@ -1876,7 +2321,8 @@ func main() {
 			// Watch loop
 			for {
 				select {
-				case <-mergedChangeCh:
+				case ev := <-group.Events():
+					fmt.Printf("  → Change detected: %s\n", ev)
 					// Reload issues from disk using appropriate method
 					var freshIssues []model.Issue
 					var err error
@@ -2238,6 +2684,10 @@ func main() {
 
 		calc := drift.NewCalculator(bl, cur, driftConfig)
 		calc.SetIssues(issues)
+		if !driftConfig.IsAlertDisabled(string(drift.AlertReopenSpike)) {
+			// Reopen analytics walk bead history; skip them when unused
+			calc.SetChurn(loadChurnReport(issues, *historyLimit, *churnWindow))
+		}
 		driftResult := calc.Calculate()
 
 		// Apply optional filters
@@ -2327,6 +2777,23 @@ func main() {
 			os.Exit(1)
 		}
 
+		switch strings.ToLower(*suggestMethod) {
+		case "", "jaccard", "keyword", "keywords":
+		case "semantic", "embedding":
+			if config.FilterType == "" || config.FilterType == analysis.SuggestionPotentialDuplicate {
+				si, err := openSemanticIndex(issues, nil)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+				config.DuplicateDetector = semanticDuplicateDetector(si, *suggestThreshold)
+				config.DuplicateMethod = "semantic"
+			}
+		default:
+			fmt.Fprintf(os.Stderr, "Invalid suggest-method: %s (use: jaccard, semantic)\n", *suggestMethod)
+			os.Exit(1)
+		}
+
 		output := analysis.GenerateRobotSuggestOutput(issues, config, dataHash)
 
 		encoder := newRobotEncoder(os.Stdout)
@@ -2339,7 +2806,7 @@ func main() {
 
 	// Handle --profile-startup
 	if *profileStartup {
-		runProfileStartup(issues, loadDuration, *profileJSON, *forceFullAnalysis)
+		runProfileStartup(issues, loadDuration, snapshotCacheStatus(snapshotCache, snapshotCacheErr), *profileJSON, *forceFullAnalysis)
 		os.Exit(0)
 	}
 
@@ -2472,6 +2939,10 @@ func main() {
 		}
 
 		calc := drift.NewCalculator(bl, current, driftConfig)
+		if !driftConfig.IsAlertDisabled(string(drift.AlertReopenSpike)) {
+			// Reopen analytics walk bead history; skip them when unused
+			calc.SetChurn(loadChurnReport(issues, *historyLimit, *churnWindow))
+		}
 		result := calc.Calculate()
 
 		if *robotDriftCheck {
@@ -2917,7 +3388,7 @@ func main() {
 								}
 							}
 
-							correlator := correlation.NewCorrelator(cwd, beadsPath)
+							correlator := correlation.NewHistoryReporter(cwd, useHistoryDB, beadsPath)
 							opts := correlation.CorrelatorOptions{Limit: limit}
 
 							// Swallow errors for triage flow - staleness is optional
@@ -3289,7 +3760,7 @@ func main() {
 		}
 
 		// Generate report with explicit beads path
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := correlation.NewHistoryReporter(cwd, useHistoryDB, beadsPath)
 		report, err := correlator.GenerateReport(beadInfos, opts)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
@@ -3328,7 +3799,8 @@ func main() {
 	}
 
 	// Handle correlation audit commands (bv-e1u6)
-	if *robotExplainCorrelation != "" || *robotConfirmCorrelation != "" || *robotRejectCorrelation != "" || *robotCorrelationStats {
+	if *robotExplainCorrelation != "" || *robotConfirmCorrelation != "" || *robotRejectCorrelation != "" || *robotCorrelationStats ||
+		*robotResetCorrelationModel || *robotExportCorrelationModel {
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
@@ -3341,11 +3813,64 @@ func main() {
 			os.Exit(1)
 		}
 
+		// The learned confidence model lives at the repository root (.bv/)
+		modelCwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Handle --robot-reset-correlation-model
+		if *robotResetCorrelationModel {
+			model, err := correlation.ResetConfidenceModel(modelCwd)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error resetting confidence model: %v\n", err)
+				os.Exit(1)
+			}
+			result := map[string]interface{}{
+				"status":   "reset",
+				"path":     correlation.ConfidenceModelFile,
+				"reset_at": model.ResetAt,
+			}
+			if err := newRobotEncoder(os.Stdout).Encode(result); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding result: %v\n", err)
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}
+
+		model, err := correlation.LoadConfidenceModel(modelCwd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading confidence model: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Handle --robot-export-correlation-model
+		if *robotExportCorrelationModel {
+			if model == nil {
+				model = &correlation.ConfidenceModel{}
+			}
+			if err := newRobotEncoder(os.Stdout).Encode(model); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding confidence model: %v\n", err)
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}
+
 		// Handle --robot-correlation-stats
 		if *robotCorrelationStats {
-			stats := feedbackStore.GetStats()
+			feedback := feedbackStore.GetAll()
+			output := struct {
+				correlation.FeedbackStats
+				Model       *correlation.ConfidenceModel  `json:"model,omitempty"`
+				Calibration correlation.CalibrationReport `json:"calibration"`
+			}{
+				FeedbackStats: feedbackStore.GetStats(),
+				Model:         model,
+				Calibration:   model.Calibrate(feedback),
+			}
 			encoder := newRobotEncoder(os.Stdout)
-			if err := encoder.Encode(stats); err != nil {
+			if err := encoder.Encode(output); err != nil {
 				fmt.Fprintf(os.Stderr, "Error encoding stats: %v\n", err)
 				os.Exit(1)
 			}
@@ -3380,7 +3905,7 @@ func main() {
 				fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
 				os.Exit(1)
 			}
-			correlator := correlation.NewCorrelator(cwd, beadsPath)
+			correlator := correlation.NewHistoryReporter(cwd, useHistoryDB, beadsPath)
 
 			beadInfos := make([]correlation.BeadInfo, len(issues))
 			for i, issue := range issues {
@@ -3459,7 +3984,7 @@ func main() {
 				fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
 				os.Exit(1)
 			}
-			correlator := correlation.NewCorrelator(cwd, beadsPath)
+			correlator := correlation.NewHistoryReporter(cwd, useHistoryDB, beadsPath)
 
 			beadInfos := make([]correlation.BeadInfo, len(issues))
 			for i, issue := range issues {
@@ -3474,28 +3999,38 @@ func main() {
 			}
 
 			var originalConf float64
+			var features *correlation.CorrelationFeatures
 			if history, ok := report.Histories[beadID]; ok {
 				for _, c := range history.Commits {
 					if strings.HasPrefix(c.SHA, commitSHA) || c.ShortSHA == commitSHA {
 						originalConf = c.Confidence
 						commitSHA = c.SHA // Use full SHA
+						f := correlation.ExtractFeatures(c, history)
+						features = &f
 						break
 					}
 				}
 			}
 
-			if err := feedbackStore.Confirm(commitSHA, beadID, feedbackBy, originalConf, *correlationFeedbackReason); err != nil {
+			if err := feedbackStore.Record(correlation.FeedbackConfirm, commitSHA, beadID, feedbackBy, originalConf, *correlationFeedbackReason, features); err != nil {
 				fmt.Fprintf(os.Stderr, "Error saving feedback: %v\n", err)
 				os.Exit(1)
 			}
-
-			result := map[string]interface{}{
-				"status":    "confirmed",
-				"commit":    commitSHA,
-				"bead":      beadID,
-				"by":        feedbackBy,
-				"reason":    *correlationFeedbackReason,
-				"orig_conf": originalConf,
+			model, err := correlation.RetrainConfidenceModel(cwd, feedbackStore.GetAll())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error retraining confidence model: %v\n", err)
+				os.Exit(1)
+			}
+
+			result := map[string]interface{}{
+				"status":        "confirmed",
+				"commit":        commitSHA,
+				"bead":          beadID,
+				"by":            feedbackBy,
+				"reason":        *correlationFeedbackReason,
+				"orig_conf":     originalConf,
+				"model_trained": model.Trained(),
+				"model_samples": model.Samples,
 			}
 			encoder := newRobotEncoder(os.Stdout)
 			if err := encoder.Encode(result); err != nil {
@@ -3529,7 +4064,7 @@ func main() {
 				fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
 				os.Exit(1)
 			}
-			correlator := correlation.NewCorrelator(cwd, beadsPath)
+			correlator := correlation.NewHistoryReporter(cwd, useHistoryDB, beadsPath)
 
 			beadInfos := make([]correlation.BeadInfo, len(issues))
 			for i, issue := range issues {
@@ -3544,28 +4079,38 @@ func main() {
 			}
 
 			var originalConf float64
+			var features *correlation.CorrelationFeatures
 			if history, ok := report.Histories[beadID]; ok {
 				for _, c := range history.Commits {
 					if strings.HasPrefix(c.SHA, commitSHA) || c.ShortSHA == commitSHA {
 						originalConf = c.Confidence
 						commitSHA = c.SHA // Use full SHA
+						f := correlation.ExtractFeatures(c, history)
+						features = &f
 						break
 					}
 				}
 			}
 
-			if err := feedbackStore.Reject(commitSHA, beadID, feedbackBy, originalConf, *correlationFeedbackReason); err != nil {
+			if err := feedbackStore.Record(correlation.FeedbackReject, commitSHA, beadID, feedbackBy, originalConf, *correlationFeedbackReason, features); err != nil {
 				fmt.Fprintf(os.Stderr, "Error saving feedback: %v\n", err)
 				os.Exit(1)
 			}
+			model, err := correlation.RetrainConfidenceModel(cwd, feedbackStore.GetAll())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error retraining confidence model: %v\n", err)
+				os.Exit(1)
+			}
 
 			result := map[string]interface{}{
-				"status":    "rejected",
-				"commit":    commitSHA,
-				"bead":      beadID,
-				"by":        feedbackBy,
-				"reason":    *correlationFeedbackReason,
-				"orig_conf": originalConf,
+				"status":        "rejected",
+				"commit":        commitSHA,
+				"bead":          beadID,
+				"by":            feedbackBy,
+				"reason":        *correlationFeedbackReason,
+				"orig_conf":     originalConf,
+				"model_trained": model.Trained(),
+				"model_samples": model.Samples,
 			}
 			encoder := newRobotEncoder(os.Stdout)
 			if err := encoder.Encode(result); err != nil {
@@ -3613,7 +4158,7 @@ func main() {
 		}
 
 		// Generate history report first (to get existing correlations)
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := correlation.NewHistoryReporter(cwd, useHistoryDB, beadsPath)
 		correlatorOpts := correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		}
@@ -3674,6 +4219,87 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --robot-delivery-metrics flag
+	if *robotDeliveryMetrics {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Validate repository
+		if err := correlation.ValidateRepository(cwd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Get beads path
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
+			os.Exit(1)
+		}
+		beadsPath, err := loader.FindJSONLPath(beadsDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
+			os.Exit(1)
+		}
+
+		beadInfos := make([]correlation.BeadInfo, len(issues))
+		for i, issue := range issues {
+			beadInfos[i] = correlation.BeadInfo{
+				ID:     issue.ID,
+				Title:  issue.Title,
+				Status: string(issue.Status),
+			}
+		}
+
+		// Commits per bead feed lead time
+		correlator := correlation.NewHistoryReporter(cwd, useHistoryDB, beadsPath)
+		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
+			Limit: *historyLimit,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
+			os.Exit(1)
+		}
+
+		tags, err := correlation.ListReleaseTags(cwd, *deliveryTagPattern)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error listing release tags: %v\n", err)
+			os.Exit(1)
+		}
+		released, err := correlation.FirstReleases(cwd, tags)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error mapping commits to releases: %v\n", err)
+			os.Exit(1)
+		}
+
+		metrics := correlation.ComputeDeliveryMetrics(issues, report, tags, released, correlation.DeliveryOptions{
+			Weeks:         *deliveryWeeks,
+			TagPattern:    *deliveryTagPattern,
+			FailureWindow: time.Duration(*deliveryFailureDays) * 24 * time.Hour,
+		})
+
+		type DeliveryOutputEnvelope struct {
+			*correlation.DeliveryMetrics
+			OutputFormat string `json:"output_format,omitempty"`
+			Version      string `json:"version,omitempty"`
+		}
+		output := DeliveryOutputEnvelope{
+			DeliveryMetrics: metrics,
+			OutputFormat:    robotOutputFormat,
+			Version:         version.Version,
+		}
+
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding delivery metrics: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Handle --robot-file-beads and --robot-file-hotspots flags (bv-hmib)
 	if *robotFileBeads != "" || *fileHotspots {
 		cwd, err := os.Getwd()
@@ -3711,7 +4337,7 @@ func main() {
 		}
 
 		// Generate history report first
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := correlation.NewHistoryReporter(cwd, useHistoryDB, beadsPath)
 		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
@@ -3740,39 +4366,294 @@ func main() {
 				Stats:         fileLookup.GetStats(),
 			}
 
-			if err := encoder.Encode(output); err != nil {
-				fmt.Fprintf(os.Stderr, "Error encoding hotspots: %v\n", err)
-				os.Exit(1)
+			if err := encoder.Encode(output); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding hotspots: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			// Output file-beads lookup
+			result := fileLookup.LookupByFile(*robotFileBeads)
+
+			// Limit closed beads if specified
+			if len(result.ClosedBeads) > *fileBeadsLimit {
+				result.ClosedBeads = result.ClosedBeads[:*fileBeadsLimit]
+			}
+
+			type FileBeadsOutput struct {
+				RobotEnvelope
+				FilePath    string                      `json:"file_path"`
+				TotalBeads  int                         `json:"total_beads"`
+				OpenBeads   []correlation.BeadReference `json:"open_beads"`
+				ClosedBeads []correlation.BeadReference `json:"closed_beads"`
+			}
+
+			output := FileBeadsOutput{
+				RobotEnvelope: NewRobotEnvelope(report.DataHash),
+				FilePath:      *robotFileBeads,
+				TotalBeads:    result.TotalBeads,
+				OpenBeads:     result.OpenBeads,
+				ClosedBeads:   result.ClosedBeads,
+			}
+
+			if err := encoder.Encode(output); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding file beads: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-experts
+	if *robotExperts {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			os.Exit(1)
+		}
+		if err := correlation.ValidateRepository(cwd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
+			os.Exit(1)
+		}
+		beadsPath, err := loader.FindJSONLPath(beadsDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
+			os.Exit(1)
+		}
+
+		beadInfos := make([]correlation.BeadInfo, len(issues))
+		labels := make(map[string][]string, len(issues))
+		for i, issue := range issues {
+			beadInfos[i] = correlation.BeadInfo{ID: issue.ID, Title: issue.Title, Status: string(issue.Status)}
+			labels[issue.ID] = issue.Labels
+		}
+		report, err := correlation.NewHistoryReporter(cwd, useHistoryDB, beadsPath).GenerateReport(beadInfos, correlation.CorrelatorOptions{
+			Limit: *historyLimit,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
+			os.Exit(1)
+		}
+		experts := correlation.BuildExpertiseMatrix(report, labels, correlation.ExpertiseOptions{})
+
+		type expertGroup struct {
+			Key     string               `json:"key"`
+			Experts []correlation.Expert `json:"experts"`
+		}
+		output := struct {
+			RobotEnvelope
+			Label       string               `json:"label,omitempty"`
+			File        string               `json:"file,omitempty"`
+			Directory   string               `json:"directory,omitempty"`
+			Experts     []correlation.Expert `json:"experts"`
+			Labels      []expertGroup        `json:"labels,omitempty"`
+			Directories []expertGroup        `json:"directories,omitempty"`
+			HalfLife    string               `json:"half_life"`
+		}{
+			RobotEnvelope: NewRobotEnvelope(report.DataHash),
+			Label:         *labelScope,
+			File:          *expertsFile,
+			HalfLife:      correlation.DefaultExpertiseHalfLife.String(),
+		}
+		switch {
+		case *labelScope != "":
+			output.Experts = experts.ForLabel(*labelScope, *expertsLimit)
+		case *expertsFile != "":
+			output.Directory, output.Experts = experts.ForPath(*expertsFile, *expertsLimit)
+		default:
+			// Every commit credits the repository root, so its experts are the overall ones
+			output.Experts = experts.ForDirectory(".", *expertsLimit)
+			for _, label := range experts.Labels() {
+				output.Labels = append(output.Labels, expertGroup{Key: label, Experts: experts.ForLabel(label, *expertsLimit)})
+			}
+			for _, dir := range experts.Directories() {
+				output.Directories = append(output.Directories, expertGroup{Key: dir, Experts: experts.ForDirectory(dir, *expertsLimit)})
+			}
+		}
+		if err := newRobotEncoder(os.Stdout).Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding experts: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-bus-factor
+	if *robotBusFactor {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			os.Exit(1)
+		}
+		if err := correlation.ValidateRepository(cwd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
+			os.Exit(1)
+		}
+		beadsPath, err := loader.FindJSONLPath(beadsDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
+			os.Exit(1)
+		}
+
+		beadInfos := make([]correlation.BeadInfo, len(issues))
+		for i, issue := range issues {
+			beadInfos[i] = correlation.BeadInfo{ID: issue.ID, Title: issue.Title, Status: string(issue.Status)}
+		}
+		report, err := correlation.NewHistoryReporter(cwd, useHistoryDB, beadsPath).GenerateReport(beadInfos, correlation.CorrelatorOptions{
+			Limit: *historyLimit,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
+			os.Exit(1)
+		}
+
+		stats := analysis.NewAnalyzer(issues).Analyze()
+		opts := analysis.DefaultBusFactorOptions()
+		opts.Threshold = *busFactorThreshold
+		opts.HotspotLimit = *hotspotsLimit
+		opts.CriticalPath = stats.CriticalPathScore()
+
+		output := struct {
+			RobotEnvelope
+			*analysis.BusFactorReport
+		}{
+			RobotEnvelope:   NewRobotEnvelope(report.DataHash),
+			BusFactorReport: analysis.ComputeBusFactor(issues, report, opts),
+		}
+		if err := newRobotEncoder(os.Stdout).Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding bus factor report: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-churn
+	if *robotChurn {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			os.Exit(1)
+		}
+		if err := correlation.ValidateRepository(cwd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
+			os.Exit(1)
+		}
+		beadsPath, err := loader.FindJSONLPath(beadsDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
+			os.Exit(1)
+		}
+
+		beadInfos := make([]correlation.BeadInfo, len(issues))
+		for i, issue := range issues {
+			beadInfos[i] = correlation.BeadInfo{ID: issue.ID, Title: issue.Title, Status: string(issue.Status)}
+		}
+		report, err := correlation.NewHistoryReporter(cwd, useHistoryDB, beadsPath).GenerateReport(beadInfos, correlation.CorrelatorOptions{
+			Limit: *historyLimit,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
+			os.Exit(1)
+		}
+
+		opts := analysis.DefaultChurnOptions()
+		opts.Window = time.Duration(*churnWindow) * 24 * time.Hour
+
+		output := struct {
+			RobotEnvelope
+			*analysis.ChurnReport
+		}{
+			RobotEnvelope: NewRobotEnvelope(report.DataHash),
+			ChurnReport:   analysis.ComputeChurn(issues, report, opts),
+		}
+		if err := newRobotEncoder(os.Stdout).Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding churn report: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-blame flag
+	if *robotBlame != "" {
+		path, start, end, err := correlation.ParseBlameTarget(*robotBlame)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := correlation.ValidateRepository(cwd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		lines, err := correlation.BlameFile(cwd, path, start, end)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
+			os.Exit(1)
+		}
+		beadsPath, err := loader.FindJSONLPath(beadsDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
+			os.Exit(1)
+		}
+
+		beadInfos := make([]correlation.BeadInfo, len(issues))
+		for i, issue := range issues {
+			beadInfos[i] = correlation.BeadInfo{
+				ID:     issue.ID,
+				Title:  issue.Title,
+				Status: string(issue.Status),
 			}
-		} else {
-			// Output file-beads lookup
-			result := fileLookup.LookupByFile(*robotFileBeads)
+		}
 
-			// Limit closed beads if specified
-			if len(result.ClosedBeads) > *fileBeadsLimit {
-				result.ClosedBeads = result.ClosedBeads[:*fileBeadsLimit]
-			}
+		correlator := correlation.NewHistoryReporter(cwd, useHistoryDB, beadsPath)
+		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
+			Limit: *historyLimit,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
+			os.Exit(1)
+		}
 
-			type FileBeadsOutput struct {
-				RobotEnvelope
-				FilePath    string                      `json:"file_path"`
-				TotalBeads  int                         `json:"total_beads"`
-				OpenBeads   []correlation.BeadReference `json:"open_beads"`
-				ClosedBeads []correlation.BeadReference `json:"closed_beads"`
-			}
+		type BlameOutput struct {
+			RobotEnvelope
+			*correlation.FileBlame
+		}
 
-			output := FileBeadsOutput{
-				RobotEnvelope: NewRobotEnvelope(report.DataHash),
-				FilePath:      *robotFileBeads,
-				TotalBeads:    result.TotalBeads,
-				OpenBeads:     result.OpenBeads,
-				ClosedBeads:   result.ClosedBeads,
-			}
+		output := BlameOutput{
+			RobotEnvelope: NewRobotEnvelope(report.DataHash),
+			FileBlame:     report.AnnotateBlame(path, lines),
+		}
 
-			if err := encoder.Encode(output); err != nil {
-				fmt.Fprintf(os.Stderr, "Error encoding file beads: %v\n", err)
-				os.Exit(1)
-			}
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding blame: %v\n", err)
+			os.Exit(1)
 		}
 		os.Exit(0)
 	}
@@ -3810,7 +4691,7 @@ func main() {
 			}
 		}
 
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := correlation.NewHistoryReporter(cwd, useHistoryDB, beadsPath)
 		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
@@ -3894,7 +4775,7 @@ func main() {
 			}
 		}
 
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := correlation.NewHistoryReporter(cwd, useHistoryDB, beadsPath)
 		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
@@ -3969,7 +4850,7 @@ func main() {
 			}
 		}
 
-		correlatorObj := correlation.NewCorrelator(cwd, beadsPath)
+		correlatorObj := correlation.NewHistoryReporter(cwd, useHistoryDB, beadsPath)
 		report, err := correlatorObj.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
@@ -4106,7 +4987,7 @@ func main() {
 		}
 
 		// Generate history report
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := correlation.NewHistoryReporter(cwd, useHistoryDB, beadsPath)
 		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
@@ -4195,7 +5076,7 @@ func main() {
 			}
 		}
 
-		correlatorObj := correlation.NewCorrelator(cwd, beadsPath)
+		correlatorObj := correlation.NewHistoryReporter(cwd, useHistoryDB, beadsPath)
 		report, err := correlatorObj.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
@@ -4738,6 +5619,136 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --release-notes flag
+	if *releaseNotes != "" {
+		switch *releaseNotesFormat {
+		case export.ReleaseNotesMarkdown, export.ReleaseNotesJSON, export.ReleaseNotesChangelog:
+		default:
+			fmt.Fprintf(os.Stderr, "Invalid --release-notes-format %q (expected markdown|json|changelog)\n", *releaseNotesFormat)
+			os.Exit(1)
+		}
+		if *releaseNotesGroup != export.ReleaseNotesGroupType && *releaseNotesGroup != export.ReleaseNotesGroupLabel {
+			fmt.Fprintf(os.Stderr, "Invalid --release-notes-group %q (expected type|label)\n", *releaseNotesGroup)
+			os.Exit(1)
+		}
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			os.Exit(1)
+		}
+		if err := correlation.ValidateRepository(cwd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		// A single ref compares against the current beads, like --diff-since.
+		fromRef, toRef, hasTo := strings.Cut(*releaseNotes, "..")
+		toRef = strings.TrimPrefix(toRef, ".")
+		if !hasTo || toRef == "" {
+			toRef = "HEAD"
+		}
+
+		gitLoader := loader.NewGitLoader(cwd)
+		fromIssues, err := gitLoader.LoadAt(fromRef)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading issues at %s: %v\n", fromRef, err)
+			os.Exit(1)
+		}
+		// An explicit range ends at a commit, even X..HEAD: uncommitted
+		// bead edits are not part of it.
+		toIssues := issues
+		if hasTo {
+			toIssues, err = gitLoader.LoadAt(toRef)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading issues at %s: %v\n", toRef, err)
+				os.Exit(1)
+			}
+		}
+		fromRevision, err := gitLoader.ResolveRevision(fromRef)
+		if err != nil {
+			fromRevision = fromRef
+		}
+		toRevision, err := gitLoader.ResolveRevision(toRef)
+		if err != nil {
+			toRevision = toRef
+		}
+		diff := analysis.CompareSnapshots(
+			analysis.NewSnapshotAt(fromIssues, time.Time{}, fromRevision),
+			analysis.NewSnapshotAt(toIssues, time.Time{}, toRevision),
+		)
+
+		revisions, err := gitLoader.ListCommitMessagesBetween(fromRef, toRef)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error listing commits: %v\n", err)
+			os.Exit(1)
+		}
+		commits := make([]export.ReleaseCommit, len(revisions))
+		for i, rev := range revisions {
+			subject, _, _ := strings.Cut(rev.Message, "\n")
+			shortSHA := rev.SHA
+			if len(shortSHA) > 7 {
+				shortSHA = shortSHA[:7]
+			}
+			commits[i] = export.ReleaseCommit{SHA: rev.SHA, ShortSHA: shortSHA, Subject: subject, Author: rev.Author, Timestamp: rev.Timestamp}
+		}
+
+		// Correlate only the history the range needs.
+		var since *time.Time
+		releaseDate := time.Now()
+		if len(revisions) > 0 {
+			oldest := revisions[len(revisions)-1].Timestamp.Add(-time.Second)
+			since = &oldest
+			releaseDate = revisions[0].Timestamp
+		}
+		beadInfos := make([]correlation.BeadInfo, len(toIssues))
+		for i, issue := range toIssues {
+			beadInfos[i] = correlation.BeadInfo{ID: issue.ID, Title: issue.Title, Status: string(issue.Status)}
+		}
+		var report *correlation.HistoryReport
+		var orphanReport *correlation.OrphanReport
+		if beadsDir, err := loader.GetBeadsDir(""); err == nil {
+			if beadsPath, err := loader.FindJSONLPath(beadsDir); err == nil {
+				report, err = correlation.NewHistoryReporter(cwd, useHistoryDB, beadsPath).GenerateReport(beadInfos, correlation.CorrelatorOptions{Since: since})
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: correlating commits: %v\n", err)
+				}
+			}
+		}
+		if report != nil {
+			orphanReport, err = correlation.NewOrphanDetector(report, cwd).DetectOrphans(correlation.ExtractOptions{Since: since})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: detecting orphan commits: %v\n", err)
+			}
+		}
+
+		notes := export.BuildReleaseNotes(diff, commits, report, orphanReport, export.ReleaseNotesOptions{
+			FromRef: fromRef,
+			ToRef:   toRef,
+			GroupBy: *releaseNotesGroup,
+			Date:    releaseDate,
+		})
+
+		switch *releaseNotesFormat {
+		case export.ReleaseNotesJSON:
+			type ReleaseNotesOutputEnvelope struct {
+				*export.ReleaseNotes
+				OutputFormat string `json:"output_format,omitempty"`
+				Version      string `json:"version,omitempty"`
+			}
+			encoder := newRobotEncoder(os.Stdout)
+			if err := encoder.Encode(ReleaseNotesOutputEnvelope{ReleaseNotes: notes, OutputFormat: robotOutputFormat, Version: version.Version}); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding release notes: %v\n", err)
+				os.Exit(1)
+			}
+		case export.ReleaseNotesChangelog:
+			fmt.Print(export.RenderReleaseNotesChangelog(notes))
+		default:
+			fmt.Print(export.RenderReleaseNotesMarkdown(notes))
+		}
+		os.Exit(0)
+	}
+
 	// Handle --diff-since flag
 	if *diffSince != "" {
 		// Auto-enable robot diff for non-interactive/agent contexts
@@ -4880,7 +5891,7 @@ func main() {
 	// Apply recipe filters and sorting if specified
 	if activeRecipe != nil {
 		issues = applyRecipeFilters(issues, activeRecipe)
-		issues = applyRecipeSort(issues, activeRecipe)
+		issues = applyRecipeSort(issues, activeRecipe, customEnumValues(beadsPath))
 	}
 
 	// Background mode rollout (bv-o11l):
@@ -4905,8 +5916,9 @@ func main() {
 	}
 
 	// Initial Model with live reload support
-	m := ui.NewModel(issues, activeRecipe, beadsPath)
+	m := ui.NewModelWithSnapshotCache(issues, activeRecipe, beadsPath, snapshotCache)
 	defer m.Stop() // Clean up file watcher
+	m.SetHistoryDB(useHistoryDB)
 
 	// Enable workspace mode if loading from workspace config
 	if workspaceInfo != nil {
@@ -5217,170 +6229,25 @@ func applyRecipeFilters(issues []model.Issue, r *recipe.Recipe) []model.Issue {
 	if r == nil {
 		return issues
 	}
+	return r.Filters.Apply(issues, time.Now())
+}
 
-	f := r.Filters
-	now := time.Now()
-
-	// Build a set of open blocker IDs for actionable filtering
-	openBlockers := make(map[string]bool)
-	for _, issue := range issues {
-		if issue.Status != model.StatusClosed {
-			openBlockers[issue.ID] = true
-		}
+// customEnumValues returns the declared enum values of the project owning
+// beadsPath, or nil when there is no schema.
+func customEnumValues(beadsPath string) map[string][]string {
+	if beadsPath == "" {
+		return nil
 	}
-
-	var result []model.Issue
-	for _, issue := range issues {
-		// Status filter
-		if len(f.Status) > 0 {
-			match := false
-			for _, s := range f.Status {
-				if strings.EqualFold(string(issue.Status), s) {
-					match = true
-					break
-				}
-			}
-			if !match {
-				continue
-			}
-		}
-
-		// Priority filter
-		if len(f.Priority) > 0 {
-			match := false
-			for _, p := range f.Priority {
-				if issue.Priority == p {
-					match = true
-					break
-				}
-			}
-			if !match {
-				continue
-			}
-		}
-
-		// Tags filter (must have all)
-		if len(f.Tags) > 0 {
-			match := true
-			for _, tag := range f.Tags {
-				found := false
-				for _, label := range issue.Labels {
-					if strings.EqualFold(label, tag) {
-						found = true
-						break
-					}
-				}
-				if !found {
-					match = false
-					break
-				}
-			}
-			if !match {
-				continue
-			}
-		}
-
-		// ExcludeTags filter
-		if len(f.ExcludeTags) > 0 {
-			excluded := false
-			for _, excludeTag := range f.ExcludeTags {
-				for _, label := range issue.Labels {
-					if strings.EqualFold(label, excludeTag) {
-						excluded = true
-						break
-					}
-				}
-				if excluded {
-					break
-				}
-			}
-			if excluded {
-				continue
-			}
-		}
-
-		// CreatedAfter filter
-		if f.CreatedAfter != "" {
-			threshold, err := recipe.ParseRelativeTime(f.CreatedAfter, now)
-			if err == nil && !issue.CreatedAt.IsZero() && issue.CreatedAt.Before(threshold) {
-				continue
-			}
-		}
-
-		// CreatedBefore filter
-		if f.CreatedBefore != "" {
-			threshold, err := recipe.ParseRelativeTime(f.CreatedBefore, now)
-			if err == nil && !issue.CreatedAt.IsZero() && issue.CreatedAt.After(threshold) {
-				continue
-			}
-		}
-
-		// UpdatedAfter filter
-		if f.UpdatedAfter != "" {
-			threshold, err := recipe.ParseRelativeTime(f.UpdatedAfter, now)
-			if err == nil && !issue.UpdatedAt.IsZero() && issue.UpdatedAt.Before(threshold) {
-				continue
-			}
-		}
-
-		// UpdatedBefore filter
-		if f.UpdatedBefore != "" {
-			threshold, err := recipe.ParseRelativeTime(f.UpdatedBefore, now)
-			if err == nil && !issue.UpdatedAt.IsZero() && issue.UpdatedAt.After(threshold) {
-				continue
-			}
-		}
-
-		// HasBlockers filter
-		if f.HasBlockers != nil {
-			hasOpenBlockers := false
-			for _, dep := range issue.Dependencies {
-				if dep.Type == model.DepBlocks && openBlockers[dep.DependsOnID] {
-					hasOpenBlockers = true
-					break
-				}
-			}
-			if *f.HasBlockers != hasOpenBlockers {
-				continue
-			}
-		}
-
-		// Actionable filter (no open blockers)
-		if f.Actionable != nil && *f.Actionable {
-			hasOpenBlockers := false
-			for _, dep := range issue.Dependencies {
-				if dep.Type == model.DepBlocks && openBlockers[dep.DependsOnID] {
-					hasOpenBlockers = true
-					break
-				}
-			}
-			if hasOpenBlockers {
-				continue
-			}
-		}
-
-		// TitleContains filter
-		if f.TitleContains != "" {
-			if !strings.Contains(strings.ToLower(issue.Title), strings.ToLower(f.TitleContains)) {
-				continue
-			}
-		}
-
-		// IDPrefix filter
-		if f.IDPrefix != "" {
-			if !strings.HasPrefix(issue.ID, f.IDPrefix) {
-				continue
-			}
-		}
-
-		result = append(result, issue)
+	schema, err := loader.ProjectSchemaForJSONL(beadsPath)
+	if err != nil {
+		return nil
 	}
-
-	return result
+	return schema.EnumValues()
 }
 
-// applyRecipeSort sorts issues based on recipe configuration
-func applyRecipeSort(issues []model.Issue, r *recipe.Recipe) []model.Issue {
+// applyRecipeSort sorts issues based on recipe configuration. enums holds the
+// declared value order of custom enum fields (see loader.Schema.EnumValues).
+func applyRecipeSort(issues []model.Issue, r *recipe.Recipe, enums map[string][]string) []model.Issue {
 	if r == nil || r.Sort.Field == "" {
 		return issues
 	}
@@ -5414,8 +6281,13 @@ func applyRecipeSort(issues []model.Issue, r *recipe.Recipe) []model.Issue {
 		case "status":
 			less = issues[i].Status < issues[j].Status
 		default:
-			// Unknown sort field, maintain order
-			return false
+			name, ok := model.CustomFieldName(s.Field)
+			if !ok {
+				// Unknown sort field, maintain order
+				return false
+			}
+			// Already directed: missing values stay last either way
+			return model.CompareCustom(&issues[i], &issues[j], name, enums[name], !ascending) < 0
 		}
 
 		if ascending {
@@ -5427,8 +6299,55 @@ func applyRecipeSort(issues []model.Issue, r *recipe.Recipe) []model.Issue {
 	return issues
 }
 
+// profileSnapshotCache describes how the TUI snapshot cache would serve this startup.
+type profileSnapshotCache struct {
+	Enabled bool   `json:"enabled"`
+	Hit     bool   `json:"hit"`
+	Path    string `json:"path,omitempty"`
+	Reason  string `json:"reason,omitempty"` // miss reason
+	Load    string `json:"load,omitempty"`   // decode time on hit
+	Issues  int    `json:"issues,omitempty"`
+	Age     string `json:"age,omitempty"`
+}
+
+// snapshotCacheStatus summarizes the startup snapshot cache lookup for --profile-startup.
+func snapshotCacheStatus(cache *ui.SnapshotCache, err error) profileSnapshotCache {
+	status := profileSnapshotCache{Enabled: ui.SnapshotCacheEnabled()}
+	if cache != nil {
+		status.Hit = true
+		status.Path = cache.Path
+		status.Load = cache.LoadDuration.String()
+		status.Issues = cache.IssueCount
+		status.Age = time.Since(cache.CreatedAt).Round(time.Second).String()
+		return status
+	}
+	if err != nil {
+		status.Reason = strings.TrimPrefix(strings.TrimPrefix(err.Error(), ui.ErrSnapshotCacheMiss.Error()), ": ")
+	}
+	if status.Reason == "" {
+		if status.Enabled {
+			status.Reason = "not applicable"
+		} else {
+			status.Reason = "disabled (BV_SNAPSHOT_CACHE=0)"
+		}
+	}
+	return status
+}
+
+// printSnapshotCacheStatus prints the snapshot cache section of the startup profile.
+func printSnapshotCacheStatus(status profileSnapshotCache) {
+	fmt.Println("Snapshot cache:")
+	if status.Hit {
+		fmt.Printf("  Hit: %d issues + metrics loaded in %s (age %s)\n", status.Issues, status.Load, status.Age)
+		fmt.Println("  The TUI skips parsing and Phase 2; timings above are for a cold start.")
+	} else {
+		fmt.Printf("  Miss: %s\n", status.Reason)
+	}
+	fmt.Println()
+}
+
 // runProfileStartup runs profiled startup analysis and outputs results
-func runProfileStartup(issues []model.Issue, loadDuration time.Duration, jsonOutput bool, forceFullAnalysis bool) {
+func runProfileStartup(issues []model.Issue, loadDuration time.Duration, cacheStatus profileSnapshotCache, jsonOutput bool, forceFullAnalysis bool) {
 	// Get actual beads path (respects BEADS_DIR)
 	beadsDir, _ := loader.GetBeadsDir("")
 	dataPath, _ := loader.FindJSONLPath(beadsDir)
@@ -5470,6 +6389,7 @@ func runProfileStartup(issues []model.Issue, loadDuration time.Duration, jsonOut
 			GeneratedAt     string                   `json:"generated_at"`
 			DataPath        string                   `json:"data_path"`
 			LoadJSONL       string                   `json:"load_jsonl"`
+			SnapshotCache   profileSnapshotCache     `json:"snapshot_cache"`
 			Profile         *analysis.StartupProfile `json:"profile"`
 			TotalWithLoad   string                   `json:"total_with_load"`
 			Recommendations []string                 `json:"recommendations"`
@@ -5477,6 +6397,7 @@ func runProfileStartup(issues []model.Issue, loadDuration time.Duration, jsonOut
 			GeneratedAt:     time.Now().UTC().Format(time.RFC3339),
 			DataPath:        dataPath,
 			LoadJSONL:       loadDuration.String(),
+			SnapshotCache:   cacheStatus,
 			Profile:         profile,
 			TotalWithLoad:   totalWithLoad.String(),
 			Recommendations: generateProfileRecommendations(profile, loadDuration, totalWithLoad),
@@ -5490,6 +6411,7 @@ func runProfileStartup(issues []model.Issue, loadDuration time.Duration, jsonOut
 	} else {
 		// Human-readable output
 		printProfileReport(profile, loadDuration, totalWithLoad)
+		printSnapshotCacheStatus(cacheStatus)
 	}
 }
 
@@ -7310,6 +8232,38 @@ type TimeTravelCommit struct {
 	BeadsClosed []string `json:"beads_closed,omitempty"`
 }
 
+// loadChurnReport computes reopen analytics for drift alerts, or returns nil
+// when git history is unavailable; the reopen spike alert is optional
+func loadChurnReport(issues []model.Issue, historyLimit, windowDays int) *analysis.ChurnReport {
+	cwd, err := os.Getwd()
+	if err != nil || correlation.ValidateRepository(cwd) != nil {
+		return nil
+	}
+	beadsDir, err := loader.GetBeadsDir("")
+	if err != nil {
+		return nil
+	}
+	beadsPath, err := loader.FindJSONLPath(beadsDir)
+	if err != nil {
+		return nil
+	}
+
+	beadInfos := make([]correlation.BeadInfo, len(issues))
+	for i, issue := range issues {
+		beadInfos[i] = correlation.BeadInfo{ID: issue.ID, Title: issue.Title, Status: string(issue.Status)}
+	}
+	report, err := correlation.NewHistoryReporter(cwd, useHistoryDB, beadsPath).GenerateReport(beadInfos, correlation.CorrelatorOptions{
+		Limit: historyLimit,
+	})
+	if err != nil {
+		return nil
+	}
+
+	opts := analysis.DefaultChurnOptions()
+	opts.Window = time.Duration(windowDays) * 24 * time.Hour
+	return analysis.ComputeChurn(issues, report, opts)
+}
+
 // generateHistoryForExport creates time-travel history data from git history
 func generateHistoryForExport(issues []model.Issue) (*TimeTravelHistory, error) {
 	cwd, err := os.Getwd()
@@ -7343,7 +8297,7 @@ func generateHistoryForExport(issues []model.Issue) (*TimeTravelHistory, error)
 	}
 
 	// Generate correlation report
-	correlator := correlation.NewCorrelator(cwd, beadsPath)
+	correlator := correlation.NewHistoryReporter(cwd, useHistoryDB, beadsPath)
 	report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 		Limit: 500, // Reasonable limit for time-travel
 	})
@@ -7410,6 +8364,7 @@ func generateHistoryForExport(issues []model.Issue) (*TimeTravelHistory, error)
 var robotOutputFormat = "json"
 var robotToonEncodeOptions = toon.DefaultEncodeOptions()
 var robotShowToonStats bool
+var useHistoryDB bool
 
 // RobotEnvelope is the standard envelope for all robot command outputs.
 // All robot outputs MUST include these fields for consistency.
@@ -7625,7 +8580,7 @@ func generateRobotDocs(topic string) map[string]interface{} {
 		"robot-suggest": {
 			Flag: "--robot-suggest", Description: "Smart suggestions: potential duplicates, missing dependencies, label assignments, cycle warnings.",
 			KeyFields:   []string{"suggestions", "type", "confidence"},
-			Params:      []string{"--suggest-type duplicate|dependency|label|cycle", "--suggest-confidence 0.0-1.0", "--suggest-bead <id>"},
+			Params:      []string{"--suggest-type duplicate|dependency|label|cycle", "--suggest-confidence 0.0-1.0", "--suggest-bead <id>", "--suggest-method jaccard|semantic", "--suggest-threshold 0.0-1.0"},
 			NeedsIssues: true,
 		},
 		"robot-schema": {
@@ -7640,8 +8595,8 @@ func generateRobotDocs(topic string) map[string]interface{} {
 		},
 		"robot-history": {
 			Flag: "--robot-history", Description: "Bead-to-commit correlations from git history.",
-			KeyFields:   []string{"correlations", "confidence", "commit_sha", "bead_id"},
-			Params:      []string{"--bead-history <id>", "--history-since <date>", "--history-limit <n>", "--min-confidence 0.0-1.0"},
+			KeyFields:   []string{"correlations", "confidence", "commit_sha", "bead_id", "pull_requests", "milestones.merged"},
+			Params:      []string{"--bead-history <id>", "--history-since <date>", "--history-limit <n>", "--history-db", "--min-confidence 0.0-1.0"},
 			NeedsIssues: true,
 		},
 		"robot-diff": {
@@ -7650,8 +8605,33 @@ func generateRobotDocs(topic string) map[string]interface{} {
 			NeedsIssues: true,
 		},
 		"robot-search": {
-			Flag: "--robot-search", Description: "Semantic vector search over issue titles and descriptions.",
-			Params:      []string{"--search <query>", "--search-limit <n>", "--search-mode text|hybrid"},
+			Flag: "--robot-search", Description: "Semantic vector search over issue titles and descriptions, with facet counts over the candidate pool.",
+			KeyFields:   []string{"results", "facets", "candidates"},
+			Params:      []string{"--search <query>", "--search-limit <n>", "--search-mode text|hybrid", "--search-filter status=open,label=api", "--search-aggregate (facets only)"},
+			NeedsIssues: true,
+		},
+		"search-history": {
+			Flag: "--search-history <query> --robot-search", Description: "Search commit messages and past versions of issue text reconstructed from git, including text later edited or compacted away.",
+			KeyFields:   []string{"results", "commit_sha", "issue_ids", "match", "current"},
+			Params:      []string{"--search-limit <n>", "--history-limit <n> (commits/revisions indexed)"},
+			NeedsIssues: true,
+		},
+		"robot-similar": {
+			Flag: "--robot-similar <id>", Description: "More like this: semantic nearest neighbours of one issue.",
+			KeyFields:   []string{"results", "score", "issue_id"},
+			Params:      []string{"--search-limit <n>"},
+			NeedsIssues: true,
+		},
+		"robot-search-learn": {
+			Flag: "--robot-search-learn", Description: "Fit the per-project 'learned' hybrid preset from search click feedback; reports NDCG before vs after.",
+			KeyFields:   []string{"weights", "ndcg_before", "ndcg_after", "events", "pairs"},
+			Params:      []string{"--search-preset <baseline>", "--search-accept <id> (with --search, to log feedback)"},
+			NeedsIssues: true,
+		},
+		"robot-search-alerts": {
+			Flag: "--robot-search-alerts", Description: "Saved searches (.bv/searches.yaml) and the issues that recently entered their top-N.",
+			KeyFields:   []string{"searches", "new", "total_alerts"},
+			Params:      []string{"--search-alerts-since <24h|7d|date>"},
 			NeedsIssues: true,
 		},
 		"robot-label-health": {
@@ -7676,6 +8656,12 @@ func generateRobotDocs(topic string) map[string]interface{} {
 			Flag: "--robot-metrics", Description: "Performance metrics: timing, cache hit rates, memory usage.",
 			NeedsIssues: true,
 		},
+		"robot-delivery-metrics": {
+			Flag: "--robot-delivery-metrics", Description: "DORA-style delivery metrics as weekly series: deployment frequency, lead time, change failure rate, time to restore.",
+			KeyFields:   []string{"summary", "weeks", "releases", "lead_time_basis"},
+			Params:      []string{"--delivery-weeks <n>", "--delivery-tag-pattern <glob>", "--delivery-failure-days <n>", "--history-limit <n>"},
+			NeedsIssues: true,
+		},
 		"robot-orphans": {
 			Flag: "--robot-orphans", Description: "Orphan commit candidates that should be linked to beads.",
 			Params:      []string{"--orphans-min-score 0-100"},
@@ -7686,11 +8672,35 @@ func generateRobotDocs(topic string) map[string]interface{} {
 			Params:      []string{"--file-beads-limit <n>"},
 			NeedsIssues: true,
 		},
+		"robot-blame": {
+			Flag: "--robot-blame <path>[:start-end]", Description: "Beads that explain each surviving line of a file, via git blame.",
+			KeyFields:   []string{"ranges", "ranges[].beads", "beads", "stats.attribution_ratio"},
+			Params:      []string{"--history-limit <n>"},
+			NeedsIssues: true,
+		},
 		"robot-file-hotspots": {
 			Flag: "--robot-file-hotspots", Description: "Files touched by the most beads.",
 			Params:      []string{"--hotspots-limit <n>"},
 			NeedsIssues: true,
 		},
+		"robot-experts": {
+			Flag: "--robot-experts", Description: "Who knows a label or directory best, from recency-weighted bead history.",
+			KeyFields:   []string{"experts", "experts[].share", "directory", "labels", "directories"},
+			Params:      []string{"--label <label>", "--file <path>", "--experts-limit <n>", "--history-limit <n>"},
+			NeedsIssues: true,
+		},
+		"robot-bus-factor": {
+			Flag: "--robot-bus-factor", Description: "Knowledge silos: labels, epics and hot files where one contributor authored most linked commits, ranked by open work.",
+			KeyFields:   []string{"areas", "areas[].top_share", "areas[].open_issues", "areas[].risk", "stats"},
+			Params:      []string{"--bus-factor-threshold 0.0-1.0", "--hotspots-limit <n>", "--history-limit <n>"},
+			NeedsIssues: true,
+		},
+		"robot-churn": {
+			Flag: "--robot-churn", Description: "Reopen rates by label, type and assignee; beads with repeated reopens, priority flips or title rewrites; files linked to reopens; recent vs historical reopen rate.",
+			KeyFields:   []string{"overall", "by_label", "beads", "beads[].hours_lost", "files", "trend"},
+			Params:      []string{"--churn-window <days>", "--history-limit <n>"},
+			NeedsIssues: true,
+		},
 		"robot-file-relations": {
 			Flag: "--robot-file-relations <path>", Description: "Files that frequently co-change with a given file.",
 			Params:      []string{"--relations-threshold 0.0-1.0", "--relations-limit <n>"},
@@ -7756,15 +8766,19 @@ func generateRobotDocs(topic string) map[string]interface{} {
 	}
 
 	envVars := map[string]string{
-		"BV_OUTPUT_FORMAT":    "Default output format: json or toon (overridden by --format)",
-		"TOON_DEFAULT_FORMAT": "Fallback format if BV_OUTPUT_FORMAT not set",
-		"TOON_STATS":          "Set to 1 to show JSON vs TOON token estimates on stderr",
-		"TOON_KEY_FOLDING":    "TOON key folding mode",
-		"TOON_INDENT":         "TOON indentation level (0-16)",
-		"BV_PRETTY_JSON":      "Set to 1 for indented JSON output",
-		"BV_ROBOT":            "Set to 1 to force robot mode (clean stdout)",
-		"BV_SEARCH_MODE":      "Search mode: text or hybrid",
-		"BV_SEARCH_PRESET":    "Hybrid search preset name",
+		"BV_OUTPUT_FORMAT":     "Default output format: json or toon (overridden by --format)",
+		"TOON_DEFAULT_FORMAT":  "Fallback format if BV_OUTPUT_FORMAT not set",
+		"TOON_STATS":           "Set to 1 to show JSON vs TOON token estimates on stderr",
+		"TOON_KEY_FOLDING":     "TOON key folding mode",
+		"TOON_INDENT":          "TOON indentation level (0-16)",
+		"BV_PRETTY_JSON":       "Set to 1 for indented JSON output",
+		"BV_ROBOT":             "Set to 1 to force robot mode (clean stdout)",
+		"BV_HISTORY_DB":        "Set to 1 to read git history through .bv/history.db (same as --history-db)",
+		"BV_SEARCH_MODE":       "Search mode: text or hybrid",
+		"BV_SEARCH_PRESET":     "Hybrid search preset name",
+		"BV_SEMANTIC_EMBEDDER": "Embedding provider: hash (default) or openai",
+		"BV_SEMANTIC_BASE_URL": "OpenAI-compatible embeddings endpoint (default https://api.openai.com/v1)",
+		"BV_SEMANTIC_API_KEY":  "Bearer token for the embeddings endpoint (falls back to OPENAI_API_KEY)",
 	}
 
 	exitCodes := map[string]string{
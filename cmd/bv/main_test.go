@@ -192,28 +192,28 @@ func TestApplyRecipeSort_DefaultsAndFields(t *testing.T) {
 
 	// Priority default ascending
 	r := &recipe.Recipe{Sort: recipe.SortConfig{Field: "priority"}}
-	sorted := applyRecipeSort(append([]model.Issue{}, issues...), r)
+	sorted := applyRecipeSort(append([]model.Issue{}, issues...), r, nil)
 	if sorted[0].ID != "B" {
 		t.Fatalf("priority sort expected B first, got %s", sorted[0].ID)
 	}
 
 	// Created default descending (newest first)
 	r.Sort = recipe.SortConfig{Field: "created"}
-	sorted = applyRecipeSort(append([]model.Issue{}, issues...), r)
+	sorted = applyRecipeSort(append([]model.Issue{}, issues...), r, nil)
 	if sorted[0].ID != "B" {
 		t.Fatalf("created sort expected newest (B) first, got %s", sorted[0].ID)
 	}
 
 	// Title ascending explicit desc
 	r.Sort = recipe.SortConfig{Field: "title", Direction: "desc"}
-	sorted = applyRecipeSort(append([]model.Issue{}, issues...), r)
+	sorted = applyRecipeSort(append([]model.Issue{}, issues...), r, nil)
 	if sorted[0].ID != "A" {
 		t.Fatalf("title desc expected A (zzz) first, got %s", sorted[0].ID)
 	}
 
 	// Status ascending (string compare)
 	r.Sort = recipe.SortConfig{Field: "status"}
-	sorted = applyRecipeSort(append([]model.Issue{}, issues...), r)
+	sorted = applyRecipeSort(append([]model.Issue{}, issues...), r, nil)
 	if sorted[0].ID != "A" { // both open; stable sort keeps original order
 		t.Fatalf("status sort expected A first, got %s", sorted[0].ID)
 	}
@@ -225,14 +225,14 @@ func TestApplyRecipeSort_DefaultsAndFields(t *testing.T) {
 		{ID: "bv-1"},
 	}
 	r.Sort = recipe.SortConfig{Field: "id"}
-	sortedIDs := applyRecipeSort(append([]model.Issue{}, idIssues...), r)
+	sortedIDs := applyRecipeSort(append([]model.Issue{}, idIssues...), r, nil)
 	if sortedIDs[0].ID != "bv-1" || sortedIDs[1].ID != "bv-2" || sortedIDs[2].ID != "bv-10" {
 		t.Fatalf("id natural sort failed: got %v", []string{sortedIDs[0].ID, sortedIDs[1].ID, sortedIDs[2].ID})
 	}
 
 	// Unknown field should preserve order
 	r.Sort = recipe.SortConfig{Field: "unknown"}
-	sorted = applyRecipeSort(append([]model.Issue{}, issues...), r)
+	sorted = applyRecipeSort(append([]model.Issue{}, issues...), r, nil)
 	if sorted[0].ID != "A" || sorted[1].ID != "B" {
 		t.Fatalf("unknown sort field should keep original order, got %v", []string{sorted[0].ID, sorted[1].ID})
 	}
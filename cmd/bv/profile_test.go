@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"os"
 	"strings"
 	"testing"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
 )
 
 // captureStdout runs f while capturing stdout to a string.
@@ -209,7 +211,7 @@ func TestRunProfileStartupJSON(t *testing.T) {
 		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}},
 	}
 	out := captureStdout(t, func() {
-		runProfileStartup(issues, 5*time.Millisecond, true, false)
+		runProfileStartup(issues, 5*time.Millisecond, snapshotCacheStatus(nil, ui.ErrSnapshotCacheMiss), true, false)
 	})
 	var payload map[string]any
 	if err := json.Unmarshal([]byte(out), &payload); err != nil {
@@ -218,4 +220,19 @@ func TestRunProfileStartupJSON(t *testing.T) {
 	if payload["profile"] == nil {
 		t.Fatalf("expected profile field in output")
 	}
+	cache, ok := payload["snapshot_cache"].(map[string]any)
+	if !ok || cache["hit"] != false {
+		t.Fatalf("expected snapshot_cache miss in output, got %v", payload["snapshot_cache"])
+	}
+}
+
+func TestSnapshotCacheStatus(t *testing.T) {
+	miss := snapshotCacheStatus(nil, fmt.Errorf("%w: source changed", ui.ErrSnapshotCacheMiss))
+	if miss.Hit || miss.Reason != "source changed" {
+		t.Fatalf("unexpected miss status: %+v", miss)
+	}
+	out := captureStdout(t, func() { printSnapshotCacheStatus(miss) })
+	if !strings.Contains(out, "Miss: source changed") {
+		t.Fatalf("printSnapshotCacheStatus missing reason: %s", out)
+	}
 }
@@ -1,13 +1,19 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
 )
 
@@ -17,6 +23,7 @@ type robotSearchResult struct {
 	TextScore       float64            `json:"text_score,omitempty"`
 	Title           string             `json:"title,omitempty"`
 	ComponentScores map[string]float64 `json:"component_scores,omitempty"`
+	Match           *search.ChunkMatch `json:"match,omitempty"`
 }
 
 type robotSearchOutput struct {
@@ -33,10 +40,87 @@ type robotSearchOutput struct {
 	Mode        search.SearchMode     `json:"mode"`
 	Preset      search.PresetName     `json:"preset,omitempty"`
 	Weights     *search.Weights       `json:"weights,omitempty"`
+	Filter      string                `json:"filter,omitempty"` // --search-filter, normalized
+	Candidates  int                   `json:"candidates"`       // candidate pool the facets count
+	Facets      search.Facets         `json:"facets"`
+	Accepted    string                `json:"accepted,omitempty"` // recorded via --search-accept
 	Results     []robotSearchResult   `json:"results"`
 	UsageHints  []string              `json:"usage_hints,omitempty"`
 }
 
+// robotSearchAggregateOutput is --search-aggregate: facet distributions for
+// a query without the ranked results.
+type robotSearchAggregateOutput struct {
+	GeneratedAt string        `json:"generated_at"`
+	DataHash    string        `json:"data_hash"`
+	Query       string        `json:"query"`
+	Filter      string        `json:"filter,omitempty"`
+	Candidates  int           `json:"candidates"`
+	Facets      search.Facets `json:"facets"`
+	UsageHints  []string      `json:"usage_hints,omitempty"`
+}
+
+// printSearchFacets writes one line per facet, at most maxBuckets values
+// each (0 = all).
+func printSearchFacets(w io.Writer, facets search.Facets, candidates, maxBuckets int) {
+	fmt.Fprintf(w, "%d candidates\n", candidates)
+	for _, field := range search.FacetFields {
+		buckets, ok := facets[field]
+		if !ok || len(buckets) == 0 {
+			continue
+		}
+		shown := buckets
+		if maxBuckets > 0 && len(shown) > maxBuckets {
+			shown = shown[:maxBuckets]
+		}
+		parts := make([]string, 0, len(shown)+1)
+		for _, b := range shown {
+			parts = append(parts, fmt.Sprintf("%s %d", b.Value, b.Count))
+		}
+		if len(shown) < len(buckets) {
+			parts = append(parts, fmt.Sprintf("+%d more", len(buckets)-len(shown)))
+		}
+		fmt.Fprintf(w, "%-9s %s\n", field+":", strings.Join(parts, ", "))
+	}
+}
+
+type robotSimilarOutput struct {
+	GeneratedAt string                `json:"generated_at"`
+	DataHash    string                `json:"data_hash"`
+	IssueID     string                `json:"issue_id"`
+	Title       string                `json:"title,omitempty"`
+	Provider    search.Provider       `json:"provider"`
+	Model       string                `json:"model,omitempty"`
+	Dim         int                   `json:"dim"`
+	IndexPath   string                `json:"index_path"`
+	Index       search.IndexSyncStats `json:"index"`
+	Limit       int                   `json:"limit"`
+	Results     []robotSearchResult   `json:"results"`
+	UsageHints  []string              `json:"usage_hints,omitempty"`
+}
+
+// openSemanticIndex opens and syncs the current project's semantic index.
+func openSemanticIndex(issues []model.Issue, progress io.Writer) (*search.ProjectIndex, error) {
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	return search.OpenProjectIndex(ctx, projectDir, issues, progress)
+}
+
+// semanticDuplicateDetector returns a --robot-suggest duplicate detector
+// backed by the vector index. threshold 0 calibrates from the corpus.
+func semanticDuplicateDetector(si *search.ProjectIndex, threshold float64) func([]model.Issue) []analysis.Suggestion {
+	return func(issues []model.Issue) []analysis.Suggestion {
+		cfg := search.DefaultSemanticDuplicateConfig(si.Config.Provider)
+		cfg.Threshold = threshold
+		groups, used := search.DetectSemanticDuplicates(issues, si.Index, si.Chunks, cfg)
+		return search.DuplicateGroupSuggestions(groups, issues, used)
+	}
+}
+
 func writeRobotSearchOutput(w io.Writer, out robotSearchOutput) error {
 	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
@@ -55,8 +139,8 @@ func applySearchConfigOverrides(cfg search.SearchConfig, modeFlag, presetFlag, w
 
 	if presetFlag != "" {
 		name := search.PresetName(strings.ToLower(presetFlag))
-		if _, err := search.GetPreset(name); err != nil {
-			return search.SearchConfig{}, err
+		if !search.IsKnownPreset(name) {
+			return search.SearchConfig{}, fmt.Errorf("unknown preset %q", name)
 		}
 		cfg.Preset = name
 	}
@@ -73,12 +157,12 @@ func applySearchConfigOverrides(cfg search.SearchConfig, modeFlag, presetFlag, w
 	return cfg, nil
 }
 
-func resolveSearchWeights(cfg search.SearchConfig) (search.Weights, search.PresetName, error) {
+func resolveSearchWeights(cfg search.SearchConfig, projectDir string) (search.Weights, search.PresetName, error) {
 	if cfg.HasWeights {
 		return cfg.Weights, search.PresetName("custom"), nil
 	}
 
-	weights, err := search.GetPreset(cfg.Preset)
+	weights, err := search.ResolvePreset(cfg.Preset, projectDir)
 	if err != nil {
 		return search.Weights{}, "", err
 	}
@@ -148,3 +232,75 @@ func promoteExactHybridResult(query string, results []search.HybridScore) []sear
 	}
 	return results
 }
+
+type robotSearchAlertsOutput struct {
+	GeneratedAt  string                     `json:"generated_at"`
+	DataHash     string                     `json:"data_hash"`
+	Since        string                     `json:"since"`
+	SearchesPath string                     `json:"searches_path"`
+	Searches     []search.SavedSearchResult `json:"searches"`
+	TotalAlerts  int                        `json:"total_alerts"`
+	UsageHints   []string                   `json:"usage_hints,omitempty"`
+}
+
+func newRobotSearchAlertsOutput(results []search.SavedSearchResult, dataHash, projectDir string, now, since time.Time) robotSearchAlertsOutput {
+	out := robotSearchAlertsOutput{
+		GeneratedAt:  now.UTC().Format(time.RFC3339),
+		DataHash:     dataHash,
+		Since:        since.UTC().Format(time.RFC3339),
+		SearchesPath: search.SavedSearchesPath(projectDir),
+		Searches:     results,
+		UsageHints: []string{
+			"jq '.searches[] | {name, new: [.new[].issue_id]}' - New arrivals per search",
+			"jq '.searches[] | select(.error)' - Searches that failed to evaluate",
+			"jq '.searches[] | select(.baseline)' - First run or edited: membership recorded, nothing alerted",
+			"--search-alerts-since 7d - Widen the alert window",
+		},
+	}
+	if out.Searches == nil {
+		out.Searches = []search.SavedSearchResult{}
+	}
+	for _, res := range results {
+		out.TotalAlerts += len(res.New)
+	}
+	return out
+}
+
+// parseSearchAlertsSince accepts a Go duration ("24h"), a relative time
+// ("7d") or a date, and returns the alert cutoff.
+func parseSearchAlertsSince(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return now.Add(-search.DefaultSavedSearchAlertWindow), nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	since, err := recipe.ParseRelativeTime(s, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --search-alerts-since: %w", err)
+	}
+	return since, nil
+}
+
+type robotSearchLearnOutput struct {
+	GeneratedAt  string `json:"generated_at"`
+	DataHash     string `json:"data_hash"`
+	FeedbackPath string `json:"feedback_path"`
+	WeightsPath  string `json:"weights_path"`
+	*search.LearnedWeights
+	NDCGCutoff int      `json:"ndcg_cutoff"`
+	UsageHints []string `json:"usage_hints,omitempty"`
+}
+
+type robotSearchHistoryOutput struct {
+	GeneratedAt string                    `json:"generated_at"`
+	DataHash    string                    `json:"data_hash"`
+	Query       string                    `json:"query"`
+	IndexPath   string                    `json:"index_path"`
+	Index       search.HistoryUpdateStats `json:"index"`
+	Docs        int                       `json:"docs"`
+	Limit       int                       `json:"limit"`
+	Results     []search.HistoryHit       `json:"results"`
+	UsageHints  []string                  `json:"usage_hints,omitempty"`
+}
@@ -2,6 +2,7 @@ package datasource
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
@@ -88,3 +89,88 @@ func LoadFromSource(source DataSource) ([]model.Issue, error) {
 		return nil, fmt.Errorf("unknown source type: %s", source.Type)
 	}
 }
+
+// LoadIssuesSlim is LoadIssues for very large datasets. It selects the best
+// source the same way but decodes only graph fields (no description, design,
+// acceptance criteria, notes or comments), returning a DetailLoader that
+// fetches the full form of any single issue on demand.
+func LoadIssuesSlim(repoPath string) ([]model.Issue, loader.DetailLoader, error) {
+	beadsDir, err := loader.GetBeadsDir(repoPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sources, err := DiscoverSources(DiscoveryOptions{
+		BeadsDir:               beadsDir,
+		RepoPath:               repoPath,
+		ValidateAfterDiscovery: true,
+		IncludeInvalid:         false,
+	})
+	if err == nil && len(sources) > 0 {
+		if best, err := SelectBestSource(sources); err == nil {
+			if issues, details, err := LoadSlimFromSource(best); err == nil {
+				return issues, details, nil
+			}
+		}
+	}
+
+	// Fall back to the preferred JSONL file
+	jsonlPath, err := loader.FindJSONLPath(beadsDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	slim, err := loader.LoadIssuesSlimFromFile(jsonlPath, loader.ParseOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	return slim.Issues, slim.Details, nil
+}
+
+// LoadSlimFromSource streams graph fields from a specific DataSource.
+func LoadSlimFromSource(source DataSource) ([]model.Issue, loader.DetailLoader, error) {
+	switch source.Type {
+	case SourceTypeSQLite:
+		reader, err := NewSQLiteReader(source)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open SQLite source %s: %w", source.Path, err)
+		}
+		defer reader.Close()
+		var issues []model.Issue
+		if err := reader.StreamIssues(func(issue *model.Issue) error {
+			issues = append(issues, *issue)
+			return nil
+		}); err != nil {
+			return nil, nil, err
+		}
+		// StreamIssues yields id order; match the full load's order
+		sort.SliceStable(issues, func(i, j int) bool {
+			return issues[i].UpdatedAt.After(issues[j].UpdatedAt)
+		})
+		return issues, sqliteDetailLoader{source: source}, nil
+
+	case SourceTypeJSONLLocal, SourceTypeJSONLWorktree:
+		slim, err := loader.LoadIssuesSlimFromFile(source.Path, loader.ParseOptions{})
+		if err != nil {
+			return nil, nil, err
+		}
+		return slim.Issues, slim.Details, nil
+
+	default:
+		return nil, nil, fmt.Errorf("unknown source type: %s", source.Type)
+	}
+}
+
+// sqliteDetailLoader opens the database per lookup so slim loads do not pin
+// a connection for the lifetime of the process.
+type sqliteDetailLoader struct {
+	source DataSource
+}
+
+func (l sqliteDetailLoader) LoadIssueDetails(id string) (*model.Issue, error) {
+	reader, err := NewSQLiteReader(l.source)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return reader.LoadIssueDetails(id)
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	_ "modernc.org/sqlite"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
 // TestDiscoverSources_OnlySQLite tests discovery with only a SQLite source
@@ -717,3 +719,156 @@ func createEmptySQLiteDB(t *testing.T, path string) {
 		t.Fatal(err)
 	}
 }
+
+// TestSQLiteReader_StreamIssues tests graph-only streaming with merged dependencies
+func TestSQLiteReader_StreamIssues(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "beads.db")
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`
+		CREATE TABLE issues (
+			id TEXT PRIMARY KEY, title TEXT NOT NULL, description TEXT,
+			status TEXT NOT NULL, priority INTEGER DEFAULT 2, issue_type TEXT DEFAULT 'task',
+			assignee TEXT, estimated_minutes INTEGER, created_at DATETIME, updated_at DATETIME,
+			due_date DATETIME, closed_at DATETIME, external_ref TEXT, compaction_level INTEGER,
+			compacted_at DATETIME, compacted_at_commit TEXT, original_size INTEGER,
+			labels TEXT, design TEXT, acceptance_criteria TEXT, notes TEXT, source_repo TEXT,
+			tombstone INTEGER DEFAULT 0
+		);
+		CREATE TABLE dependencies (issue_id TEXT, depends_on_id TEXT, dependency_type TEXT);
+		CREATE TABLE comments (id INTEGER PRIMARY KEY, issue_id TEXT, author TEXT, text TEXT, created_at DATETIME);
+		INSERT INTO issues (id, title, description, status, labels, notes) VALUES
+			('B-2', 'Second', 'long b', 'open', '["api"]', 'note'),
+			('A-1', 'First', 'long a', 'open', NULL, NULL),
+			('C-3', 'Third', 'long c', 'closed', NULL, NULL),
+			('D-4', 'Gone', 'x', 'open', NULL, NULL);
+		UPDATE issues SET tombstone = 1 WHERE id = 'D-4';
+		INSERT INTO dependencies VALUES
+			('C-3', 'B-2', 'blocks'), ('B-2', 'A-1', 'blocks'), ('C-3', 'A-1', 'related'), ('Z-9', 'A-1', 'blocks');
+		INSERT INTO comments (issue_id, author, text) VALUES ('B-2', 'alice', 'looks good');
+	`)
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := NewSQLiteReader(DataSource{Type: SourceTypeSQLite, Path: dbPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+
+	depCounts := map[string]int{}
+	err = reader.StreamIssues(func(issue *model.Issue) error {
+		if issue.Description != "" || issue.Notes != "" {
+			t.Errorf("%s: stream should skip long-form fields", issue.ID)
+		}
+		depCounts[issue.ID] = len(issue.Dependencies)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamIssues failed: %v", err)
+	}
+	want := map[string]int{"A-1": 0, "B-2": 1, "C-3": 2}
+	if len(depCounts) != len(want) {
+		t.Fatalf("Expected %d issues, got %v", len(want), depCounts)
+	}
+	for id, n := range want {
+		if depCounts[id] != n {
+			t.Errorf("%s: expected %d deps, got %d", id, n, depCounts[id])
+		}
+	}
+
+	full, err := reader.LoadIssueDetails("B-2")
+	if err != nil {
+		t.Fatalf("LoadIssueDetails failed: %v", err)
+	}
+	if full.Description != "long b" || full.Notes != "note" || len(full.Comments) != 1 || len(full.Labels) != 1 {
+		t.Errorf("LoadIssueDetails returned incomplete issue: %+v", full)
+	}
+	if _, err := reader.LoadIssueDetails("missing"); err == nil {
+		t.Error("Expected error for missing issue")
+	}
+}
+
+// TestLoadSlimFromSource_SQLiteMatchesJSONL loads the same issues from JSONL
+// and SQLite and expects identical slim results
+func TestLoadSlimFromSource_SQLiteMatchesJSONL(t *testing.T) {
+	dir := t.TempDir()
+	jsonlPath := filepath.Join(dir, "issues.jsonl")
+	jsonl := `{"id":"B-2","title":"Second","description":"long b","status":"open","priority":1,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-03T00:00:00Z","dependencies":[{"issue_id":"B-2","depends_on_id":"A-1","type":"blocks"}],"comments":[{"id":1,"issue_id":"B-2","author":"alice","text":"looks good","created_at":"2025-01-02T00:00:00Z"},{"id":2,"issue_id":"B-2","author":"bob","text":"agreed","created_at":"2025-01-02T12:00:00Z"}]}
+{"id":"C-3","title":"Third","description":"long c","status":"closed","priority":2,"issue_type":"bug","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-02T00:00:00Z","comments":[{"id":3,"issue_id":"C-3","author":"carol","text":"fixed","created_at":"2025-01-02T00:00:00Z"}]}
+{"id":"A-1","title":"First","description":"long a","status":"open","priority":2,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"}
+`
+	if err := os.WriteFile(jsonlPath, []byte(jsonl), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dbPath := filepath.Join(dir, "beads.db")
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`
+		CREATE TABLE issues (
+			id TEXT PRIMARY KEY, title TEXT NOT NULL, description TEXT,
+			status TEXT NOT NULL, priority INTEGER DEFAULT 2, issue_type TEXT DEFAULT 'task',
+			assignee TEXT, estimated_minutes INTEGER, created_at DATETIME, updated_at DATETIME,
+			due_date DATETIME, closed_at DATETIME, external_ref TEXT, compaction_level INTEGER,
+			compacted_at DATETIME, compacted_at_commit TEXT, original_size INTEGER,
+			labels TEXT, design TEXT, acceptance_criteria TEXT, notes TEXT, source_repo TEXT,
+			tombstone INTEGER DEFAULT 0
+		);
+		CREATE TABLE dependencies (issue_id TEXT, depends_on_id TEXT, dependency_type TEXT);
+		CREATE TABLE comments (id INTEGER PRIMARY KEY, issue_id TEXT, author TEXT, text TEXT, created_at DATETIME);
+		INSERT INTO issues (id, title, description, status, priority, issue_type, created_at, updated_at) VALUES
+			('A-1', 'First', 'long a', 'open', 2, 'task', '2025-01-01T00:00:00Z', '2025-01-01T00:00:00Z'),
+			('B-2', 'Second', 'long b', 'open', 1, 'task', '2025-01-01T00:00:00Z', '2025-01-03T00:00:00Z'),
+			('C-3', 'Third', 'long c', 'closed', 2, 'bug', '2025-01-01T00:00:00Z', '2025-01-02T00:00:00Z');
+		INSERT INTO dependencies VALUES ('B-2', 'A-1', 'blocks');
+		INSERT INTO comments (id, issue_id, author, text, created_at) VALUES
+			(3, 'C-3', 'carol', 'fixed', '2025-01-02T00:00:00Z'),
+			(1, 'B-2', 'alice', 'looks good', '2025-01-02T00:00:00Z'),
+			(2, 'B-2', 'bob', 'agreed', '2025-01-02T12:00:00Z');
+	`)
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fromJSONL, _, err := LoadSlimFromSource(DataSource{Type: SourceTypeJSONLLocal, Path: jsonlPath})
+	if err != nil {
+		t.Fatalf("JSONL slim load failed: %v", err)
+	}
+	fromSQLite, _, err := LoadSlimFromSource(DataSource{Type: SourceTypeSQLite, Path: dbPath})
+	if err != nil {
+		t.Fatalf("SQLite slim load failed: %v", err)
+	}
+
+	if len(fromJSONL) != len(fromSQLite) {
+		t.Fatalf("JSONL loaded %d issues, SQLite %d", len(fromJSONL), len(fromSQLite))
+	}
+	for i := range fromJSONL {
+		j, s := fromJSONL[i], fromSQLite[i]
+		if j.ID != s.ID {
+			t.Fatalf("issue %d: JSONL %s, SQLite %s", i, j.ID, s.ID)
+		}
+		if j.Description != "" || s.Description != "" {
+			t.Errorf("%s: slim loads should skip descriptions", j.ID)
+		}
+		if len(j.Dependencies) != len(s.Dependencies) {
+			t.Errorf("%s: JSONL has %d deps, SQLite %d", j.ID, len(j.Dependencies), len(s.Dependencies))
+		}
+		if len(j.Comments) != len(s.Comments) {
+			t.Fatalf("%s: JSONL has %d comments, SQLite %d", j.ID, len(j.Comments), len(s.Comments))
+		}
+		for k := range j.Comments {
+			jc, sc := j.Comments[k], s.Comments[k]
+			if jc.ID != sc.ID || jc.Author != sc.Author || !jc.CreatedAt.Equal(sc.CreatedAt) || jc.Text != "" || sc.Text != "" {
+				t.Errorf("%s comment %d: JSONL %+v, SQLite %+v", j.ID, k, jc, sc)
+			}
+		}
+	}
+}
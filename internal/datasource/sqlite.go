@@ -66,15 +66,10 @@ func (r *SQLiteReader) LoadIssues() ([]model.Issue, error) {
 func (r *SQLiteReader) LoadIssuesFiltered(filter func(*model.Issue) bool) ([]model.Issue, error) {
 	// Query for all non-tombstone issues
 	query := `
-		SELECT
-			id, title, description, status, priority, issue_type,
-			assignee, estimated_minutes, created_at, updated_at,
-			due_date, closed_at, external_ref, compaction_level,
-			compacted_at, compacted_at_commit, original_size,
-			labels, design, acceptance_criteria, notes, source_repo
+		SELECT ` + fullIssueColumns + `
 		FROM issues
 		WHERE (tombstone IS NULL OR tombstone = 0)
-		ORDER BY updated_at DESC
+		ORDER BY updated_at DESC, id
 	`
 
 	rows, err := r.db.Query(query)
@@ -85,33 +80,177 @@ func (r *SQLiteReader) LoadIssuesFiltered(filter func(*model.Issue) bool) ([]mod
 	defer rows.Close()
 
 	var issues []model.Issue
+	for rows.Next() {
+		issue, err := scanFullIssue(rows)
+		if err != nil {
+			continue
+		}
+
+		// Load dependencies for this issue
+		issue.Dependencies = r.loadDependencies(issue.ID)
+
+		// Load comments for this issue
+		issue.Comments = r.loadComments(issue.ID)
+
+		// Apply filter
+		if filter != nil && !filter(&issue) {
+			continue
+		}
+
+		issues = append(issues, issue)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating issues: %w", err)
+	}
+
+	return issues, nil
+}
+
+// fullIssueColumns lists the issue columns read by the full loaders, in the
+// order expected by scanFullIssue.
+const fullIssueColumns = `
+			id, title, description, status, priority, issue_type,
+			assignee, estimated_minutes, created_at, updated_at,
+			due_date, closed_at, external_ref, compaction_level,
+			compacted_at, compacted_at_commit, original_size,
+			labels, design, acceptance_criteria, notes, source_repo`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanFullIssue scans a row selected with fullIssueColumns.
+func scanFullIssue(row rowScanner) (model.Issue, error) {
+	var issue model.Issue
+	var estimatedMinutes, compactionLevel, originalSize sql.NullInt64
+	var createdAt, updatedAt, dueDate, closedAt, compactedAt sql.NullTime
+	var description, assignee, externalRef, design, acceptanceCriteria, notes, sourceRepo, compactedAtCommit sql.NullString
+	var labelsJSON sql.NullString
+	var issueType string
+
+	err := row.Scan(
+		&issue.ID, &issue.Title, &description, &issue.Status, &issue.Priority, &issueType,
+		&assignee, &estimatedMinutes, &createdAt, &updatedAt,
+		&dueDate, &closedAt, &externalRef, &compactionLevel,
+		&compactedAt, &compactedAtCommit, &originalSize,
+		&labelsJSON, &design, &acceptanceCriteria, &notes, &sourceRepo,
+	)
+	if err != nil {
+		return model.Issue{}, err
+	}
+
+	// Map nullable fields
+	if description.Valid {
+		issue.Description = description.String
+	}
+	issue.IssueType = model.IssueType(issueType)
+	if assignee.Valid {
+		issue.Assignee = assignee.String
+	}
+	if estimatedMinutes.Valid {
+		v := int(estimatedMinutes.Int64)
+		issue.EstimatedMinutes = &v
+	}
+	if createdAt.Valid {
+		issue.CreatedAt = createdAt.Time
+	}
+	if updatedAt.Valid {
+		issue.UpdatedAt = updatedAt.Time
+	}
+	if dueDate.Valid {
+		t := dueDate.Time
+		issue.DueDate = &t
+	}
+	if closedAt.Valid {
+		t := closedAt.Time
+		issue.ClosedAt = &t
+	}
+	if externalRef.Valid {
+		s := externalRef.String
+		issue.ExternalRef = &s
+	}
+	if compactionLevel.Valid {
+		issue.CompactionLevel = int(compactionLevel.Int64)
+	}
+	if compactedAt.Valid {
+		t := compactedAt.Time
+		issue.CompactedAt = &t
+	}
+	if compactedAtCommit.Valid {
+		s := compactedAtCommit.String
+		issue.CompactedAtCommit = &s
+	}
+	if originalSize.Valid {
+		issue.OriginalSize = int(originalSize.Int64)
+	}
+	if design.Valid {
+		issue.Design = design.String
+	}
+	if acceptanceCriteria.Valid {
+		issue.AcceptanceCriteria = acceptanceCriteria.String
+	}
+	if notes.Valid {
+		issue.Notes = notes.String
+	}
+	if sourceRepo.Valid {
+		issue.SourceRepo = sourceRepo.String
+	}
+
+	// Parse labels JSON array
+	if labelsJSON.Valid && labelsJSON.String != "" && labelsJSON.String != "null" {
+		issue.Labels = parseJSONStringArray(labelsJSON.String)
+	}
+
+	return issue, nil
+}
+
+// StreamIssues calls fn for each non-tombstone issue in id order, decoding
+// only the fields needed for graph analysis. Description, design, acceptance
+// criteria and notes are left empty, and comments are stubs without text,
+// as in a slim JSONL load; use LoadIssueDetails to fetch them.
+//
+// Issues, dependencies and comments are read with ordered cursors and
+// merged, so memory stays constant regardless of database size. Returning
+// an error from fn stops the stream and returns that error.
+func (r *SQLiteReader) StreamIssues(fn func(*model.Issue) error) error {
+	query := `
+		SELECT id, title, status, priority, issue_type, assignee,
+			estimated_minutes, created_at, updated_at, due_date, closed_at,
+			compaction_level, labels, source_repo
+		FROM issues
+		WHERE (tombstone IS NULL OR tombstone = 0)
+		ORDER BY id
+	`
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return r.streamIssuesSimple(fn)
+	}
+	defer rows.Close()
+
+	deps := r.newDependencyCursor()
+	defer deps.close()
+	comments := r.newCommentCursor()
+	defer comments.close()
+
 	for rows.Next() {
 		var issue model.Issue
-		var estimatedMinutes, compactionLevel, originalSize sql.NullInt64
-		var createdAt, updatedAt, dueDate, closedAt, compactedAt sql.NullTime
-		var description, assignee, externalRef, design, acceptanceCriteria, notes, sourceRepo, compactedAtCommit sql.NullString
-		var labelsJSON sql.NullString
+		var assignee, labelsJSON, sourceRepo sql.NullString
+		var estimatedMinutes, compactionLevel sql.NullInt64
+		var createdAt, updatedAt, dueDate, closedAt sql.NullTime
 		var issueType string
 
-		err := rows.Scan(
-			&issue.ID, &issue.Title, &description, &issue.Status, &issue.Priority, &issueType,
-			&assignee, &estimatedMinutes, &createdAt, &updatedAt,
-			&dueDate, &closedAt, &externalRef, &compactionLevel,
-			&compactedAt, &compactedAtCommit, &originalSize,
-			&labelsJSON, &design, &acceptanceCriteria, &notes, &sourceRepo,
-		)
-		if err != nil {
+		if err := rows.Scan(
+			&issue.ID, &issue.Title, &issue.Status, &issue.Priority, &issueType, &assignee,
+			&estimatedMinutes, &createdAt, &updatedAt, &dueDate, &closedAt,
+			&compactionLevel, &labelsJSON, &sourceRepo,
+		); err != nil {
 			continue
 		}
 
-		// Map nullable fields
-		if description.Valid {
-			issue.Description = description.String
-		}
 		issue.IssueType = model.IssueType(issueType)
-		if assignee.Valid {
-			issue.Assignee = assignee.String
-		}
+		issue.Assignee = assignee.String
+		issue.SourceRepo = sourceRepo.String
 		if estimatedMinutes.Valid {
 			v := int(estimatedMinutes.Int64)
 			issue.EstimatedMinutes = &v
@@ -130,61 +269,183 @@ func (r *SQLiteReader) LoadIssuesFiltered(filter func(*model.Issue) bool) ([]mod
 			t := closedAt.Time
 			issue.ClosedAt = &t
 		}
-		if externalRef.Valid {
-			s := externalRef.String
-			issue.ExternalRef = &s
-		}
 		if compactionLevel.Valid {
 			issue.CompactionLevel = int(compactionLevel.Int64)
 		}
-		if compactedAt.Valid {
-			t := compactedAt.Time
-			issue.CompactedAt = &t
+		if labelsJSON.Valid && labelsJSON.String != "" && labelsJSON.String != "null" {
+			issue.Labels = parseJSONStringArray(labelsJSON.String)
 		}
-		if compactedAtCommit.Valid {
-			s := compactedAtCommit.String
-			issue.CompactedAtCommit = &s
+		issue.Dependencies = deps.take(issue.ID)
+		issue.Comments = comments.take(issue.ID)
+
+		if err := fn(&issue); err != nil {
+			return err
 		}
-		if originalSize.Valid {
-			issue.OriginalSize = int(originalSize.Int64)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating issues: %w", err)
+	}
+	return nil
+}
+
+// streamIssuesSimple is the StreamIssues fallback for databases with fewer columns.
+func (r *SQLiteReader) streamIssuesSimple(fn func(*model.Issue) error) error {
+	issues, err := r.loadIssuesSimple(nil)
+	if err != nil {
+		return err
+	}
+	for i := range issues {
+		issues[i].Description = ""
+		if err := fn(&issues[i]); err != nil {
+			return err
 		}
-		if design.Valid {
-			issue.Design = design.String
+	}
+	return nil
+}
+
+// dependencyCursor walks the dependencies table ordered by issue_id so it
+// can be merged with an issue cursor ordered by id.
+type dependencyCursor struct {
+	rows    *sql.Rows
+	pending *model.Dependency
+	done    bool
+}
+
+func (r *SQLiteReader) newDependencyCursor() *dependencyCursor {
+	rows, err := r.db.Query(`SELECT issue_id, depends_on_id, dependency_type FROM dependencies ORDER BY issue_id`)
+	if err != nil {
+		return &dependencyCursor{done: true}
+	}
+	return &dependencyCursor{rows: rows}
+}
+
+func (c *dependencyCursor) advance() {
+	c.pending = nil
+	for !c.done {
+		if !c.rows.Next() {
+			c.done = true
+			return
 		}
-		if acceptanceCriteria.Valid {
-			issue.AcceptanceCriteria = acceptanceCriteria.String
+		var dep model.Dependency
+		var depType sql.NullString
+		if err := c.rows.Scan(&dep.IssueID, &dep.DependsOnID, &depType); err != nil {
+			continue
 		}
-		if notes.Valid {
-			issue.Notes = notes.String
+		dep.Type = model.DependencyType(depType.String)
+		c.pending = &dep
+		return
+	}
+}
+
+// take returns the dependencies for issueID. IDs must be requested in
+// ascending order; dependencies of skipped IDs are discarded.
+func (c *dependencyCursor) take(issueID string) []*model.Dependency {
+	var deps []*model.Dependency
+	for {
+		if c.pending == nil {
+			c.advance()
+			if c.pending == nil {
+				return deps
+			}
 		}
-		if sourceRepo.Valid {
-			issue.SourceRepo = sourceRepo.String
+		switch {
+		case c.pending.IssueID < issueID:
+			c.pending = nil
+		case c.pending.IssueID == issueID:
+			deps = append(deps, c.pending)
+			c.pending = nil
+		default:
+			return deps
 		}
+	}
+}
 
-		// Parse labels JSON array
-		if labelsJSON.Valid && labelsJSON.String != "" && labelsJSON.String != "null" {
-			labels := parseJSONStringArray(labelsJSON.String)
-			issue.Labels = labels
-		}
+func (c *dependencyCursor) close() {
+	if c.rows != nil {
+		c.rows.Close()
+	}
+}
 
-		// Load dependencies for this issue
-		issue.Dependencies = r.loadDependencies(issue.ID)
+// commentCursor walks comment metadata ordered by issue_id, the same way
+// dependencyCursor walks dependencies. Comment text is not read.
+type commentCursor struct {
+	rows    *sql.Rows
+	pending *model.Comment
+	done    bool
+}
 
-		// Load comments for this issue
-		issue.Comments = r.loadComments(issue.ID)
+func (r *SQLiteReader) newCommentCursor() *commentCursor {
+	rows, err := r.db.Query(`SELECT id, issue_id, author, created_at FROM comments ORDER BY issue_id, created_at`)
+	if err != nil {
+		return &commentCursor{done: true}
+	}
+	return &commentCursor{rows: rows}
+}
 
-		// Apply filter
-		if filter != nil && !filter(&issue) {
+func (c *commentCursor) advance() {
+	c.pending = nil
+	for !c.done {
+		if !c.rows.Next() {
+			c.done = true
+			return
+		}
+		var comment model.Comment
+		var createdAt sql.NullTime
+		if err := c.rows.Scan(&comment.ID, &comment.IssueID, &comment.Author, &createdAt); err != nil {
 			continue
 		}
+		if createdAt.Valid {
+			comment.CreatedAt = createdAt.Time
+		}
+		c.pending = &comment
+		return
+	}
+}
 
-		issues = append(issues, issue)
+// take returns the comment stubs for issueID. IDs must be requested in
+// ascending order; comments of skipped IDs are discarded.
+func (c *commentCursor) take(issueID string) []*model.Comment {
+	var comments []*model.Comment
+	for {
+		if c.pending == nil {
+			c.advance()
+			if c.pending == nil {
+				return comments
+			}
+		}
+		switch {
+		case c.pending.IssueID < issueID:
+			c.pending = nil
+		case c.pending.IssueID == issueID:
+			comments = append(comments, c.pending)
+			c.pending = nil
+		default:
+			return comments
+		}
 	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating issues: %w", err)
+}
+
+func (c *commentCursor) close() {
+	if c.rows != nil {
+		c.rows.Close()
 	}
+}
 
-	return issues, nil
+// LoadIssueDetails loads one issue with all fields, dependencies and comments.
+// It satisfies loader.DetailLoader for issues obtained from StreamIssues.
+func (r *SQLiteReader) LoadIssueDetails(id string) (*model.Issue, error) {
+	row := r.db.QueryRow(`SELECT `+fullIssueColumns+` FROM issues WHERE id = ?`, id)
+	issue, err := scanFullIssue(row)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("issue not found: %s", id)
+	}
+	if err != nil {
+		// Older schemas lack some columns; fall back to the filtered loader.
+		return r.GetIssueByID(id)
+	}
+	issue.Dependencies = r.loadDependencies(issue.ID)
+	issue.Comments = r.loadComments(issue.ID)
+	return &issue, nil
 }
 
 // loadIssuesSimple is a fallback for databases with fewer columns
@@ -193,7 +454,7 @@ func (r *SQLiteReader) loadIssuesSimple(filter func(*model.Issue) bool) ([]model
 		SELECT id, title, description, status, priority, issue_type, created_at, updated_at
 		FROM issues
 		WHERE (tombstone IS NULL OR tombstone = 0)
-		ORDER BY updated_at DESC
+		ORDER BY updated_at DESC, id
 	`
 
 	rows, err := r.db.Query(query)
@@ -0,0 +1,157 @@
+package analysis_test
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ============================================================================
+// Streaming-Load Benchmarks: --robot-triage on archive-sized JSONL
+// ============================================================================
+//
+// Compares the retained heap of the full parser against the graph-only
+// streaming loader (--stream-load) for the robot triage path. Issues carry
+// ~2KB of description plus comments, matching real archives where long-form
+// text dominates file size.
+//
+// The default size keeps `go test -bench` affordable. To reproduce the
+// 200k-issue archive case:
+//
+//   BV_BENCH_ARCHIVE_ISSUES=200000 go test -run=^$ -bench=BenchmarkRobotTriage_Archive -benchtime=1x ./pkg/analysis/
+//
+// Reported metrics:
+//   live-MB    heap retained after load (issues held for analysis)
+//   peak-MB    heap in use after triage completes, before release
+
+func archiveIssueCount() int {
+	if v := os.Getenv("BV_BENCH_ARCHIVE_ISSUES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 20000
+}
+
+// writeArchiveJSONL writes n issues with long descriptions to a temp file.
+func writeArchiveJSONL(tb testing.TB, n int) string {
+	tb.Helper()
+	path := filepath.Join(tb.TempDir(), "beads.jsonl")
+	f, err := os.Create(path)
+	if err != nil {
+		tb.Fatalf("create archive: %v", err)
+	}
+	w := bufio.NewWriterSize(f, 1<<20)
+	body := strings.Repeat("Long-form design discussion and reproduction notes. ", 40)
+	statuses := []string{"open", "in_progress", "blocked", "closed", "closed"}
+	for i := 0; i < n; i++ {
+		deps := ""
+		if i > 0 {
+			deps = fmt.Sprintf(`,"dependencies":[{"issue_id":"arc-%d","depends_on_id":"arc-%d","type":"blocks"}]`, i, i/2)
+		}
+		fmt.Fprintf(w, `{"id":"arc-%d","title":"Archive issue %d","description":"%s","notes":"%s","status":"%s","priority":%d,"issue_type":"task","labels":["area-%d"],"created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-02T00:00:00Z","comments":[{"id":%d,"issue_id":"arc-%d","author":"a","text":"%s","created_at":"2024-01-02T00:00:00Z"}]%s}`+"\n",
+			i, i, body, body[:200], statuses[i%len(statuses)], i%5, i%50, i, i, body[:400], deps)
+	}
+	if err := w.Flush(); err != nil {
+		tb.Fatalf("flush archive: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		tb.Fatalf("close archive: %v", err)
+	}
+	return path
+}
+
+func heapInUseMB() float64 {
+	runtime.GC()
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return float64(ms.HeapInuse) / (1 << 20)
+}
+
+func BenchmarkRobotTriage_Archive(b *testing.B) {
+	n := archiveIssueCount()
+	path := writeArchiveJSONL(b, n)
+	opts := loader.ParseOptions{WarningHandler: func(string) {}}
+
+	loaders := []struct {
+		name string
+		load func() ([]model.Issue, error)
+	}{
+		{"load=full", func() ([]model.Issue, error) {
+			return loader.LoadIssuesFromFileWithOptions(path, opts)
+		}},
+		{"load=stream", func() ([]model.Issue, error) {
+			slim, err := loader.LoadIssuesSlimFromFile(path, opts)
+			return slim.Issues, err
+		}},
+	}
+
+	for _, l := range loaders {
+		b.Run(fmt.Sprintf("issues=%d/%s", n, l.name), func(b *testing.B) {
+			b.ReportAllocs()
+			var live, peak float64
+			for i := 0; i < b.N; i++ {
+				base := heapInUseMB()
+				issues, err := l.load()
+				if err != nil {
+					b.Fatalf("load: %v", err)
+				}
+				if len(issues) != n {
+					b.Fatalf("loaded %d issues, want %d", len(issues), n)
+				}
+				live = heapInUseMB() - base
+
+				triage := analysis.ComputeTriageWithOptions(issues, analysis.TriageOptions{
+					WaitForPhase2: true,
+					UseFastConfig: true,
+				})
+				peak = heapInUseMB() - base
+				runtime.KeepAlive(issues)
+				runtime.KeepAlive(triage)
+			}
+			b.ReportMetric(live, "live-MB")
+			b.ReportMetric(peak, "peak-MB")
+		})
+	}
+}
+
+// TestStreamingLoad_TriageMatchesFullLoad guards that the graph-only loader
+// feeds triage the same inputs as the full parser.
+func TestStreamingLoad_TriageMatchesFullLoad(t *testing.T) {
+	path := writeArchiveJSONL(t, 300)
+	opts := loader.ParseOptions{WarningHandler: func(string) {}}
+
+	full, err := loader.LoadIssuesFromFileWithOptions(path, opts)
+	if err != nil {
+		t.Fatalf("full load: %v", err)
+	}
+	slim, err := loader.LoadIssuesSlimFromFile(path, opts)
+	if err != nil {
+		t.Fatalf("slim load: %v", err)
+	}
+
+	triageOpts := analysis.TriageOptions{WaitForPhase2: true, UseFastConfig: true}
+	a := analysis.ComputeTriageWithOptions(full, triageOpts)
+	b := analysis.ComputeTriageWithOptions(slim.Issues, triageOpts)
+
+	if len(a.Recommendations) != len(b.Recommendations) {
+		t.Fatalf("recommendation count differs: full=%d slim=%d", len(a.Recommendations), len(b.Recommendations))
+	}
+	for i := range a.Recommendations {
+		if a.Recommendations[i].ID != b.Recommendations[i].ID || math.Abs(a.Recommendations[i].Score-b.Recommendations[i].Score) > 1e-6 {
+			t.Fatalf("recommendation %d differs: full=%s/%.4f slim=%s/%.4f", i,
+				a.Recommendations[i].ID, a.Recommendations[i].Score,
+				b.Recommendations[i].ID, b.Recommendations[i].Score)
+		}
+	}
+}
@@ -0,0 +1,312 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Bus factor area kinds
+const (
+	BusFactorLabel = "label"
+	BusFactorEpic  = "epic"
+	BusFactorFile  = "file"
+)
+
+// BusFactorOptions tunes knowledge-concentration detection
+type BusFactorOptions struct {
+	Threshold    float64            // Top contributor share that makes an area a silo (default 0.5)
+	MinCommits   int                // Areas with fewer linked commits are ignored (default 3)
+	HotspotLimit int                // Hot files to examine (default 10)
+	CriticalPath map[string]float64 // Critical-path depth per issue (optional)
+}
+
+// DefaultBusFactorOptions returns the default silo thresholds
+func DefaultBusFactorOptions() BusFactorOptions {
+	return BusFactorOptions{Threshold: 0.5, MinCommits: 3, HotspotLimit: 10}
+}
+
+// BusFactorArea is a label, epic or hot file whose linked commits come
+// mostly from one contributor
+type BusFactorArea struct {
+	Kind           string   `json:"kind"` // label, epic, file
+	Key            string   `json:"key"`  // Label name, epic ID or file path
+	Title          string   `json:"title,omitempty"`
+	TotalCommits   int      `json:"total_commits"`
+	Contributors   int      `json:"contributors"`
+	BusFactor      int      `json:"bus_factor"` // Fewest contributors covering over half the commits
+	TopAuthor      string   `json:"top_author"`
+	TopAuthorEmail string   `json:"top_author_email,omitempty"`
+	TopShare       float64  `json:"top_share"` // Top contributor's share of commits
+	OpenCount      int      `json:"open_count"`
+	CriticalOpen   int      `json:"critical_open"` // Open issues in the upper half of critical-path depth
+	OpenIssues     []string `json:"open_issues,omitempty"`
+	Risk           float64  `json:"risk"` // Concentration weighted by open and critical work
+}
+
+// BusFactorStats summarizes the areas examined
+type BusFactorStats struct {
+	AreasAnalyzed int            `json:"areas_analyzed"`
+	Silos         int            `json:"silos"`
+	SilosWithOpen int            `json:"silos_with_open_work"`
+	ByKind        map[string]int `json:"by_kind"` // Silos per kind
+}
+
+// BusFactorReport lists knowledge silos, riskiest first
+type BusFactorReport struct {
+	Threshold  float64         `json:"threshold"`
+	MinCommits int             `json:"min_commits"`
+	Areas      []BusFactorArea `json:"areas"`
+	Stats      BusFactorStats  `json:"stats"`
+}
+
+// busFactorAcc gathers the commits and issues of one area
+type busFactorAcc struct {
+	commits map[string]string // SHA -> author key
+	issues  map[string]bool
+}
+
+// ComputeBusFactor finds labels, epics and hot files where a single
+// contributor authored most of the linked commits, and weighs each by the
+// open (and critical-path) work still in that area
+func ComputeBusFactor(issues []model.Issue, history *correlation.HistoryReport, opts BusFactorOptions) *BusFactorReport {
+	defaults := DefaultBusFactorOptions()
+	if opts.Threshold <= 0 {
+		opts.Threshold = defaults.Threshold
+	}
+	if opts.MinCommits <= 0 {
+		opts.MinCommits = defaults.MinCommits
+	}
+	if opts.HotspotLimit <= 0 {
+		opts.HotspotLimit = defaults.HotspotLimit
+	}
+
+	report := &BusFactorReport{
+		Threshold:  opts.Threshold,
+		MinCommits: opts.MinCommits,
+		Areas:      []BusFactorArea{},
+		Stats:      BusFactorStats{ByKind: map[string]int{}},
+	}
+	if history == nil {
+		return report
+	}
+
+	issueMap := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+
+	// Authors are keyed by email so name changes don't split a person
+	authors := make(map[string][2]string) // key -> name, email
+	authorKey := func(c correlation.CorrelatedCommit) string {
+		key := strings.ToLower(strings.TrimSpace(c.AuthorEmail))
+		if key == "" {
+			key = strings.TrimSpace(c.Author)
+		}
+		if key != "" {
+			authors[key] = [2]string{c.Author, c.AuthorEmail}
+		}
+		return key
+	}
+
+	areas := map[string]map[string]*busFactorAcc{
+		BusFactorLabel: {},
+		BusFactorEpic:  {},
+		BusFactorFile:  {},
+	}
+	add := func(kind, key, issueID, sha, author string) {
+		acc := areas[kind][key]
+		if acc == nil {
+			acc = &busFactorAcc{commits: map[string]string{}, issues: map[string]bool{}}
+			areas[kind][key] = acc
+		}
+		acc.issues[issueID] = true
+		if sha != "" && author != "" {
+			acc.commits[sha] = author
+		}
+	}
+
+	hotFiles := make(map[string]bool)
+	for _, h := range correlation.NewFileLookup(history).GetHotspots(opts.HotspotLimit) {
+		hotFiles[h.FilePath] = true
+	}
+
+	// Issues with no commits still count as open work in their areas
+	for _, issue := range issues {
+		for _, label := range issue.Labels {
+			add(BusFactorLabel, label, issue.ID, "", "")
+		}
+		for _, epic := range epicAncestors(issue.ID, issueMap) {
+			add(BusFactorEpic, epic, issue.ID, "", "")
+		}
+	}
+	for id, h := range history.Histories {
+		issue := issueMap[id]
+		if issue == nil {
+			continue
+		}
+		epics := epicAncestors(id, issueMap)
+		for _, c := range h.Commits {
+			author := authorKey(c)
+			for _, label := range issue.Labels {
+				add(BusFactorLabel, label, id, c.SHA, author)
+			}
+			for _, epic := range epics {
+				add(BusFactorEpic, epic, id, c.SHA, author)
+			}
+			for _, f := range c.Files {
+				if path := strings.TrimPrefix(f.Path, "./"); hotFiles[path] {
+					add(BusFactorFile, path, id, c.SHA, author)
+				}
+			}
+		}
+	}
+
+	maxCritical := 0.0
+	for _, v := range opts.CriticalPath {
+		maxCritical = math.Max(maxCritical, v)
+	}
+
+	for _, kind := range []string{BusFactorLabel, BusFactorEpic, BusFactorFile} {
+		for key, acc := range areas[kind] {
+			if len(acc.commits) < opts.MinCommits {
+				continue
+			}
+			report.Stats.AreasAnalyzed++
+
+			area, ok := busFactorArea(kind, key, acc, opts.Threshold)
+			if !ok {
+				continue
+			}
+			who := authors[area.TopAuthor]
+			area.TopAuthor, area.TopAuthorEmail = who[0], who[1]
+			if kind == BusFactorEpic {
+				if epic := issueMap[key]; epic != nil {
+					area.Title = epic.Title
+				}
+			}
+
+			for id := range acc.issues {
+				issue := issueMap[id]
+				if issue == nil || isClosedLikeStatus(issue.Status) || id == key {
+					continue
+				}
+				area.OpenIssues = append(area.OpenIssues, id)
+				if maxCritical > 0 && opts.CriticalPath[id] >= maxCritical/2 {
+					area.CriticalOpen++
+				}
+			}
+			area.OpenCount = len(area.OpenIssues)
+			sort.Slice(area.OpenIssues, func(i, j int) bool {
+				ci, cj := opts.CriticalPath[area.OpenIssues[i]], opts.CriticalPath[area.OpenIssues[j]]
+				if ci != cj {
+					return ci > cj
+				}
+				return area.OpenIssues[i] < area.OpenIssues[j]
+			})
+			area.Risk = math.Round(area.TopShare*math.Log2(1+float64(area.OpenCount+area.CriticalOpen))*1000) / 1000
+
+			report.Areas = append(report.Areas, area)
+			report.Stats.Silos++
+			report.Stats.ByKind[kind]++
+			if area.OpenCount > 0 {
+				report.Stats.SilosWithOpen++
+			}
+		}
+	}
+
+	sort.Slice(report.Areas, func(i, j int) bool {
+		a, b := report.Areas[i], report.Areas[j]
+		if a.Risk != b.Risk {
+			return a.Risk > b.Risk
+		}
+		if a.TopShare != b.TopShare {
+			return a.TopShare > b.TopShare
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Key < b.Key
+	})
+	return report
+}
+
+// busFactorArea measures concentration in one area; ok is false unless the
+// top contributor's share exceeds threshold
+func busFactorArea(kind, key string, acc *busFactorAcc, threshold float64) (BusFactorArea, bool) {
+	counts := make(map[string]int)
+	for _, author := range acc.commits {
+		counts[author]++
+	}
+	type authorCount struct {
+		key   string
+		count int
+	}
+	ranked := make([]authorCount, 0, len(counts))
+	for k, n := range counts {
+		ranked = append(ranked, authorCount{k, n})
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].count != ranked[j].count {
+			return ranked[i].count > ranked[j].count
+		}
+		return ranked[i].key < ranked[j].key
+	})
+
+	total := len(acc.commits)
+	share := float64(ranked[0].count) / float64(total)
+	if share <= threshold {
+		return BusFactorArea{}, false
+	}
+
+	busFactor, covered := 0, 0
+	for _, r := range ranked {
+		busFactor++
+		covered += r.count
+		if covered*2 > total {
+			break
+		}
+	}
+
+	return BusFactorArea{
+		Kind:         kind,
+		Key:          key,
+		TotalCommits: total,
+		Contributors: len(ranked),
+		BusFactor:    busFactor,
+		TopAuthor:    ranked[0].key,
+		TopShare:     math.Round(share*1000) / 1000,
+	}, true
+}
+
+// epicAncestors returns the epics an issue belongs to through parent-child
+// links, including the issue itself when it is an epic
+func epicAncestors(id string, issueMap map[string]*model.Issue) []string {
+	var epics []string
+	seen := make(map[string]bool)
+	queue := []string{id}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if seen[cur] {
+			continue
+		}
+		seen[cur] = true
+		issue := issueMap[cur]
+		if issue == nil {
+			continue
+		}
+		if issue.IssueType == model.TypeEpic {
+			epics = append(epics, cur)
+		}
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type == model.DepParentChild {
+				queue = append(queue, dep.DependsOnID)
+			}
+		}
+	}
+	return epics
+}
@@ -0,0 +1,68 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeBusFactor(t *testing.T) {
+	now := time.Now()
+	child := func(id, parent string) []*model.Dependency {
+		return []*model.Dependency{{IssueID: id, DependsOnID: parent, Type: model.DepParentChild}}
+	}
+	issues := []model.Issue{
+		{ID: "epic", Title: "Auth rewrite", IssueType: model.TypeEpic, Status: model.StatusOpen},
+		{ID: "a1", Status: model.StatusClosed, Labels: []string{"auth"}, Dependencies: child("a1", "epic")},
+		{ID: "a2", Status: model.StatusClosed, Labels: []string{"auth"}, Dependencies: child("a2", "epic")},
+		{ID: "a3", Status: model.StatusOpen, Labels: []string{"auth"}, Dependencies: child("a3", "epic")},
+		{ID: "u1", Status: model.StatusClosed, Labels: []string{"ui"}},
+		{ID: "u2", Status: model.StatusOpen, Labels: []string{"ui"}},
+	}
+	commit := func(sha, author, file string) correlation.CorrelatedCommit {
+		return correlation.CorrelatedCommit{SHA: sha, Author: author, AuthorEmail: author + "@example.com", Timestamp: now,
+			Files: []correlation.FileChange{{Path: file}}}
+	}
+	history := &correlation.HistoryReport{Histories: map[string]correlation.BeadHistory{
+		"a1": {BeadID: "a1", Status: "closed", Commits: []correlation.CorrelatedCommit{commit("c1", "alice", "auth/login.go"), commit("c2", "alice", "auth/login.go")}},
+		"a2": {BeadID: "a2", Status: "closed", Commits: []correlation.CorrelatedCommit{commit("c3", "alice", "auth/login.go"), commit("c4", "bob", "auth/token.go")}},
+		"u1": {BeadID: "u1", Status: "closed", Commits: []correlation.CorrelatedCommit{commit("c5", "bob", "ui/view.go"), commit("c6", "carol", "ui/view.go"), commit("c7", "dave", "ui/view.go")}},
+	}}
+
+	report := ComputeBusFactor(issues, history, BusFactorOptions{CriticalPath: map[string]float64{"a3": 4, "u2": 1}})
+
+	byKey := make(map[string]BusFactorArea)
+	for _, a := range report.Areas {
+		byKey[a.Kind+":"+a.Key] = a
+	}
+	auth, ok := byKey["label:auth"]
+	if !ok || auth.TopAuthor != "alice" || auth.TopAuthorEmail != "alice@example.com" || auth.TopShare != 0.75 || auth.BusFactor != 1 {
+		t.Fatalf("auth label = %+v", auth)
+	}
+	if auth.Contributors != 2 || auth.OpenCount != 1 || auth.CriticalOpen != 1 || auth.OpenIssues[0] != "a3" {
+		t.Fatalf("auth open work = %+v", auth)
+	}
+	epic, ok := byKey["epic:epic"]
+	if !ok || epic.Title != "Auth rewrite" || epic.OpenCount != 1 || epic.TotalCommits != 4 {
+		t.Fatalf("epic = %+v", epic)
+	}
+	if file, ok := byKey["file:auth/login.go"]; !ok || file.TopShare != 1 || file.OpenCount != 0 || file.Risk != 0 {
+		t.Fatalf("hot file = %+v", file)
+	}
+	// Evenly shared work is not a silo.
+	if ui, ok := byKey["label:ui"]; ok {
+		t.Fatalf("ui should not be a silo: %+v", ui)
+	}
+	if report.Areas[0].Risk < report.Areas[len(report.Areas)-1].Risk || report.Areas[len(report.Areas)-1].Risk != 0 {
+		t.Fatalf("areas not sorted by risk: %+v", report.Areas)
+	}
+	if report.Stats.Silos != 3 || report.Stats.SilosWithOpen != 2 || report.Stats.ByKind[BusFactorFile] != 1 || report.Stats.AreasAnalyzed != 5 {
+		t.Fatalf("stats = %+v", report.Stats)
+	}
+
+	if empty := ComputeBusFactor(issues, nil, DefaultBusFactorOptions()); len(empty.Areas) != 0 {
+		t.Fatalf("nil history should report nothing, got %+v", empty)
+	}
+}
@@ -3,6 +3,7 @@ package analysis
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/gob"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -205,6 +206,26 @@ func ComputeDataHash(issues []model.Issue) string {
 		}
 		h.Write([]byte{0})
 
+		// Custom fields (sorted by key)
+		if len(issue.Custom) > 0 {
+			keys := make([]string, 0, len(issue.Custom))
+			for k := range issue.Custom {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				h.Write([]byte(k))
+				h.Write([]byte{0})
+				if v, err := json.Marshal(issue.Custom[k]); err == nil {
+					h.Write(v)
+				} else {
+					h.Write([]byte(fmt.Sprint(issue.Custom[k])))
+				}
+				h.Write([]byte{0})
+			}
+		}
+		h.Write([]byte{0})
+
 		// Dependencies (sorted)
 		if len(issue.Dependencies) > 0 {
 			type depKey struct {
@@ -587,10 +608,12 @@ func (ca *CachedAnalyzer) AnalyzeAsync(ctx context.Context) *GraphStats {
 	ca.cacheHit = false
 	stats := ca.Analyzer.AnalyzeAsync(ctx)
 
-	// Store in cache when Phase 2 completes
+	// Store in cache when Phase 2 completes (not when ctx cancelled it early)
 	go func() {
 		stats.WaitForPhase2()
-		ca.cache.SetByHash(fullHash, stats)
+		if stats.IsPhase2Ready() {
+			ca.cache.SetByHash(fullHash, stats)
+		}
 	}()
 
 	return stats
@@ -713,6 +736,60 @@ func (b graphStatsCacheBlob) toGraphStats() *GraphStats {
 	return stats
 }
 
+func newGraphStatsCacheBlob(stats *GraphStats) graphStatsCacheBlob {
+	stats.mu.RLock()
+	defer stats.mu.RUnlock()
+
+	blob := graphStatsCacheBlob{
+		OutDegree:        stats.OutDegree,
+		InDegree:         stats.InDegree,
+		TopologicalOrder: stats.TopologicalOrder,
+		Density:          stats.Density,
+		NodeCount:        stats.NodeCount,
+		EdgeCount:        stats.EdgeCount,
+		Config:           stats.Config,
+
+		PageRank:          stats.pageRank,
+		Betweenness:       stats.betweenness,
+		Eigenvector:       stats.eigenvector,
+		Hubs:              stats.hubs,
+		Authorities:       stats.authorities,
+		CriticalPathScore: stats.criticalPathScore,
+		CoreNumber:        stats.coreNumber,
+		Slack:             stats.slack,
+		Cycles:            stats.cycles,
+		Status:            stats.status,
+	}
+	if stats.articulation != nil {
+		blob.Articulation = make([]string, 0, len(stats.articulation))
+		for id := range stats.articulation {
+			blob.Articulation = append(blob.Articulation, id)
+		}
+		sort.Strings(blob.Articulation)
+	}
+	return blob
+}
+
+// EncodeGraphStats writes a binary (gob) encoding of stats to w.
+// Only Phase 2-complete stats can be encoded; callers persisting UI state
+// (e.g. the TUI snapshot cache) should wait for Phase 2 first.
+func EncodeGraphStats(w io.Writer, stats *GraphStats) error {
+	if stats == nil || !stats.IsPhase2Ready() {
+		return fmt.Errorf("graph stats not ready for encoding")
+	}
+	return gob.NewEncoder(w).Encode(newGraphStatsCacheBlob(stats))
+}
+
+// DecodeGraphStats reads stats written by EncodeGraphStats.
+// The returned stats are Phase 2-ready; rank maps are recomputed on decode.
+func DecodeGraphStats(r io.Reader) (*GraphStats, error) {
+	var blob graphStatsCacheBlob
+	if err := gob.NewDecoder(r).Decode(&blob); err != nil {
+		return nil, fmt.Errorf("decoding graph stats: %w", err)
+	}
+	return blob.toGraphStats(), nil
+}
+
 func robotDiskCacheEnabled() bool {
 	return os.Getenv("BV_ROBOT") == "1"
 }
@@ -855,35 +932,7 @@ func putRobotDiskCachedStats(fullKey, dataHash, configHash string, stats *GraphS
 		return
 	}
 
-	stats.mu.RLock()
-	blob := graphStatsCacheBlob{
-		OutDegree:        stats.OutDegree,
-		InDegree:         stats.InDegree,
-		TopologicalOrder: stats.TopologicalOrder,
-		Density:          stats.Density,
-		NodeCount:        stats.NodeCount,
-		EdgeCount:        stats.EdgeCount,
-		Config:           stats.Config,
-
-		PageRank:          stats.pageRank,
-		Betweenness:       stats.betweenness,
-		Eigenvector:       stats.eigenvector,
-		Hubs:              stats.hubs,
-		Authorities:       stats.authorities,
-		CriticalPathScore: stats.criticalPathScore,
-		CoreNumber:        stats.coreNumber,
-		Slack:             stats.slack,
-		Cycles:            stats.cycles,
-		Status:            stats.status,
-	}
-	if stats.articulation != nil {
-		blob.Articulation = make([]string, 0, len(stats.articulation))
-		for id := range stats.articulation {
-			blob.Articulation = append(blob.Articulation, id)
-		}
-		sort.Strings(blob.Articulation)
-	}
-	stats.mu.RUnlock()
+	blob := newGraphStatsCacheBlob(stats)
 
 	if b, err := json.Marshal(blob); err != nil || len(b) > robotAnalysisDiskCacheMaxEntrySize {
 		return
@@ -76,6 +76,22 @@ func TestComputeDataHash_DifferentData(t *testing.T) {
 	}
 }
 
+func TestComputeDataHash_CustomFields(t *testing.T) {
+	issues1 := []model.Issue{{ID: "A", Custom: map[string]any{"team": "core", "points": 3}}}
+	issues2 := []model.Issue{{ID: "A", Custom: map[string]any{"team": "core", "points": 5}}}
+	issues3 := []model.Issue{{ID: "A", Custom: map[string]any{"points": 3, "team": "core"}}}
+
+	hash1 := analysis.ComputeDataHash(issues1)
+	if hash1 == analysis.ComputeDataHash(issues2) {
+		t.Error("Different custom field values should produce different hashes")
+	}
+	for i := 0; i < 10; i++ {
+		if analysis.ComputeDataHash(issues3) != hash1 {
+			t.Fatal("Custom field hashing should not depend on map order")
+		}
+	}
+}
+
 func TestComputeDataHash_Dependencies(t *testing.T) {
 	issues1 := []model.Issue{{
 		ID: "A",
@@ -514,3 +530,39 @@ func TestRobotDiskCache_EvictsToMaxEntries(t *testing.T) {
 		t.Fatalf("expected 10 entries after eviction, got %d", len(cf.Entries))
 	}
 }
+
+func TestEncodeDecodeGraphStats_RoundTrip(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "C", DependsOnID: "B", Type: model.DepBlocks}}},
+	}
+	stats := analysis.NewAnalyzer(issues).AnalyzeAsync(context.Background())
+
+	stats.WaitForPhase2()
+
+	var buf strings.Builder
+	if err := analysis.EncodeGraphStats(&buf, stats); err != nil {
+		t.Fatalf("EncodeGraphStats: %v", err)
+	}
+	decoded, err := analysis.DecodeGraphStats(strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("DecodeGraphStats: %v", err)
+	}
+	if !decoded.IsPhase2Ready() {
+		t.Fatal("decoded stats should be Phase 2 ready")
+	}
+	if !reflect.DeepEqual(stats.PageRank(), decoded.PageRank()) {
+		t.Errorf("pagerank mismatch after round trip")
+	}
+	if !reflect.DeepEqual(stats.CriticalPathScore(), decoded.CriticalPathScore()) {
+		t.Errorf("critical path mismatch after round trip")
+	}
+	if !reflect.DeepEqual(stats.PageRankRank(), decoded.PageRankRank()) {
+		t.Errorf("ranks should be recomputed on decode")
+	}
+
+	if err := analysis.EncodeGraphStats(&buf, nil); err == nil {
+		t.Error("expected error encoding nil stats")
+	}
+}
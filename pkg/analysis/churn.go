@@ -0,0 +1,314 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ChurnOptions tunes reopen and churn detection
+type ChurnOptions struct {
+	Window   time.Duration // Recent period compared against all earlier history (default 30 days)
+	MinFlips int           // Priority changes or title rewrites that make a bead churny (default 2)
+	MinBeads int           // Files touched by fewer beads are not correlated (default 2)
+	Limit    int           // Max beads and files listed (default 20)
+	Now      time.Time     // Reference time (zero = time.Now())
+}
+
+// DefaultChurnOptions returns the default churn thresholds
+func DefaultChurnOptions() ChurnOptions {
+	return ChurnOptions{Window: 30 * 24 * time.Hour, MinFlips: 2, MinBeads: 2, Limit: 20}
+}
+
+// ReopenRate is how often closed beads in a group came back
+type ReopenRate struct {
+	Key      string  `json:"key,omitempty"`
+	Closed   int     `json:"closed"`   // Beads closed at least once
+	Reopened int     `json:"reopened"` // Of those, beads reopened at least once
+	Reopens  int     `json:"reopens"`  // Total reopen events
+	Rate     float64 `json:"rate"`     // Reopened / Closed
+}
+
+// ChurnBead is a bead that was reopened or repeatedly rewritten
+type ChurnBead struct {
+	ID              string  `json:"id"`
+	Title           string  `json:"title"`
+	Status          string  `json:"status"`
+	Reopens         int     `json:"reopens"`
+	PriorityFlips   int     `json:"priority_flips"`
+	TitleRewrites   int     `json:"title_rewrites"`
+	AssigneeChanges int     `json:"assignee_changes"`
+	HoursLost       float64 `json:"hours_lost"` // Time from each reopen until the next close (or now)
+}
+
+// ReopenFile correlates a file with reopens of the beads that touched it
+type ReopenFile struct {
+	Path          string  `json:"path"`
+	Beads         int     `json:"beads"` // Closed beads whose commits touched the file
+	ReopenedBeads int     `json:"reopened_beads"`
+	Rate          float64 `json:"rate"`
+	Lift          float64 `json:"lift"` // Rate relative to the overall reopen rate
+}
+
+// ReopenTrend compares the reopen rate of the recent window with the
+// history before it
+type ReopenTrend struct {
+	WindowDays      float64  `json:"window_days"`
+	RecentCloses    int      `json:"recent_closes"`
+	RecentReopens   int      `json:"recent_reopens"`
+	RecentRate      float64  `json:"recent_rate"` // Reopens per close
+	RecentReopened  []string `json:"recent_reopened,omitempty"`
+	BaselineCloses  int      `json:"baseline_closes"`
+	BaselineReopens int      `json:"baseline_reopens"`
+	BaselineRate    float64  `json:"baseline_rate"`
+}
+
+// ChurnReport aggregates reopen and rewrite activity from bead history
+type ChurnReport struct {
+	Overall    ReopenRate   `json:"overall"`
+	ByLabel    []ReopenRate `json:"by_label"`
+	ByType     []ReopenRate `json:"by_type"`
+	ByAssignee []ReopenRate `json:"by_assignee"`
+	HoursLost  float64      `json:"hours_lost"`
+	Beads      []ChurnBead  `json:"beads"`
+	Files      []ReopenFile `json:"files"`
+	Trend      ReopenTrend  `json:"trend"`
+}
+
+// churnGroup accumulates one reopen-rate group
+type churnGroup struct {
+	closed, reopened, reopens int
+}
+
+// ComputeChurn measures how often beads bounce back after closing, by label,
+// type and assignee; which beads keep changing priority or title; how long
+// reopened cycles took; and which files the reopened beads touched. A bead
+// leaving closed for in_progress counts as a reopen, as does an explicit
+// reopen.
+func ComputeChurn(issues []model.Issue, history *correlation.HistoryReport, opts ChurnOptions) *ChurnReport {
+	defaults := DefaultChurnOptions()
+	if opts.Window <= 0 {
+		opts.Window = defaults.Window
+	}
+	if opts.MinFlips <= 0 {
+		opts.MinFlips = defaults.MinFlips
+	}
+	if opts.MinBeads <= 0 {
+		opts.MinBeads = defaults.MinBeads
+	}
+	if opts.Limit <= 0 {
+		opts.Limit = defaults.Limit
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	windowStart := opts.Now.Add(-opts.Window)
+
+	report := &ChurnReport{
+		ByLabel:    []ReopenRate{},
+		ByType:     []ReopenRate{},
+		ByAssignee: []ReopenRate{},
+		Beads:      []ChurnBead{},
+		Files:      []ReopenFile{},
+		Trend:      ReopenTrend{WindowDays: opts.Window.Hours() / 24},
+	}
+
+	var overall churnGroup
+	byLabel := make(map[string]*churnGroup)
+	byType := make(map[string]*churnGroup)
+	byAssignee := make(map[string]*churnGroup)
+	fileBeads := make(map[string]*churnGroup)
+	recentReopened := make(map[string]bool)
+
+	for _, issue := range issues {
+		var h correlation.BeadHistory
+		if history != nil {
+			h = history.Histories[issue.ID]
+		}
+		events := append([]correlation.BeadEvent(nil), h.Events...)
+		sort.SliceStable(events, func(i, j int) bool { return events[i].Timestamp.Before(events[j].Timestamp) })
+
+		bead := ChurnBead{ID: issue.ID, Title: issue.Title, Status: string(issue.Status)}
+		everClosed := issue.Status == model.StatusClosed
+		var closedAt, reopenedAt time.Time
+		for _, e := range events {
+			switch {
+			case e.EventType == correlation.EventClosed:
+				everClosed = true
+				if !reopenedAt.IsZero() {
+					bead.HoursLost += e.Timestamp.Sub(reopenedAt).Hours()
+					reopenedAt = time.Time{}
+				}
+				closedAt = e.Timestamp
+				if e.Timestamp.After(windowStart) {
+					report.Trend.RecentCloses++
+				} else {
+					report.Trend.BaselineCloses++
+				}
+			case e.EventType == correlation.EventReopened || e.EventType == correlation.EventClaimed && !closedAt.IsZero():
+				// The close may predate the history window; a reopen implies it
+				everClosed = true
+				bead.Reopens++
+				reopenedAt, closedAt = e.Timestamp, time.Time{}
+				if e.Timestamp.After(windowStart) {
+					report.Trend.RecentReopens++
+					recentReopened[issue.ID] = true
+				} else {
+					report.Trend.BaselineReopens++
+				}
+			}
+			if e.HasChange(correlation.ChangePriority) {
+				bead.PriorityFlips++
+			}
+			if e.HasChange(correlation.ChangeTitle) {
+				bead.TitleRewrites++
+			}
+			if e.HasChange(correlation.ChangeAssignee) {
+				bead.AssigneeChanges++
+			}
+		}
+		if !reopenedAt.IsZero() {
+			bead.HoursLost += opts.Now.Sub(reopenedAt).Hours()
+		}
+		bead.HoursLost = round3(bead.HoursLost)
+		report.HoursLost += bead.HoursLost
+
+		if bead.Reopens > 0 || bead.PriorityFlips >= opts.MinFlips || bead.TitleRewrites >= opts.MinFlips {
+			report.Beads = append(report.Beads, bead)
+		}
+		if !everClosed {
+			continue
+		}
+
+		count := func(g *churnGroup) {
+			g.closed++
+			g.reopens += bead.Reopens
+			if bead.Reopens > 0 {
+				g.reopened++
+			}
+		}
+		count(&overall)
+		for _, label := range issue.Labels {
+			count(churnGroupFor(byLabel, label))
+		}
+		count(churnGroupFor(byType, string(issue.IssueType)))
+		assignee := issue.Assignee
+		if assignee == "" {
+			assignee = "(unassigned)"
+		}
+		count(churnGroupFor(byAssignee, assignee))
+
+		seen := make(map[string]bool)
+		for _, c := range h.Commits {
+			for _, f := range c.Files {
+				path := strings.TrimPrefix(f.Path, "./")
+				if !seen[path] {
+					seen[path] = true
+					count(churnGroupFor(fileBeads, path))
+				}
+			}
+		}
+	}
+
+	report.Overall = reopenRate("", &overall)
+	report.HoursLost = round3(report.HoursLost)
+	report.ByLabel = reopenRates(byLabel)
+	report.ByType = reopenRates(byType)
+	report.ByAssignee = reopenRates(byAssignee)
+
+	for path, g := range fileBeads {
+		if g.closed < opts.MinBeads || g.reopened == 0 {
+			continue
+		}
+		f := ReopenFile{Path: path, Beads: g.closed, ReopenedBeads: g.reopened, Rate: reopenRate(path, g).Rate}
+		if report.Overall.Rate > 0 {
+			f.Lift = round3(f.Rate / report.Overall.Rate)
+		}
+		report.Files = append(report.Files, f)
+	}
+	sort.Slice(report.Files, func(i, j int) bool {
+		a, b := report.Files[i], report.Files[j]
+		if a.ReopenedBeads != b.ReopenedBeads {
+			return a.ReopenedBeads > b.ReopenedBeads
+		}
+		if a.Rate != b.Rate {
+			return a.Rate > b.Rate
+		}
+		return a.Path < b.Path
+	})
+	if len(report.Files) > opts.Limit {
+		report.Files = report.Files[:opts.Limit]
+	}
+
+	sort.Slice(report.Beads, func(i, j int) bool {
+		a, b := report.Beads[i], report.Beads[j]
+		if a.HoursLost != b.HoursLost {
+			return a.HoursLost > b.HoursLost
+		}
+		ca, cb := a.Reopens+a.PriorityFlips+a.TitleRewrites, b.Reopens+b.PriorityFlips+b.TitleRewrites
+		if ca != cb {
+			return ca > cb
+		}
+		return a.ID < b.ID
+	})
+	if len(report.Beads) > opts.Limit {
+		report.Beads = report.Beads[:opts.Limit]
+	}
+
+	t := &report.Trend
+	if t.RecentCloses > 0 {
+		t.RecentRate = round3(float64(t.RecentReopens) / float64(t.RecentCloses))
+	}
+	if t.BaselineCloses > 0 {
+		t.BaselineRate = round3(float64(t.BaselineReopens) / float64(t.BaselineCloses))
+	}
+	for id := range recentReopened {
+		t.RecentReopened = append(t.RecentReopened, id)
+	}
+	sort.Strings(t.RecentReopened)
+
+	return report
+}
+
+func churnGroupFor(groups map[string]*churnGroup, key string) *churnGroup {
+	g := groups[key]
+	if g == nil {
+		g = &churnGroup{}
+		groups[key] = g
+	}
+	return g
+}
+
+func reopenRate(key string, g *churnGroup) ReopenRate {
+	r := ReopenRate{Key: key, Closed: g.closed, Reopened: g.reopened, Reopens: g.reopens}
+	if g.closed > 0 {
+		r.Rate = round3(float64(g.reopened) / float64(g.closed))
+	}
+	return r
+}
+
+// reopenRates returns the groups by descending reopen rate
+func reopenRates(groups map[string]*churnGroup) []ReopenRate {
+	rates := make([]ReopenRate, 0, len(groups))
+	for key, g := range groups {
+		rates = append(rates, reopenRate(key, g))
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		if rates[i].Rate != rates[j].Rate {
+			return rates[i].Rate > rates[j].Rate
+		}
+		if rates[i].Closed != rates[j].Closed {
+			return rates[i].Closed > rates[j].Closed
+		}
+		return rates[i].Key < rates[j].Key
+	})
+	return rates
+}
+
+func round3(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
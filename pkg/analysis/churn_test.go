@@ -0,0 +1,102 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeChurn(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := func(n int) time.Time { return now.AddDate(0, 0, -n) }
+	ev := func(typ correlation.EventType, at time.Time, changes ...string) correlation.BeadEvent {
+		return correlation.BeadEvent{EventType: typ, Timestamp: at, Changes: changes}
+	}
+	files := func(paths ...string) []correlation.CorrelatedCommit {
+		c := correlation.CorrelatedCommit{SHA: paths[0]}
+		for _, p := range paths {
+			c.Files = append(c.Files, correlation.FileChange{Path: p})
+		}
+		return []correlation.CorrelatedCommit{c}
+	}
+	issues := []model.Issue{
+		{ID: "b1", Status: model.StatusClosed, IssueType: model.TypeBug, Assignee: "alice", Labels: []string{"auth"}},
+		{ID: "b2", Status: model.StatusInProgress, IssueType: model.TypeBug, Assignee: "alice", Labels: []string{"auth"}},
+		{ID: "t1", Status: model.StatusClosed, IssueType: model.TypeTask, Labels: []string{"ui"}},
+		{ID: "t2", Status: model.StatusOpen, IssueType: model.TypeTask},
+	}
+	history := &correlation.HistoryReport{Histories: map[string]correlation.BeadHistory{
+		// Closed, reopened 10 days later, closed again after 2 days.
+		"b1": {Events: []correlation.BeadEvent{
+			ev(correlation.EventClosed, day(60)),
+			ev(correlation.EventReopened, day(50)),
+			ev(correlation.EventClosed, day(48)),
+		}, Commits: files("auth/login.go")},
+		// Claimed again after closing, still in progress a day later.
+		"b2": {Events: []correlation.BeadEvent{
+			ev(correlation.EventClaimed, day(20)),
+			ev(correlation.EventClosed, day(10)),
+			ev(correlation.EventClaimed, day(1), correlation.ChangeAssignee),
+		}, Commits: files("auth/login.go")},
+		"t1": {Events: []correlation.BeadEvent{ev(correlation.EventClosed, day(40))}, Commits: files("auth/login.go", "ui/view.go")},
+		// Never closed, but reprioritized and renamed repeatedly.
+		"t2": {Events: []correlation.BeadEvent{
+			ev(correlation.EventModified, day(9), correlation.ChangePriority),
+			ev(correlation.EventModified, day(8), correlation.ChangePriority, correlation.ChangeTitle),
+			ev(correlation.EventModified, day(7), correlation.ChangeTitle),
+		}},
+	}}
+
+	report := ComputeChurn(issues, history, ChurnOptions{Now: now})
+
+	if o := report.Overall; o.Closed != 3 || o.Reopened != 2 || o.Reopens != 2 || o.Rate != 0.667 {
+		t.Fatalf("overall = %+v", o)
+	}
+	if r := report.ByType[0]; r.Key != "bug" || r.Rate != 1 || r.Closed != 2 {
+		t.Fatalf("by type = %+v", report.ByType)
+	}
+	if len(report.ByLabel) != 2 || report.ByLabel[0].Key != "auth" || report.ByLabel[1].Rate != 0 {
+		t.Fatalf("by label = %+v", report.ByLabel)
+	}
+	if r := report.ByAssignee[0]; r.Key != "alice" || r.Reopened != 2 || report.ByAssignee[1].Key != "(unassigned)" {
+		t.Fatalf("by assignee = %+v", report.ByAssignee)
+	}
+
+	if len(report.Beads) != 3 {
+		t.Fatalf("beads = %+v", report.Beads)
+	}
+	if b := report.Beads[0]; b.ID != "b1" || b.Reopens != 1 || b.HoursLost != 48 {
+		t.Fatalf("b1 = %+v", b)
+	}
+	if b := report.Beads[1]; b.ID != "b2" || b.HoursLost != 24 || b.AssigneeChanges != 1 {
+		t.Fatalf("b2 = %+v", b)
+	}
+	if b := report.Beads[2]; b.ID != "t2" || b.PriorityFlips != 2 || b.TitleRewrites != 2 || b.Reopens != 0 {
+		t.Fatalf("t2 = %+v", b)
+	}
+	if report.HoursLost != 72 {
+		t.Fatalf("hours lost = %v", report.HoursLost)
+	}
+
+	// ui/view.go was touched by a single bead, below MinBeads.
+	if len(report.Files) != 1 {
+		t.Fatalf("files = %+v", report.Files)
+	}
+	if f := report.Files[0]; f.Path != "auth/login.go" || f.Beads != 3 || f.ReopenedBeads != 2 || f.Lift != 1 {
+		t.Fatalf("login.go = %+v", f)
+	}
+
+	tr := report.Trend
+	if tr.RecentCloses != 1 || tr.RecentReopens != 1 || tr.RecentRate != 1 || tr.BaselineCloses != 3 || tr.BaselineRate != 0.333 {
+		t.Fatalf("trend = %+v", tr)
+	}
+	if len(tr.RecentReopened) != 1 || tr.RecentReopened[0] != "b2" {
+		t.Fatalf("recent reopened = %v", tr.RecentReopened)
+	}
+
+	if empty := ComputeChurn(issues, nil, DefaultChurnOptions()); empty.Overall.Closed != 2 || empty.Overall.Reopened != 0 || len(empty.Beads) != 0 {
+		t.Fatalf("without history = %+v", empty)
+	}
+}
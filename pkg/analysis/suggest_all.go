@@ -12,6 +12,13 @@ type SuggestAllConfig struct {
 	// Duplicates detection config
 	Duplicates DuplicateConfig
 
+	// DuplicateDetector replaces keyword (Jaccard) duplicate detection when
+	// set, e.g. with the embedding-based detector from pkg/search.
+	DuplicateDetector func(issues []model.Issue) []Suggestion
+
+	// DuplicateMethod names the duplicate detector in output ("jaccard" if empty)
+	DuplicateMethod string
+
 	// Dependencies suggestion config
 	Dependencies DependencySuggestionConfig
 
@@ -68,7 +75,12 @@ func GenerateAllSuggestions(issues []model.Issue, config SuggestAllConfig, dataH
 
 	// Run enabled detectors
 	if config.EnableDuplicates && (config.FilterType == "" || config.FilterType == SuggestionPotentialDuplicate) {
-		duplicates := DetectDuplicates(issues, config.Duplicates)
+		var duplicates []Suggestion
+		if config.DuplicateDetector != nil {
+			duplicates = config.DuplicateDetector(issues)
+		} else {
+			duplicates = DetectDuplicates(issues, config.Duplicates)
+		}
 		allSuggestions = append(allSuggestions, duplicates...)
 	}
 
@@ -132,9 +144,10 @@ type RobotSuggestOutput struct {
 
 // SuggestFilter describes applied filters
 type SuggestFilter struct {
-	Type          string  `json:"type,omitempty"`
-	MinConfidence float64 `json:"min_confidence,omitempty"`
-	BeadID        string  `json:"bead_id,omitempty"`
+	Type            string  `json:"type,omitempty"`
+	MinConfidence   float64 `json:"min_confidence,omitempty"`
+	BeadID          string  `json:"bead_id,omitempty"`
+	DuplicateMethod string  `json:"duplicate_method,omitempty"`
 }
 
 // GenerateRobotSuggestOutput creates the full robot-suggest output
@@ -145,9 +158,10 @@ func GenerateRobotSuggestOutput(issues []model.Issue, config SuggestAllConfig, d
 		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
 		DataHash:    dataHash,
 		Filters: SuggestFilter{
-			Type:          string(config.FilterType),
-			MinConfidence: config.MinConfidence,
-			BeadID:        config.FilterBead,
+			Type:            string(config.FilterType),
+			MinConfidence:   config.MinConfidence,
+			BeadID:          config.FilterBead,
+			DuplicateMethod: config.DuplicateMethod,
 		},
 		Set: set,
 		UsageHints: []string{
@@ -159,6 +173,7 @@ func GenerateRobotSuggestOutput(issues []model.Issue, config SuggestAllConfig, d
 			"--suggest-type=dependency - Filter to dependency suggestions",
 			"--suggest-confidence=0.7 - Minimum confidence threshold",
 			"--suggest-bead=<id> - Suggestions for specific bead",
+			"--suggest-method=semantic - Embedding-based duplicate groups instead of keyword overlap",
 		},
 	}
 }
@@ -117,6 +117,37 @@ func TestGenerateAllSuggestions_OnlyDuplicates(t *testing.T) {
 	}
 }
 
+func TestGenerateAllSuggestions_CustomDuplicateDetector(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "DUP-1", Title: "Fix authentication bug in login", Status: model.StatusOpen},
+		{ID: "DUP-2", Title: "Fix authentication bug in login page", Status: model.StatusOpen},
+	}
+
+	config := DefaultSuggestAllConfig()
+	config.FilterType = SuggestionPotentialDuplicate
+	config.Duplicates.JaccardThreshold = 0.3
+	config.DuplicateMethod = "semantic"
+	called := false
+	config.DuplicateDetector = func(got []model.Issue) []Suggestion {
+		called = len(got) == len(issues)
+		return []Suggestion{
+			NewSuggestion(SuggestionPotentialDuplicate, "DUP-2", "Potential duplicate of DUP-1", "test", 0.9).
+				WithRelatedBead("DUP-1").WithMetadata("method", "semantic"),
+		}
+	}
+
+	output := GenerateRobotSuggestOutput(issues, config, "dup-hash")
+	if !called {
+		t.Fatal("DuplicateDetector was not called with the issues")
+	}
+	if len(output.Set.Suggestions) != 1 || output.Set.Suggestions[0].Metadata["method"] != "semantic" {
+		t.Fatalf("expected only the custom detector's suggestion, got %+v", output.Set.Suggestions)
+	}
+	if output.Filters.DuplicateMethod != "semantic" {
+		t.Errorf("Filters.DuplicateMethod = %q, want semantic", output.Filters.DuplicateMethod)
+	}
+}
+
 func TestGenerateAllSuggestions_OnlyLabels(t *testing.T) {
 	issues := []model.Issue{
 		{ID: "BUG-1", Title: "Fix critical bug in auth", Status: model.StatusOpen},
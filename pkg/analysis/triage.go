@@ -74,6 +74,10 @@ type Recommendation struct {
 	Reasons     []string       `json:"reasons"`
 	UnblocksIDs []string       `json:"unblocks_ids,omitempty"`
 	BlockedBy   []string       `json:"blocked_by,omitempty"`
+
+	// SuggestedAssignee is set for unassigned actionable items when history
+	// shows who knows the item's labels and files
+	SuggestedAssignee *correlation.AssigneeSuggestion `json:"suggested_assignee,omitempty"`
 }
 
 // QuickWin represents a low-effort, high-impact item
@@ -414,6 +418,9 @@ func ComputeTriageFromAnalyzer(analyzer *Analyzer, stats *GraphStats, issues []m
 	// Build recommendations using enhanced scores (bv-148)
 	// Pass triageCtx instead of analyzer for cached blocker lookups (bv-k4az)
 	recommendations := buildRecommendationsFromTriageScores(triageScores, triageCtx, opts.TopN)
+	if opts.History != nil {
+		suggestAssignees(recommendations, issues, opts.History, now)
+	}
 
 	// Build quick wins
 	quickWins := buildQuickWins(impactScores, unblocksMap, opts.QuickWinN)
@@ -479,6 +486,28 @@ func ComputeTriageFromAnalyzer(analyzer *Analyzer, stats *GraphStats, issues []m
 	}
 }
 
+// suggestAssignees fills SuggestedAssignee for unassigned, unblocked
+// recommendations from the expertise history shows for their labels and files
+func suggestAssignees(recs []Recommendation, issues []model.Issue, history *correlation.HistoryReport, now time.Time) {
+	labels := make(map[string][]string, len(issues))
+	assigned := make(map[string]bool)
+	for _, issue := range issues {
+		labels[issue.ID] = issue.Labels
+		if issue.Assignee != "" {
+			assigned[issue.ID] = true
+		}
+	}
+	experts := correlation.BuildExpertiseMatrix(history, labels, correlation.ExpertiseOptions{Now: now})
+
+	for i := range recs {
+		rec := &recs[i]
+		if assigned[rec.ID] || len(rec.BlockedBy) > 0 || rec.Status == string(model.StatusInProgress) {
+			continue
+		}
+		rec.SuggestedAssignee = experts.SuggestAssignee(rec.Labels, correlation.BeadDirectories(history.Histories[rec.ID]))
+	}
+}
+
 // ComputeStaleness calculates staleness metrics from history
 func ComputeStaleness(history *correlation.HistoryReport, issues []model.Issue, now time.Time) *Staleness {
 	const thresholdDays = 14
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
@@ -1452,3 +1453,35 @@ func TestBuildTopPicks_AllBlocked(t *testing.T) {
 		t.Errorf("expected 0 picks when all are blocked, got %d", len(picks))
 	}
 }
+
+func TestComputeTriage_SuggestsAssignees(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "a", Title: "A", Status: model.StatusOpen, Priority: 0, Labels: []string{"api"}, UpdatedAt: now},
+		{ID: "b", Title: "B", Status: model.StatusOpen, Priority: 1, Labels: []string{"api"}, Assignee: "carol", UpdatedAt: now},
+		{ID: "c", Title: "C", Status: model.StatusOpen, Priority: 1, Labels: []string{"ui"}, UpdatedAt: now},
+		{ID: "done", Title: "Done", Status: model.StatusClosed, Labels: []string{"api"}, UpdatedAt: now},
+	}
+	history := &correlation.HistoryReport{Histories: map[string]correlation.BeadHistory{
+		"done": {BeadID: "done", Commits: []correlation.CorrelatedCommit{
+			{SHA: "abc", Author: "Alice", AuthorEmail: "alice@example.com", Timestamp: now.Add(-24 * time.Hour), Confidence: 0.9,
+				Files: []correlation.FileChange{{Path: "api/handler.go"}}},
+		}},
+	}}
+
+	triage := ComputeTriageWithOptions(issues, TriageOptions{History: history})
+
+	byID := make(map[string]Recommendation)
+	for _, rec := range triage.Recommendations {
+		byID[rec.ID] = rec
+	}
+	if s := byID["a"].SuggestedAssignee; s == nil || s.Assignee != "Alice" || s.Email != "alice@example.com" {
+		t.Errorf("expected Alice suggested for a, got %+v", s)
+	}
+	if s := byID["b"].SuggestedAssignee; s != nil {
+		t.Errorf("assigned issue should get no suggestion, got %+v", s)
+	}
+	if s := byID["c"].SuggestedAssignee; s != nil {
+		t.Errorf("issue without known experts should get no suggestion, got %+v", s)
+	}
+}
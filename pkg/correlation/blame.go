@@ -0,0 +1,271 @@
+// Package correlation provides line-level bead attribution via git blame.
+package correlation
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// uncommittedSHA is what git blame reports for lines not yet committed.
+const uncommittedSHA = "0000000000000000000000000000000000000000"
+
+// BlameLine is one line of a file as reported by git blame.
+type BlameLine struct {
+	Line      int       // 1-based line number in the current file
+	SHA       string    // Commit that last changed the line
+	Author    string    // Commit author
+	Timestamp time.Time // Author time
+	Summary   string    // Commit subject
+	Content   string    // Line text
+}
+
+// BlameBead is a bead credited for a blamed commit.
+type BlameBead struct {
+	ID         string  `json:"id"`
+	Title      string  `json:"title"`
+	Status     string  `json:"status"`
+	Confidence float64 `json:"confidence"` // Correlation confidence of the commit
+}
+
+// BlameRange is a run of consecutive lines last changed by the same commit.
+type BlameRange struct {
+	StartLine int         `json:"start_line"`
+	EndLine   int         `json:"end_line"`
+	SHA       string      `json:"sha"`
+	ShortSHA  string      `json:"short_sha"`
+	Author    string      `json:"author"`
+	Timestamp time.Time   `json:"timestamp"`
+	Summary   string      `json:"summary"`
+	Beads     []BlameBead `json:"beads"` // Empty when no bead explains the commit
+}
+
+// BlameBeadSummary counts the surviving lines a bead explains.
+type BlameBeadSummary struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	Lines  int    `json:"lines"`
+}
+
+// BlameStats provides aggregate attribution for the blamed lines.
+type BlameStats struct {
+	TotalLines       int     `json:"total_lines"`
+	AttributedLines  int     `json:"attributed_lines"`
+	UncommittedLines int     `json:"uncommitted_lines"`
+	AttributionRatio float64 `json:"attribution_ratio"` // Attributed / committed lines
+}
+
+// FileBlame annotates a file's current lines with the beads that explain them.
+type FileBlame struct {
+	Path      string             `json:"path"`
+	StartLine int                `json:"start_line"`
+	EndLine   int                `json:"end_line"`
+	Ranges    []BlameRange       `json:"ranges"`
+	Beads     []BlameBeadSummary `json:"beads"` // By lines explained, most first
+	Stats     BlameStats         `json:"stats"`
+	Lines     []BlameLine        `json:"-"` // Raw lines for viewers
+}
+
+// ParseBlameTarget splits "path[:start-end]" into a path and a line range.
+// A single line number selects one line; zero bounds mean the whole file.
+func ParseBlameTarget(target string) (path string, start, end int, err error) {
+	path = target
+	idx := strings.LastIndex(target, ":")
+	if idx <= 0 {
+		return path, 0, 0, nil
+	}
+	spec := target[idx+1:]
+	if spec == "" || strings.Trim(spec, "0123456789-") != "" {
+		return path, 0, 0, nil // Not a line range; treat ':' as part of the path
+	}
+	path = target[:idx]
+	from, to, isRange := strings.Cut(spec, "-")
+	if start, err = strconv.Atoi(from); err != nil || start < 1 {
+		return "", 0, 0, fmt.Errorf("invalid start line in %q", target)
+	}
+	end = start
+	if isRange {
+		if end, err = strconv.Atoi(to); err != nil || end < start {
+			return "", 0, 0, fmt.Errorf("invalid line range in %q", target)
+		}
+	}
+	return path, start, end, nil
+}
+
+// BlameFile runs git blame --porcelain on path, optionally limited to the
+// lines start..end (1-based, inclusive; zero means the whole file).
+func BlameFile(repoPath, path string, start, end int) ([]BlameLine, error) {
+	args := []string{"blame", "--porcelain"}
+	if start > 0 {
+		args = append(args, fmt.Sprintf("-L%d,%d", start, end))
+	}
+	args = append(args, "--", path)
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git blame failed: %s", msg)
+		}
+		return nil, fmt.Errorf("git blame failed: %w", err)
+	}
+	return parseBlamePorcelain(out), nil
+}
+
+// parseBlamePorcelain parses git blame --porcelain output. Commit headers
+// (author, summary, ...) appear only the first time a commit is seen.
+func parseBlamePorcelain(data []byte) []BlameLine {
+	type commitInfo struct {
+		author  string
+		time    time.Time
+		summary string
+	}
+	commits := make(map[string]*commitInfo)
+
+	var lines []BlameLine
+	var current *BlameLine
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		text := scanner.Text()
+		if current == nil {
+			fields := strings.Fields(text)
+			if len(fields) < 3 || len(fields[0]) != 40 {
+				continue
+			}
+			lineNo, err := strconv.Atoi(fields[2])
+			if err != nil {
+				continue
+			}
+			current = &BlameLine{Line: lineNo, SHA: fields[0]}
+			if commits[current.SHA] == nil {
+				commits[current.SHA] = &commitInfo{}
+			}
+			continue
+		}
+
+		info := commits[current.SHA]
+		switch {
+		case strings.HasPrefix(text, "\t"):
+			current.Content = text[1:]
+			current.Author = info.author
+			current.Timestamp = info.time
+			current.Summary = info.summary
+			lines = append(lines, *current)
+			current = nil
+		case strings.HasPrefix(text, "author "):
+			info.author = strings.TrimPrefix(text, "author ")
+		case strings.HasPrefix(text, "author-time "):
+			if secs, err := strconv.ParseInt(strings.TrimPrefix(text, "author-time "), 10, 64); err == nil {
+				info.time = time.Unix(secs, 0).UTC()
+			}
+		case strings.HasPrefix(text, "summary "):
+			info.summary = strings.TrimPrefix(text, "summary ")
+		}
+	}
+	return lines
+}
+
+// AnnotateBlame maps each blamed line's commit to beads through the
+// report's commit index and groups consecutive lines from the same commit
+// into ranges.
+func (r *HistoryReport) AnnotateBlame(path string, lines []BlameLine) *FileBlame {
+	fb := &FileBlame{
+		Path:   path,
+		Ranges: []BlameRange{},
+		Beads:  []BlameBeadSummary{},
+		Lines:  lines,
+	}
+	if len(lines) == 0 {
+		return fb
+	}
+	fb.StartLine = lines[0].Line
+	fb.EndLine = lines[len(lines)-1].Line
+
+	beadsBySHA := make(map[string][]BlameBead)
+	lineCounts := make(map[string]int)
+	for _, line := range lines {
+		beads, ok := beadsBySHA[line.SHA]
+		if !ok {
+			beads = r.blameBeads(line.SHA)
+			beadsBySHA[line.SHA] = beads
+		}
+
+		fb.Stats.TotalLines++
+		switch {
+		case line.SHA == uncommittedSHA:
+			fb.Stats.UncommittedLines++
+		case len(beads) > 0:
+			fb.Stats.AttributedLines++
+		}
+		for _, b := range beads {
+			lineCounts[b.ID]++
+		}
+
+		if n := len(fb.Ranges); n > 0 && fb.Ranges[n-1].SHA == line.SHA && fb.Ranges[n-1].EndLine == line.Line-1 {
+			fb.Ranges[n-1].EndLine = line.Line
+			continue
+		}
+		fb.Ranges = append(fb.Ranges, BlameRange{
+			StartLine: line.Line,
+			EndLine:   line.Line,
+			SHA:       line.SHA,
+			ShortSHA:  shortSHA(line.SHA),
+			Author:    line.Author,
+			Timestamp: line.Timestamp,
+			Summary:   line.Summary,
+			Beads:     beads,
+		})
+	}
+
+	if committed := fb.Stats.TotalLines - fb.Stats.UncommittedLines; committed > 0 {
+		fb.Stats.AttributionRatio = float64(fb.Stats.AttributedLines) / float64(committed)
+	}
+
+	for id, n := range lineCounts {
+		h := r.Histories[id]
+		fb.Beads = append(fb.Beads, BlameBeadSummary{ID: id, Title: h.Title, Status: h.Status, Lines: n})
+	}
+	sort.Slice(fb.Beads, func(i, j int) bool {
+		if fb.Beads[i].Lines != fb.Beads[j].Lines {
+			return fb.Beads[i].Lines > fb.Beads[j].Lines
+		}
+		return fb.Beads[i].ID < fb.Beads[j].ID
+	})
+	return fb
+}
+
+// blameBeads returns the beads the commit index credits for sha.
+func (r *HistoryReport) blameBeads(sha string) []BlameBead {
+	beads := []BlameBead{}
+	if r == nil || sha == uncommittedSHA {
+		return beads
+	}
+	for _, id := range r.CommitIndex[sha] {
+		h := r.Histories[id]
+		bead := BlameBead{ID: id, Title: h.Title, Status: h.Status}
+		for _, c := range h.Commits {
+			if c.SHA == sha {
+				bead.Confidence = c.Confidence
+				break
+			}
+		}
+		beads = append(beads, bead)
+	}
+	sort.Slice(beads, func(i, j int) bool {
+		if beads[i].Confidence != beads[j].Confidence {
+			return beads[i].Confidence > beads[j].Confidence
+		}
+		return beads[i].ID < beads[j].ID
+	})
+	return beads
+}
@@ -0,0 +1,107 @@
+package correlation
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseBlameTarget(t *testing.T) {
+	tests := []struct {
+		target     string
+		path       string
+		start, end int
+		wantErr    bool
+	}{
+		{"pkg/a.go", "pkg/a.go", 0, 0, false},
+		{"pkg/a.go:10-20", "pkg/a.go", 10, 20, false},
+		{"pkg/a.go:7", "pkg/a.go", 7, 7, false},
+		{"dir:with/colon.go", "dir:with/colon.go", 0, 0, false},
+		{"pkg/a.go:20-10", "", 0, 0, true},
+		{"pkg/a.go:0", "", 0, 0, true},
+	}
+	for _, tt := range tests {
+		path, start, end, err := ParseBlameTarget(tt.target)
+		if (err != nil) != tt.wantErr || path != tt.path || start != tt.start || end != tt.end {
+			t.Errorf("ParseBlameTarget(%q) = %q, %d, %d, %v", tt.target, path, start, end, err)
+		}
+	}
+}
+
+func TestBlameFile_AnnotatesLinesWithBeads(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, body string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("init", "-q")
+	git("config", "user.email", "dev@example.com")
+	git("config", "user.name", "Dev")
+	write(".beads/issues.jsonl", `{"id":"web-9gfx","title":"Retry uploads","status":"open"}`+"\n")
+	write("upload.go", "package main\n\nfunc upload() {}\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "Initial layout")
+
+	write("upload.go", "package main\n\nfunc upload() {}\n\nfunc retry() {}\nfunc backoff() {}\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "Add upload retries", "-m", "Bead: web-9gfx")
+	write("upload.go", "package main\n\nfunc upload() {}\n\nfunc retry() {}\nfunc backoff() {}\n// TODO\n")
+
+	report, err := NewCorrelator(dir).GenerateReport([]BeadInfo{{ID: "web-9gfx", Title: "Retry uploads", Status: "open"}}, CorrelatorOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines, err := BlameFile(dir, "upload.go", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 7 || lines[4].Content != "func retry() {}" || lines[4].Summary != "Add upload retries" || lines[0].Author != "Dev" {
+		t.Fatalf("lines = %+v", lines)
+	}
+
+	fb := report.AnnotateBlame("upload.go", lines)
+	if len(fb.Ranges) != 3 {
+		t.Fatalf("ranges = %+v", fb.Ranges)
+	}
+	r := fb.Ranges[1]
+	if r.StartLine != 4 || r.EndLine != 6 || len(r.Beads) != 1 || r.Beads[0].ID != "web-9gfx" || r.Beads[0].Title != "Retry uploads" {
+		t.Fatalf("bead range = %+v", r)
+	}
+	if len(fb.Ranges[0].Beads) != 0 || fb.Ranges[2].SHA != uncommittedSHA {
+		t.Fatalf("unattributed ranges = %+v", fb.Ranges)
+	}
+	want := BlameStats{TotalLines: 7, AttributedLines: 3, UncommittedLines: 1, AttributionRatio: 0.5}
+	if fb.Stats != want {
+		t.Fatalf("stats = %+v", fb.Stats)
+	}
+	if len(fb.Beads) != 1 || fb.Beads[0].Lines != 3 {
+		t.Fatalf("beads = %+v", fb.Beads)
+	}
+
+	partial, err := BlameFile(dir, "upload.go", 5, 6)
+	if err != nil || len(partial) != 2 || partial[0].Line != 5 {
+		t.Fatalf("partial = %+v, %v", partial, err)
+	}
+	if _, err := BlameFile(dir, "missing.go", 0, 0); err == nil {
+		t.Fatal("expected error for missing file")
+	}
+}
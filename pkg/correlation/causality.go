@@ -2,6 +2,7 @@
 package correlation
 
 import (
+	"fmt"
 	"sort"
 	"time"
 )
@@ -24,6 +25,10 @@ const (
 	CausalClosed CausalEventType = "closed"
 	// CausalReopened indicates the bead was reopened
 	CausalReopened CausalEventType = "reopened"
+	// CausalInReview indicates a linked pull request was opened for review
+	CausalInReview CausalEventType = "in_review"
+	// CausalMerged indicates a linked pull request was merged
+	CausalMerged CausalEventType = "merged"
 )
 
 // CausalEvent represents a single event in the causal chain
@@ -152,6 +157,23 @@ func (hr *HistoryReport) BuildCausalityChain(beadID string, opts CausalityOption
 		})
 	}
 
+	// Add pull request review and merge events
+	for _, pr := range history.PullRequests {
+		if pr.OpenedAt != nil {
+			rawEvents = append(rawEvents, rawEvent{
+				timestamp:   *pr.OpenedAt,
+				eventType:   CausalInReview,
+				description: fmt.Sprintf("PR #%d opened for review", pr.Number),
+			})
+		}
+		rawEvents = append(rawEvents, rawEvent{
+			timestamp:   pr.MergedAt,
+			eventType:   CausalMerged,
+			description: fmt.Sprintf("PR #%d merged", pr.Number),
+			commitSHA:   shortSHA(pr.MergeSHA),
+		})
+	}
+
 	// Add commit events if requested
 	if opts.IncludeCommits {
 		for _, commit := range history.Commits {
@@ -12,9 +12,10 @@ import (
 
 // Correlator orchestrates the extraction and correlation of bead history data
 type Correlator struct {
-	repoPath    string
-	extractor   *Extractor
-	coCommitter *CoCommitExtractor
+	repoPath     string
+	extractor    *Extractor
+	coCommitter  *CoCommitExtractor
+	pullRequests *PullRequestExtractor
 }
 
 // NewCorrelator creates a new correlator for the given repository.
@@ -23,9 +24,10 @@ type Correlator struct {
 // single-argument callers.
 func NewCorrelator(repoPath string, beadsFilePath ...string) *Correlator {
 	return &Correlator{
-		repoPath:    repoPath,
-		extractor:   NewExtractor(repoPath, beadsFilePath...),
-		coCommitter: NewCoCommitExtractor(repoPath),
+		repoPath:     repoPath,
+		extractor:    NewExtractor(repoPath, beadsFilePath...),
+		coCommitter:  NewCoCommitExtractor(repoPath),
+		pullRequests: NewPullRequestExtractor(repoPath),
 	}
 }
 
@@ -47,6 +49,40 @@ func (c *Correlator) GenerateReport(beads []BeadInfo, opts CorrelatorOptions) (*
 		BeadID: opts.BeadID,
 	}
 
+	batch, err := c.extract(beads, extractOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build bead histories
+	histories := c.buildHistories(beads, batch.events, batch.commits)
+
+	// Apply bead filter if specified
+	if opts.BeadID != "" {
+		filtered := make(map[string]BeadHistory)
+		if h, ok := histories[opts.BeadID]; ok {
+			filtered[opts.BeadID] = h
+		}
+		histories = filtered
+	}
+
+	// Link merged pull requests
+	attachPullRequests(histories, batch.prs, batch.matcher)
+
+	return c.assembleReport(beads, opts, histories, batch.events, batch.commits), nil
+}
+
+// historyBatch is the raw correlation data extracted from one git walk
+type historyBatch struct {
+	events  []BeadEvent
+	commits []CorrelatedCommit
+	prs     []PullRequest
+	matcher *ExplicitMatcher
+}
+
+// extract walks git history for lifecycle events, co-committed files,
+// commits referencing beads, and merged pull requests.
+func (c *Correlator) extract(beads []BeadInfo, extractOpts ExtractOptions) (*historyBatch, error) {
 	// Extract lifecycle events from git history
 	events, err := c.extractor.Extract(extractOpts)
 	if err != nil {
@@ -59,42 +95,74 @@ func (c *Correlator) GenerateReport(beads []BeadInfo, opts CorrelatorOptions) (*
 		return nil, fmt.Errorf("extracting co-commits: %w", err)
 	}
 
-	// Build bead histories
-	histories := c.buildHistories(beads, events, commits)
+	// Link commits whose messages reference beads
+	matcher, err := newProjectExplicitMatcher(c.repoPath, beads)
+	if err != nil {
+		return nil, fmt.Errorf("loading correlation patterns: %w", err)
+	}
+	explicit, err := matcher.ScanCommits(extractOpts)
+	if err != nil {
+		return nil, fmt.Errorf("scanning commit messages: %w", err)
+	}
+	commits = append(commits, explicitCommits(matcher, explicit, commits, c.coCommitter)...)
 
-	// Apply bead filter if specified
-	if opts.BeadID != "" {
-		filtered := make(map[string]BeadHistory)
-		if h, ok := histories[opts.BeadID]; ok {
-			filtered[opts.BeadID] = h
-		}
-		histories = filtered
+	prs, err := c.pullRequests.Extract(extractOpts)
+	if err != nil {
+		return nil, fmt.Errorf("extracting pull requests: %w", err)
 	}
 
-	// Build commit index
-	commitIndex := c.buildCommitIndex(histories)
+	return &historyBatch{events: events, commits: commits, prs: prs, matcher: matcher}, nil
+}
 
-	// Calculate stats
-	stats := c.calculateStats(histories, commits)
+// extractCommitList is extract restricted to the given commits, oldest first.
+func (c *Correlator) extractCommitList(beads []BeadInfo, shas []string) (*historyBatch, error) {
+	events, err := extractEventsFromCommits(c.extractor, shas, "")
+	if err != nil {
+		return nil, fmt.Errorf("extracting events: %w", err)
+	}
+
+	commits, err := c.coCommitter.ExtractAllCoCommits(events)
+	if err != nil {
+		return nil, fmt.Errorf("extracting co-commits: %w", err)
+	}
 
-	// Build git range description
-	gitRange := c.describeGitRange(opts)
+	matcher, err := newProjectExplicitMatcher(c.repoPath, beads)
+	if err != nil {
+		return nil, fmt.Errorf("loading correlation patterns: %w", err)
+	}
+	explicit, err := matcher.ScanCommitList(shas)
+	if err != nil {
+		return nil, fmt.Errorf("scanning commit messages: %w", err)
+	}
+	commits = append(commits, explicitCommits(matcher, explicit, commits, c.coCommitter)...)
 
-	// Calculate data hash
-	dataHash := c.calculateDataHash(beads)
+	prs, err := c.pullRequests.ExtractCommitList(shas)
+	if err != nil {
+		return nil, fmt.Errorf("extracting pull requests: %w", err)
+	}
 
-	// Get latest commit SHA for incremental updates
-	latestCommitSHA := c.findLatestCommitSHA(events, commits)
+	return &historyBatch{events: events, commits: commits, prs: prs, matcher: matcher}, nil
+}
 
-	return &HistoryReport{
+// assembleReport wraps built histories with the commit index, stats and
+// metadata of a history report, recalibrating confidence when a learned
+// model is available.
+func (c *Correlator) assembleReport(beads []BeadInfo, opts CorrelatorOptions, histories map[string]BeadHistory, events []BeadEvent, commits []CorrelatedCommit) *HistoryReport {
+	report := &HistoryReport{
 		GeneratedAt:     time.Now().UTC(),
-		DataHash:        dataHash,
-		GitRange:        gitRange,
-		LatestCommitSHA: latestCommitSHA,
-		Stats:           stats,
+		DataHash:        c.calculateDataHash(beads),
+		GitRange:        c.describeGitRange(opts),
+		LatestCommitSHA: c.findLatestCommitSHA(events, commits), // For incremental updates
+		Stats:           c.calculateStats(histories, commits),
 		Histories:       histories,
-		CommitIndex:     commitIndex,
-	}, nil
+		CommitIndex:     c.buildCommitIndex(histories),
+	}
+
+	// The model only adjusts scores; an unreadable one leaves the heuristics.
+	if model, err := LoadConfidenceModel(c.repoPath); err == nil {
+		model.Recalibrate(report)
+	}
+	return report
 }
 
 // findLatestCommitSHA finds the most recent commit SHA from events and commits
@@ -162,11 +230,8 @@ func (c *Correlator) buildHistories(beads []BeadInfo, events []BeadEvent, commit
 		history.Events = eventsByBead[beadID]
 		history.Commits = dedupCommits(commitsByBead[beadID])
 
-		// Calculate milestones
-		history.Milestones = GetBeadMilestones(history.Events)
-
-		// Calculate cycle time
-		history.CycleTime = CalculateCycleTime(history.Milestones)
+		// Calculate milestones and cycle time
+		history.refreshMilestones()
 
 		// Set last author
 		if len(history.Commits) > 0 {
@@ -225,6 +290,9 @@ func (c *Correlator) calculateStats(histories map[string]BeadHistory, commits []
 		if len(history.Commits) > 0 {
 			stats.BeadsWithCommits++
 		}
+		if len(history.PullRequests) > 0 {
+			stats.BeadsWithPRs++
+		}
 
 		for _, commit := range history.Commits {
 			uniqueCommits[commit.SHA] = true
@@ -0,0 +1,388 @@
+// Package correlation provides DORA-style delivery metrics derived from bead
+// and commit history.
+package correlation
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DefaultReleaseTagPattern selects the tags counted as deployments.
+const DefaultReleaseTagPattern = "v*"
+
+// DefaultFailureWindow is how long after a bead closes a bug discovered
+// from it still counts as a failed change.
+const DefaultFailureWindow = 14 * 24 * time.Hour
+
+// Lead time bases
+const (
+	LeadTimeToRelease = "release" // Created to the first release tag containing the bead's last commit
+	LeadTimeToClose   = "closed"  // Created to closed, when no release tags match
+)
+
+// ReleaseTag is a git tag counted as a deployment.
+type ReleaseTag struct {
+	Name      string    `json:"name"`
+	SHA       string    `json:"sha"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// DeliveryOptions configures delivery metrics.
+type DeliveryOptions struct {
+	Weeks         int           // Weekly buckets ending with the current week (default 12)
+	TagPattern    string        // Release tag glob (default DefaultReleaseTagPattern)
+	FailureWindow time.Duration // Default DefaultFailureWindow
+	Now           time.Time     // Default time.Now()
+}
+
+// DeliveryWeek is one week of delivery metrics. Durations are medians in hours.
+type DeliveryWeek struct {
+	WeekStart          time.Time `json:"week_start"` // Monday, UTC
+	Deployments        int       `json:"deployments"`
+	LeadTimes          int       `json:"lead_times"` // Beads delivered this week
+	LeadTimeHours      *float64  `json:"lead_time_hours,omitempty"`
+	Changes            int       `json:"changes"`        // Beads closed this week
+	FailedChanges      int       `json:"failed_changes"` // ...that a bug was later discovered from
+	ChangeFailureRate  *float64  `json:"change_failure_rate,omitempty"`
+	Restores           int       `json:"restores"` // P0 bugs closed this week
+	TimeToRestoreHours *float64  `json:"time_to_restore_hours,omitempty"`
+}
+
+// DeliverySummary aggregates the whole window. Durations are medians in hours.
+type DeliverySummary struct {
+	Deployments            int      `json:"deployments"`
+	DeploymentsPerWeek     float64  `json:"deployments_per_week"`
+	LeadTimes              int      `json:"lead_times"`
+	LeadTimeHours          *float64 `json:"lead_time_hours,omitempty"`
+	CreateToCommitHours    *float64 `json:"create_to_commit_hours,omitempty"`
+	CommitToReleaseHours   *float64 `json:"commit_to_release_hours,omitempty"`
+	Changes                int      `json:"changes"`
+	FailedChanges          int      `json:"failed_changes"`
+	ChangeFailureRate      *float64 `json:"change_failure_rate,omitempty"`
+	Restores               int      `json:"restores"`
+	TimeToRestoreHours     *float64 `json:"time_to_restore_hours,omitempty"`
+	FailedChangeIDs        []string `json:"failed_change_ids,omitempty"`
+	UnreleasedWithCommits  int      `json:"unreleased_with_commits,omitempty"` // Closed beads whose commits no release contains yet
+	LeadTimeBasis          string   `json:"lead_time_basis"`
+	FailureWindowDays      float64  `json:"failure_window_days"`
+	ReleaseTagPattern      string   `json:"release_tag_pattern"`
+	ReleasesMatchedPattern int      `json:"releases_matched_pattern"` // All matching tags, not just the window
+}
+
+// DeliveryMetrics is the output of --robot-delivery-metrics.
+type DeliveryMetrics struct {
+	GeneratedAt time.Time       `json:"generated_at"`
+	Summary     DeliverySummary `json:"summary"`
+	Weeks       []DeliveryWeek  `json:"weeks"` // Oldest first
+	Releases    []ReleaseTag    `json:"releases"`
+}
+
+// ListReleaseTags returns the tags matching pattern (a glob such as "v*"),
+// oldest first.
+func ListReleaseTags(repoPath, pattern string) ([]ReleaseTag, error) {
+	if pattern == "" {
+		pattern = DefaultReleaseTagPattern
+	}
+	cmd := exec.Command("git", "for-each-ref", "--sort=creatordate",
+		"--format=%(refname:short)%00%(objectname)%00%(*objectname)%00%(creatordate:iso-strict)",
+		"refs/tags/"+pattern)
+	cmd.Dir = repoPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("git for-each-ref failed: %s", string(exitErr.Stderr))
+		}
+		return nil, fmt.Errorf("git for-each-ref failed: %w", err)
+	}
+
+	var tags []ReleaseTag
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		parts := strings.Split(line, "\x00")
+		if len(parts) != 4 {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339, parts[3])
+		if err != nil {
+			continue
+		}
+		sha := parts[1]
+		if parts[2] != "" {
+			sha = parts[2] // Annotated tag: use the tagged commit
+		}
+		tags = append(tags, ReleaseTag{Name: parts[0], SHA: sha, Timestamp: ts})
+	}
+	return tags, nil
+}
+
+// FirstReleases maps each commit to the index of the earliest tag (by
+// tag order) that contains it. Commits in no tag are absent.
+func FirstReleases(repoPath string, tags []ReleaseTag) (map[string]int, error) {
+	first := make(map[string]int)
+	for i, tag := range tags {
+		// Feed earlier tags as exclusions on stdin so the argument list
+		// stays short however many releases there are.
+		var stdin strings.Builder
+		stdin.WriteString(tag.SHA + "\n")
+		for _, prev := range tags[:i] {
+			stdin.WriteString("^" + prev.SHA + "\n")
+		}
+		cmd := exec.Command("git", "rev-list", "--stdin")
+		cmd.Dir = repoPath
+		cmd.Stdin = strings.NewReader(stdin.String())
+
+		out, err := cmd.Output()
+		if err != nil {
+			if exitErr, ok := err.(*exec.ExitError); ok {
+				return nil, fmt.Errorf("git rev-list failed: %s", string(exitErr.Stderr))
+			}
+			return nil, fmt.Errorf("git rev-list failed: %w", err)
+		}
+		scanner := bufio.NewScanner(strings.NewReader(string(out)))
+		for scanner.Scan() {
+			if sha := strings.TrimSpace(scanner.Text()); sha != "" {
+				if _, seen := first[sha]; !seen {
+					first[sha] = i
+				}
+			}
+		}
+	}
+	return first, nil
+}
+
+// ComputeDeliveryMetrics derives weekly delivery metrics:
+//
+//   - deployment frequency: release tags per week
+//   - lead time for changes: bead created to the first release containing
+//     its last correlated commit (created to closed without release tags)
+//   - change failure rate: closed beads a bug was discovered from within
+//     the failure window, over all closed beads
+//   - time to restore: P0 bugs, created to closed
+//
+// report may be nil, in which case lead time falls back to close time.
+// released maps commits to tag indexes as returned by FirstReleases.
+func ComputeDeliveryMetrics(issues []model.Issue, report *HistoryReport, tags []ReleaseTag, released map[string]int, opts DeliveryOptions) *DeliveryMetrics {
+	if opts.Weeks <= 0 {
+		opts.Weeks = 12
+	}
+	if opts.TagPattern == "" {
+		opts.TagPattern = DefaultReleaseTagPattern
+	}
+	if opts.FailureWindow <= 0 {
+		opts.FailureWindow = DefaultFailureWindow
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	now := opts.Now.UTC()
+	weekday := int(now.Weekday())
+	if weekday == 0 {
+		weekday = 7 // Sunday = 7
+	}
+	currentWeekStart := now.AddDate(0, 0, -(weekday - 1)).Truncate(24 * time.Hour)
+	windowStart := currentWeekStart.AddDate(0, 0, -7*(opts.Weeks-1))
+	weekOf := func(t time.Time) int {
+		t = t.UTC()
+		if t.Before(windowStart) || t.After(now) {
+			return -1
+		}
+		return int(t.Sub(windowStart) / (7 * 24 * time.Hour))
+	}
+
+	out := &DeliveryMetrics{
+		GeneratedAt: time.Now().UTC(),
+		Weeks:       make([]DeliveryWeek, opts.Weeks),
+		Releases:    []ReleaseTag{},
+		Summary: DeliverySummary{
+			LeadTimeBasis:          LeadTimeToClose,
+			FailureWindowDays:      opts.FailureWindow.Hours() / 24,
+			ReleaseTagPattern:      opts.TagPattern,
+			ReleasesMatchedPattern: len(tags),
+		},
+	}
+	for i := range out.Weeks {
+		out.Weeks[i].WeekStart = windowStart.AddDate(0, 0, 7*i)
+	}
+
+	// Deployment frequency
+	for _, tag := range tags {
+		if w := weekOf(tag.Timestamp); w >= 0 {
+			out.Weeks[w].Deployments++
+			out.Summary.Deployments++
+			out.Releases = append(out.Releases, tag)
+		}
+	}
+	out.Summary.DeploymentsPerWeek = float64(out.Summary.Deployments) / float64(opts.Weeks)
+
+	// Lead time for changes
+	useReleases := len(tags) > 0 && report != nil
+	if useReleases {
+		out.Summary.LeadTimeBasis = LeadTimeToRelease
+	}
+	weekLead := make([][]float64, opts.Weeks)
+	var allLead, createToCommit, commitToRelease []float64
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Status.IsTombstone() || issue.IssueType == model.TypeEpic {
+			continue
+		}
+		var delivered, firstCommit time.Time
+		if useReleases {
+			h, ok := report.Histories[issue.ID]
+			if !ok || len(h.Commits) == 0 {
+				continue
+			}
+			first, last := h.Commits[0], h.Commits[0]
+			for _, c := range h.Commits[1:] {
+				if c.Timestamp.Before(first.Timestamp) {
+					first = c
+				}
+				if c.Timestamp.After(last.Timestamp) {
+					last = c
+				}
+			}
+			idx, ok := released[last.SHA]
+			if !ok {
+				if issue.Status.IsClosed() {
+					out.Summary.UnreleasedWithCommits++
+				}
+				continue
+			}
+			delivered = tags[idx].Timestamp
+			firstCommit = first.Timestamp
+		} else {
+			if !issue.Status.IsClosed() || issue.ClosedAt == nil {
+				continue
+			}
+			delivered = *issue.ClosedAt
+		}
+		w := weekOf(delivered)
+		if w < 0 || issue.CreatedAt.IsZero() {
+			continue
+		}
+		hours := hoursBetween(issue.CreatedAt, delivered)
+		weekLead[w] = append(weekLead[w], hours)
+		allLead = append(allLead, hours)
+		if useReleases {
+			createToCommit = append(createToCommit, hoursBetween(issue.CreatedAt, firstCommit))
+			commitToRelease = append(commitToRelease, hoursBetween(firstCommit, delivered))
+		}
+	}
+	for w := range out.Weeks {
+		out.Weeks[w].LeadTimes = len(weekLead[w])
+		out.Weeks[w].LeadTimeHours = medianHours(weekLead[w])
+	}
+	out.Summary.LeadTimes = len(allLead)
+	out.Summary.LeadTimeHours = medianHours(allLead)
+	out.Summary.CreateToCommitHours = medianHours(createToCommit)
+	out.Summary.CommitToReleaseHours = medianHours(commitToRelease)
+
+	// Change failure rate: bugs discovered from a bead shortly after it closed
+	byID := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+	failed := make(map[string]bool)
+	for i := range issues {
+		bug := &issues[i]
+		if bug.IssueType != model.TypeBug || bug.Status.IsTombstone() {
+			continue
+		}
+		for _, dep := range bug.Dependencies {
+			if dep == nil || dep.Type != model.DepDiscoveredFrom {
+				continue
+			}
+			src, ok := byID[dep.DependsOnID]
+			if !ok || src.ClosedAt == nil {
+				continue
+			}
+			gap := bug.CreatedAt.Sub(*src.ClosedAt)
+			if gap >= 0 && gap <= opts.FailureWindow {
+				failed[src.ID] = true
+			}
+		}
+	}
+	for i := range issues {
+		issue := &issues[i]
+		if !issue.Status.IsClosed() || issue.ClosedAt == nil || issue.IssueType == model.TypeEpic {
+			continue
+		}
+		w := weekOf(*issue.ClosedAt)
+		if w < 0 {
+			continue
+		}
+		out.Weeks[w].Changes++
+		out.Summary.Changes++
+		if failed[issue.ID] {
+			out.Weeks[w].FailedChanges++
+			out.Summary.FailedChanges++
+			out.Summary.FailedChangeIDs = append(out.Summary.FailedChangeIDs, issue.ID)
+		}
+	}
+	sort.Strings(out.Summary.FailedChangeIDs)
+	for w := range out.Weeks {
+		out.Weeks[w].ChangeFailureRate = ratio(out.Weeks[w].FailedChanges, out.Weeks[w].Changes)
+	}
+	out.Summary.ChangeFailureRate = ratio(out.Summary.FailedChanges, out.Summary.Changes)
+
+	// Time to restore: P0 bugs
+	weekRestore := make([][]float64, opts.Weeks)
+	var allRestore []float64
+	for i := range issues {
+		issue := &issues[i]
+		if issue.IssueType != model.TypeBug || issue.Priority != 0 || !issue.Status.IsClosed() || issue.ClosedAt == nil {
+			continue
+		}
+		w := weekOf(*issue.ClosedAt)
+		if w < 0 {
+			continue
+		}
+		hours := hoursBetween(issue.CreatedAt, *issue.ClosedAt)
+		weekRestore[w] = append(weekRestore[w], hours)
+		allRestore = append(allRestore, hours)
+	}
+	for w := range out.Weeks {
+		out.Weeks[w].Restores = len(weekRestore[w])
+		out.Weeks[w].TimeToRestoreHours = medianHours(weekRestore[w])
+	}
+	out.Summary.Restores = len(allRestore)
+	out.Summary.TimeToRestoreHours = medianHours(allRestore)
+
+	return out
+}
+
+func hoursBetween(from, to time.Time) float64 {
+	h := to.Sub(from).Hours()
+	if h < 0 {
+		return 0
+	}
+	return h
+}
+
+func medianHours(xs []float64) *float64 {
+	if len(xs) == 0 {
+		return nil
+	}
+	sorted := append([]float64(nil), xs...)
+	sort.Float64s(sorted)
+	m := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		m = (sorted[len(sorted)/2-1] + m) / 2
+	}
+	return &m
+}
+
+func ratio(n, d int) *float64 {
+	if d == 0 {
+		return nil
+	}
+	r := float64(n) / float64(d)
+	return &r
+}
@@ -0,0 +1,146 @@
+package correlation
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeDeliveryMetrics(t *testing.T) {
+	// Wednesday; the current week starts Monday 2024-06-10.
+	now := time.Date(2024, 6, 12, 12, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2024, 6, d, 9, 0, 0, 0, time.UTC) }
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	issues := []model.Issue{
+		{ID: "f-1", IssueType: model.TypeFeature, Status: model.StatusClosed, CreatedAt: day(1), ClosedAt: ptr(day(4))},
+		{ID: "f-2", IssueType: model.TypeFeature, Status: model.StatusClosed, CreatedAt: day(3), ClosedAt: ptr(day(5))},
+		{ID: "t-3", IssueType: model.TypeTask, Status: model.StatusClosed, CreatedAt: day(9), ClosedAt: ptr(day(11))},
+		// Discovered from f-1 two days after it closed: a failed change.
+		{ID: "b-4", IssueType: model.TypeBug, Priority: 0, Status: model.StatusClosed, CreatedAt: day(6), ClosedAt: ptr(day(6).Add(6 * time.Hour)),
+			Dependencies: []*model.Dependency{{IssueID: "b-4", DependsOnID: "f-1", Type: model.DepDiscoveredFrom}}},
+		// Discovered from f-2 long after the failure window.
+		{ID: "b-5", IssueType: model.TypeBug, Priority: 2, Status: model.StatusOpen, CreatedAt: day(11).AddDate(0, 0, 30),
+			Dependencies: []*model.Dependency{{IssueID: "b-5", DependsOnID: "f-2", Type: model.DepDiscoveredFrom}}},
+		{ID: "e-6", IssueType: model.TypeEpic, Status: model.StatusClosed, CreatedAt: day(1), ClosedAt: ptr(day(11))},
+	}
+	report := &HistoryReport{Histories: map[string]BeadHistory{
+		"f-1": {Commits: []CorrelatedCommit{{SHA: "a1", Timestamp: day(2)}, {SHA: "a2", Timestamp: day(3)}}},
+		"f-2": {Commits: []CorrelatedCommit{{SHA: "b1", Timestamp: day(4)}}},
+		"t-3": {Commits: []CorrelatedCommit{{SHA: "c1", Timestamp: day(10)}}},
+	}}
+	tags := []ReleaseTag{
+		{Name: "v1.0.0", SHA: "a2", Timestamp: day(5)},
+		{Name: "v1.1.0", SHA: "c1", Timestamp: day(11)},
+	}
+	released := map[string]int{"a1": 0, "a2": 0, "b1": 0, "c1": 1}
+
+	dm := ComputeDeliveryMetrics(issues, report, tags, released, DeliveryOptions{Weeks: 2, Now: now})
+	if len(dm.Weeks) != 2 || !dm.Weeks[0].WeekStart.Equal(time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("weeks = %+v", dm.Weeks)
+	}
+	s := dm.Summary
+	if s.LeadTimeBasis != LeadTimeToRelease || s.Deployments != 2 || s.DeploymentsPerWeek != 1 {
+		t.Fatalf("summary = %+v", s)
+	}
+	if dm.Weeks[0].Deployments != 1 || dm.Weeks[1].Deployments != 1 {
+		t.Fatalf("deployments = %d, %d", dm.Weeks[0].Deployments, dm.Weeks[1].Deployments)
+	}
+
+	// f-1: 4 days, f-2: 2 days (both v1.0.0); t-3: 2 days (v1.1.0).
+	if dm.Weeks[0].LeadTimes != 2 || *dm.Weeks[0].LeadTimeHours != 72 || *dm.Weeks[1].LeadTimeHours != 48 {
+		t.Fatalf("lead times = %+v / %+v", dm.Weeks[0], dm.Weeks[1])
+	}
+	if s.LeadTimes != 3 || *s.LeadTimeHours != 48 || *s.CreateToCommitHours != 24 {
+		t.Fatalf("summary lead time = %+v", s)
+	}
+
+	// Epics are not changes; only f-1 failed within the window.
+	if s.Changes != 4 || s.FailedChanges != 1 || len(s.FailedChangeIDs) != 1 || s.FailedChangeIDs[0] != "f-1" {
+		t.Fatalf("change failure = %+v", s)
+	}
+	if dm.Weeks[0].Changes != 3 || *dm.Weeks[0].ChangeFailureRate != 1.0/3 {
+		t.Fatalf("week change failure = %+v", dm.Weeks[0])
+	}
+
+	if s.Restores != 1 || *s.TimeToRestoreHours != 6 || dm.Weeks[1].TimeToRestoreHours != nil {
+		t.Fatalf("time to restore = %+v", s)
+	}
+}
+
+func TestComputeDeliveryMetrics_NoTagsUsesCloseTime(t *testing.T) {
+	now := time.Date(2024, 6, 12, 12, 0, 0, 0, time.UTC)
+	closed := now.Add(-24 * time.Hour)
+	issues := []model.Issue{
+		{ID: "f-1", IssueType: model.TypeFeature, Status: model.StatusClosed, CreatedAt: closed.Add(-10 * time.Hour), ClosedAt: &closed},
+		{ID: "f-2", IssueType: model.TypeFeature, Status: model.StatusOpen, CreatedAt: closed},
+	}
+
+	dm := ComputeDeliveryMetrics(issues, nil, nil, nil, DeliveryOptions{Weeks: 4, Now: now})
+	s := dm.Summary
+	if s.LeadTimeBasis != LeadTimeToClose || s.LeadTimes != 1 || *s.LeadTimeHours != 10 || s.Deployments != 0 {
+		t.Fatalf("summary = %+v", s)
+	}
+	if s.ChangeFailureRate == nil || *s.ChangeFailureRate != 0 || dm.Weeks[3].LeadTimes != 1 {
+		t.Fatalf("weeks = %+v", dm.Weeks)
+	}
+}
+
+func TestListReleaseTagsAndFirstReleases(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return string(out)
+	}
+	commit := func(name string) string {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", ".")
+		git("commit", "-q", "-m", "add "+name)
+		return git("rev-parse", "HEAD")[:40]
+	}
+	git("init", "-q")
+	git("config", "user.email", "dev@example.com")
+	git("config", "user.name", "Dev")
+
+	first := commit("a")
+	git("tag", "v1.0.0")
+	second := commit("b")
+	git("tag", "-a", "v1.1.0", "-m", "release 1.1")
+	git("tag", "nightly")
+	third := commit("c")
+
+	tags, err := ListReleaseTags(dir, "v*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 2 || tags[0].Name != "v1.0.0" || tags[1].Name != "v1.1.0" || tags[1].SHA != second {
+		t.Fatalf("tags = %+v", tags)
+	}
+
+	released, err := FirstReleases(dir, tags)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if released[first] != 0 || released[second] != 1 {
+		t.Fatalf("released = %v", released)
+	}
+	if _, ok := released[third]; ok {
+		t.Fatalf("unreleased commit mapped: %v", released)
+	}
+}
@@ -0,0 +1,339 @@
+// Package correlation provides code ownership and expertise maps built from bead history.
+package correlation
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultExpertiseHalfLife is how long it takes a contribution to lose half
+// its weight in the expertise matrix.
+const DefaultExpertiseHalfLife = 90 * 24 * time.Hour
+
+// eventExpertiseWeight discounts lifecycle events (claiming, closing) relative
+// to commits when crediting label expertise.
+const eventExpertiseWeight = 0.5
+
+// ExpertiseOptions controls how contributions are weighted.
+type ExpertiseOptions struct {
+	HalfLife time.Duration // Recency half-life (0 = DefaultExpertiseHalfLife)
+	Now      time.Time     // Reference time for decay (zero = time.Now())
+}
+
+// Expert is an author's standing for one label or directory.
+type Expert struct {
+	Author     string    `json:"author"`
+	Email      string    `json:"email,omitempty"`
+	Score      float64   `json:"score"`   // Recency-decayed, confidence-weighted contributions
+	Share      float64   `json:"share"`   // Fraction of all expertise for the label or directory
+	Commits    int       `json:"commits"` // Correlated commits contributing to the score
+	Beads      int       `json:"beads"`   // Distinct beads contributing to the score
+	LastActive time.Time `json:"last_active"`
+}
+
+// AssigneeSuggestion proposes who should pick up an unassigned bead.
+type AssigneeSuggestion struct {
+	Assignee string  `json:"assignee"`
+	Email    string  `json:"email,omitempty"`
+	Score    float64 `json:"score"`
+	Reason   string  `json:"reason"`
+}
+
+// expertAcc accumulates one author's contributions to one label or directory.
+type expertAcc struct {
+	score   float64
+	commits map[string]bool
+	beads   map[string]bool
+	last    time.Time
+}
+
+// expertIdentity is an author as seen across commits and events.
+type expertIdentity struct {
+	name  string
+	email string
+	seen  time.Time // Most recent use of name
+}
+
+// ExpertiseMatrix scores authors by label and by directory.
+type ExpertiseMatrix struct {
+	byLabel map[string]map[string]*expertAcc
+	byDir   map[string]map[string]*expertAcc
+	authors map[string]*expertIdentity
+}
+
+// BuildExpertiseMatrix credits the authors of each bead's correlated commits
+// with expertise in the bead's labels and in the directories the commits
+// touched. Commits are weighted by correlation confidence and decay with age;
+// claiming or closing a bead also counts toward its labels. A commit
+// correlated to several beads credits its directories once, at its highest
+// confidence.
+func BuildExpertiseMatrix(report *HistoryReport, labels map[string][]string, opts ExpertiseOptions) *ExpertiseMatrix {
+	m := &ExpertiseMatrix{
+		byLabel: make(map[string]map[string]*expertAcc),
+		byDir:   make(map[string]map[string]*expertAcc),
+		authors: make(map[string]*expertIdentity),
+	}
+	if report == nil {
+		return m
+	}
+	halfLife := opts.HalfLife
+	if halfLife <= 0 {
+		halfLife = DefaultExpertiseHalfLife
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	decay := func(t time.Time) float64 {
+		age := now.Sub(t)
+		if age < 0 {
+			age = 0
+		}
+		return math.Pow(0.5, float64(age)/float64(halfLife))
+	}
+
+	// Directory credit is per commit, not per bead it mentions.
+	dirConfidence := make(map[string]float64)
+	for _, h := range report.Histories {
+		for _, c := range h.Commits {
+			dirConfidence[c.SHA] = math.Max(dirConfidence[c.SHA], c.Confidence)
+		}
+	}
+	dirCredited := make(map[string]bool)
+
+	for beadID, h := range report.Histories {
+		beadLabels := labels[beadID]
+		for _, c := range h.Commits {
+			author := m.identify(c.Author, c.AuthorEmail, c.Timestamp)
+			if author == "" {
+				continue
+			}
+			weight := c.Confidence * decay(c.Timestamp)
+			for _, label := range beadLabels {
+				m.credit(m.byLabel, label, author, weight, c.SHA, beadID, c.Timestamp)
+			}
+			// Later beads sharing the commit still count toward Beads.
+			dirWeight := 0.0
+			if !dirCredited[c.SHA] {
+				dirCredited[c.SHA] = true
+				dirWeight = dirConfidence[c.SHA] * decay(c.Timestamp)
+			}
+			for _, dir := range commitDirs(c.Files) {
+				m.credit(m.byDir, dir, author, dirWeight, c.SHA, beadID, c.Timestamp)
+			}
+		}
+		for _, e := range h.Events {
+			if e.EventType != EventClaimed && e.EventType != EventClosed {
+				continue
+			}
+			author := m.identify(e.Author, e.AuthorEmail, e.Timestamp)
+			if author == "" {
+				continue
+			}
+			weight := eventExpertiseWeight * decay(e.Timestamp)
+			for _, label := range beadLabels {
+				m.credit(m.byLabel, label, author, weight, "", beadID, e.Timestamp)
+			}
+		}
+	}
+	return m
+}
+
+// identify returns the key for an author, preferring the email so renamed
+// authors stay one person, and remembers the most recent display name.
+func (m *ExpertiseMatrix) identify(name, email string, at time.Time) string {
+	key := strings.ToLower(strings.TrimSpace(email))
+	if key == "" {
+		key = strings.TrimSpace(name)
+	}
+	if key == "" {
+		return ""
+	}
+	id := m.authors[key]
+	if id == nil {
+		id = &expertIdentity{email: strings.TrimSpace(email)}
+		m.authors[key] = id
+	}
+	if name != "" && (id.name == "" || at.After(id.seen)) {
+		id.name = name
+		id.seen = at
+	}
+	return key
+}
+
+func (m *ExpertiseMatrix) credit(table map[string]map[string]*expertAcc, key, author string, weight float64, sha, beadID string, at time.Time) {
+	if table[key] == nil {
+		table[key] = make(map[string]*expertAcc)
+	}
+	acc := table[key][author]
+	if acc == nil {
+		acc = &expertAcc{commits: make(map[string]bool), beads: make(map[string]bool)}
+		table[key][author] = acc
+	}
+	acc.score += weight
+	if sha != "" {
+		acc.commits[sha] = true
+	}
+	acc.beads[beadID] = true
+	if at.After(acc.last) {
+		acc.last = at
+	}
+}
+
+// commitDirs returns each directory the files live in, with its ancestors,
+// so expertise in pkg/auth/oauth also counts toward pkg/auth and pkg.
+func commitDirs(files []FileChange) []string {
+	seen := make(map[string]bool)
+	var dirs []string
+	for _, f := range files {
+		dir := path.Dir(normalizePath(f.Path))
+		for {
+			if seen[dir] {
+				break
+			}
+			seen[dir] = true
+			dirs = append(dirs, dir)
+			if dir == "." || dir == "/" {
+				break
+			}
+			dir = path.Dir(dir)
+		}
+	}
+	return dirs
+}
+
+// BeadDirectories returns the directories directly containing the files a
+// bead's commits touched, sorted.
+func BeadDirectories(h BeadHistory) []string {
+	seen := make(map[string]bool)
+	for _, c := range h.Commits {
+		for _, f := range c.Files {
+			seen[path.Dir(normalizePath(f.Path))] = true
+		}
+	}
+	dirs := make([]string, 0, len(seen))
+	for dir := range seen {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// ForLabel returns the experts on a label, best first (limit <= 0 = all).
+func (m *ExpertiseMatrix) ForLabel(label string, limit int) []Expert {
+	return m.experts(m.byLabel[label], limit)
+}
+
+// ForDirectory returns the experts on exactly dir, best first.
+func (m *ExpertiseMatrix) ForDirectory(dir string, limit int) []Expert {
+	return m.experts(m.byDir[dir], limit)
+}
+
+// ForPath returns the experts on a file or directory, best first. A file
+// resolves to the nearest directory with recorded expertise, which is
+// returned along with the experts.
+func (m *ExpertiseMatrix) ForPath(p string, limit int) (string, []Expert) {
+	dir := normalizePath(p)
+	if dir == "" {
+		dir = "."
+	}
+	if _, ok := m.byDir[dir]; !ok {
+		dir = path.Dir(dir)
+		for m.byDir[dir] == nil && dir != "." && dir != "/" {
+			dir = path.Dir(dir)
+		}
+	}
+	return dir, m.experts(m.byDir[dir], limit)
+}
+
+// Labels returns every label with recorded expertise, sorted.
+func (m *ExpertiseMatrix) Labels() []string {
+	return sortedKeys(m.byLabel)
+}
+
+// Directories returns every directory with recorded expertise, sorted.
+func (m *ExpertiseMatrix) Directories() []string {
+	return sortedKeys(m.byDir)
+}
+
+// SuggestAssignee picks the author with the most combined expertise in the
+// given labels and directories, or nil when nobody has any.
+func (m *ExpertiseMatrix) SuggestAssignee(labels, dirs []string) *AssigneeSuggestion {
+	scores := make(map[string]float64)
+	basis := make(map[string][]string)
+	add := func(table map[string]map[string]*expertAcc, key, kind string) {
+		for author, acc := range table[key] {
+			scores[author] += acc.score
+			basis[author] = append(basis[author], fmt.Sprintf("%s %s", kind, key))
+		}
+	}
+	for _, label := range labels {
+		add(m.byLabel, label, "label")
+	}
+	for _, dir := range dirs {
+		add(m.byDir, dir, "directory")
+	}
+
+	best := ""
+	for author, score := range scores {
+		if best == "" || score > scores[best] || score == scores[best] && author < best {
+			best = author
+		}
+	}
+	if best == "" || scores[best] <= 0 {
+		return nil
+	}
+	id := m.authors[best]
+	return &AssigneeSuggestion{
+		Assignee: id.name,
+		Email:    id.email,
+		Score:    round3(scores[best]),
+		Reason:   "Recent work on " + strings.Join(basis[best], ", "),
+	}
+}
+
+func (m *ExpertiseMatrix) experts(accs map[string]*expertAcc, limit int) []Expert {
+	experts := []Expert{}
+	total := 0.0
+	for _, acc := range accs {
+		total += acc.score
+	}
+	for author, acc := range accs {
+		id := m.authors[author]
+		e := Expert{
+			Author:     id.name,
+			Email:      id.email,
+			Score:      round3(acc.score),
+			Commits:    len(acc.commits),
+			Beads:      len(acc.beads),
+			LastActive: acc.last,
+		}
+		if total > 0 {
+			e.Share = round3(acc.score / total)
+		}
+		experts = append(experts, e)
+	}
+	sort.Slice(experts, func(i, j int) bool {
+		if experts[i].Score != experts[j].Score {
+			return experts[i].Score > experts[j].Score
+		}
+		return experts[i].Author < experts[j].Author
+	})
+	if limit > 0 && len(experts) > limit {
+		experts = experts[:limit]
+	}
+	return experts
+}
+
+func sortedKeys(table map[string]map[string]*expertAcc) []string {
+	keys := make([]string, 0, len(table))
+	for k := range table {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
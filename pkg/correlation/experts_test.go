@@ -0,0 +1,94 @@
+package correlation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildExpertiseMatrix(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	commit := func(sha, author, email string, age time.Duration, files ...string) CorrelatedCommit {
+		c := CorrelatedCommit{SHA: sha, Author: author, AuthorEmail: email, Timestamp: now.Add(-age), Confidence: 1}
+		for _, f := range files {
+			c.Files = append(c.Files, FileChange{Path: f})
+		}
+		return c
+	}
+	report := &HistoryReport{Histories: map[string]BeadHistory{
+		"bv-1": {BeadID: "bv-1", Commits: []CorrelatedCommit{
+			commit("a1", "Alice", "alice@example.com", 0, "pkg/auth/login.go", "pkg/auth/oauth/token.go"),
+			commit("a2", "Alice Smith", "Alice@Example.com", 90*24*time.Hour, "pkg/auth/session.go"),
+		}},
+		"bv-2": {BeadID: "bv-2", Commits: []CorrelatedCommit{
+			// Older work counts for less.
+			commit("b1", "Bob", "bob@example.com", 180*24*time.Hour, "pkg/auth/login.go"),
+			commit("b2", "Bob", "bob@example.com", 180*24*time.Hour, "pkg/auth/logout.go"),
+		}},
+		"bv-3": {BeadID: "bv-3",
+			Commits: []CorrelatedCommit{commit("b3", "Bob", "bob@example.com", 0, "ui/view.go")},
+			Events:  []BeadEvent{{EventType: EventClosed, Author: "Carol", AuthorEmail: "carol@example.com", Timestamp: now}},
+		},
+	}}
+	labels := map[string][]string{"bv-1": {"auth"}, "bv-2": {"auth"}, "bv-3": {"ui"}}
+	m := BuildExpertiseMatrix(report, labels, ExpertiseOptions{Now: now})
+
+	auth := m.ForLabel("auth", 0)
+	if len(auth) != 2 || auth[0].Author != "Alice" || auth[0].Commits != 2 || auth[0].Score != 1.5 || auth[1].Score != 0.5 {
+		t.Fatalf("auth experts = %+v", auth)
+	}
+	if auth[0].Share != 0.75 || !auth[0].LastActive.Equal(now) {
+		t.Fatalf("alice = %+v", auth[0])
+	}
+
+	// Closing a bead counts at half weight toward its labels.
+	ui := m.ForLabel("ui", 0)
+	if len(ui) != 2 || ui[0].Author != "Bob" || ui[1].Author != "Carol" || ui[1].Score != 0.5 || ui[1].Commits != 0 {
+		t.Fatalf("ui experts = %+v", ui)
+	}
+
+	// Files resolve to their directory; ancestors aggregate subdirectories.
+	dir, experts := m.ForPath("pkg/auth/oauth/token.go", 0)
+	if dir != "pkg/auth/oauth" || len(experts) != 1 || experts[0].Author != "Alice" {
+		t.Fatalf("oauth = %s %+v", dir, experts)
+	}
+	dir, experts = m.ForPath("./pkg/auth/new_file.go", 1)
+	if dir != "pkg/auth" || len(experts) != 1 || experts[0].Beads != 1 {
+		t.Fatalf("auth dir = %s %+v", dir, experts)
+	}
+	if _, experts = m.ForPath("docs/guide.md", 0); len(experts) != 2 {
+		t.Fatalf("root experts = %+v", experts)
+	}
+
+	s := m.SuggestAssignee([]string{"auth"}, []string{"pkg/auth"})
+	if s == nil || s.Assignee != "Alice" || s.Email != "alice@example.com" || s.Score != 3 {
+		t.Fatalf("suggestion = %+v", s)
+	}
+	if s := m.SuggestAssignee([]string{"docs"}, nil); s != nil {
+		t.Fatalf("expected no suggestion, got %+v", s)
+	}
+}
+
+func TestBuildExpertiseMatrix_SharedCommitCreditsDirsOnce(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	shared := CorrelatedCommit{SHA: "s1", Author: "Alice", AuthorEmail: "alice@example.com", Timestamp: now, Confidence: 1,
+		Files: []FileChange{{Path: "pkg/auth/login.go"}}}
+
+	single := BuildExpertiseMatrix(&HistoryReport{Histories: map[string]BeadHistory{
+		"bv-1": {BeadID: "bv-1", Commits: []CorrelatedCommit{shared}},
+	}}, nil, ExpertiseOptions{Now: now})
+	weaker := shared
+	weaker.Confidence = 0.5
+	multi := BuildExpertiseMatrix(&HistoryReport{Histories: map[string]BeadHistory{
+		"bv-1": {BeadID: "bv-1", Commits: []CorrelatedCommit{shared}},
+		"bv-2": {BeadID: "bv-2", Commits: []CorrelatedCommit{weaker}},
+	}}, nil, ExpertiseOptions{Now: now})
+
+	_, want := single.ForPath("pkg/auth/login.go", 0)
+	_, got := multi.ForPath("pkg/auth/login.go", 0)
+	if len(want) != 1 || len(got) != 1 || got[0].Score != want[0].Score || got[0].Commits != 1 {
+		t.Fatalf("shared commit experts = %+v, want score of %+v", got, want)
+	}
+	if got[0].Beads != 2 {
+		t.Fatalf("expected both beads counted, got %+v", got[0])
+	}
+}
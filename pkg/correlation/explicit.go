@@ -15,6 +15,15 @@ import (
 type ExplicitMatcher struct {
 	repoPath string
 	patterns []*regexp.Regexp
+
+	// Set by NewExplicitMatcherForIDs. With a known ID set, built-in
+	// pattern matches must name a known ID; known IDs are found exactly
+	// by the trie and IDs under known prefixes by prefixRe.
+	custom      []*regexp.Regexp // from .bv/correlation.yaml, always trusted
+	trailerKeys []string
+	known       map[string]string // lowercase ID -> canonical ID
+	trie        *idTrie
+	prefixRe    *regexp.Regexp
 }
 
 // DefaultPatterns returns the default set of bead ID patterns.
@@ -55,6 +64,45 @@ func NewExplicitMatcherWithPatterns(repoPath string, patterns []*regexp.Regexp)
 	}
 }
 
+// NewExplicitMatcherForIDs creates a matcher driven by the project's actual
+// issue IDs. Prefixes are derived from knownIDs (workspace IDs carry their
+// repo prefix) and cfg.Prefixes; cfg adds regexes and trailer keys.
+func NewExplicitMatcherForIDs(repoPath string, knownIDs []string, cfg CorrelationConfig) (*ExplicitMatcher, error) {
+	custom, err := cfg.compilePatterns()
+	if err != nil {
+		return nil, err
+	}
+	m := &ExplicitMatcher{
+		repoPath:    repoPath,
+		patterns:    DefaultPatterns(),
+		custom:      custom,
+		trailerKeys: append(append([]string(nil), DefaultTrailerKeys...), cfg.Trailers...),
+		known:       make(map[string]string, len(knownIDs)),
+		trie:        newIDTrie(knownIDs),
+	}
+	prefixes := append([]string(nil), cfg.Prefixes...)
+	for _, id := range knownIDs {
+		m.known[strings.ToLower(id)] = id
+		if p := IDPrefix(id); p != "" {
+			prefixes = append(prefixes, p)
+		}
+	}
+	m.prefixRe = prefixPattern(prefixes)
+	return m, nil
+}
+
+// canonicalID normalizes a raw ID and maps it onto the known ID set.
+func (m *ExplicitMatcher) canonicalID(raw string) (string, bool) {
+	id := normalizeBeadID(raw)
+	if m.known == nil {
+		return id, true
+	}
+	if canonical, ok := m.known[id]; ok {
+		return canonical, true
+	}
+	return id, false
+}
+
 // AddPattern adds a custom pattern to the matcher.
 func (m *ExplicitMatcher) AddPattern(pattern *regexp.Regexp) {
 	m.patterns = append(m.patterns, pattern)
@@ -77,25 +125,59 @@ type ExplicitMatch struct {
 func (m *ExplicitMatcher) ExtractIDsFromMessage(message string) []IDMatch {
 	var matches []IDMatch
 	seen := make(map[string]bool)
+	add := func(id, matchType, raw string) {
+		key := strings.ToLower(id)
+		if id == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		matches = append(matches, IDMatch{ID: id, MatchType: matchType, RawMatch: raw})
+	}
+
+	// Trailers state intent most directly, then keyword/bracket forms.
+	for _, raw := range trailerIDs(message, m.trailerKeys) {
+		id, _ := m.canonicalID(raw)
+		add(id, "trailer", raw)
+	}
 
 	for _, pattern := range m.patterns {
 		found := pattern.FindAllStringSubmatch(message, -1)
 		for _, match := range found {
 			if len(match) >= 2 {
-				id := normalizeBeadID(match[1])
-				if !seen[id] {
-					seen[id] = true
-					matchType := classifyMatch(match[0])
-					matches = append(matches, IDMatch{
-						ID:        id,
-						MatchType: matchType,
-						RawMatch:  match[0],
-					})
+				// With a known ID set, a partial match such as "web-9" out
+				// of "web-9gfx" is dropped; the trie finds the full ID.
+				if id, ok := m.canonicalID(match[1]); ok {
+					add(id, classifyMatch(match[0]), match[0])
 				}
 			}
 		}
 	}
 
+	for _, pattern := range m.custom {
+		for _, match := range pattern.FindAllStringSubmatch(message, -1) {
+			if len(match) >= 2 && match[1] != "" {
+				id, _ := m.canonicalID(match[1])
+				add(id, classifyMatch(match[0]), match[0])
+			}
+		}
+	}
+
+	if m.trie != nil {
+		for _, hit := range m.trie.findAll(message) {
+			matchType, raw := classifyContext(message, hit.start, hit.end)
+			add(hit.id, matchType, raw)
+		}
+	}
+
+	if m.prefixRe != nil {
+		for _, loc := range m.prefixRe.FindAllStringSubmatchIndex(message, -1) {
+			start, end := loc[2], loc[3]
+			id, _ := m.canonicalID(message[start:end])
+			matchType, raw := classifyContext(message, start, end)
+			add(id, matchType, raw)
+		}
+	}
+
 	return matches
 }
 
@@ -152,6 +234,8 @@ func CalculateConfidence(matchType string, totalMatches int) float64 {
 		base += 0.01 // Just a reference
 	case "bead":
 		base += 0.03 // Project-specific format
+	case "trailer":
+		base += 0.06 // Structured trailer (Bead: <id>)
 	}
 
 	// Penalty for multiple IDs in same message (less specific)
@@ -317,24 +401,99 @@ func (m *ExplicitMatcher) parseGrepOutput(data []byte, searchPattern string) ([]
 	return matches, scanner.Err()
 }
 
-// CreateCorrelatedCommit converts an ExplicitMatch to a CorrelatedCommit.
-func (m *ExplicitMatcher) CreateCorrelatedCommit(match ExplicitMatch, coCommitter *CoCommitExtractor) CorrelatedCommit {
-	// Create a BeadEvent to get file information
-	event := BeadEvent{
-		BeadID:      match.BeadID,
-		CommitSHA:   match.CommitSHA,
-		CommitMsg:   match.Message,
-		Author:      match.Author,
-		AuthorEmail: match.AuthorEmail,
-		Timestamp:   match.Timestamp,
+// ScanCommits reads every commit's full message (subject, body and
+// trailers) in one git log pass and returns a match per referenced bead.
+// Unlike FindCommitsForBead it does not run one grep per bead.
+func (m *ExplicitMatcher) ScanCommits(opts ExtractOptions) ([]ExplicitMatch, error) {
+	args := []string{"log", "--format=" + gitLogBodyFormat}
+	if opts.Since != nil {
+		args = append(args, fmt.Sprintf("--since=%s", opts.Since.Format(time.RFC3339)))
+	}
+	if opts.Until != nil {
+		args = append(args, fmt.Sprintf("--until=%s", opts.Until.Format(time.RFC3339)))
+	}
+	if opts.Limit > 0 {
+		args = append(args, fmt.Sprintf("-n%d", opts.Limit))
+	}
+	return m.scan(args, nil, opts.BeadID)
+}
+
+// ScanCommitList is ScanCommits over the given commits only.
+func (m *ExplicitMatcher) ScanCommitList(shas []string) ([]ExplicitMatch, error) {
+	if len(shas) == 0 {
+		return nil, nil
+	}
+	// SHAs go on stdin so the argument list stays short however many
+	// commits there are.
+	args := []string{"log", "--no-walk", "--stdin", "--format=" + gitLogBodyFormat}
+	return m.scan(args, shas, "")
+}
+
+// scan runs git log with args, feeding stdin lines (if any) to --stdin.
+func (m *ExplicitMatcher) scan(args []string, stdin []string, beadID string) ([]ExplicitMatch, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = m.repoPath
+	if len(stdin) > 0 {
+		cmd.Stdin = strings.NewReader(strings.Join(stdin, "\n") + "\n")
+	}
+
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("git log failed: %s", string(exitErr.Stderr))
+		}
+		return nil, fmt.Errorf("git log failed: %w", err)
+	}
+	return m.parseScanOutput(out, beadID), nil
+}
+
+// parseScanOutput parses gitLogBodyFormat records. Message holds the
+// subject line, as for other correlated commits.
+func (m *ExplicitMatcher) parseScanOutput(data []byte, beadID string) []ExplicitMatch {
+	var matches []ExplicitMatch
+	for _, record := range strings.Split(string(data), "\x1e") {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
+			continue
+		}
+		info, err := parseCommitInfo(record)
+		if err != nil {
+			continue
+		}
+		body := strings.TrimSpace(info.Message)
+		subject, _, _ := strings.Cut(body, "\n")
+
+		idMatches := m.ExtractIDsFromMessage(body)
+		for _, idMatch := range idMatches {
+			if beadID != "" && !strings.EqualFold(idMatch.ID, beadID) {
+				continue
+			}
+			matches = append(matches, ExplicitMatch{
+				BeadID:      idMatch.ID,
+				CommitSHA:   info.SHA,
+				Message:     subject,
+				Author:      info.Author,
+				AuthorEmail: info.AuthorEmail,
+				Timestamp:   info.Timestamp,
+				MatchType:   idMatch.MatchType,
+				Confidence:  CalculateConfidence(idMatch.MatchType, len(idMatches)),
+			})
+		}
 	}
+	return matches
+}
 
+// CreateCorrelatedCommit converts an ExplicitMatch to a CorrelatedCommit.
+func (m *ExplicitMatcher) CreateCorrelatedCommit(match ExplicitMatch, coCommitter *CoCommitExtractor) CorrelatedCommit {
 	// Try to get file changes
 	var files []FileChange
 	if coCommitter != nil {
-		files, _ = coCommitter.ExtractCoCommittedFiles(event)
+		files, _ = coCommitter.ExtractCoCommittedFiles(BeadEvent{CommitSHA: match.CommitSHA})
 	}
+	return explicitCorrelatedCommit(match, files)
+}
 
+func explicitCorrelatedCommit(match ExplicitMatch, files []FileChange) CorrelatedCommit {
 	reason := fmt.Sprintf("Commit message explicitly references %s (%s)", match.BeadID, match.MatchType)
 
 	return CorrelatedCommit{
@@ -369,3 +528,50 @@ func (m *ExplicitMatcher) FindAllExplicitMatches(beadIDs []string, opts ExtractO
 
 	return results, nil
 }
+
+// newProjectExplicitMatcher builds the matcher for a report: known IDs from
+// beads plus the repository's .bv/correlation.yaml.
+func newProjectExplicitMatcher(repoPath string, beads []BeadInfo) (*ExplicitMatcher, error) {
+	cfg, err := LoadCorrelationConfig(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(beads))
+	for i, b := range beads {
+		ids[i] = b.ID
+	}
+	return NewExplicitMatcherForIDs(repoPath, ids, cfg)
+}
+
+// explicitCommits converts matches on known beads into correlated commits,
+// skipping bead/commit pairs already linked by another method.
+func explicitCommits(m *ExplicitMatcher, matches []ExplicitMatch, linked []CorrelatedCommit, coCommitter *CoCommitExtractor) []CorrelatedCommit {
+	seen := make(map[string]bool, len(linked))
+	for _, c := range linked {
+		seen[c.BeadID+"\x00"+c.SHA] = true
+	}
+	fileCache := make(map[string][]FileChange)
+
+	var out []CorrelatedCommit
+	for _, match := range matches {
+		if m.known != nil {
+			if _, ok := m.known[strings.ToLower(match.BeadID)]; !ok {
+				continue
+			}
+		}
+		key := match.BeadID + "\x00" + match.CommitSHA
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		files, cached := fileCache[match.CommitSHA]
+		if !cached && coCommitter != nil {
+			// Non-fatal: an explicit reference stands without file info.
+			files, _ = coCommitter.ExtractCoCommittedFiles(BeadEvent{CommitSHA: match.CommitSHA})
+			fileCache[match.CommitSHA] = files
+		}
+		out = append(out, explicitCorrelatedCommit(match, files))
+	}
+	return out
+}
@@ -75,9 +75,11 @@ type commitInfo struct {
 
 // beadSnapshot represents a bead's state at a point in time
 type beadSnapshot struct {
-	ID     string
-	Status string
-	Title  string
+	ID       string
+	Status   string
+	Title    string
+	Priority *int // nil when the field is absent
+	Assignee string
 }
 
 // Extract extracts bead lifecycle events from git history
@@ -365,6 +367,7 @@ func (e *Extractor) parseDiff(diffData []byte, info commitInfo, filterBeadID str
 			event.EventType = EventCreated
 			events = append(events, event)
 		} else if hadOld && hasNew {
+			event.Changes = snapshotChanges(oldSnap, newSnap)
 			// Check for status change
 			if oldSnap.Status != newSnap.Status {
 				event.EventType = determineStatusEvent(oldSnap.Status, newSnap.Status)
@@ -384,9 +387,11 @@ func (e *Extractor) parseDiff(diffData []byte, info commitInfo, filterBeadID str
 // parseBeadJSON extracts minimal bead info from a JSON line
 func parseBeadJSON(jsonStr string) (beadSnapshot, bool) {
 	var partial struct {
-		ID     string `json:"id"`
-		Status string `json:"status"`
-		Title  string `json:"title"`
+		ID       string `json:"id"`
+		Status   string `json:"status"`
+		Title    string `json:"title"`
+		Priority *int   `json:"priority"`
+		Assignee string `json:"assignee"`
 	}
 
 	if err := json.Unmarshal([]byte(jsonStr), &partial); err != nil {
@@ -398,12 +403,30 @@ func parseBeadJSON(jsonStr string) (beadSnapshot, bool) {
 	}
 
 	return beadSnapshot{
-		ID:     partial.ID,
-		Status: partial.Status,
-		Title:  partial.Title,
+		ID:       partial.ID,
+		Status:   partial.Status,
+		Title:    partial.Title,
+		Priority: partial.Priority,
+		Assignee: partial.Assignee,
 	}, true
 }
 
+// snapshotChanges lists the tracked fields that differ between two states
+func snapshotChanges(oldSnap, newSnap beadSnapshot) []string {
+	var changes []string
+	if oldSnap.Title != newSnap.Title {
+		changes = append(changes, ChangeTitle)
+	}
+	if (oldSnap.Priority == nil) != (newSnap.Priority == nil) ||
+		oldSnap.Priority != nil && *oldSnap.Priority != *newSnap.Priority {
+		changes = append(changes, ChangePriority)
+	}
+	if oldSnap.Assignee != newSnap.Assignee {
+		changes = append(changes, ChangeAssignee)
+	}
+	return changes
+}
+
 // determineStatusEvent determines the appropriate event type for a status transition
 func determineStatusEvent(oldStatus, newStatus string) EventType {
 	switch newStatus {
@@ -459,22 +482,25 @@ func GetBeadMilestones(events []BeadEvent) BeadMilestones {
 	return milestones
 }
 
-// CalculateCycleTime computes cycle time metrics from milestones
+// CalculateCycleTime computes cycle time metrics from milestones. It returns
+// nil until the bead is closed or a linked pull request is merged.
 func CalculateCycleTime(milestones BeadMilestones) *CycleTime {
-	if milestones.Closed == nil {
+	if milestones.Closed == nil && milestones.Merged == nil {
 		return nil
 	}
 
 	ct := &CycleTime{}
 
-	if milestones.Claimed != nil {
+	if milestones.Closed != nil && milestones.Claimed != nil {
 		d := milestones.Closed.Timestamp.Sub(milestones.Claimed.Timestamp)
 		ct.ClaimToClose = &d
 	}
 
 	if milestones.Created != nil {
-		d := milestones.Closed.Timestamp.Sub(milestones.Created.Timestamp)
-		ct.CreateToClose = &d
+		if milestones.Closed != nil {
+			d := milestones.Closed.Timestamp.Sub(milestones.Created.Timestamp)
+			ct.CreateToClose = &d
+		}
 
 		if milestones.Claimed != nil {
 			d := milestones.Claimed.Timestamp.Sub(milestones.Created.Timestamp)
@@ -482,5 +508,20 @@ func CalculateCycleTime(milestones BeadMilestones) *CycleTime {
 		}
 	}
 
+	if milestones.Merged != nil {
+		if milestones.Claimed != nil {
+			d := milestones.Merged.Timestamp.Sub(milestones.Claimed.Timestamp)
+			ct.ClaimToMerge = &d
+		}
+		if milestones.Created != nil {
+			d := milestones.Merged.Timestamp.Sub(milestones.Created.Timestamp)
+			ct.CreateToMerge = &d
+		}
+		if milestones.InReview != nil {
+			d := milestones.Merged.Timestamp.Sub(milestones.InReview.Timestamp)
+			ct.ReviewTime = &d
+		}
+	}
+
 	return ct
 }
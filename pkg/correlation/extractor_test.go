@@ -504,6 +504,25 @@ func TestParseDiff(t *testing.T) {
 		if events[0].EventType != EventModified {
 			t.Errorf("Expected EventModified, got %v", events[0].EventType)
 		}
+		if len(events[0].Changes) != 1 || !events[0].HasChange(ChangeTitle) {
+			t.Errorf("Expected title change, got %v", events[0].Changes)
+		}
+	})
+
+	t.Run("field changes recorded on status events", func(t *testing.T) {
+		diffData := []byte(`diff --git a/.beads/beads.jsonl b/.beads/beads.jsonl
+-{"id":"bv-123","title":"Test","status":"open","priority":0}
++{"id":"bv-123","title":"Test","status":"in_progress","priority":2,"assignee":"alice"}
+`)
+
+		events := e.parseDiff(diffData, info, "")
+
+		if len(events) != 1 || events[0].EventType != EventClaimed {
+			t.Fatalf("Expected 1 claimed event, got %+v", events)
+		}
+		if got := events[0].Changes; len(got) != 2 || got[0] != ChangePriority || got[1] != ChangeAssignee {
+			t.Errorf("Expected priority and assignee changes, got %v", got)
+		}
 	})
 
 	t.Run("empty diff", func(t *testing.T) {
@@ -176,42 +176,31 @@ func (fs *FeedbackStore) GetStats() FeedbackStats {
 
 // Confirm records a confirmation that the correlation is correct
 func (fs *FeedbackStore) Confirm(commitSHA, beadID, feedbackBy string, originalConf float64, reason string) error {
-	fb := CorrelationFeedback{
-		CommitSHA:    commitSHA,
-		BeadID:       beadID,
-		FeedbackAt:   time.Now().UTC(),
-		FeedbackBy:   feedbackBy,
-		Type:         FeedbackConfirm,
-		Reason:       reason,
-		OriginalConf: originalConf,
-	}
-	return fs.Save(fb)
+	return fs.Record(FeedbackConfirm, commitSHA, beadID, feedbackBy, originalConf, reason, nil)
 }
 
 // Reject records a rejection that the correlation is incorrect
 func (fs *FeedbackStore) Reject(commitSHA, beadID, feedbackBy string, originalConf float64, reason string) error {
-	fb := CorrelationFeedback{
-		CommitSHA:    commitSHA,
-		BeadID:       beadID,
-		FeedbackAt:   time.Now().UTC(),
-		FeedbackBy:   feedbackBy,
-		Type:         FeedbackReject,
-		Reason:       reason,
-		OriginalConf: originalConf,
-	}
-	return fs.Save(fb)
+	return fs.Record(FeedbackReject, commitSHA, beadID, feedbackBy, originalConf, reason, nil)
 }
 
 // Ignore records that this correlation should be excluded from training
 func (fs *FeedbackStore) Ignore(commitSHA, beadID, feedbackBy string, originalConf float64, reason string) error {
+	return fs.Record(FeedbackIgnore, commitSHA, beadID, feedbackBy, originalConf, reason, nil)
+}
+
+// Record stores feedback of the given type along with the correlation
+// features the confidence model trains on (nil if unavailable).
+func (fs *FeedbackStore) Record(fbType FeedbackType, commitSHA, beadID, feedbackBy string, originalConf float64, reason string, features *CorrelationFeatures) error {
 	fb := CorrelationFeedback{
 		CommitSHA:    commitSHA,
 		BeadID:       beadID,
 		FeedbackAt:   time.Now().UTC(),
 		FeedbackBy:   feedbackBy,
-		Type:         FeedbackIgnore,
+		Type:         fbType,
 		Reason:       reason,
 		OriginalConf: originalConf,
+		Features:     features,
 	}
 	return fs.Save(fb)
 }
@@ -3,6 +3,14 @@ package correlation
 const (
 	gitLogHeaderFormat = "%H%x00%aI%x00%an%x00%ae%x00%s"
 
+	// gitLogBodyFormat carries the full message (body and trailers); records
+	// end with an ASCII record separator since bodies span lines.
+	gitLogBodyFormat = "%H%x00%aI%x00%an%x00%ae%x00%B%x1e"
+
+	// gitLogMergeFormat adds parent SHAs and uses the committer date, which
+	// is when a pull request landed rather than when its work was authored.
+	gitLogMergeFormat = "%H%x00%cI%x00%an%x00%ae%x00%P%x00%B%x1e"
+
 	// gitLogMaxScanTokenSize matches the loader and stream limits; it prevents
 	// bufio.Scanner from failing on unusually long lines.
 	gitLogMaxScanTokenSize = 10 * 1024 * 1024 // 10MB
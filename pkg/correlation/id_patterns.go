@@ -0,0 +1,244 @@
+package correlation
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CorrelationConfigFile is the per-project config for explicit ID matching,
+// relative to the repository root.
+const CorrelationConfigFile = ".bv/correlation.yaml"
+
+// DefaultTrailerKeys are the git trailer keys whose values are read as bead IDs.
+var DefaultTrailerKeys = []string{"Bead", "Beads", "Bead-Id"}
+
+// CorrelationConfig extends explicit ID matching. Example:
+//
+//	patterns:            # extra regexes; the first capture group is the ID
+//	  - 'TICKET#(\w+-\w+)'
+//	trailers: [Closes-Bead]
+//	prefixes: [web, api]
+type CorrelationConfig struct {
+	Patterns []string `yaml:"patterns,omitempty" json:"patterns,omitempty"`
+	Trailers []string `yaml:"trailers,omitempty" json:"trailers,omitempty"`
+	Prefixes []string `yaml:"prefixes,omitempty" json:"prefixes,omitempty"`
+}
+
+// LoadCorrelationConfig reads .bv/correlation.yaml under repoPath. A missing
+// file yields an empty config.
+func LoadCorrelationConfig(repoPath string) (CorrelationConfig, error) {
+	var cfg CorrelationConfig
+	path := filepath.Join(repoPath, CorrelationConfigFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if _, err := cfg.compilePatterns(); err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func (cfg CorrelationConfig) compilePatterns() ([]*regexp.Regexp, error) {
+	out := make([]*regexp.Regexp, 0, len(cfg.Patterns))
+	for _, p := range cfg.Patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+		if re.NumSubexp() < 1 {
+			return nil, fmt.Errorf("pattern %q needs a capture group for the ID", p)
+		}
+		out = append(out, re)
+	}
+	return out, nil
+}
+
+// IDPrefix returns the namespace prefix of an issue ID: "web" for
+// "web-9gfx" and "bv" for "bv-9gf.3". IDs without a dash have none.
+func IDPrefix(id string) string {
+	base := id
+	if dot := strings.IndexByte(base, '.'); dot != -1 {
+		base = base[:dot]
+	}
+	if dash := strings.LastIndexByte(base, '-'); dash > 0 {
+		return base[:dash]
+	}
+	return ""
+}
+
+// idTrie matches known issue IDs exactly and case-insensitively, so
+// hash-style IDs like web-9gfx are found without a numeric-ID regex.
+type idTrie struct {
+	root idTrieNode
+}
+
+type idTrieNode struct {
+	next map[byte]*idTrieNode
+	id   string // canonical ID ending here
+}
+
+type trieHit struct {
+	start, end int
+	id         string
+}
+
+func newIDTrie(ids []string) *idTrie {
+	t := &idTrie{}
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+		n := &t.root
+		lower := strings.ToLower(id)
+		for i := 0; i < len(lower); i++ {
+			if n.next == nil {
+				n.next = make(map[byte]*idTrieNode)
+			}
+			child, ok := n.next[lower[i]]
+			if !ok {
+				child = &idTrieNode{}
+				n.next[lower[i]] = child
+			}
+			n = child
+		}
+		n.id = id
+	}
+	return t
+}
+
+func isIDWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || b == '_'
+}
+
+// idStartBoundary reports whether an ID may start at text[i]: it must not
+// continue a word or a dashed token ("foo-web-9gfx").
+func idStartBoundary(text string, i int) bool {
+	return i == 0 || !(isIDWordByte(text[i-1]) || text[i-1] == '-')
+}
+
+// idEndBoundary reports whether an ID may end before text[i]. A dash or dot
+// followed by more ID characters continues the token ("bv-12.3", "bv-12-x").
+func idEndBoundary(text string, i int) bool {
+	if i >= len(text) {
+		return true
+	}
+	if isIDWordByte(text[i]) {
+		return false
+	}
+	if (text[i] == '-' || text[i] == '.') && i+1 < len(text) && isIDWordByte(text[i+1]) {
+		return false
+	}
+	return true
+}
+
+// findAll returns the longest known ID at each token start, left to right.
+func (t *idTrie) findAll(text string) []trieHit {
+	var hits []trieHit
+	for i := 0; i < len(text); i++ {
+		if !idStartBoundary(text, i) {
+			continue
+		}
+		n := &t.root
+		best := trieHit{start: -1}
+		for j := i; j < len(text); j++ {
+			c := text[j]
+			if c >= 'A' && c <= 'Z' {
+				c += 'a' - 'A'
+			}
+			n = n.next[c]
+			if n == nil {
+				break
+			}
+			if n.id != "" && idEndBoundary(text, j+1) {
+				best = trieHit{start: i, end: j + 1, id: n.id}
+			}
+		}
+		if best.start >= 0 {
+			hits = append(hits, best)
+			i = best.end - 1
+		}
+	}
+	return hits
+}
+
+// prefixPattern matches IDs under any of the given prefixes, including IDs
+// no longer in the issue set (deleted or renamed beads).
+func prefixPattern(prefixes []string) *regexp.Regexp {
+	seen := make(map[string]bool, len(prefixes))
+	var alts []string
+	for _, p := range prefixes {
+		p = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(p), "-"))
+		if p == "" || seen[p] {
+			continue
+		}
+		seen[p] = true
+		alts = append(alts, regexp.QuoteMeta(p))
+	}
+	if len(alts) == 0 {
+		return nil
+	}
+	// Longest first so "web-app" wins over "web".
+	sort.Slice(alts, func(i, j int) bool {
+		if len(alts[i]) == len(alts[j]) {
+			return alts[i] < alts[j]
+		}
+		return len(alts[i]) > len(alts[j])
+	})
+	return regexp.MustCompile(`(?i)(?:^|[^A-Za-z0-9_-])((?:` + strings.Join(alts, "|") + `)-[a-z0-9]+(?:\.[0-9]+)*)\b`)
+}
+
+// keywordBefore matches an action keyword immediately preceding an ID.
+var keywordBefore = regexp.MustCompile(`(?i)\b(close[sd]?|fix(?:es|ed)?|refs?|resolve[sd]?)\b:?\s*#?$`)
+
+// classifyContext classifies a match found by position (trie or prefix
+// pattern) from the text around it.
+func classifyContext(text string, start, end int) (matchType, raw string) {
+	if start > 0 && text[start-1] == '[' && end < len(text) && text[end] == ']' {
+		return "bracket", text[start-1 : end+1]
+	}
+	if loc := keywordBefore.FindStringSubmatchIndex(text[:start]); loc != nil {
+		return classifyMatch(text[loc[2]:loc[3]]), text[loc[0]:end]
+	}
+	return "generic", text[start:end]
+}
+
+// trailerLine matches a git trailer ("Key: value").
+var trailerLine = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9-]*):\s*(.+)$`)
+
+// trailerIDs returns the values of the given trailer keys, split on commas
+// and whitespace.
+func trailerIDs(message string, keys []string) []string {
+	if len(keys) == 0 {
+		return nil
+	}
+	var ids []string
+	for _, line := range strings.Split(message, "\n") {
+		m := trailerLine.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		for _, k := range keys {
+			if strings.EqualFold(m[1], k) {
+				ids = append(ids, strings.FieldsFunc(m[2], func(r rune) bool {
+					return r == ',' || r == ' ' || r == '\t'
+				})...)
+				break
+			}
+		}
+	}
+	return ids
+}
@@ -0,0 +1,135 @@
+package correlation
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func matchIDs(matches []IDMatch) string {
+	parts := make([]string, len(matches))
+	for i, m := range matches {
+		parts[i] = m.ID + ":" + m.MatchType
+	}
+	return strings.Join(parts, " ")
+}
+
+func TestExplicitMatcherForIDs_HashIDs(t *testing.T) {
+	m, err := NewExplicitMatcherForIDs("", []string{"web-9gfx", "web-9gfx.2", "api-k3p1", "bv-12"}, CorrelationConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		msg  string
+		want string
+	}{
+		{"Fixes WEB-9GFX: handle retries", "web-9gfx:fixes"},
+		{"[api-k3p1] tighten auth", "api-k3p1:bracket"},
+		{"work on web-9gfx.2 and bv-12", "bv-12:bead web-9gfx.2:generic"},
+		{"see old-web-9gfx", ""},
+		// Unknown ID under a known prefix is still an explicit reference.
+		{"refs web-zz01", "web-zz01:refs"},
+		{"Upgrade to UTF-8 and SHA-256", ""},
+	}
+	for _, tt := range tests {
+		if got := matchIDs(m.ExtractIDsFromMessage(tt.msg)); got != tt.want {
+			t.Errorf("ExtractIDsFromMessage(%q) = %q, want %q", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestExplicitMatcherForIDs_TrailersAndConfig(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cfgYAML := "patterns:\n  - 'TICKET#(\\w+-\\w+)'\ntrailers: [Closes-Bead]\nprefixes: [ops]\n"
+	if err := os.WriteFile(filepath.Join(dir, CorrelationConfigFile), []byte(cfgYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadCorrelationConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewExplicitMatcherForIDs(dir, []string{"web-9gfx", "api-k3p1"}, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := "Tidy up\n\nTICKET#x-77 follow-up, ops-4 too\n\nBead: web-9gfx, api-k3p1\nCloses-Bead: web-a1\n"
+	if got, want := matchIDs(m.ExtractIDsFromMessage(msg)), "web-9gfx:trailer api-k3p1:trailer web-a1:trailer x-77:generic ops-4:generic"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if c := CalculateConfidence("trailer", 1); c <= CalculateConfidence("fixes", 1) {
+		t.Fatalf("trailer confidence %.2f should beat keyword matches", c)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, CorrelationConfigFile), []byte("patterns: ['NOGROUP-\\d+']\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCorrelationConfig(dir); err == nil {
+		t.Fatal("pattern without a capture group should be rejected")
+	}
+}
+
+func TestIDPrefix(t *testing.T) {
+	for id, want := range map[string]string{"web-9gfx": "web", "bv-9gf.3": "bv", "my-proj-a1": "my-proj", "plain": ""} {
+		if got := IDPrefix(id); got != want {
+			t.Errorf("IDPrefix(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
+
+func TestGenerateReport_LinksExplicitReferences(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, body string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("init", "-q")
+	git("config", "user.email", "dev@example.com")
+	git("config", "user.name", "Dev")
+	write(".beads/issues.jsonl", `{"id":"web-9gfx","title":"Retry uploads","status":"open"}`+"\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "add beads")
+	write("upload.go", "package main\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "Add upload retries", "-m", "Bead: web-9gfx")
+	write("upload.go", "package main\n\n// retry\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "unrelated web-9 tweak")
+
+	report, err := NewCorrelator(dir).GenerateReport([]BeadInfo{{ID: "web-9gfx", Title: "Retry uploads", Status: "open"}}, CorrelatorOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	commits := report.Histories["web-9gfx"].Commits
+	if len(commits) != 1 {
+		t.Fatalf("commits = %+v", commits)
+	}
+	c := commits[0]
+	if c.Method != MethodExplicitID || c.Message != "Add upload retries" || len(c.Files) != 1 || c.Files[0].Path != "upload.go" {
+		t.Fatalf("commit = %+v", c)
+	}
+	if report.Stats.MethodDistribution[MethodExplicitID.String()] != 1 {
+		t.Fatalf("stats = %+v", report.Stats)
+	}
+}
@@ -178,9 +178,38 @@ func (ic *IncrementalCorrelator) tryIncrementalUpdate(existing *HistoryReport, b
 		return nil, fmt.Errorf("extracting co-commits: %w", err)
 	}
 
+	// Link new commits whose messages reference beads
+	matcher, err := newProjectExplicitMatcher(ic.cache.repoPath, beads)
+	if err != nil {
+		return nil, fmt.Errorf("loading correlation patterns: %w", err)
+	}
+	explicit, err := matcher.ScanCommitList(newCommits)
+	if err != nil {
+		return nil, fmt.Errorf("scanning commit messages: %w", err)
+	}
+	if opts.BeadID != "" {
+		filtered := explicit[:0]
+		for _, match := range explicit {
+			if strings.EqualFold(match.BeadID, opts.BeadID) {
+				filtered = append(filtered, match)
+			}
+		}
+		explicit = filtered
+	}
+	newCorrelatedCommits = append(newCorrelatedCommits, explicitCommits(matcher, explicit, newCorrelatedCommits, coCommitter)...)
+
 	// Merge new data with existing report
 	merged := mergeReports(existing, beads, newEvents, newCorrelatedCommits)
 
+	// Link pull requests merged in the new commits
+	prs, err := NewPullRequestExtractor(ic.cache.repoPath).ExtractCommitList(newCommits)
+	if err != nil {
+		return nil, fmt.Errorf("extracting pull requests: %w", err)
+	}
+	if attachPullRequests(merged.Histories, prs, matcher) > 0 {
+		merged.Stats = calculateMergedStats(merged.Histories, newCorrelatedCommits)
+	}
+
 	return &IncrementalUpdateResult{
 		Report:            merged,
 		WasIncremental:    true,
@@ -286,16 +315,22 @@ func mergeReports(existing *HistoryReport, beads []BeadInfo, newEvents []BeadEve
 		copy(eventsCopy, h.Events)
 		commitsCopy := make([]CorrelatedCommit, len(h.Commits))
 		copy(commitsCopy, h.Commits)
+		var prsCopy []PullRequest
+		for _, pr := range h.PullRequests {
+			pr.Commits = append([]string(nil), pr.Commits...)
+			prsCopy = append(prsCopy, pr)
+		}
 
 		histories[id] = BeadHistory{
-			BeadID:     h.BeadID,
-			Title:      h.Title,
-			Status:     h.Status,
-			Events:     eventsCopy,
-			Milestones: h.Milestones,
-			Commits:    commitsCopy,
-			CycleTime:  h.CycleTime,
-			LastAuthor: h.LastAuthor,
+			BeadID:       h.BeadID,
+			Title:        h.Title,
+			Status:       h.Status,
+			Events:       eventsCopy,
+			Milestones:   h.Milestones,
+			Commits:      commitsCopy,
+			PullRequests: prsCopy,
+			CycleTime:    h.CycleTime,
+			LastAuthor:   h.LastAuthor,
 		}
 	}
 
@@ -331,8 +366,7 @@ func mergeReports(existing *HistoryReport, beads []BeadInfo, newEvents []BeadEve
 		if h, exists := histories[beadID]; exists {
 			h.Events = append(h.Events, events...)
 			// Recalculate milestones
-			h.Milestones = GetBeadMilestones(h.Events)
-			h.CycleTime = CalculateCycleTime(h.Milestones)
+			h.refreshMilestones()
 			histories[beadID] = h
 		}
 	}
@@ -416,6 +450,9 @@ func calculateMergedStats(histories map[string]BeadHistory, newCommits []Correla
 		if len(h.Commits) > 0 {
 			stats.BeadsWithCommits++
 		}
+		if len(h.PullRequests) > 0 {
+			stats.BeadsWithPRs++
+		}
 
 		for _, commit := range h.Commits {
 			uniqueCommits[commit.SHA] = true
@@ -0,0 +1,433 @@
+// Package correlation provides a confidence model learned from correlation feedback.
+package correlation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ConfidenceModelFile stores the learned confidence model, relative to the
+// repository root.
+const ConfidenceModelFile = ".bv/correlation_model.json"
+
+const (
+	// MinModelSamples is the number of confirm/reject decisions needed
+	// before a model is trained.
+	MinModelSamples = 8
+	// modelPriorStrength is how many decisions the heuristic confidence is
+	// worth when blended with the learned probability; the model takes over
+	// as feedback accumulates.
+	modelPriorStrength = 10.0
+	modelIterations    = 2000
+	modelLearningRate  = 0.5
+	modelL2            = 0.01
+	calibrationBins    = 5
+)
+
+// modelFeatureNames lists the model inputs in vector order.
+var modelFeatureNames = []string{
+	"method_co_committed",
+	"method_explicit_id",
+	"method_temporal_author",
+	"heuristic_logit",
+	"log_hours_to_event",
+	"author_match",
+	"file_overlap",
+	"message_mentions_id",
+	"message_keyword",
+	"log_file_count",
+}
+
+// feedbackKeywords mark commit messages that state intent to work on an issue.
+var feedbackKeywords = []string{"fix", "close", "resolve", "implement", "refs", "part of"}
+
+// CorrelationFeatures are the signals the confidence model learns from,
+// captured when feedback is given.
+type CorrelationFeatures struct {
+	Method              CorrelationMethod `json:"method"`
+	HeuristicConfidence float64           `json:"heuristic_confidence"`
+	HoursToEvent        float64           `json:"hours_to_event"`      // To the nearest bead lifecycle event; -1 if none
+	AuthorMatch         bool              `json:"author_match"`        // Commit author also changed the bead
+	FileOverlap         float64           `json:"file_overlap"`        // Share of files other bead commits also touched
+	MessageMentionsID   bool              `json:"message_mentions_id"` // Message contains the bead ID
+	MessageKeyword      bool              `json:"message_keyword"`     // Message has fix/close/implement-style wording
+	FileCount           int               `json:"file_count"`
+}
+
+// ExtractFeatures computes the model features of a commit linked to a bead.
+func ExtractFeatures(commit CorrelatedCommit, history BeadHistory) CorrelationFeatures {
+	f := CorrelationFeatures{
+		Method:              commit.Method,
+		HeuristicConfidence: commit.HeuristicConfidence(),
+		HoursToEvent:        -1,
+		MessageMentionsID:   containsBeadID(commit.Message, history.BeadID),
+		FileCount:           len(commit.Files),
+	}
+
+	msg := strings.ToLower(commit.Message)
+	for _, kw := range feedbackKeywords {
+		if strings.Contains(msg, kw) {
+			f.MessageKeyword = true
+			break
+		}
+	}
+
+	for _, e := range history.Events {
+		hours := math.Abs(commit.Timestamp.Sub(e.Timestamp).Hours())
+		if f.HoursToEvent < 0 || hours < f.HoursToEvent {
+			f.HoursToEvent = hours
+		}
+		if e.AuthorEmail != "" && strings.EqualFold(e.AuthorEmail, commit.AuthorEmail) ||
+			e.Author != "" && e.Author == commit.Author {
+			f.AuthorMatch = true
+		}
+	}
+
+	if len(commit.Files) > 0 {
+		others := make(map[string]bool)
+		for _, c := range history.Commits {
+			if c.SHA == commit.SHA {
+				continue
+			}
+			for _, file := range c.Files {
+				others[file.Path] = true
+			}
+		}
+		shared := 0
+		for _, file := range commit.Files {
+			if others[file.Path] {
+				shared++
+			}
+		}
+		f.FileOverlap = float64(shared) / float64(len(commit.Files))
+	}
+
+	return f
+}
+
+// vector returns the features in modelFeatureNames order.
+func (f CorrelationFeatures) vector() []float64 {
+	boolf := func(b bool) float64 {
+		if b {
+			return 1
+		}
+		return 0
+	}
+	hours := 0.0
+	if f.HoursToEvent >= 0 {
+		hours = math.Log1p(f.HoursToEvent)
+	}
+	return []float64{
+		boolf(f.Method == MethodCoCommitted),
+		boolf(f.Method == MethodExplicitID),
+		boolf(f.Method == MethodTemporalAuthor),
+		logit(f.HeuristicConfidence),
+		hours,
+		boolf(f.AuthorMatch),
+		f.FileOverlap,
+		boolf(f.MessageMentionsID),
+		boolf(f.MessageKeyword),
+		math.Log1p(float64(f.FileCount)),
+	}
+}
+
+// ConfidenceModel is a logistic regression over correlation features,
+// trained on confirm/reject feedback.
+type ConfidenceModel struct {
+	TrainedAt *time.Time         `json:"trained_at,omitempty"`
+	ResetAt   *time.Time         `json:"reset_at,omitempty"` // Feedback before this is not used for training
+	Samples   int                `json:"samples"`
+	Confirmed int                `json:"confirmed"`
+	Rejected  int                `json:"rejected"`
+	Bias      float64            `json:"bias"`
+	Weights   map[string]float64 `json:"weights,omitempty"` // Keyed by feature name
+}
+
+// Trained reports whether the model has learned weights to apply.
+func (m *ConfidenceModel) Trained() bool {
+	return m != nil && m.TrainedAt != nil && len(m.Weights) > 0
+}
+
+// Probability returns the learned probability that a correlation is correct.
+func (m *ConfidenceModel) Probability(f CorrelationFeatures) float64 {
+	z := m.Bias
+	for i, x := range f.vector() {
+		z += m.Weights[modelFeatureNames[i]] * x
+	}
+	return sigmoid(z)
+}
+
+// Confidence blends the learned probability with the heuristic confidence,
+// trusting the model more as it sees more feedback.
+func (m *ConfidenceModel) Confidence(f CorrelationFeatures) float64 {
+	if !m.Trained() {
+		return f.HeuristicConfidence
+	}
+	n := float64(m.Samples)
+	blended := (n*m.Probability(f) + modelPriorStrength*f.HeuristicConfidence) / (n + modelPriorStrength)
+	return math.Max(0.01, math.Min(0.99, blended))
+}
+
+// Recalibrate replaces commit confidences in the report with the model's,
+// keeping the heuristic value in BaseConfidence.
+func (m *ConfidenceModel) Recalibrate(report *HistoryReport) {
+	if !m.Trained() || report == nil {
+		return
+	}
+	for id, h := range report.Histories {
+		if len(h.Commits) == 0 {
+			continue
+		}
+		commits := make([]CorrelatedCommit, len(h.Commits))
+		for i, c := range h.Commits {
+			conf := m.Confidence(ExtractFeatures(c, h))
+			c.BaseConfidence = c.HeuristicConfidence()
+			c.Confidence = math.Round(conf*1000) / 1000
+			commits[i] = c
+		}
+		h.Commits = commits
+		report.Histories[id] = h
+	}
+}
+
+// TrainConfidenceModel fits a model to the confirm/reject feedback given
+// after resetAt. Feedback recorded without features is skipped. It returns
+// ErrInsufficientFeedback until there are MinModelSamples decisions
+// including at least one of each kind.
+func TrainConfidenceModel(feedback []CorrelationFeedback, resetAt *time.Time) (*ConfidenceModel, error) {
+	samples, labels := trainingSet(feedback, resetAt)
+	m := &ConfidenceModel{ResetAt: resetAt, Samples: len(samples), Weights: map[string]float64{}}
+	for _, y := range labels {
+		if y == 1 {
+			m.Confirmed++
+		} else {
+			m.Rejected++
+		}
+	}
+	if m.Samples < MinModelSamples || m.Confirmed == 0 || m.Rejected == 0 {
+		m.Weights = nil
+		return m, ErrInsufficientFeedback
+	}
+
+	// Batch gradient descent with L2 regularization on the weights.
+	weights := make([]float64, len(modelFeatureNames))
+	bias := 0.0
+	vectors := make([][]float64, len(samples))
+	for i, s := range samples {
+		vectors[i] = s.vector()
+	}
+	n := float64(len(samples))
+	for iter := 0; iter < modelIterations; iter++ {
+		grad := make([]float64, len(weights))
+		gradBias := 0.0
+		for i, x := range vectors {
+			z := bias
+			for j, v := range x {
+				z += weights[j] * v
+			}
+			diff := sigmoid(z) - labels[i]
+			for j, v := range x {
+				grad[j] += diff * v
+			}
+			gradBias += diff
+		}
+		for j := range weights {
+			weights[j] -= modelLearningRate * (grad[j]/n + modelL2*weights[j])
+		}
+		bias -= modelLearningRate * gradBias / n
+	}
+
+	now := time.Now().UTC()
+	m.TrainedAt = &now
+	m.Bias = math.Round(bias*1e4) / 1e4
+	for j, name := range modelFeatureNames {
+		m.Weights[name] = math.Round(weights[j]*1e4) / 1e4
+	}
+	return m, nil
+}
+
+// ErrInsufficientFeedback is returned when there is too little feedback to train.
+var ErrInsufficientFeedback = errors.New("not enough confirm/reject feedback to train a confidence model")
+
+// trainingSet returns the features and labels (1 = confirmed) of usable feedback.
+func trainingSet(feedback []CorrelationFeedback, resetAt *time.Time) ([]CorrelationFeatures, []float64) {
+	sorted := append([]CorrelationFeedback(nil), feedback...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].FeedbackAt.Before(sorted[j].FeedbackAt) })
+
+	var samples []CorrelationFeatures
+	var labels []float64
+	for _, fb := range sorted {
+		if fb.Features == nil || resetAt != nil && !fb.FeedbackAt.After(*resetAt) {
+			continue
+		}
+		switch fb.Type {
+		case FeedbackConfirm:
+			labels = append(labels, 1)
+		case FeedbackReject:
+			labels = append(labels, 0)
+		default:
+			continue
+		}
+		samples = append(samples, *fb.Features)
+	}
+	return samples, labels
+}
+
+// CalibrationBin compares predicted confidence with the observed confirm
+// rate for the feedback whose prediction falls in [Low, High).
+type CalibrationBin struct {
+	Low           float64 `json:"low"`
+	High          float64 `json:"high"`
+	Count         int     `json:"count"`
+	MeanPredicted float64 `json:"mean_predicted"`
+	ObservedRate  float64 `json:"observed_rate"`
+}
+
+// CalibrationCurve is a reliability curve with its Brier score (mean
+// squared error; lower is better).
+type CalibrationCurve struct {
+	Brier float64          `json:"brier"`
+	Bins  []CalibrationBin `json:"bins"`
+}
+
+// CalibrationReport compares heuristic and learned confidence against the
+// feedback the model was trained on.
+type CalibrationReport struct {
+	Samples   int               `json:"samples"`
+	Heuristic CalibrationCurve  `json:"heuristic"`
+	Learned   *CalibrationCurve `json:"learned,omitempty"` // nil until a model is trained
+}
+
+// Calibrate builds reliability curves for the heuristic confidence and,
+// when trained, the model's confidence.
+func (m *ConfidenceModel) Calibrate(feedback []CorrelationFeedback) CalibrationReport {
+	var resetAt *time.Time
+	if m != nil {
+		resetAt = m.ResetAt
+	}
+	samples, labels := trainingSet(feedback, resetAt)
+	report := CalibrationReport{Samples: len(samples)}
+
+	heuristic := make([]float64, len(samples))
+	for i, s := range samples {
+		heuristic[i] = s.HeuristicConfidence
+	}
+	report.Heuristic = calibrationCurve(heuristic, labels)
+
+	if m.Trained() {
+		learned := make([]float64, len(samples))
+		for i, s := range samples {
+			learned[i] = m.Confidence(s)
+		}
+		curve := calibrationCurve(learned, labels)
+		report.Learned = &curve
+	}
+	return report
+}
+
+func calibrationCurve(predicted, labels []float64) CalibrationCurve {
+	curve := CalibrationCurve{Bins: make([]CalibrationBin, calibrationBins)}
+	for i := range curve.Bins {
+		curve.Bins[i].Low = float64(i) / calibrationBins
+		curve.Bins[i].High = float64(i+1) / calibrationBins
+	}
+	if len(predicted) == 0 {
+		return curve
+	}
+
+	var sqErr float64
+	for i, p := range predicted {
+		sqErr += (p - labels[i]) * (p - labels[i])
+		b := min(int(p*calibrationBins), calibrationBins-1)
+		curve.Bins[b].Count++
+		curve.Bins[b].MeanPredicted += p
+		curve.Bins[b].ObservedRate += labels[i]
+	}
+	curve.Brier = round3(sqErr / float64(len(predicted)))
+	for i := range curve.Bins {
+		if n := float64(curve.Bins[i].Count); n > 0 {
+			curve.Bins[i].MeanPredicted = round3(curve.Bins[i].MeanPredicted / n)
+			curve.Bins[i].ObservedRate = round3(curve.Bins[i].ObservedRate / n)
+		}
+	}
+	return curve
+}
+
+// LoadConfidenceModel reads the repository's confidence model. A missing
+// file returns nil.
+func LoadConfidenceModel(repoPath string) (*ConfidenceModel, error) {
+	data, err := os.ReadFile(filepath.Join(repoPath, ConfidenceModelFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading confidence model: %w", err)
+	}
+	var m ConfidenceModel
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", ConfidenceModelFile, err)
+	}
+	return &m, nil
+}
+
+// SaveConfidenceModel writes the model to the repository.
+func SaveConfidenceModel(repoPath string, m *ConfidenceModel) error {
+	path := filepath.Join(repoPath, ConfidenceModelFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating model directory: %w", err)
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// ResetConfidenceModel discards learned weights; feedback given so far is
+// no longer used for training, so confidence reverts to the heuristics.
+func ResetConfidenceModel(repoPath string) (*ConfidenceModel, error) {
+	now := time.Now().UTC()
+	m := &ConfidenceModel{ResetAt: &now}
+	return m, SaveConfidenceModel(repoPath, m)
+}
+
+// RetrainConfidenceModel retrains from feedback, honoring a previous reset,
+// and saves the result. Too little feedback saves an untrained model.
+func RetrainConfidenceModel(repoPath string, feedback []CorrelationFeedback) (*ConfidenceModel, error) {
+	prev, err := LoadConfidenceModel(repoPath)
+	if err != nil {
+		return nil, err
+	}
+	var resetAt *time.Time
+	if prev != nil {
+		resetAt = prev.ResetAt
+	}
+	m, err := TrainConfidenceModel(feedback, resetAt)
+	if err != nil && !errors.Is(err, ErrInsufficientFeedback) {
+		return nil, err
+	}
+	if err := SaveConfidenceModel(repoPath, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func sigmoid(z float64) float64 {
+	return 1 / (1 + math.Exp(-z))
+}
+
+func logit(p float64) float64 {
+	p = math.Max(0.01, math.Min(0.99, p))
+	return math.Log(p / (1 - p))
+}
+
+func round3(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
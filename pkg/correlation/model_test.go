@@ -0,0 +1,126 @@
+package correlation
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func modelFeedback(start time.Time, n int) []CorrelationFeedback {
+	var feedback []CorrelationFeedback
+	for i := 0; i < n; i++ {
+		// Same-author commits near bead events are right; the rest are wrong.
+		good := i%2 == 0
+		f := &CorrelationFeatures{Method: MethodTemporalAuthor, HeuristicConfidence: 0.5, HoursToEvent: 1, AuthorMatch: good, FileCount: 2}
+		fbType := FeedbackConfirm
+		if !good {
+			f.HoursToEvent = 40
+			fbType = FeedbackReject
+		}
+		feedback = append(feedback, CorrelationFeedback{
+			CommitSHA:  string(rune('a' + i)),
+			BeadID:     "bv-1",
+			FeedbackAt: start.Add(time.Duration(i) * time.Minute),
+			Type:       fbType,
+			Features:   f,
+		})
+	}
+	return feedback
+}
+
+func TestTrainConfidenceModel(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, err := TrainConfidenceModel(modelFeedback(start, 4), nil); !errors.Is(err, ErrInsufficientFeedback) {
+		t.Fatalf("expected ErrInsufficientFeedback, got %v", err)
+	}
+
+	feedback := modelFeedback(start, 20)
+	// Feedback without features and ignores are not training data.
+	feedback = append(feedback,
+		CorrelationFeedback{CommitSHA: "old", BeadID: "bv-1", FeedbackAt: start, Type: FeedbackReject},
+		CorrelationFeedback{CommitSHA: "ign", BeadID: "bv-1", FeedbackAt: start, Type: FeedbackIgnore, Features: &CorrelationFeatures{}})
+	model, err := TrainConfidenceModel(feedback, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if model.Samples != 20 || model.Confirmed != 10 || model.Rejected != 10 || !model.Trained() {
+		t.Fatalf("model = %+v", model)
+	}
+
+	good := CorrelationFeatures{Method: MethodTemporalAuthor, HeuristicConfidence: 0.5, HoursToEvent: 1, AuthorMatch: true, FileCount: 2}
+	bad := CorrelationFeatures{Method: MethodTemporalAuthor, HeuristicConfidence: 0.5, HoursToEvent: 40, FileCount: 2}
+	if pg, pb := model.Probability(good), model.Probability(bad); pg < 0.8 || pb > 0.2 {
+		t.Fatalf("probabilities good=%.2f bad=%.2f", pg, pb)
+	}
+	// Blending keeps the heuristic in play: 20 samples vs a prior of 10.
+	if c := model.Confidence(good); c <= 0.5 || c >= model.Probability(good) {
+		t.Fatalf("blended confidence = %.3f", c)
+	}
+
+	cal := model.Calibrate(feedback)
+	if cal.Samples != 20 || cal.Learned == nil || cal.Learned.Brier >= cal.Heuristic.Brier {
+		t.Fatalf("calibration = %+v", cal)
+	}
+	if b := cal.Heuristic.Bins[2]; b.Count != 20 || b.MeanPredicted != 0.5 || b.ObservedRate != 0.5 {
+		t.Fatalf("heuristic bin = %+v", b)
+	}
+
+	// Feedback before a reset is not used.
+	resetAt := start.Add(time.Hour)
+	if _, err := TrainConfidenceModel(feedback, &resetAt); !errors.Is(err, ErrInsufficientFeedback) {
+		t.Fatalf("expected reset to discard feedback, got %v", err)
+	}
+}
+
+func TestConfidenceModel_RecalibrateAndPersist(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if m, err := LoadConfidenceModel(dir); err != nil || m != nil {
+		t.Fatalf("missing model = %+v, %v", m, err)
+	}
+
+	now := time.Now()
+	feedback := modelFeedback(now.Add(-time.Hour), 12)
+	model, err := RetrainConfidenceModel(dir, feedback)
+	if err != nil || !model.Trained() {
+		t.Fatalf("retrain = %+v, %v", model, err)
+	}
+	loaded, err := LoadConfidenceModel(dir)
+	if err != nil || !loaded.Trained() || loaded.Weights["author_match"] != model.Weights["author_match"] {
+		t.Fatalf("loaded = %+v, %v", loaded, err)
+	}
+
+	report := &HistoryReport{Histories: map[string]BeadHistory{
+		"bv-1": {
+			BeadID: "bv-1",
+			Events: []BeadEvent{{BeadID: "bv-1", EventType: EventClaimed, Timestamp: start, Author: "Dev"}},
+			Commits: []CorrelatedCommit{
+				{SHA: "aaa", Author: "Dev", Timestamp: start.Add(time.Hour), Method: MethodTemporalAuthor, Confidence: 0.5, Files: []FileChange{{Path: "a.go"}, {Path: "b.go"}}},
+				{SHA: "bbb", Author: "Other", Timestamp: start.Add(40 * time.Hour), Method: MethodTemporalAuthor, Confidence: 0.5, Files: []FileChange{{Path: "c.go"}, {Path: "d.go"}}},
+			},
+		},
+	}}
+	loaded.Recalibrate(report)
+	commits := report.Histories["bv-1"].Commits
+	if commits[0].BaseConfidence != 0.5 || commits[0].Confidence <= 0.5 || commits[1].Confidence >= 0.5 {
+		t.Fatalf("recalibrated commits = %+v", commits)
+	}
+	// Recalibrating again starts from the heuristic, not the previous result.
+	first := commits[0].Confidence
+	loaded.Recalibrate(report)
+	if got := report.Histories["bv-1"].Commits[0]; got.Confidence != first || got.BaseConfidence != 0.5 {
+		t.Fatalf("second recalibration = %+v", got)
+	}
+
+	reset, err := ResetConfidenceModel(dir)
+	if err != nil || reset.Trained() || reset.ResetAt == nil {
+		t.Fatalf("reset = %+v, %v", reset, err)
+	}
+	// Retraining after a reset ignores the earlier feedback.
+	model, err = RetrainConfidenceModel(dir, feedback)
+	if err != nil || model.Trained() || model.ResetAt == nil {
+		t.Fatalf("retrain after reset = %+v, %v", model, err)
+	}
+}
@@ -0,0 +1,490 @@
+package correlation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PullRequestMetadataFile is an optional cache of pull request metadata,
+// relative to the repository root, in `gh pr list --json` form:
+//
+//	gh pr list --state merged --limit 1000 \
+//	  --json number,title,body,url,author,headRefName,createdAt,mergedAt,mergeCommit,commits \
+//	  > .bv/pull-requests.json
+const PullRequestMetadataFile = ".bv/pull-requests.json"
+
+// Pull request sources
+const (
+	PRSourceMergeCommit = "merge_commit" // "Merge pull request #N" or "See merge request !N"
+	PRSourceSquash      = "squash"       // Squash-merge subject ending in "(#N)"
+	PRSourceMetadata    = "metadata"     // Only in PullRequestMetadataFile (e.g. rebase merges)
+)
+
+// PullRequest is a merged pull request (or merge request) linked to a bead.
+// OpenedAt and ReviewDuration are only known from the metadata file.
+type PullRequest struct {
+	Number         int            `json:"number"`
+	Title          string         `json:"title,omitempty"`
+	URL            string         `json:"url,omitempty"`
+	Author         string         `json:"author,omitempty"`
+	Branch         string         `json:"branch,omitempty"`
+	OpenedAt       *time.Time     `json:"opened_at,omitempty"`
+	MergedAt       time.Time      `json:"merged_at"`
+	MergeSHA       string         `json:"merge_sha,omitempty"`
+	Commits        []string       `json:"commits,omitempty"`         // Commits the pull request brought in
+	ReviewDuration *time.Duration `json:"review_duration,omitempty"` // OpenedAt to MergedAt
+	Source         string         `json:"source"`
+
+	body    string   // metadata description, scanned for bead IDs
+	parents []string // merge commit parents, resolved into Commits
+}
+
+var (
+	// mergePRSubject matches GitHub merge commits.
+	mergePRSubject = regexp.MustCompile(`^Merge pull request #(\d+)(?: from (\S+))?`)
+	// mergeRequestRef matches the GitLab merge commit trailer line.
+	mergeRequestRef = regexp.MustCompile(`(?m)^See merge request \S*!(\d+)\s*$`)
+	// mergeBranchSubject matches GitLab merge commit subjects.
+	mergeBranchSubject = regexp.MustCompile(`^Merge branch '([^']+)'`)
+	// squashPRSubject matches squash-merge subjects ("Fix login (#123)").
+	squashPRSubject = regexp.MustCompile(`^(.*?)\s*\(#(\d+)\)$`)
+)
+
+// PullRequestExtractor finds merged pull requests from merge commits,
+// squash-merge subjects and the optional metadata file.
+type PullRequestExtractor struct {
+	repoPath string
+}
+
+// NewPullRequestExtractor creates a pull request extractor for the given repository
+func NewPullRequestExtractor(repoPath string) *PullRequestExtractor {
+	return &PullRequestExtractor{repoPath: repoPath}
+}
+
+// Extract returns pull requests merged into the first-parent history within
+// opts, sorted by merge time. opts.BeadID is ignored; linking is done later.
+func (p *PullRequestExtractor) Extract(opts ExtractOptions) ([]PullRequest, error) {
+	args := []string{"log", "--first-parent", "--format=" + gitLogMergeFormat}
+	if opts.Since != nil {
+		args = append(args, fmt.Sprintf("--since=%s", opts.Since.Format(time.RFC3339)))
+	}
+	if opts.Until != nil {
+		args = append(args, fmt.Sprintf("--until=%s", opts.Until.Format(time.RFC3339)))
+	}
+	if opts.Limit > 0 {
+		args = append(args, fmt.Sprintf("-n%d", opts.Limit))
+	}
+	prs, err := p.fromGit(args, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := LoadPullRequestMetadata(p.repoPath)
+	if err != nil {
+		return nil, err
+	}
+	inWindow := meta[:0]
+	for _, pr := range meta {
+		if opts.Since != nil && pr.MergedAt.Before(*opts.Since) {
+			continue
+		}
+		if opts.Until != nil && pr.MergedAt.After(*opts.Until) {
+			continue
+		}
+		inWindow = append(inWindow, pr)
+	}
+	return mergePullRequestMetadata(prs, inWindow), nil
+}
+
+// ExtractCommitList is Extract over the given commits only. The whole
+// metadata file is included; linking deduplicates by number.
+func (p *PullRequestExtractor) ExtractCommitList(shas []string) ([]PullRequest, error) {
+	var prs []PullRequest
+	if len(shas) > 0 {
+		var err error
+		// SHAs go on stdin so the argument list stays short however many
+		// commits there are.
+		args := []string{"log", "--no-walk", "--stdin", "--format=" + gitLogMergeFormat}
+		if prs, err = p.fromGit(args, shas); err != nil {
+			return nil, err
+		}
+	}
+	meta, err := LoadPullRequestMetadata(p.repoPath)
+	if err != nil {
+		return nil, err
+	}
+	return mergePullRequestMetadata(prs, meta), nil
+}
+
+// fromGit runs git log with args, feeding stdin lines (if any) to --stdin.
+func (p *PullRequestExtractor) fromGit(args []string, stdin []string) ([]PullRequest, error) {
+	out, err := p.gitStdin(stdin, args...)
+	if err != nil {
+		return nil, err
+	}
+	prs := parsePullRequestLog(out)
+	for i := range prs {
+		if len(prs[i].parents) < 2 {
+			continue
+		}
+		// Commits reachable from the merged branch but not from mainline.
+		list, err := p.git("rev-list", "^"+prs[i].parents[0], prs[i].parents[1])
+		if err != nil {
+			return nil, err
+		}
+		prs[i].Commits = strings.Fields(string(list))
+		prs[i].parents = nil
+	}
+	return prs, nil
+}
+
+func (p *PullRequestExtractor) git(args ...string) ([]byte, error) {
+	return p.gitStdin(nil, args...)
+}
+
+func (p *PullRequestExtractor) gitStdin(stdin []string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = p.repoPath
+	if len(stdin) > 0 {
+		cmd.Stdin = strings.NewReader(strings.Join(stdin, "\n") + "\n")
+	}
+
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("git %s failed: %s", args[0], string(exitErr.Stderr))
+		}
+		return nil, fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+	return out, nil
+}
+
+// parsePullRequestLog parses gitLogMergeFormat records, keeping merge
+// commits and squash merges that name a pull request number.
+func parsePullRequestLog(data []byte) []PullRequest {
+	var prs []PullRequest
+	for _, record := range strings.Split(string(data), "\x1e") {
+		record = strings.TrimLeft(record, "\n")
+		parts := strings.SplitN(record, "\x00", 6)
+		if len(parts) < 6 {
+			continue
+		}
+		mergedAt, err := time.Parse(time.RFC3339, parts[1])
+		if err != nil {
+			continue
+		}
+		parents := strings.Fields(parts[4])
+		message := strings.TrimSpace(parts[5])
+		subject, rest, _ := strings.Cut(message, "\n")
+		subject = strings.TrimSpace(subject)
+
+		pr := PullRequest{MergedAt: mergedAt, MergeSHA: parts[0]}
+		if len(parents) > 1 {
+			if m := mergePRSubject.FindStringSubmatch(subject); m != nil {
+				pr.Number, _ = strconv.Atoi(m[1])
+				pr.Branch = m[2]
+			} else if m := mergeRequestRef.FindStringSubmatch(message); m != nil {
+				pr.Number, _ = strconv.Atoi(m[1])
+				if b := mergeBranchSubject.FindStringSubmatch(subject); b != nil {
+					pr.Branch = b[1]
+				}
+			} else {
+				continue
+			}
+			pr.Title = firstLine(rest)
+			pr.Source = PRSourceMergeCommit
+			pr.parents = parents
+		} else if m := squashPRSubject.FindStringSubmatch(subject); m != nil {
+			pr.Number, _ = strconv.Atoi(m[2])
+			pr.Title = m[1]
+			pr.Author = parts[2]
+			pr.Commits = []string{parts[0]}
+			pr.Source = PRSourceSquash
+		} else {
+			continue
+		}
+		if pr.Number > 0 {
+			prs = append(prs, pr)
+		}
+	}
+	return prs
+}
+
+// firstLine returns the first non-blank line of s, skipping GitLab's
+// "See merge request" trailer.
+func firstLine(s string) string {
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !mergeRequestRef.MatchString(line) {
+			return line
+		}
+	}
+	return ""
+}
+
+type prMetadata struct {
+	Number      int        `json:"number"`
+	Title       string     `json:"title"`
+	Body        string     `json:"body"`
+	URL         string     `json:"url"`
+	HeadRefName string     `json:"headRefName"`
+	CreatedAt   *time.Time `json:"createdAt"`
+	MergedAt    *time.Time `json:"mergedAt"`
+	Author      struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	MergeCommit *struct {
+		OID string `json:"oid"`
+	} `json:"mergeCommit"`
+	Commits []struct {
+		OID string `json:"oid"`
+	} `json:"commits"`
+}
+
+// LoadPullRequestMetadata reads .bv/pull-requests.json under repoPath and
+// returns its merged pull requests. A missing file yields none.
+func LoadPullRequestMetadata(repoPath string) ([]PullRequest, error) {
+	path := filepath.Join(repoPath, PullRequestMetadataFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var raw []prMetadata
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	prs := make([]PullRequest, 0, len(raw))
+	for _, r := range raw {
+		if r.Number <= 0 || r.MergedAt == nil || r.MergedAt.IsZero() {
+			continue
+		}
+		pr := PullRequest{
+			Number:   r.Number,
+			Title:    r.Title,
+			URL:      r.URL,
+			Author:   r.Author.Login,
+			Branch:   r.HeadRefName,
+			OpenedAt: r.CreatedAt,
+			MergedAt: *r.MergedAt,
+			Source:   PRSourceMetadata,
+			body:     r.Body,
+		}
+		if r.MergeCommit != nil {
+			pr.MergeSHA = r.MergeCommit.OID
+		}
+		for _, c := range r.Commits {
+			if c.OID != "" {
+				pr.Commits = append(pr.Commits, c.OID)
+			}
+		}
+		prs = append(prs, pr)
+	}
+	return prs, nil
+}
+
+// absorb fills pr from other, which describes the same pull request.
+// Metadata is authoritative for descriptive fields and merge time.
+func (pr *PullRequest) absorb(other PullRequest) {
+	if other.Source == PRSourceMetadata {
+		if other.Title != "" {
+			pr.Title = other.Title
+		}
+		if !other.MergedAt.IsZero() {
+			pr.MergedAt = other.MergedAt
+		}
+	}
+	if pr.Title == "" {
+		pr.Title = other.Title
+	}
+	if pr.URL == "" {
+		pr.URL = other.URL
+	}
+	if pr.Author == "" {
+		pr.Author = other.Author
+	}
+	if pr.Branch == "" {
+		pr.Branch = other.Branch
+	}
+	if pr.OpenedAt == nil {
+		pr.OpenedAt = other.OpenedAt
+	}
+	if pr.MergeSHA == "" {
+		pr.MergeSHA = other.MergeSHA
+	}
+	if pr.body == "" {
+		pr.body = other.body
+	}
+	if pr.Source == PRSourceMetadata {
+		pr.Source = other.Source
+	}
+	seen := make(map[string]bool, len(pr.Commits))
+	for _, sha := range pr.Commits {
+		seen[sha] = true
+	}
+	for _, sha := range other.Commits {
+		if !seen[sha] {
+			seen[sha] = true
+			pr.Commits = append(pr.Commits, sha)
+		}
+	}
+	pr.setReviewDuration()
+}
+
+func (pr *PullRequest) setReviewDuration() {
+	pr.ReviewDuration = nil
+	if pr.OpenedAt != nil && !pr.MergedAt.IsZero() {
+		d := pr.MergedAt.Sub(*pr.OpenedAt)
+		pr.ReviewDuration = &d
+	}
+}
+
+// mergePullRequestMetadata enriches pull requests found in git with
+// metadata by number and adds metadata-only ones, sorted by merge time.
+func mergePullRequestMetadata(prs, meta []PullRequest) []PullRequest {
+	byNumber := make(map[int]int, len(prs))
+	for i := range prs {
+		if _, ok := byNumber[prs[i].Number]; !ok {
+			byNumber[prs[i].Number] = i
+		}
+	}
+	for _, m := range meta {
+		if i, ok := byNumber[m.Number]; ok {
+			prs[i].absorb(m)
+			continue
+		}
+		m.setReviewDuration()
+		byNumber[m.Number] = len(prs)
+		prs = append(prs, m)
+	}
+	sort.SliceStable(prs, func(i, j int) bool {
+		return prs[i].MergedAt.Before(prs[j].MergedAt)
+	})
+	return prs
+}
+
+// attachPullRequests links pull requests to the histories whose commits or
+// lifecycle events they contain, or whose IDs their title, branch or
+// description mention, then refreshes milestones and cycle time of the
+// beads it touched. It returns the number of beads touched.
+func attachPullRequests(histories map[string]BeadHistory, prs []PullRequest, matcher *ExplicitMatcher) int {
+	if len(prs) == 0 {
+		return 0
+	}
+
+	beadsBySHA := make(map[string][]string)
+	for beadID, h := range histories {
+		for _, c := range h.Commits {
+			beadsBySHA[c.SHA] = append(beadsBySHA[c.SHA], beadID)
+		}
+		for _, e := range h.Events {
+			if e.CommitSHA != "" {
+				beadsBySHA[e.CommitSHA] = append(beadsBySHA[e.CommitSHA], beadID)
+			}
+		}
+	}
+
+	touched := make(map[string]bool)
+	for _, pr := range prs {
+		linked := make(map[string]bool)
+		for _, sha := range append([]string{pr.MergeSHA}, pr.Commits...) {
+			for _, beadID := range beadsBySHA[sha] {
+				linked[beadID] = true
+			}
+		}
+		if matcher != nil {
+			text := strings.Join([]string{pr.Title, pr.Branch, pr.body}, "\n")
+			for _, m := range matcher.ExtractIDsFromMessage(text) {
+				if _, ok := histories[m.ID]; ok {
+					linked[m.ID] = true
+				}
+			}
+		}
+
+		for beadID := range linked {
+			h := histories[beadID]
+			h.PullRequests = addPullRequest(h.PullRequests, pr)
+			histories[beadID] = h
+			touched[beadID] = true
+		}
+	}
+
+	for beadID := range touched {
+		h := histories[beadID]
+		h.refreshMilestones()
+		histories[beadID] = h
+	}
+	return len(touched)
+}
+
+// addPullRequest adds pr to a bead's pull requests, merging it into an
+// existing entry with the same number, and keeps them in merge order.
+func addPullRequest(prs []PullRequest, pr PullRequest) []PullRequest {
+	for i := range prs {
+		if prs[i].Number == pr.Number {
+			prs[i].absorb(pr)
+			return prs
+		}
+	}
+	pr.Commits = append([]string(nil), pr.Commits...)
+	prs = append(prs, pr)
+	sort.SliceStable(prs, func(i, j int) bool {
+		return prs[i].MergedAt.Before(prs[j].MergedAt)
+	})
+	return prs
+}
+
+// refreshMilestones recomputes milestones and cycle time from the history's
+// lifecycle events and linked pull requests.
+func (h *BeadHistory) refreshMilestones() {
+	h.Milestones = GetBeadMilestones(h.Events)
+	h.Milestones.InReview, h.Milestones.Merged = pullRequestMilestones(h.BeadID, h.PullRequests)
+	h.CycleTime = CalculateCycleTime(h.Milestones)
+}
+
+// pullRequestMilestones derives the "in review" milestone from the earliest
+// pull request opened and "merged" from the latest merged.
+func pullRequestMilestones(beadID string, prs []PullRequest) (inReview, merged *BeadEvent) {
+	for i := range prs {
+		pr := &prs[i]
+		if pr.OpenedAt != nil && (inReview == nil || pr.OpenedAt.Before(inReview.Timestamp)) {
+			inReview = &BeadEvent{
+				BeadID:    beadID,
+				EventType: EventInReview,
+				Timestamp: *pr.OpenedAt,
+				CommitMsg: pullRequestLabel(pr),
+				Author:    pr.Author,
+			}
+		}
+		if merged == nil || !pr.MergedAt.Before(merged.Timestamp) {
+			merged = &BeadEvent{
+				BeadID:    beadID,
+				EventType: EventMerged,
+				Timestamp: pr.MergedAt,
+				CommitSHA: pr.MergeSHA,
+				CommitMsg: pullRequestLabel(pr),
+				Author:    pr.Author,
+			}
+		}
+	}
+	return inReview, merged
+}
+
+func pullRequestLabel(pr *PullRequest) string {
+	if pr.Title == "" {
+		return fmt.Sprintf("PR #%d", pr.Number)
+	}
+	return fmt.Sprintf("PR #%d: %s", pr.Number, pr.Title)
+}
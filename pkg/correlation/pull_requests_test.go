@@ -0,0 +1,163 @@
+package correlation
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParsePullRequestLog(t *testing.T) {
+	record := func(sha, parents, message string) string {
+		return sha + "\x00" + "2024-03-01T10:00:00Z" + "\x00" + "Dev" + "\x00" + "dev@example.com" + "\x00" + parents + "\x00" + message + "\x1e\n"
+	}
+	data := record("m1", "p1 p2", "Merge pull request #12 from dev/retries\n\nRetry uploads\n") +
+		record("m2", "p1 p3", "Merge branch 'fix-login' into 'main'\n\nFix login\n\nSee merge request group/app!7\n") +
+		record("s1", "p1", "Fix flaky test (#13)\n") +
+		record("c1", "p1", "Plain commit\n") +
+		record("m3", "p1 p4", "Merge branch 'main' into feature\n")
+
+	prs := parsePullRequestLog([]byte(data))
+	if len(prs) != 3 {
+		t.Fatalf("prs = %+v", prs)
+	}
+	if pr := prs[0]; pr.Number != 12 || pr.Source != PRSourceMergeCommit || pr.Title != "Retry uploads" || pr.Branch != "dev/retries" || len(pr.parents) != 2 {
+		t.Fatalf("github merge = %+v", pr)
+	}
+	if pr := prs[1]; pr.Number != 7 || pr.Title != "Fix login" || pr.Branch != "fix-login" {
+		t.Fatalf("gitlab merge = %+v", pr)
+	}
+	if pr := prs[2]; pr.Number != 13 || pr.Source != PRSourceSquash || pr.Title != "Fix flaky test" || len(pr.Commits) != 1 || pr.Commits[0] != "s1" {
+		t.Fatalf("squash = %+v", pr)
+	}
+}
+
+func TestMergePullRequestMetadata(t *testing.T) {
+	opened := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	merged := time.Date(2024, 3, 2, 9, 0, 0, 0, time.UTC)
+	git := []PullRequest{{Number: 12, MergedAt: merged.Add(time.Minute), MergeSHA: "m1", Commits: []string{"a"}, Source: PRSourceMergeCommit}}
+	meta := []PullRequest{
+		{Number: 12, Title: "Retry uploads", OpenedAt: &opened, MergedAt: merged, Commits: []string{"a", "b"}, Source: PRSourceMetadata},
+		{Number: 14, MergedAt: opened, Source: PRSourceMetadata},
+	}
+
+	prs := mergePullRequestMetadata(git, meta)
+	if len(prs) != 2 || prs[0].Number != 14 {
+		t.Fatalf("prs = %+v", prs)
+	}
+	pr := prs[1]
+	if pr.Source != PRSourceMergeCommit || pr.Title != "Retry uploads" || !pr.MergedAt.Equal(merged) || len(pr.Commits) != 2 {
+		t.Fatalf("merged = %+v", pr)
+	}
+	if pr.ReviewDuration == nil || *pr.ReviewDuration != 24*time.Hour {
+		t.Fatalf("review duration = %v", pr.ReviewDuration)
+	}
+}
+
+func TestCalculateCycleTime_Merged(t *testing.T) {
+	at := func(h int) *BeadEvent {
+		return &BeadEvent{Timestamp: time.Date(2024, 3, 1, h, 0, 0, 0, time.UTC)}
+	}
+	ct := CalculateCycleTime(BeadMilestones{Created: at(1), Claimed: at(2), InReview: at(4), Merged: at(7)})
+	if ct == nil || ct.ClaimToClose != nil {
+		t.Fatalf("cycle time = %+v", ct)
+	}
+	if *ct.ClaimToMerge != 5*time.Hour || *ct.CreateToMerge != 6*time.Hour || *ct.ReviewTime != 3*time.Hour {
+		t.Fatalf("cycle time = %v %v %v", *ct.ClaimToMerge, *ct.CreateToMerge, *ct.ReviewTime)
+	}
+}
+
+func TestGenerateReport_LinksPullRequests(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, body string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("init", "-q")
+	git("config", "user.email", "dev@example.com")
+	git("config", "user.name", "Dev")
+	write(".beads/issues.jsonl", `{"id":"web-9gfx","title":"Retry uploads","status":"open"}`+"\n"+
+		`{"id":"web-2kq","title":"Flaky test","status":"open"}`+"\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "add beads")
+
+	git("checkout", "-q", "-b", "retries")
+	write("upload.go", "package main\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "Add upload retries", "-m", "Bead: web-9gfx")
+	git("checkout", "-q", "-")
+	git("merge", "-q", "--no-ff", "retries", "-m", "Merge pull request #12 from dev/retries", "-m", "Retry uploads")
+
+	write("upload_test.go", "package main\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "Stabilize web-2kq test (#13)")
+
+	opened := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	merged := time.Now().Add(25 * time.Hour).UTC().Format(time.RFC3339)
+	write(PullRequestMetadataFile, `[{"number":12,"title":"Retry uploads","url":"https://example.com/pull/12",
+		"author":{"login":"dev"},"createdAt":"`+opened+`","mergedAt":"`+merged+`"},
+		{"number":20,"title":"Draft","createdAt":"`+opened+`","mergedAt":null}]`)
+
+	beads := []BeadInfo{
+		{ID: "web-9gfx", Title: "Retry uploads", Status: "open"},
+		{ID: "web-2kq", Title: "Flaky test", Status: "open"},
+	}
+	report, err := NewCorrelator(dir).GenerateReport(beads, CorrelatorOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := report.Histories["web-9gfx"]
+	if len(h.PullRequests) != 1 {
+		t.Fatalf("pull requests = %+v", h.PullRequests)
+	}
+	pr := h.PullRequests[0]
+	if pr.Number != 12 || pr.Source != PRSourceMergeCommit || pr.URL == "" || len(pr.Commits) != 1 {
+		t.Fatalf("pr = %+v", pr)
+	}
+	if pr.ReviewDuration == nil || *pr.ReviewDuration != 24*time.Hour {
+		t.Fatalf("review duration = %v", pr.ReviewDuration)
+	}
+	if h.Milestones.InReview == nil || h.Milestones.Merged == nil || h.Milestones.Merged.EventType != EventMerged {
+		t.Fatalf("milestones = %+v", h.Milestones)
+	}
+	if h.CycleTime == nil || h.CycleTime.ReviewTime == nil || *h.CycleTime.ReviewTime != 24*time.Hour {
+		t.Fatalf("cycle time = %+v", h.CycleTime)
+	}
+
+	squash := report.Histories["web-2kq"].PullRequests
+	if len(squash) != 1 || squash[0].Number != 13 || squash[0].Source != PRSourceSquash {
+		t.Fatalf("squash = %+v", squash)
+	}
+	if report.Stats.BeadsWithPRs != 2 {
+		t.Fatalf("stats = %+v", report.Stats)
+	}
+
+	chain := report.BuildCausalityChain("web-9gfx", DefaultCausalityOptions()).Chain
+	var types []CausalEventType
+	for _, e := range chain.Events {
+		types = append(types, e.Type)
+	}
+	last := chain.Events[len(chain.Events)-1]
+	if len(types) < 2 || types[len(types)-2] != CausalInReview || last.Type != CausalMerged || last.Description != "PR #12 merged" {
+		t.Fatalf("chain = %v", types)
+	}
+}
@@ -0,0 +1,691 @@
+// Package correlation provides a persistent SQLite store of correlated history.
+package correlation
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// HistoryStoreFile is the persistent correlation database, relative to the
+// repository root.
+const HistoryStoreFile = ".bv/history.db"
+
+// historyStoreSchemaVersion is bumped whenever the tables change; a
+// mismatch drops the tables and rebuilds the database from scratch.
+const historyStoreSchemaVersion = "2"
+
+// historyStoreBatchSize caps how many commits are passed to one git
+// invocation during an incremental sync.
+const historyStoreBatchSize = 200
+
+// historyStoreSchema creates the tables. Timestamps are stored both as
+// RFC 3339 text (readable in ad-hoc queries) and as Unix seconds (for
+// filtering and ordering).
+const historyStoreSchema = `
+CREATE TABLE IF NOT EXISTS meta (
+	key TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS commits (
+	sha TEXT PRIMARY KEY,
+	short_sha TEXT NOT NULL,
+	author TEXT NOT NULL,
+	author_email TEXT NOT NULL,
+	timestamp TEXT NOT NULL,
+	unix INTEGER NOT NULL,
+	message TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS file_changes (
+	commit_sha TEXT NOT NULL,
+	path TEXT NOT NULL,
+	action TEXT NOT NULL,
+	insertions INTEGER NOT NULL,
+	deletions INTEGER NOT NULL,
+	PRIMARY KEY (commit_sha, path)
+);
+CREATE TABLE IF NOT EXISTS bead_events (
+	bead_id TEXT NOT NULL,
+	event_type TEXT NOT NULL,
+	commit_sha TEXT NOT NULL,
+	commit_message TEXT NOT NULL,
+	author TEXT NOT NULL,
+	author_email TEXT NOT NULL,
+	timestamp TEXT NOT NULL,
+	unix INTEGER NOT NULL,
+	changes TEXT NOT NULL DEFAULT '',
+	PRIMARY KEY (bead_id, event_type, commit_sha)
+);
+CREATE TABLE IF NOT EXISTS correlations (
+	bead_id TEXT NOT NULL,
+	commit_sha TEXT NOT NULL,
+	method TEXT NOT NULL,
+	confidence REAL NOT NULL,
+	reason TEXT NOT NULL,
+	PRIMARY KEY (bead_id, commit_sha)
+);
+CREATE TABLE IF NOT EXISTS pull_requests (
+	bead_id TEXT NOT NULL,
+	number INTEGER NOT NULL,
+	merged_at TEXT NOT NULL,
+	unix INTEGER NOT NULL,
+	data TEXT NOT NULL,
+	PRIMARY KEY (bead_id, number)
+);
+CREATE INDEX IF NOT EXISTS idx_file_changes_path ON file_changes(path);
+CREATE INDEX IF NOT EXISTS idx_bead_events_commit ON bead_events(commit_sha);
+CREATE INDEX IF NOT EXISTS idx_correlations_commit ON correlations(commit_sha);
+`
+
+// historyStoreTables lists the data tables cleared by a full rebuild.
+var historyStoreTables = []string{"commits", "file_changes", "bead_events", "correlations", "pull_requests"}
+
+// HistoryStore persists commits, bead events, file changes and correlations
+// in a SQLite database, so each run only walks commits added since the last
+// indexed one. Reports are assembled from the database and are not bounded
+// by how much history a single git walk can afford.
+type HistoryStore struct {
+	db         *sql.DB
+	path       string
+	correlator *Correlator
+}
+
+// HistoryStoreSync describes what a Sync did.
+type HistoryStoreSync struct {
+	IndexedSHA   string `json:"indexed_sha"`
+	FullRebuild  bool   `json:"full_rebuild"`
+	Reason       string `json:"reason,omitempty"` // Why a full rebuild was needed
+	NewCommits   int    `json:"new_commits"`      // Commits walked (0 for a full rebuild)
+	Events       int    `json:"events"`
+	Correlations int    `json:"correlations"`
+}
+
+// HistoryStoreEnabled reports whether BV_HISTORY_DB=1 asks for history to
+// be read through the persistent store.
+func HistoryStoreEnabled() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("BV_HISTORY_DB"))) {
+	case "1", "true", "yes", "on":
+		return true
+	}
+	return false
+}
+
+// ReportGenerator builds history reports; implemented by Correlator and
+// StoredCorrelator.
+type ReportGenerator interface {
+	GenerateReport(beads []BeadInfo, opts CorrelatorOptions) (*HistoryReport, error)
+}
+
+// NewHistoryReporter returns a StoredCorrelator when useStore is set and a
+// Correlator walking git directly otherwise.
+func NewHistoryReporter(repoPath string, useStore bool, beadsFilePath ...string) ReportGenerator {
+	if useStore {
+		return NewStoredCorrelator(repoPath, beadsFilePath...)
+	}
+	return NewCorrelator(repoPath, beadsFilePath...)
+}
+
+// StoredCorrelator generates reports through the repository's history
+// database, opening it for each report.
+type StoredCorrelator struct {
+	repoPath      string
+	beadsFilePath []string
+}
+
+// NewStoredCorrelator creates a correlator backed by the history database
+func NewStoredCorrelator(repoPath string, beadsFilePath ...string) *StoredCorrelator {
+	return &StoredCorrelator{repoPath: repoPath, beadsFilePath: beadsFilePath}
+}
+
+// GenerateReport syncs the history database and builds a report from it
+func (c *StoredCorrelator) GenerateReport(beads []BeadInfo, opts CorrelatorOptions) (*HistoryReport, error) {
+	store, err := OpenHistoryStore(c.repoPath, c.beadsFilePath...)
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+	return store.GenerateReport(beads, opts)
+}
+
+// OpenHistoryStore opens (creating if needed) the history database of the
+// repository. beadsFilePath is forwarded to the underlying Correlator.
+func OpenHistoryStore(repoPath string, beadsFilePath ...string) (*HistoryStore, error) {
+	path := filepath.Join(repoPath, HistoryStoreFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("creating history database directory: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("opening history database: %w", err)
+	}
+	// One connection keeps the busy timeout in effect for every statement,
+	// so a TUI and a robot command can share the file.
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec("PRAGMA busy_timeout = 5000"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("configuring history database: %w", err)
+	}
+	if err := migrateHistoryStore(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &HistoryStore{
+		db:         db,
+		path:       path,
+		correlator: NewCorrelator(repoPath, beadsFilePath...),
+	}, nil
+}
+
+// migrateHistoryStore creates the tables, first dropping those written by
+// a different schema version. Sync then sees an empty database and rebuilds.
+func migrateHistoryStore(db *sql.DB) error {
+	if _, err := db.Exec(historyStoreSchema); err != nil {
+		return fmt.Errorf("creating history database schema: %w", err)
+	}
+	var version string
+	err := db.QueryRow("SELECT value FROM meta WHERE key = 'schema_version'").Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) || version == historyStoreSchemaVersion {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading history database schema version: %w", err)
+	}
+
+	for _, table := range historyStoreTables {
+		if _, err := db.Exec("DROP TABLE IF EXISTS " + table); err != nil {
+			return fmt.Errorf("dropping %s: %w", table, err)
+		}
+	}
+	if _, err := db.Exec("DELETE FROM meta"); err != nil {
+		return fmt.Errorf("clearing meta: %w", err)
+	}
+	if _, err := db.Exec(historyStoreSchema); err != nil {
+		return fmt.Errorf("creating history database schema: %w", err)
+	}
+	return nil
+}
+
+// Path returns the database file location
+func (s *HistoryStore) Path() string {
+	return s.path
+}
+
+// Close closes the database
+func (s *HistoryStore) Close() error {
+	return s.db.Close()
+}
+
+// GenerateReport syncs the store with HEAD and builds a report from it.
+// It is a drop-in replacement for Correlator.GenerateReport.
+func (s *HistoryStore) GenerateReport(beads []BeadInfo, opts CorrelatorOptions) (*HistoryReport, error) {
+	if _, err := s.Sync(beads); err != nil {
+		return nil, err
+	}
+	return s.Report(beads, opts)
+}
+
+// Sync indexes commits added since the last indexed commit. The database is
+// rebuilt from scratch when it is empty, when the schema, correlation
+// settings or set of bead IDs changed, or when the indexed commit is no
+// longer an ancestor of HEAD (rebase, reset, branch switch).
+func (s *HistoryStore) Sync(beads []BeadInfo) (*HistoryStoreSync, error) {
+	repoPath := s.correlator.repoPath
+	head, err := getGitHead(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolving HEAD: %w", err)
+	}
+	fingerprint, err := s.fingerprint(beads)
+	if err != nil {
+		return nil, err
+	}
+
+	indexed, err := s.meta("indexed_sha")
+	if err != nil {
+		return nil, err
+	}
+	storedFingerprint, err := s.meta("fingerprint")
+	if err != nil {
+		return nil, err
+	}
+
+	result := &HistoryStoreSync{IndexedSHA: head}
+	switch {
+	case indexed == "":
+		result.FullRebuild, result.Reason = true, "empty database"
+	case storedFingerprint != fingerprint:
+		result.FullRebuild, result.Reason = true, "schema, correlation settings or bead set changed"
+	case indexed == head:
+		return result, nil
+	case !isAncestor(repoPath, indexed, head):
+		result.FullRebuild, result.Reason = true, "indexed commit is not an ancestor of HEAD"
+	}
+
+	var batches []*historyBatch
+	if result.FullRebuild {
+		batch, err := s.correlator.extract(beads, ExtractOptions{})
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, batch)
+	} else {
+		shas, err := getCommitsSince(repoPath, indexed)
+		if err != nil {
+			return nil, fmt.Errorf("finding new commits: %w", err)
+		}
+		result.NewCommits = len(shas)
+		for start := 0; start < len(shas); start += historyStoreBatchSize {
+			end := min(start+historyStoreBatchSize, len(shas))
+			batch, err := s.correlator.extractCommitList(beads, shas[start:end])
+			if err != nil {
+				return nil, err
+			}
+			batches = append(batches, batch)
+		}
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if result.FullRebuild {
+		for _, table := range historyStoreTables {
+			if _, err := tx.Exec("DELETE FROM " + table); err != nil {
+				return nil, fmt.Errorf("clearing %s: %w", table, err)
+			}
+		}
+	}
+	for _, batch := range batches {
+		if err := insertHistoryBatch(tx, batch); err != nil {
+			return nil, err
+		}
+		result.Events += len(batch.events)
+		result.Correlations += len(batch.commits)
+	}
+	if err := s.linkPullRequests(tx, beads, batches); err != nil {
+		return nil, err
+	}
+
+	for key, value := range map[string]string{
+		"schema_version": historyStoreSchemaVersion,
+		"fingerprint":    fingerprint,
+		"indexed_sha":    head,
+		"indexed_at":     time.Now().UTC().Format(time.RFC3339),
+	} {
+		if _, err := tx.Exec("INSERT OR REPLACE INTO meta (key, value) VALUES (?, ?)", key, value); err != nil {
+			return nil, fmt.Errorf("updating meta: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing history database: %w", err)
+	}
+	return result, nil
+}
+
+// Report builds a history report from the indexed data. A Limit keeps only
+// the most recent indexed commits; zero means all of them.
+func (s *HistoryStore) Report(beads []BeadInfo, opts CorrelatorOptions) (*HistoryReport, error) {
+	filter, args := storeFilter(opts)
+
+	events, err := s.loadEvents(filter, args)
+	if err != nil {
+		return nil, err
+	}
+	commits, err := s.loadCommits(filter, args)
+	if err != nil {
+		return nil, err
+	}
+
+	histories := s.correlator.buildHistories(beads, events, commits)
+	if opts.BeadID != "" {
+		filtered := make(map[string]BeadHistory)
+		if h, ok := histories[opts.BeadID]; ok {
+			filtered[opts.BeadID] = h
+		}
+		histories = filtered
+	}
+
+	if err := s.loadPullRequests(histories, opts); err != nil {
+		return nil, err
+	}
+
+	report := s.correlator.assembleReport(beads, opts, histories, events, commits)
+	if indexed, err := s.meta("indexed_sha"); err == nil && indexed != "" {
+		report.LatestCommitSHA = indexed
+	}
+	return report, nil
+}
+
+// fingerprint identifies the settings the indexed correlations depend on.
+func (s *HistoryStore) fingerprint(beads []BeadInfo) (string, error) {
+	h := sha256.New()
+	h.Write([]byte(historyStoreSchemaVersion + "\x00" + s.correlator.extractor.primaryBeadsFile() + "\x00"))
+	cfg, err := os.ReadFile(filepath.Join(s.correlator.repoPath, CorrelationConfigFile))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("reading %s: %w", CorrelationConfigFile, err)
+	}
+	h.Write(cfg)
+	// Commit messages only match known bead IDs, so a new bead must rescan
+	// the commits indexed before it existed.
+	ids := make([]string, len(beads))
+	for i, b := range beads {
+		ids[i] = strings.ToLower(b.ID)
+	}
+	sort.Strings(ids)
+	h.Write([]byte("\x00" + strings.Join(ids, "\n")))
+	return hex.EncodeToString(h.Sum(nil))[:16], nil
+}
+
+// meta returns a value from the meta table, or "" when unset.
+func (s *HistoryStore) meta(key string) (string, error) {
+	var value string
+	err := s.db.QueryRow("SELECT value FROM meta WHERE key = ?", key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading meta %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// insertHistoryBatch writes one batch's events and correlated commits.
+func insertHistoryBatch(tx *sql.Tx, batch *historyBatch) error {
+	for _, e := range batch.events {
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO bead_events
+			(bead_id, event_type, commit_sha, commit_message, author, author_email, timestamp, unix, changes)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			e.BeadID, string(e.EventType), e.CommitSHA, e.CommitMsg, e.Author, e.AuthorEmail,
+			e.Timestamp.Format(time.RFC3339), e.Timestamp.Unix(), strings.Join(e.Changes, ",")); err != nil {
+			return fmt.Errorf("inserting event: %w", err)
+		}
+	}
+
+	for _, c := range batch.commits {
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO commits
+			(sha, short_sha, author, author_email, timestamp, unix, message)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			c.SHA, c.ShortSHA, c.Author, c.AuthorEmail, c.Timestamp.Format(time.RFC3339), c.Timestamp.Unix(), c.Message); err != nil {
+			return fmt.Errorf("inserting commit: %w", err)
+		}
+		for _, f := range c.Files {
+			if _, err := tx.Exec(`INSERT OR IGNORE INTO file_changes
+				(commit_sha, path, action, insertions, deletions) VALUES (?, ?, ?, ?, ?)`,
+				c.SHA, f.Path, f.Action, f.Insertions, f.Deletions); err != nil {
+				return fmt.Errorf("inserting file change: %w", err)
+			}
+		}
+		// The first method to link a bead and commit wins, as in dedupCommits.
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO correlations
+			(bead_id, commit_sha, method, confidence, reason) VALUES (?, ?, ?, ?, ?)`,
+			c.BeadID, c.SHA, string(c.Method), c.Confidence, c.Reason); err != nil {
+			return fmt.Errorf("inserting correlation: %w", err)
+		}
+	}
+	return nil
+}
+
+// linkPullRequests attaches the batches' pull requests to beads through
+// every indexed correlation, not just the new ones, and stores the links.
+func (s *HistoryStore) linkPullRequests(tx *sql.Tx, beads []BeadInfo, batches []*historyBatch) error {
+	var prs []PullRequest
+	var matcher *ExplicitMatcher
+	for _, batch := range batches {
+		prs = append(prs, batch.prs...)
+		matcher = batch.matcher
+	}
+	if len(prs) == 0 {
+		return nil
+	}
+
+	// Only commit SHAs and existing pull requests matter for linking.
+	histories := make(map[string]BeadHistory, len(beads))
+	for _, b := range beads {
+		histories[b.ID] = BeadHistory{BeadID: b.ID}
+	}
+	rows, err := tx.Query(`SELECT bead_id, commit_sha FROM correlations
+		UNION SELECT bead_id, commit_sha FROM bead_events`)
+	if err != nil {
+		return fmt.Errorf("loading correlations: %w", err)
+	}
+	for rows.Next() {
+		var beadID, sha string
+		if err := rows.Scan(&beadID, &sha); err != nil {
+			rows.Close()
+			return err
+		}
+		if h, ok := histories[beadID]; ok {
+			h.Commits = append(h.Commits, CorrelatedCommit{SHA: sha})
+			histories[beadID] = h
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if err := scanPullRequests(tx, "SELECT bead_id, data FROM pull_requests", nil, histories); err != nil {
+		return err
+	}
+
+	attachPullRequests(histories, prs, matcher)
+
+	for beadID, h := range histories {
+		for _, pr := range h.PullRequests {
+			data, err := json.Marshal(pr)
+			if err != nil {
+				return err
+			}
+			if _, err := tx.Exec(`INSERT OR REPLACE INTO pull_requests
+				(bead_id, number, merged_at, unix, data) VALUES (?, ?, ?, ?, ?)`,
+				beadID, pr.Number, pr.MergedAt.Format(time.RFC3339), pr.MergedAt.Unix(), string(data)); err != nil {
+				return fmt.Errorf("inserting pull request: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// storeFilter builds a WHERE clause over a table with bead_id, commit_sha
+// and unix columns (aliased t) for the report options.
+func storeFilter(opts CorrelatorOptions) (string, []any) {
+	var clauses []string
+	var args []any
+	if opts.BeadID != "" {
+		clauses = append(clauses, "t.bead_id = ?")
+		args = append(args, opts.BeadID)
+	}
+	if opts.Since != nil {
+		clauses = append(clauses, "t.unix >= ?")
+		args = append(args, opts.Since.Unix())
+	}
+	if opts.Until != nil {
+		clauses = append(clauses, "t.unix <= ?")
+		args = append(args, opts.Until.Unix())
+	}
+	if opts.Limit > 0 {
+		clauses = append(clauses, `t.commit_sha IN (
+			SELECT sha FROM (
+				SELECT commit_sha AS sha, unix FROM bead_events
+				UNION SELECT sha, unix FROM commits
+			) GROUP BY sha ORDER BY MAX(unix) DESC LIMIT ?)`)
+		args = append(args, opts.Limit)
+	}
+	if len(clauses) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(clauses, " AND "), args
+}
+
+// loadEvents returns matching events in chronological order.
+func (s *HistoryStore) loadEvents(filter string, args []any) ([]BeadEvent, error) {
+	rows, err := s.db.Query(`SELECT t.bead_id, t.event_type, t.commit_sha, t.commit_message,
+		t.author, t.author_email, t.timestamp, t.changes FROM bead_events t`+filter+` ORDER BY t.unix, t.rowid`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("loading events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []BeadEvent
+	for rows.Next() {
+		var e BeadEvent
+		var eventType, ts, changes string
+		if err := rows.Scan(&e.BeadID, &eventType, &e.CommitSHA, &e.CommitMsg, &e.Author, &e.AuthorEmail, &ts, &changes); err != nil {
+			return nil, err
+		}
+		e.EventType = EventType(eventType)
+		if changes != "" {
+			e.Changes = strings.Split(changes, ",")
+		}
+		e.Timestamp, _ = time.Parse(time.RFC3339, ts)
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// storeCorrelatedCommits joins correlations with their commit rows; the
+// result is aliased t so storeFilter applies to it.
+const storeCorrelatedCommits = `(SELECT c.*, c.rowid AS seq, m.unix, m.short_sha, m.message, m.author, m.author_email, m.timestamp
+			FROM correlations c JOIN commits m ON m.sha = c.commit_sha) t`
+
+// loadCommits returns matching correlated commits, oldest first, with their
+// file changes.
+func (s *HistoryStore) loadCommits(filter string, args []any) ([]CorrelatedCommit, error) {
+	rows, err := s.db.Query(`SELECT t.bead_id, t.commit_sha, t.method, t.confidence, t.reason,
+		t.short_sha, t.message, t.author, t.author_email, t.timestamp
+		FROM `+storeCorrelatedCommits+filter+` ORDER BY t.unix, t.seq`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("loading correlations: %w", err)
+	}
+	defer rows.Close()
+
+	var commits []CorrelatedCommit
+	for rows.Next() {
+		var c CorrelatedCommit
+		var method, ts string
+		if err := rows.Scan(&c.BeadID, &c.SHA, &method, &c.Confidence, &c.Reason,
+			&c.ShortSHA, &c.Message, &c.Author, &c.AuthorEmail, &ts); err != nil {
+			return nil, err
+		}
+		c.Method = CorrelationMethod(method)
+		c.Timestamp, _ = time.Parse(time.RFC3339, ts)
+		commits = append(commits, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	files, err := s.loadFileChanges(filter, args)
+	if err != nil {
+		return nil, err
+	}
+	for i := range commits {
+		commits[i].Files = files[commits[i].SHA]
+		if commits[i].Files == nil {
+			commits[i].Files = []FileChange{}
+		}
+	}
+	return commits, nil
+}
+
+// loadFileChanges returns the file changes of the commits loadCommits
+// selects with the same filter, keyed by commit.
+func (s *HistoryStore) loadFileChanges(filter string, args []any) (map[string][]FileChange, error) {
+	rows, err := s.db.Query(`SELECT commit_sha, path, action, insertions, deletions FROM file_changes
+		WHERE commit_sha IN (SELECT t.commit_sha FROM `+storeCorrelatedCommits+filter+`) ORDER BY rowid`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("loading file changes: %w", err)
+	}
+	defer rows.Close()
+
+	files := make(map[string][]FileChange)
+	for rows.Next() {
+		var sha string
+		var f FileChange
+		if err := rows.Scan(&sha, &f.Path, &f.Action, &f.Insertions, &f.Deletions); err != nil {
+			return nil, err
+		}
+		files[sha] = append(files[sha], f)
+	}
+	return files, rows.Err()
+}
+
+// loadPullRequests attaches stored pull requests merged within the report's
+// time window to the histories.
+func (s *HistoryStore) loadPullRequests(histories map[string]BeadHistory, opts CorrelatorOptions) error {
+	query := "SELECT bead_id, data FROM pull_requests WHERE 1 = 1"
+	var args []any
+	if opts.Since != nil {
+		query += " AND unix >= ?"
+		args = append(args, opts.Since.Unix())
+	}
+	if opts.Until != nil {
+		query += " AND unix <= ?"
+		args = append(args, opts.Until.Unix())
+	}
+	if err := scanPullRequests(s.db, query, args, histories); err != nil {
+		return err
+	}
+	for beadID, h := range histories {
+		if len(h.PullRequests) == 0 {
+			continue
+		}
+		sort.SliceStable(h.PullRequests, func(i, j int) bool {
+			return h.PullRequests[i].MergedAt.Before(h.PullRequests[j].MergedAt)
+		})
+		h.refreshMilestones()
+		histories[beadID] = h
+	}
+	return nil
+}
+
+// scanPullRequests appends the pull requests a query returns (bead_id, data)
+// to the histories of known beads.
+func scanPullRequests(q interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}, query string, args []any, histories map[string]BeadHistory) error {
+	rows, err := q.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("loading pull requests: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var beadID, data string
+		if err := rows.Scan(&beadID, &data); err != nil {
+			return err
+		}
+		h, ok := histories[beadID]
+		if !ok {
+			continue
+		}
+		var pr PullRequest
+		if err := json.Unmarshal([]byte(data), &pr); err != nil {
+			return fmt.Errorf("decoding pull request: %w", err)
+		}
+		h.PullRequests = append(h.PullRequests, pr)
+		histories[beadID] = h
+	}
+	return rows.Err()
+}
+
+// isAncestor reports whether commit a is an ancestor of (or equal to) b.
+func isAncestor(repoPath, a, b string) bool {
+	cmd := exec.Command("git", "merge-base", "--is-ancestor", a, b)
+	cmd.Dir = repoPath
+	return cmd.Run() == nil
+}
@@ -0,0 +1,245 @@
+package correlation
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestHistoryStore_SyncsIncrementally(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	day := 0
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		date := fmt.Sprintf("2024-03-%02dT10:00:00Z", day+1)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	commit := func(msg string, files map[string]string) {
+		t.Helper()
+		for name, body := range files {
+			path := filepath.Join(dir, name)
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		day++
+		git("add", ".")
+		git("commit", "-q", "-m", msg)
+	}
+	git("init", "-q")
+	git("config", "user.email", "dev@example.com")
+	git("config", "user.name", "Dev")
+
+	commit("add beads", map[string]string{
+		".gitignore":          ".bv/\n",
+		".beads/issues.jsonl": `{"id":"bv-1","title":"Login","status":"open"}` + "\n" + `{"id":"bv-2","title":"Logout","status":"open"}` + "\n",
+	})
+	commit("Implement login for bv-1", map[string]string{"auth/login.go": "package auth\n"})
+
+	beads := []BeadInfo{{ID: "bv-1", Title: "Login", Status: "open"}, {ID: "bv-2", Title: "Logout", Status: "open"}}
+	store, err := OpenHistoryStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	sync, err := store.Sync(beads)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sync.FullRebuild || sync.Reason != "empty database" {
+		t.Fatalf("first sync = %+v", sync)
+	}
+	report, err := store.Report(beads, CorrelatorOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := NewCorrelator(dir).GenerateReport(beads, CorrelatorOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := commitSHAs(report, "bv-1"), commitSHAs(want, "bv-1"); len(got) != 1 || got[0] != exp[0] {
+		t.Fatalf("bv-1 commits = %v, want %v", got, exp)
+	}
+	if c := report.Histories["bv-1"].Commits[0]; c.Method != MethodExplicitID || len(c.Files) != 1 || c.Files[0].Path != "auth/login.go" {
+		t.Fatalf("bv-1 commit = %+v", c)
+	}
+	if len(report.Histories["bv-1"].Events) != 1 || report.Stats.TotalCommits != want.Stats.TotalCommits {
+		t.Fatalf("report = %+v, want stats %+v", report.Histories["bv-1"], want.Stats)
+	}
+
+	// Nothing new: no work.
+	if sync, err = store.Sync(beads); err != nil || sync.FullRebuild || sync.NewCommits != 0 {
+		t.Fatalf("noop sync = %+v, %v", sync, err)
+	}
+
+	commit("Close bv-2 with logout", map[string]string{
+		".beads/issues.jsonl": `{"id":"bv-1","title":"Login","status":"open"}` + "\n" + `{"id":"bv-2","title":"Logout","status":"closed","priority":1}` + "\n",
+		"auth/logout.go":      "package auth\n",
+	})
+	beads[1].Status = "closed"
+	sync, err = store.Sync(beads)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sync.FullRebuild || sync.NewCommits != 1 || sync.Events != 1 {
+		t.Fatalf("incremental sync = %+v", sync)
+	}
+	report, err = store.Report(beads, CorrelatorOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := report.Histories["bv-2"]
+	if h.Milestones.Closed == nil || len(h.Commits) != 1 || h.Commits[0].Method != MethodCoCommitted {
+		t.Fatalf("bv-2 history = %+v", h)
+	}
+	if !h.Milestones.Closed.HasChange(ChangePriority) {
+		t.Fatalf("bv-2 close lost its field changes: %+v", h.Milestones.Closed)
+	}
+	if len(report.Histories["bv-1"].Commits) != 1 {
+		t.Fatalf("bv-1 lost its commit: %+v", report.Histories["bv-1"])
+	}
+
+	// Limit keeps only the most recent indexed commits.
+	limited, err := store.Report(beads, CorrelatorOptions{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(limited.Histories["bv-1"].Commits) != 0 || len(limited.Histories["bv-2"].Commits) != 1 {
+		t.Fatalf("limited report = %+v", limited.Histories)
+	}
+	if files := limited.Histories["bv-2"].Commits[0].Files; len(files) != 1 || files[0].Path != "auth/logout.go" {
+		t.Fatalf("limited report lost file changes: %+v", files)
+	}
+
+	// Rewritten history forces a rebuild.
+	git("reset", "-q", "--hard", "HEAD~1")
+	commit("Unrelated change", map[string]string{"README.md": "hi\n"})
+	beads[1].Status = "open"
+	sync, err = store.Sync(beads)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sync.FullRebuild || sync.Reason != "indexed commit is not an ancestor of HEAD" {
+		t.Fatalf("rewrite sync = %+v", sync)
+	}
+	report, err = store.Report(beads, CorrelatorOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Histories["bv-2"].Commits) != 0 || len(report.Histories["bv-1"].Commits) != 1 {
+		t.Fatalf("rebuilt report = %+v", report.Histories)
+	}
+}
+
+func TestHistoryStore_NewBeadGetsEarlierCommits(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	commit := func(msg, name, body string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", ".")
+		git("commit", "-q", "-m", msg)
+	}
+	git("init", "-q")
+	git("config", "user.email", "dev@example.com")
+	git("config", "user.name", "Dev")
+	commit("add beads", ".beads/issues.jsonl", `{"id":"bv-1","title":"Login","status":"open"}`+"\n")
+	commit("Groundwork for bv-7", "auth/token.go", "package auth\n")
+
+	store, err := OpenHistoryStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	beads := []BeadInfo{{ID: "bv-1", Title: "Login", Status: "open"}}
+	if _, err := store.Sync(beads); err != nil {
+		t.Fatal(err)
+	}
+
+	// bv-7 is created after its groundwork commit was indexed.
+	commit("file bv-7", ".beads/issues.jsonl", `{"id":"bv-1","title":"Login","status":"open"}`+"\n"+`{"id":"bv-7","title":"Tokens","status":"open"}`+"\n")
+	beads = append(beads, BeadInfo{ID: "bv-7", Title: "Tokens", Status: "open"})
+	report, err := store.GenerateReport(beads, CorrelatorOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := NewCorrelator(dir).GenerateReport(beads, CorrelatorOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, exp := commitSHAs(report, "bv-7"), commitSHAs(want, "bv-7")
+	if len(exp) == 0 || fmt.Sprint(got) != fmt.Sprint(exp) {
+		t.Fatalf("bv-7 commits = %v, want %v", got, exp)
+	}
+}
+
+func TestHistoryStore_DropsTablesFromOlderSchema(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenHistoryStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stmt := range []string{
+		"DROP TABLE bead_events",
+		"CREATE TABLE bead_events (bead_id TEXT, event_type TEXT, commit_sha TEXT, commit_message TEXT, author TEXT, author_email TEXT, timestamp TEXT, unix INTEGER)",
+		"INSERT INTO meta (key, value) VALUES ('schema_version', '1'), ('indexed_sha', 'abc')",
+	} {
+		if _, err := store.db.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store.Close()
+
+	store, err = OpenHistoryStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if indexed, err := store.meta("indexed_sha"); err != nil || indexed != "" {
+		t.Fatalf("indexed_sha = %q, %v; want cleared", indexed, err)
+	}
+	if _, err := store.db.Exec("SELECT changes FROM bead_events"); err != nil {
+		t.Fatalf("bead_events not recreated: %v", err)
+	}
+}
+
+func commitSHAs(report *HistoryReport, beadID string) []string {
+	var shas []string
+	for _, c := range report.Histories[beadID].Commits {
+		shas = append(shas, c.SHA)
+	}
+	sort.Strings(shas)
+	return shas
+}
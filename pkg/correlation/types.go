@@ -20,6 +20,12 @@ const (
 	EventReopened EventType = "reopened"
 	// EventModified indicates other significant changes (title, priority, deps)
 	EventModified EventType = "modified"
+	// EventInReview indicates a linked pull request was opened for review.
+	// Derived from pull requests; only appears in BeadMilestones.
+	EventInReview EventType = "in_review"
+	// EventMerged indicates a linked pull request was merged.
+	// Derived from pull requests; only appears in BeadMilestones.
+	EventMerged EventType = "merged"
 )
 
 // String returns the string representation of EventType
@@ -30,7 +36,7 @@ func (e EventType) String() string {
 // IsValid returns true if the event type is a recognized value
 func (e EventType) IsValid() bool {
 	switch e {
-	case EventCreated, EventClaimed, EventClosed, EventReopened, EventModified:
+	case EventCreated, EventClaimed, EventClosed, EventReopened, EventModified, EventInReview, EventMerged:
 		return true
 	}
 	return false
@@ -45,6 +51,27 @@ type BeadEvent struct {
 	CommitMsg   string    `json:"commit_message"`
 	Author      string    `json:"author"`
 	AuthorEmail string    `json:"author_email"`
+
+	// Changes lists the tracked fields (title, priority, assignee) the
+	// commit changed, whatever the event type
+	Changes []string `json:"changes,omitempty"`
+}
+
+// Tracked bead fields reported in BeadEvent.Changes
+const (
+	ChangeTitle    = "title"
+	ChangePriority = "priority"
+	ChangeAssignee = "assignee"
+)
+
+// HasChange reports whether the event changed the given field
+func (e BeadEvent) HasChange(field string) bool {
+	for _, c := range e.Changes {
+		if c == field {
+			return true
+		}
+	}
+	return false
 }
 
 // CorrelationMethod describes how a commit was linked to a bead
@@ -94,6 +121,19 @@ type CorrelatedCommit struct {
 	Method      CorrelationMethod `json:"method"`
 	Confidence  float64           `json:"confidence"` // 0.0 to 1.0
 	Reason      string            `json:"reason"`     // Human-readable explanation
+
+	// BaseConfidence is the heuristic confidence when Confidence has been
+	// recalibrated by a learned model (0 = not recalibrated).
+	BaseConfidence float64 `json:"base_confidence,omitempty"`
+}
+
+// HeuristicConfidence returns the confidence assigned by the correlation
+// heuristics, before any learned recalibration.
+func (c CorrelatedCommit) HeuristicConfidence() float64 {
+	if c.BaseConfidence > 0 {
+		return c.BaseConfidence
+	}
+	return c.Confidence
 }
 
 // BeadMilestones contains key lifecycle timestamps for quick access
@@ -101,7 +141,9 @@ type BeadMilestones struct {
 	Created  *BeadEvent `json:"created,omitempty"`
 	Claimed  *BeadEvent `json:"claimed,omitempty"`
 	Closed   *BeadEvent `json:"closed,omitempty"`
-	Reopened *BeadEvent `json:"reopened,omitempty"` // Most recent if multiple
+	Reopened *BeadEvent `json:"reopened,omitempty"`  // Most recent if multiple
+	InReview *BeadEvent `json:"in_review,omitempty"` // Earliest linked pull request opened
+	Merged   *BeadEvent `json:"merged,omitempty"`    // Most recent linked pull request merged
 }
 
 // CycleTime represents the duration between lifecycle events
@@ -109,18 +151,22 @@ type CycleTime struct {
 	ClaimToClose  *time.Duration `json:"claim_to_close,omitempty"`  // Time from claimed to closed
 	CreateToClose *time.Duration `json:"create_to_close,omitempty"` // Time from created to closed
 	CreateToClaim *time.Duration `json:"create_to_claim,omitempty"` // Time from created to claimed
+	ClaimToMerge  *time.Duration `json:"claim_to_merge,omitempty"`  // Time from claimed to merged
+	CreateToMerge *time.Duration `json:"create_to_merge,omitempty"` // Time from created to merged
+	ReviewTime    *time.Duration `json:"review_time,omitempty"`     // Time from in review to merged
 }
 
 // BeadHistory is the complete correlation record for a single bead
 type BeadHistory struct {
-	BeadID     string             `json:"bead_id"`
-	Title      string             `json:"title"`
-	Status     string             `json:"status"`
-	Events     []BeadEvent        `json:"events"`      // All lifecycle events, chronological
-	Milestones BeadMilestones     `json:"milestones"`  // Key events for quick access
-	Commits    []CorrelatedCommit `json:"commits"`     // Related code commits
-	CycleTime  *CycleTime         `json:"cycle_time"`  // nil if not yet closed
-	LastAuthor string             `json:"last_author"` // Most recent committer
+	BeadID       string             `json:"bead_id"`
+	Title        string             `json:"title"`
+	Status       string             `json:"status"`
+	Events       []BeadEvent        `json:"events"`                  // All lifecycle events, chronological
+	Milestones   BeadMilestones     `json:"milestones"`              // Key events for quick access
+	Commits      []CorrelatedCommit `json:"commits"`                 // Related code commits
+	PullRequests []PullRequest      `json:"pull_requests,omitempty"` // Linked merged pull requests, by merge time
+	CycleTime    *CycleTime         `json:"cycle_time"`              // nil if not yet closed or merged
+	LastAuthor   string             `json:"last_author"`             // Most recent committer
 }
 
 // CommitIndex provides O(1) lookup from commit SHA to bead IDs
@@ -135,6 +181,7 @@ type HistoryStats struct {
 	AvgCommitsPerBead  float64        `json:"avg_commits_per_bead"`
 	AvgCycleTimeDays   *float64       `json:"avg_cycle_time_days,omitempty"` // nil if no closed beads
 	MethodDistribution map[string]int `json:"method_distribution"`           // Count per correlation method
+	BeadsWithPRs       int            `json:"beads_with_prs,omitempty"`      // Beads linked to a merged pull request
 }
 
 // HistoryReport is the top-level output structure for --robot-history
@@ -218,6 +265,10 @@ type CorrelationFeedback struct {
 	Type         FeedbackType `json:"type"`          // confirm, reject, ignore
 	Reason       string       `json:"reason"`        // Optional explanation
 	OriginalConf float64      `json:"original_conf"` // Confidence before feedback
+
+	// Features are the signals the confidence model trains on; absent in
+	// feedback recorded before the model existed.
+	Features *CorrelationFeatures `json:"features,omitempty"`
 }
 
 // FeedbackStats provides aggregate statistics about correlation feedback
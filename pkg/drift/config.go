@@ -45,6 +45,12 @@ type Config struct {
 	BlockingCascadeInfo    int `yaml:"blocking_cascade_info_threshold" json:"blocking_cascade_info_threshold"`
 	BlockingCascadeWarning int `yaml:"blocking_cascade_warning_threshold" json:"blocking_cascade_warning_threshold"`
 
+	// Reopen spike thresholds: warn when the recent reopen rate exceeds the
+	// historical rate by this percentage (critical at twice that)
+	ReopenSpikeWarningPct float64 `yaml:"reopen_spike_warning_pct" json:"reopen_spike_warning_pct"`
+	// ReopenSpikeMinReopens is the fewest recent reopens worth alerting on
+	ReopenSpikeMinReopens int `yaml:"reopen_spike_min_reopens" json:"reopen_spike_min_reopens"`
+
 	// Alert type enable/disable flags (bv-167)
 	// Disabled alert types will not generate alerts
 	DisabledAlerts []string `yaml:"disabled_alerts,omitempty" json:"disabled_alerts,omitempty"`
@@ -80,6 +86,8 @@ func DefaultConfig() *Config {
 		InProgressStaleMultiplier:    0.5, // In-progress thresholds are half as long
 		BlockingCascadeInfo:          3,   // Info alert when unblocks >=3
 		BlockingCascadeWarning:       5,   // Warning when unblocks >=5
+		ReopenSpikeWarningPct:        50,  // Recent reopen rate 50%+ above history triggers warning
+		ReopenSpikeMinReopens:        3,   // Ignore spikes of fewer than 3 reopens
 	}
 }
 
@@ -161,6 +169,12 @@ func (c *Config) Validate() error {
 	if c.InProgressStaleMultiplier == 0 {
 		c.InProgressStaleMultiplier = DefaultConfig().InProgressStaleMultiplier
 	}
+	if c.ReopenSpikeWarningPct == 0 {
+		c.ReopenSpikeWarningPct = DefaultConfig().ReopenSpikeWarningPct
+	}
+	if c.ReopenSpikeMinReopens == 0 {
+		c.ReopenSpikeMinReopens = DefaultConfig().ReopenSpikeMinReopens
+	}
 
 	if c.DensityWarningPct < 0 || c.DensityWarningPct > 1000 {
 		return fmt.Errorf("density_warning_pct must be between 0 and 1000")
@@ -201,6 +215,12 @@ func (c *Config) Validate() error {
 	if c.BlockingCascadeWarning < c.BlockingCascadeInfo {
 		return fmt.Errorf("blocking_cascade_warning_threshold must be >= blocking_cascade_info_threshold")
 	}
+	if c.ReopenSpikeWarningPct < 0 || c.ReopenSpikeWarningPct > 1000 {
+		return fmt.Errorf("reopen_spike_warning_pct must be between 0 and 1000")
+	}
+	if c.ReopenSpikeMinReopens < 0 {
+		return fmt.Errorf("reopen_spike_min_reopens must be non-negative")
+	}
 	// Validate label overrides (bv-167)
 	for label, lc := range c.LabelOverrides {
 		if lc == nil {
@@ -315,12 +335,17 @@ in_progress_stale_multiplier: 0.5  # In-progress items age twice as fast
 blocking_cascade_info_threshold: 3   # Info alert if completing an issue unblocks 3+ items
 blocking_cascade_warning_threshold: 5 # Warning if unblocks 5+ items
 
+# Reopen spike thresholds (needs git history)
+reopen_spike_warning_pct: 50     # Warn if the last 30 days' reopen rate is 50%+ above history
+reopen_spike_min_reopens: 3      # Ignore spikes of fewer than 3 reopens
+
 # Disable specific alert types (bv-167)
 # Uncomment to disable:
 # disabled_alerts:
 #   - stale_issue
 #   - new_cycle
 #   - blocking_cascade
+#   - reopen_spike
 
 # Per-label staleness overrides (bv-167)
 # Use tighter thresholds for urgent/priority labels
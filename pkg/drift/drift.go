@@ -4,6 +4,7 @@ package drift
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -38,6 +39,7 @@ const (
 	AlertHighImpactUnblock  AlertType = "high_impact_unblock"
 	AlertAbandonedClaim     AlertType = "abandoned_claim"
 	AlertPotentialDuplicate AlertType = "potential_duplicate"
+	AlertReopenSpike        AlertType = "reopen_spike"
 )
 
 // Alert represents a single drift detection alert
@@ -78,6 +80,7 @@ type Calculator struct {
 	baseline *baseline.Baseline
 	current  *baseline.Baseline
 	issues   []model.Issue
+	churn    *analysis.ChurnReport
 }
 
 // NewCalculator creates a drift calculator with the given baseline and current snapshot
@@ -98,6 +101,12 @@ func (c *Calculator) SetIssues(issues []model.Issue) {
 	c.issues = issues
 }
 
+// SetChurn attaches reopen analytics for the reopen spike alert.
+// Optional: the alert is skipped without git history.
+func (c *Calculator) SetChurn(report *analysis.ChurnReport) {
+	c.churn = report
+}
+
 // Calculate performs drift detection and returns results
 func (c *Calculator) Calculate() *Result {
 	result := &Result{
@@ -128,6 +137,9 @@ func (c *Calculator) Calculate() *Result {
 	// Check blocking cascades (uses current issues if provided)
 	c.checkBlockingCascade(result)
 
+	// Check reopen rate against its history (uses churn if provided)
+	c.checkReopenSpike(result)
+
 	// Compute summary
 	for _, alert := range result.Alerts {
 		switch alert.Severity {
@@ -512,6 +524,48 @@ func (c *Calculator) checkBlockingCascade(result *Result) {
 	}
 }
 
+// checkReopenSpike raises an alert when beads are being reopened more often
+// in the recent window than they were before it.
+func (c *Calculator) checkReopenSpike(result *Result) {
+	if c.config.IsAlertDisabled(string(AlertReopenSpike)) {
+		return
+	}
+	if c.churn == nil || c.config.ReopenSpikeWarningPct <= 0 {
+		return
+	}
+	t := c.churn.Trend
+	if t.RecentReopens == 0 || t.RecentReopens < c.config.ReopenSpikeMinReopens {
+		return
+	}
+
+	severity := SeverityWarning
+	switch {
+	case t.BaselineRate > 0:
+		pct := math.Round((t.RecentRate-t.BaselineRate)/t.BaselineRate*1000) / 10
+		if pct < c.config.ReopenSpikeWarningPct {
+			return
+		}
+		if pct >= 2*c.config.ReopenSpikeWarningPct {
+			severity = SeverityCritical
+		}
+	case t.BaselineCloses == 0:
+		return // No history to compare against
+	}
+	// Otherwise nothing was reopened before the window: any qualifying run is a spike
+
+	result.Alerts = append(result.Alerts, Alert{
+		Type:     AlertReopenSpike,
+		Severity: severity,
+		Message: fmt.Sprintf("Reopen rate %.0f%% over the last %.0f days vs %.0f%% before (%d reopens)",
+			t.RecentRate*100, t.WindowDays, t.BaselineRate*100, t.RecentReopens),
+		BaselineVal: t.BaselineRate,
+		CurrentVal:  t.RecentRate,
+		Delta:       t.RecentRate - t.BaselineRate,
+		Details:     t.RecentReopened,
+		DetectedAt:  time.Now().UTC(),
+	})
+}
+
 // cycleKey creates a normalized key for a cycle for comparison.
 // It rotates the cycle so the lexicographically smallest element is first,
 // preserving the order (direction) of elements.
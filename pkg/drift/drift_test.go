@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"gopkg.in/yaml.v3"
@@ -257,6 +258,61 @@ func TestCalculatorBlockingCascade(t *testing.T) {
 	}
 }
 
+func TestCalculatorReopenSpike(t *testing.T) {
+	bl := &baseline.Baseline{Stats: baseline.GraphStats{}}
+	current := &baseline.Baseline{Stats: baseline.GraphStats{}}
+	reopenAlert := func(trend analysis.ReopenTrend, cfg *Config) *Alert {
+		calc := NewCalculator(bl, current, cfg)
+		calc.SetChurn(&analysis.ChurnReport{Trend: trend})
+		for _, a := range calc.Calculate().Alerts {
+			if a.Type == AlertReopenSpike {
+				return &a
+			}
+		}
+		return nil
+	}
+
+	trend := analysis.ReopenTrend{
+		WindowDays:   30,
+		RecentCloses: 10, RecentReopens: 4, RecentRate: 0.4,
+		RecentReopened: []string{"A", "B", "C", "D"},
+		BaselineCloses: 50, BaselineReopens: 10, BaselineRate: 0.2,
+	}
+	a := reopenAlert(trend, DefaultConfig())
+	if a == nil || a.Severity != SeverityCritical || a.CurrentVal != 0.4 || a.BaselineVal != 0.2 || len(a.Details) != 4 {
+		t.Fatalf("doubled reopen rate alert = %+v", a)
+	}
+
+	trend.RecentRate = 0.3
+	if a := reopenAlert(trend, DefaultConfig()); a == nil || a.Severity != SeverityWarning {
+		t.Fatalf("50%% rise alert = %+v", a)
+	}
+
+	trend.RecentRate = 0.25
+	if a := reopenAlert(trend, DefaultConfig()); a != nil {
+		t.Fatalf("expected no alert below threshold, got %+v", a)
+	}
+
+	// Too few reopens to matter, even from a zero baseline.
+	quiet := analysis.ReopenTrend{RecentCloses: 5, RecentReopens: 2, RecentRate: 0.4, BaselineCloses: 20}
+	if a := reopenAlert(quiet, DefaultConfig()); a != nil {
+		t.Fatalf("expected no alert for 2 reopens, got %+v", a)
+	}
+	quiet.RecentReopens = 3
+	if a := reopenAlert(quiet, DefaultConfig()); a == nil || a.Severity != SeverityWarning {
+		t.Fatalf("zero baseline alert = %+v", a)
+	}
+
+	cfg := DefaultConfig()
+	cfg.DisabledAlerts = []string{string(AlertReopenSpike)}
+	if a := reopenAlert(quiet, cfg); a != nil {
+		t.Fatalf("disabled alert fired: %+v", a)
+	}
+	if a := reopenAlert(analysis.ReopenTrend{RecentCloses: 3, RecentReopens: 3, RecentRate: 1}, DefaultConfig()); a != nil {
+		t.Fatalf("expected no alert without history, got %+v", a)
+	}
+}
+
 // TestCalculatorBlockingCascadeWithPriorities verifies the downstream priority sum calculation (bv-165)
 func TestCalculatorBlockingCascadeWithPriorities(t *testing.T) {
 	issues := []model.Issue{
@@ -667,6 +723,7 @@ func TestConfigValidate(t *testing.T) {
 		{"actionable decrease > 100", &Config{DensityWarningPct: 50, ActionableDecreaseWarningPct: 150}, true},
 		{"negative actionable increase", &Config{DensityWarningPct: 50, ActionableIncreaseInfoPct: -10}, true},
 		{"negative pagerank change", &Config{DensityWarningPct: 50, PageRankChangeWarningPct: -20}, true},
+		{"negative reopen spike", &Config{DensityWarningPct: 50, ReopenSpikeWarningPct: -1}, true},
 	}
 
 	for _, tt := range tests {
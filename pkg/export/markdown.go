@@ -173,6 +173,7 @@ func GenerateMarkdown(issues []model.Issue, title string) (string, error) {
 			}
 			sb.WriteString(fmt.Sprintf("| **Labels** | %s |\n", strings.Join(escapedLabels, ", ")))
 		}
+		sb.WriteString(customFieldRows(i))
 		sb.WriteString("\n")
 
 		if i.Description != "" {
@@ -231,6 +232,32 @@ func GenerateMarkdown(issues []model.Issue, title string) (string, error) {
 	return sb.String(), nil
 }
 
+// customFieldRows renders schema-declared custom fields as metadata table rows,
+// sorted by field name for stable output.
+func customFieldRows(i model.Issue) string {
+	if len(i.Custom) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(i.Custom))
+	for name := range i.Custom {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		value := i.CustomString(name)
+		if value == "" {
+			continue
+		}
+		value = strings.ReplaceAll(value, "\n", " ")
+		value = strings.ReplaceAll(value, "\r", "")
+		sb.WriteString(fmt.Sprintf("| **%s** | %s |\n",
+			strings.ReplaceAll(name, "|", "\\|"), strings.ReplaceAll(value, "|", "\\|")))
+	}
+	return sb.String()
+}
+
 func issueHeadingText(i model.Issue) string {
 	typeIcon := getTypeEmoji(string(i.IssueType))
 	return fmt.Sprintf("%s %s %s", typeIcon, i.ID, i.Title)
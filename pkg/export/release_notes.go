@@ -0,0 +1,418 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Release notes output formats.
+const (
+	ReleaseNotesMarkdown  = "markdown"
+	ReleaseNotesJSON      = "json"
+	ReleaseNotesChangelog = "changelog" // Keep a Changelog (keepachangelog.com)
+)
+
+// Release notes groupings.
+const (
+	ReleaseNotesGroupType  = "type"
+	ReleaseNotesGroupLabel = "label"
+)
+
+// ReleaseNotesOptions configures BuildReleaseNotes.
+type ReleaseNotesOptions struct {
+	FromRef string    // Range start as given (tag, branch, SHA)
+	ToRef   string    // Range end as given; "HEAD" renders as Unreleased
+	GroupBy string    // ReleaseNotesGroupType (default) or ReleaseNotesGroupLabel
+	Date    time.Time // Release date for the changelog heading
+}
+
+// ReleaseCommit is a commit in the release range.
+type ReleaseCommit struct {
+	SHA           string    `json:"sha"`
+	ShortSHA      string    `json:"short_sha"`
+	Subject       string    `json:"subject"`
+	Author        string    `json:"author"`
+	Timestamp     time.Time `json:"timestamp"`
+	ProbableBeads []string  `json:"probable_beads,omitempty"` // Orphan detector guesses for unlinked commits
+}
+
+// ReleaseNoteEntry is a bead closed within the release range.
+type ReleaseNoteEntry struct {
+	ID           string          `json:"id"`
+	Title        string          `json:"title"`
+	Type         string          `json:"type"`
+	Priority     int             `json:"priority"`
+	Labels       []string        `json:"labels,omitempty"`
+	ClosedAt     *time.Time      `json:"closed_at,omitempty"`
+	Commits      []ReleaseCommit `json:"commits"`
+	Contributors []string        `json:"contributors"`
+}
+
+// ReleaseNoteSection groups entries under one heading.
+type ReleaseNoteSection struct {
+	Key     string             `json:"key"` // Issue type or label
+	Title   string             `json:"title"`
+	Entries []ReleaseNoteEntry `json:"entries"`
+}
+
+// ReleaseContributor summarizes one author's commits in the range.
+type ReleaseContributor struct {
+	Name    string `json:"name"`
+	Commits int    `json:"commits"`
+	Beads   int    `json:"beads"` // Closed beads they committed to
+}
+
+// ReleaseNotesStats provides aggregate counts for the range.
+type ReleaseNotesStats struct {
+	BeadsClosed         int `json:"beads_closed"`
+	Commits             int `json:"commits"`
+	LinkedCommits       int `json:"linked_commits"`
+	UnlinkedCommits     int `json:"unlinked_commits"`
+	BeadsWithoutCommits int `json:"beads_without_commits"`
+	Contributors        int `json:"contributors"`
+}
+
+// ReleaseNotes lists the beads closed between two refs with their commits.
+type ReleaseNotes struct {
+	GeneratedAt         time.Time            `json:"generated_at"`
+	FromRef             string               `json:"from_ref"`
+	ToRef               string               `json:"to_ref"`
+	FromRevision        string               `json:"from_revision,omitempty"`
+	ToRevision          string               `json:"to_revision,omitempty"`
+	Date                time.Time            `json:"date"`
+	GroupBy             string               `json:"group_by"`
+	Stats               ReleaseNotesStats    `json:"stats"`
+	Sections            []ReleaseNoteSection `json:"sections"`
+	Contributors        []ReleaseContributor `json:"contributors"`
+	UnlinkedCommits     []ReleaseCommit      `json:"unlinked_commits"`      // Commits with no bead
+	BeadsWithoutCommits []ReleaseNoteEntry   `json:"beads_without_commits"` // Closed with no commit in range
+}
+
+// typeSections orders the type grouping; unknown types fall into "other".
+var typeSections = []struct {
+	key   string
+	title string
+	types []model.IssueType
+}{
+	{"feature", "Features", []model.IssueType{model.TypeFeature}},
+	{"bug", "Bug Fixes", []model.IssueType{model.TypeBug}},
+	{"chore", "Tasks & Chores", []model.IssueType{model.TypeTask, model.TypeChore}},
+	{"epic", "Epics", []model.IssueType{model.TypeEpic}},
+	{"other", "Other", nil},
+}
+
+// BuildReleaseNotes collects the beads a snapshot diff shows as closed,
+// links the correlated commits that fall inside the release range, and
+// flags range commits with no bead. commits must be the commits in the
+// range; orphans may be nil.
+func BuildReleaseNotes(diff *analysis.SnapshotDiff, commits []ReleaseCommit, report *correlation.HistoryReport, orphans *correlation.OrphanReport, opts ReleaseNotesOptions) *ReleaseNotes {
+	if opts.GroupBy == "" {
+		opts.GroupBy = ReleaseNotesGroupType
+	}
+	rn := &ReleaseNotes{
+		GeneratedAt:         time.Now(),
+		FromRef:             opts.FromRef,
+		ToRef:               opts.ToRef,
+		FromRevision:        diff.FromRevision,
+		ToRevision:          diff.ToRevision,
+		Date:                opts.Date,
+		GroupBy:             opts.GroupBy,
+		Sections:            []ReleaseNoteSection{},
+		Contributors:        []ReleaseContributor{},
+		UnlinkedCommits:     []ReleaseCommit{},
+		BeadsWithoutCommits: []ReleaseNoteEntry{},
+	}
+
+	inRange := make(map[string]ReleaseCommit, len(commits))
+	for _, c := range commits {
+		inRange[c.SHA] = c
+	}
+
+	// A commit is linked if any bead claims it, whether as correlated code
+	// or as the commit that changed the bead's own status.
+	linked := make(map[string]bool)
+	if report != nil {
+		for sha := range report.CommitIndex {
+			linked[sha] = true
+		}
+		for _, h := range report.Histories {
+			for _, e := range h.Events {
+				linked[e.CommitSHA] = true
+			}
+		}
+	}
+
+	// Beads created and closed inside the range are new in the diff.
+	closed := append([]model.Issue{}, diff.ClosedIssues...)
+	for _, issue := range diff.NewIssues {
+		if issue.Status == model.StatusClosed {
+			closed = append(closed, issue)
+		}
+	}
+
+	authorBeads := make(map[string]map[string]bool)
+	var entries []ReleaseNoteEntry
+	for _, issue := range closed {
+		if issue.Status == model.StatusTombstone {
+			continue
+		}
+		entry := ReleaseNoteEntry{
+			ID:           issue.ID,
+			Title:        issue.Title,
+			Type:         string(issue.IssueType),
+			Priority:     issue.Priority,
+			Labels:       issue.Labels,
+			ClosedAt:     issue.ClosedAt,
+			Commits:      []ReleaseCommit{},
+			Contributors: []string{},
+		}
+		if report != nil {
+			seen := make(map[string]bool)
+			for _, cc := range report.Histories[issue.ID].Commits {
+				c, ok := inRange[cc.SHA]
+				if !ok || seen[cc.SHA] {
+					continue
+				}
+				seen[cc.SHA] = true
+				entry.Commits = append(entry.Commits, c)
+				entry.Contributors = appendUniqueString(entry.Contributors, c.Author)
+				if authorBeads[c.Author] == nil {
+					authorBeads[c.Author] = make(map[string]bool)
+				}
+				authorBeads[c.Author][issue.ID] = true
+			}
+		}
+		entries = append(entries, entry)
+		if len(entry.Commits) == 0 {
+			rn.BeadsWithoutCommits = append(rn.BeadsWithoutCommits, entry)
+		}
+	}
+	sortReleaseEntries(entries)
+	sortReleaseEntries(rn.BeadsWithoutCommits)
+	rn.Sections = groupReleaseEntries(entries, opts.GroupBy)
+
+	probable := make(map[string][]string)
+	if orphans != nil {
+		for _, cand := range orphans.Candidates {
+			for _, pb := range cand.ProbableBeads {
+				probable[cand.SHA] = append(probable[cand.SHA], pb.BeadID)
+			}
+		}
+	}
+
+	commitCounts := make(map[string]int)
+	for _, c := range commits {
+		commitCounts[c.Author]++
+		if linked[c.SHA] {
+			rn.Stats.LinkedCommits++
+			continue
+		}
+		c.ProbableBeads = probable[c.SHA]
+		rn.UnlinkedCommits = append(rn.UnlinkedCommits, c)
+	}
+
+	for name, n := range commitCounts {
+		rn.Contributors = append(rn.Contributors, ReleaseContributor{Name: name, Commits: n, Beads: len(authorBeads[name])})
+	}
+	sort.Slice(rn.Contributors, func(i, j int) bool {
+		if rn.Contributors[i].Commits != rn.Contributors[j].Commits {
+			return rn.Contributors[i].Commits > rn.Contributors[j].Commits
+		}
+		return rn.Contributors[i].Name < rn.Contributors[j].Name
+	})
+
+	rn.Stats.BeadsClosed = len(entries)
+	rn.Stats.Commits = len(commits)
+	rn.Stats.UnlinkedCommits = len(rn.UnlinkedCommits)
+	rn.Stats.BeadsWithoutCommits = len(rn.BeadsWithoutCommits)
+	rn.Stats.Contributors = len(rn.Contributors)
+	return rn
+}
+
+// sortReleaseEntries orders entries by priority, then ID.
+func sortReleaseEntries(entries []ReleaseNoteEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Priority != entries[j].Priority {
+			return entries[i].Priority < entries[j].Priority
+		}
+		return entries[i].ID < entries[j].ID
+	})
+}
+
+// groupReleaseEntries splits entries into sections by type or by label.
+// With label grouping an entry appears under each of its labels.
+func groupReleaseEntries(entries []ReleaseNoteEntry, groupBy string) []ReleaseNoteSection {
+	sections := []ReleaseNoteSection{}
+	if groupBy == ReleaseNotesGroupLabel {
+		byLabel := make(map[string][]ReleaseNoteEntry)
+		for _, e := range entries {
+			if len(e.Labels) == 0 {
+				byLabel[""] = append(byLabel[""], e)
+			}
+			for _, l := range e.Labels {
+				byLabel[l] = append(byLabel[l], e)
+			}
+		}
+		labels := make([]string, 0, len(byLabel))
+		for l := range byLabel {
+			if l != "" {
+				labels = append(labels, l)
+			}
+		}
+		sort.Strings(labels)
+		for _, l := range labels {
+			sections = append(sections, ReleaseNoteSection{Key: l, Title: l, Entries: byLabel[l]})
+		}
+		if unlabeled := byLabel[""]; len(unlabeled) > 0 {
+			sections = append(sections, ReleaseNoteSection{Key: "", Title: "Unlabeled", Entries: unlabeled})
+		}
+		return sections
+	}
+
+	for _, ts := range typeSections {
+		section := ReleaseNoteSection{Key: ts.key, Title: ts.title}
+		for _, e := range entries {
+			if releaseSectionKey(e.Type) == ts.key {
+				section.Entries = append(section.Entries, e)
+			}
+		}
+		if len(section.Entries) > 0 {
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+// releaseSectionKey maps an issue type to its type-section key.
+func releaseSectionKey(issueType string) string {
+	for _, ts := range typeSections {
+		for _, t := range ts.types {
+			if string(t) == issueType {
+				return ts.key
+			}
+		}
+	}
+	return "other"
+}
+
+func appendUniqueString(list []string, s string) []string {
+	for _, existing := range list {
+		if existing == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// RenderReleaseNotesMarkdown renders release notes with commits, contributors
+// and the unlinked-work audit.
+func RenderReleaseNotesMarkdown(rn *ReleaseNotes) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Release Notes: %s..%s\n\n", rn.FromRef, rn.ToRef))
+	sb.WriteString(fmt.Sprintf("*Generated: %s*\n\n", rn.GeneratedAt.Format(time.RFC1123)))
+	sb.WriteString(fmt.Sprintf("%d beads closed · %d commits by %d contributors · %d unlinked commits\n\n",
+		rn.Stats.BeadsClosed, rn.Stats.Commits, rn.Stats.Contributors, rn.Stats.UnlinkedCommits))
+
+	for _, section := range rn.Sections {
+		sb.WriteString(fmt.Sprintf("## %s\n\n", section.Title))
+		for _, e := range section.Entries {
+			sb.WriteString(fmt.Sprintf("- **%s** %s", e.ID, e.Title))
+			if len(e.Commits) > 0 {
+				shas := make([]string, len(e.Commits))
+				for i, c := range e.Commits {
+					shas[i] = "`" + c.ShortSHA + "`"
+				}
+				sb.WriteString(" (" + strings.Join(shas, ", ") + ")")
+			}
+			if len(e.Contributors) > 0 {
+				sb.WriteString(" — " + strings.Join(e.Contributors, ", "))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(rn.Contributors) > 0 {
+		sb.WriteString("## Contributors\n\n")
+		for _, c := range rn.Contributors {
+			sb.WriteString(fmt.Sprintf("- %s (%d commits, %d beads)\n", c.Name, c.Commits, c.Beads))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(rn.UnlinkedCommits) > 0 {
+		sb.WriteString("## Commits Without a Bead\n\n")
+		for _, c := range rn.UnlinkedCommits {
+			sb.WriteString(fmt.Sprintf("- `%s` %s — %s", c.ShortSHA, c.Subject, c.Author))
+			if len(c.ProbableBeads) > 0 {
+				sb.WriteString(" (probably " + strings.Join(c.ProbableBeads, ", ") + ")")
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(rn.BeadsWithoutCommits) > 0 {
+		sb.WriteString("## Beads Closed Without Commits\n\n")
+		for _, e := range rn.BeadsWithoutCommits {
+			sb.WriteString(fmt.Sprintf("- **%s** %s\n", e.ID, e.Title))
+		}
+		sb.WriteString("\n")
+	}
+
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
+
+// RenderReleaseNotesChangelog renders one Keep a Changelog version block.
+// Features and epics are Added, bugs are Fixed, everything else Changed.
+func RenderReleaseNotesChangelog(rn *ReleaseNotes) string {
+	var sb strings.Builder
+	if rn.ToRef == "" || rn.ToRef == "HEAD" {
+		sb.WriteString("## [Unreleased]\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("## [%s] - %s\n", strings.TrimPrefix(rn.ToRef, "v"), rn.Date.Format("2006-01-02")))
+	}
+
+	var entries []ReleaseNoteEntry
+	seen := make(map[string]bool)
+	for _, section := range rn.Sections {
+		for _, e := range section.Entries {
+			if !seen[e.ID] {
+				seen[e.ID] = true
+				entries = append(entries, e)
+			}
+		}
+	}
+	sortReleaseEntries(entries)
+
+	for _, heading := range []string{"Added", "Changed", "Fixed"} {
+		var lines []string
+		for _, e := range entries {
+			if changelogHeading(e.Type) == heading {
+				lines = append(lines, fmt.Sprintf("- %s (%s)", e.Title, e.ID))
+			}
+		}
+		if len(lines) > 0 {
+			sb.WriteString("\n### " + heading + "\n\n")
+			sb.WriteString(strings.Join(lines, "\n") + "\n")
+		}
+	}
+	return sb.String()
+}
+
+func changelogHeading(issueType string) string {
+	switch model.IssueType(issueType) {
+	case model.TypeFeature, model.TypeEpic:
+		return "Added"
+	case model.TypeBug:
+		return "Fixed"
+	default:
+		return "Changed"
+	}
+}
@@ -0,0 +1,118 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func releaseNotesFixture() (*analysis.SnapshotDiff, []ReleaseCommit, *correlation.HistoryReport, *correlation.OrphanReport) {
+	diff := &analysis.SnapshotDiff{
+		ClosedIssues: []model.Issue{
+			{ID: "bv-2", Title: "Crash on empty input", IssueType: model.TypeBug, Priority: 0, Status: model.StatusClosed, Labels: []string{"api"}},
+			{ID: "bv-1", Title: "Upload retries", IssueType: model.TypeFeature, Priority: 1, Status: model.StatusClosed, Labels: []string{"api", "ux"}},
+			{ID: "bv-9", Title: "Deleted", IssueType: model.TypeTask, Status: model.StatusTombstone},
+		},
+		NewIssues: []model.Issue{
+			{ID: "bv-3", Title: "Tidy docs", IssueType: model.TypeChore, Priority: 3, Status: model.StatusClosed},
+			{ID: "bv-4", Title: "Still open", IssueType: model.TypeFeature, Status: model.StatusOpen},
+		},
+	}
+	commits := []ReleaseCommit{
+		{SHA: "c4", ShortSHA: "c4", Subject: "Refactor helpers", Author: "Bob"},
+		{SHA: "c3", ShortSHA: "c3", Subject: "Fix crash", Author: "Bob"},
+		{SHA: "c2", ShortSHA: "c2", Subject: "Close beads", Author: "Alice"},
+		{SHA: "c1", ShortSHA: "c1", Subject: "Add retries", Author: "Alice"},
+	}
+	report := &correlation.HistoryReport{
+		Histories: map[string]correlation.BeadHistory{
+			"bv-1": {Commits: []correlation.CorrelatedCommit{{SHA: "c0"}, {SHA: "c1"}}, Events: []correlation.BeadEvent{{CommitSHA: "c2"}}},
+			"bv-2": {Commits: []correlation.CorrelatedCommit{{SHA: "c3"}}},
+		},
+		CommitIndex: correlation.CommitIndex{"c0": {"bv-1"}, "c1": {"bv-1"}, "c3": {"bv-2"}},
+	}
+	orphans := &correlation.OrphanReport{Candidates: []correlation.OrphanCandidate{
+		{SHA: "c4", ProbableBeads: []correlation.ProbableBead{{BeadID: "bv-2"}}},
+	}}
+	return diff, commits, report, orphans
+}
+
+func TestBuildReleaseNotes(t *testing.T) {
+	diff, commits, report, orphans := releaseNotesFixture()
+	rn := BuildReleaseNotes(diff, commits, report, orphans, ReleaseNotesOptions{FromRef: "v1.0.0", ToRef: "v1.1.0"})
+
+	want := ReleaseNotesStats{BeadsClosed: 3, Commits: 4, LinkedCommits: 3, UnlinkedCommits: 1, BeadsWithoutCommits: 1, Contributors: 2}
+	if rn.Stats != want {
+		t.Fatalf("stats = %+v, want %+v", rn.Stats, want)
+	}
+	var titles []string
+	for _, s := range rn.Sections {
+		titles = append(titles, s.Title)
+	}
+	if strings.Join(titles, ",") != "Features,Bug Fixes,Tasks & Chores" {
+		t.Fatalf("sections = %v", titles)
+	}
+	// Commits outside the range (c0) are not listed.
+	if e := rn.Sections[0].Entries[0]; e.ID != "bv-1" || len(e.Commits) != 1 || e.Commits[0].SHA != "c1" || e.Contributors[0] != "Alice" {
+		t.Fatalf("feature entry = %+v", e)
+	}
+	if u := rn.UnlinkedCommits; len(u) != 1 || u[0].SHA != "c4" || len(u[0].ProbableBeads) != 1 || u[0].ProbableBeads[0] != "bv-2" {
+		t.Fatalf("unlinked = %+v", u)
+	}
+	if rn.BeadsWithoutCommits[0].ID != "bv-3" {
+		t.Fatalf("without commits = %+v", rn.BeadsWithoutCommits)
+	}
+	if c := rn.Contributors[0]; c.Name != "Alice" || c.Commits != 2 || c.Beads != 1 {
+		t.Fatalf("contributors = %+v", rn.Contributors)
+	}
+}
+
+func TestBuildReleaseNotes_GroupByLabel(t *testing.T) {
+	diff, commits, report, orphans := releaseNotesFixture()
+	rn := BuildReleaseNotes(diff, commits, report, orphans, ReleaseNotesOptions{GroupBy: ReleaseNotesGroupLabel})
+
+	if len(rn.Sections) != 3 {
+		t.Fatalf("sections = %+v", rn.Sections)
+	}
+	api, ux, unlabeled := rn.Sections[0], rn.Sections[1], rn.Sections[2]
+	if api.Key != "api" || len(api.Entries) != 2 || api.Entries[0].ID != "bv-2" {
+		t.Fatalf("api = %+v", api)
+	}
+	if ux.Key != "ux" || len(ux.Entries) != 1 || unlabeled.Title != "Unlabeled" || unlabeled.Entries[0].ID != "bv-3" {
+		t.Fatalf("ux/unlabeled = %+v / %+v", ux, unlabeled)
+	}
+}
+
+func TestRenderReleaseNotes(t *testing.T) {
+	diff, commits, report, orphans := releaseNotesFixture()
+	date := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	rn := BuildReleaseNotes(diff, commits, report, orphans, ReleaseNotesOptions{FromRef: "v1.0.0", ToRef: "v1.1.0", Date: date})
+
+	md := RenderReleaseNotesMarkdown(rn)
+	for _, want := range []string{
+		"# Release Notes: v1.0.0..v1.1.0",
+		"- **bv-1** Upload retries (`c1`) — Alice",
+		"## Commits Without a Bead",
+		"- `c4` Refactor helpers — Bob (probably bv-2)",
+		"## Beads Closed Without Commits\n\n- **bv-3** Tidy docs",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+
+	changelog := RenderReleaseNotesChangelog(rn)
+	want := "## [1.1.0] - 2024-06-01\n\n### Added\n\n- Upload retries (bv-1)\n\n### Changed\n\n- Tidy docs (bv-3)\n\n### Fixed\n\n- Crash on empty input (bv-2)\n"
+	if changelog != want {
+		t.Errorf("changelog =\n%s\nwant\n%s", changelog, want)
+	}
+
+	rn.ToRef = "HEAD"
+	if !strings.HasPrefix(RenderReleaseNotesChangelog(rn), "## [Unreleased]\n") {
+		t.Error("HEAD should render as Unreleased")
+	}
+}
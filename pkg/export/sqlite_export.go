@@ -655,6 +655,7 @@ func (e *SQLiteExporter) GetExportedIssues() []ExportIssue {
 			CreatedAt:   issue.CreatedAt,
 			UpdatedAt:   issue.UpdatedAt,
 			ClosedAt:    issue.ClosedAt,
+			Custom:      issue.Custom,
 		}
 
 		if m := e.Metrics; m != nil {
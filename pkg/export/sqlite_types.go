@@ -26,6 +26,9 @@ type ExportIssue struct {
 	UpdatedAt   time.Time       `json:"updated_at"`
 	ClosedAt    *time.Time      `json:"closed_at,omitempty"`
 
+	// Custom fields declared in .bv/schema.yaml
+	Custom map[string]any `json:"custom,omitempty"`
+
 	// Computed graph metrics
 	PageRank       float64  `json:"pagerank"`
 	Betweenness    float64  `json:"betweenness,omitempty"`
@@ -0,0 +1,21 @@
+//go:build !windows
+
+package instance
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+func lockFileExclusive(f *os.File) error {
+	return unix.Flock(int(f.Fd()), unix.LOCK_EX)
+}
+
+func lockFileShared(f *os.File) error {
+	return unix.Flock(int(f.Fd()), unix.LOCK_SH)
+}
+
+func unlockFile(f *os.File) error {
+	return unix.Flock(int(f.Fd()), unix.LOCK_UN)
+}
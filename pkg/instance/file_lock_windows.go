@@ -0,0 +1,27 @@
+//go:build windows
+
+package instance
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+func lockFileExclusive(f *os.File) error {
+	handle := windows.Handle(f.Fd())
+	var ol windows.Overlapped
+	return windows.LockFileEx(handle, windows.LOCKFILE_EXCLUSIVE_LOCK, 0, 1, 0, &ol)
+}
+
+func lockFileShared(f *os.File) error {
+	handle := windows.Handle(f.Fd())
+	var ol windows.Overlapped
+	return windows.LockFileEx(handle, 0, 0, 1, 0, &ol)
+}
+
+func unlockFile(f *os.File) error {
+	handle := windows.Handle(f.Fd())
+	var ol windows.Overlapped
+	return windows.UnlockFileEx(handle, 0, 1, 0, &ol)
+}
@@ -130,6 +130,33 @@ func (l *Lock) HolderPID() int {
 	return l.pid
 }
 
+// HolderAlive reports whether the process holding the lock is still running.
+func (l *Lock) HolderAlive() bool {
+	return l.isFirst || isProcessAlive(l.pid)
+}
+
+// TryPromote takes over the lock if its holder has exited (cleanly, removing
+// the lock file, or by crashing), making this the primary instance. It
+// reports whether this instance is now the primary.
+func (l *Lock) TryPromote() bool {
+	if l.isFirst {
+		return true
+	}
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0644)
+	if err == nil {
+		l.lockFile = file
+		l.isFirst = true
+		l.pid = os.Getpid()
+		if err := l.writeLockInfo(); err != nil {
+			l.Release()
+			return false
+		}
+		return true
+	}
+	l.checkStale()
+	return l.isFirst
+}
+
 // checkStale checks if the existing lock is stale (held by a dead process)
 // and takes it over if so. Uses atomic rename to avoid TOCTOU race conditions.
 func (l *Lock) checkStale() {
@@ -0,0 +1,276 @@
+package instance
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/watcher"
+)
+
+const (
+	// SharedDirName is the directory under the project's .bv/ where the
+	// primary instance publishes computed analysis for other instances.
+	SharedDirName = "shared"
+
+	sharedManifestName    = "manifest.json"
+	sharedLockName        = ".lock"
+	sharedManifestVersion = 1
+	// maxSharedEntries bounds how many published snapshots are kept. Instances
+	// briefly disagree on the data version while a change propagates, so a
+	// few recent entries are retained rather than only the latest.
+	maxSharedEntries = 4
+)
+
+// holderCheckInterval is how often Wait re-checks that the publisher is alive.
+var holderCheckInterval = time.Second
+
+var (
+	// ErrSharedMiss is returned when no published entry matches the request.
+	ErrSharedMiss = errors.New("shared analysis miss")
+	// ErrHolderGone is returned by Wait when the publishing instance exits
+	// before publishing the requested data version.
+	ErrHolderGone = errors.New("shared analysis holder is gone")
+)
+
+// SharedEntry describes one published analysis payload.
+type SharedEntry struct {
+	DataHash    string    `json:"data_hash"`
+	ConfigHash  string    `json:"config_hash"`
+	File        string    `json:"file"`
+	Size        int64     `json:"size"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// SharedManifest indexes the published entries, newest first.
+type SharedManifest struct {
+	Version   int           `json:"version"`
+	HolderPID int           `json:"holder_pid"`
+	Hostname  string        `json:"hostname,omitempty"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	Entries   []SharedEntry `json:"entries"`
+}
+
+// SharedAnalysis is a directory of analysis snapshots shared between bv
+// instances running on the same project. The primary instance (the one
+// holding the instance Lock) publishes; other instances attach read-only,
+// look up entries by data and config hash, and Wait for the primary to
+// publish a version they need instead of recomputing it themselves.
+//
+// Readers and the writer coordinate through an advisory file lock, so a
+// reader never observes a manifest that references a half-written payload.
+// The payload format is opaque to this package.
+type SharedAnalysis struct {
+	dir string
+}
+
+// SharedAnalysisDir returns <project>/.bv/shared for a .beads directory.
+func SharedAnalysisDir(beadsDir string) string {
+	return filepath.Join(filepath.Dir(beadsDir), ".bv", SharedDirName)
+}
+
+// OpenSharedAnalysis opens (creating if needed) the shared analysis directory
+// for beadsDir.
+func OpenSharedAnalysis(beadsDir string) (*SharedAnalysis, error) {
+	dir := SharedAnalysisDir(beadsDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating shared analysis dir: %w", err)
+	}
+	return &SharedAnalysis{dir: dir}, nil
+}
+
+// Dir returns the shared analysis directory.
+func (s *SharedAnalysis) Dir() string {
+	return s.dir
+}
+
+func (s *SharedAnalysis) manifestPath() string {
+	return filepath.Join(s.dir, sharedManifestName)
+}
+
+// withLock runs fn while holding the directory lock, shared for readers and
+// exclusive for the publisher.
+func (s *SharedAnalysis) withLock(exclusive bool, fn func() error) error {
+	f, err := os.OpenFile(filepath.Join(s.dir, sharedLockName), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	lock := lockFileShared
+	if exclusive {
+		lock = lockFileExclusive
+	}
+	if err := lock(f); err != nil {
+		return err
+	}
+	defer func() { _ = unlockFile(f) }()
+	return fn()
+}
+
+// readManifest reads the manifest without locking. A missing or unreadable
+// manifest is treated as empty.
+func (s *SharedAnalysis) readManifest() SharedManifest {
+	data, err := os.ReadFile(s.manifestPath())
+	if err != nil {
+		return SharedManifest{Version: sharedManifestVersion}
+	}
+	var m SharedManifest
+	if err := json.Unmarshal(data, &m); err != nil || m.Version != sharedManifestVersion {
+		return SharedManifest{Version: sharedManifestVersion}
+	}
+	return m
+}
+
+// Manifest returns the current manifest.
+func (s *SharedAnalysis) Manifest() (SharedManifest, error) {
+	var m SharedManifest
+	err := s.withLock(false, func() error {
+		m = s.readManifest()
+		return nil
+	})
+	return m, err
+}
+
+func sharedEntryFile(dataHash, configHash string) string {
+	sum := sha256.Sum256([]byte(dataHash + "|" + configHash))
+	return "analysis-" + hex.EncodeToString(sum[:8]) + ".bin"
+}
+
+// writeFileAtomic writes data to path via a temp file and rename.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op after a successful rename
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// Publish stores payload for (dataHash, configHash) and records this process
+// as the holder. Older entries beyond the retention limit are removed.
+func (s *SharedAnalysis) Publish(dataHash, configHash string, payload []byte) error {
+	return s.withLock(true, func() error {
+		file := sharedEntryFile(dataHash, configHash)
+		if err := writeFileAtomic(filepath.Join(s.dir, file), payload); err != nil {
+			return err
+		}
+
+		m := s.readManifest()
+		hostname, _ := os.Hostname()
+		now := time.Now().UTC()
+		m.HolderPID = os.Getpid()
+		m.Hostname = hostname
+		m.UpdatedAt = now
+
+		entries := []SharedEntry{{
+			DataHash:    dataHash,
+			ConfigHash:  configHash,
+			File:        file,
+			Size:        int64(len(payload)),
+			PublishedAt: now,
+		}}
+		for _, e := range m.Entries {
+			if e.File == file {
+				continue
+			}
+			if len(entries) >= maxSharedEntries {
+				_ = os.Remove(filepath.Join(s.dir, e.File))
+				continue
+			}
+			entries = append(entries, e)
+		}
+		m.Entries = entries
+
+		data, err := json.MarshalIndent(m, "", "  ")
+		if err != nil {
+			return err
+		}
+		return writeFileAtomic(s.manifestPath(), data)
+	})
+}
+
+// holderOnThisHost reports whether holderPID can be checked locally: false
+// only when the manifest records it as published from a different host.
+func (s *SharedAnalysis) holderOnThisHost(holderPID int) bool {
+	m, err := s.Manifest()
+	if err != nil || m.HolderPID != holderPID || m.Hostname == "" {
+		return true
+	}
+	hostname, _ := os.Hostname()
+	return m.Hostname == hostname
+}
+
+// Lookup returns the payload published for (dataHash, configHash), or
+// ErrSharedMiss if there is none.
+func (s *SharedAnalysis) Lookup(dataHash, configHash string) ([]byte, error) {
+	var payload []byte
+	err := s.withLock(false, func() error {
+		for _, e := range s.readManifest().Entries {
+			if e.DataHash != dataHash || e.ConfigHash != configHash {
+				continue
+			}
+			data, err := os.ReadFile(filepath.Join(s.dir, e.File))
+			if err != nil || int64(len(data)) != e.Size {
+				return ErrSharedMiss
+			}
+			payload = data
+			return nil
+		}
+		return ErrSharedMiss
+	})
+	return payload, err
+}
+
+// Wait blocks until the instance with holderPID publishes (dataHash,
+// configHash), returning the payload. It returns ErrHolderGone as soon as the
+// holder is no longer running, so callers can fall back to computing locally
+// (and take over as publisher via Lock.TryPromote). A holder that last
+// published from another host cannot be checked by PID; the wait is then
+// bounded by ctx alone.
+func (s *SharedAnalysis) Wait(ctx context.Context, holderPID int, dataHash, configHash string) ([]byte, error) {
+	w, err := watcher.NewWatcher(s.manifestPath(), watcher.WithDebounceDuration(watcher.SourceDebounce))
+	if err != nil {
+		return nil, err
+	}
+	if err := w.Start(); err != nil {
+		return nil, err
+	}
+	defer w.Stop()
+
+	ticker := time.NewTicker(holderCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		// Check after the watcher is running so a publish between the lookup
+		// and the first notification is not missed.
+		if payload, err := s.Lookup(dataHash, configHash); err == nil {
+			return payload, nil
+		}
+		if s.holderOnThisHost(holderPID) && !isProcessAlive(holderPID) {
+			return nil, ErrHolderGone
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-w.Changed():
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package instance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestSharedAnalysis(t *testing.T) *SharedAnalysis {
+	t.Helper()
+	beadsDir := filepath.Join(t.TempDir(), ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	s, err := OpenSharedAnalysis(beadsDir)
+	if err != nil {
+		t.Fatalf("OpenSharedAnalysis: %v", err)
+	}
+	if s.Dir() != filepath.Join(filepath.Dir(beadsDir), ".bv", SharedDirName) {
+		t.Fatalf("unexpected dir %s", s.Dir())
+	}
+	return s
+}
+
+func TestSharedAnalysis_PublishAndLookup(t *testing.T) {
+	s := newTestSharedAnalysis(t)
+
+	if _, err := s.Lookup("d1", "c1"); !errors.Is(err, ErrSharedMiss) {
+		t.Fatalf("expected miss before publish, got %v", err)
+	}
+	if err := s.Publish("d1", "c1", []byte("payload-1")); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	got, err := s.Lookup("d1", "c1")
+	if err != nil || string(got) != "payload-1" {
+		t.Fatalf("Lookup = %q, %v", got, err)
+	}
+	if _, err := s.Lookup("d1", "other-config"); !errors.Is(err, ErrSharedMiss) {
+		t.Errorf("expected miss for another config, got %v", err)
+	}
+
+	m, err := s.Manifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.HolderPID != os.Getpid() || len(m.Entries) != 1 {
+		t.Errorf("unexpected manifest %+v", m)
+	}
+}
+
+func TestSharedAnalysis_PrunesOldEntries(t *testing.T) {
+	s := newTestSharedAnalysis(t)
+	hashes := []string{"d1", "d2", "d3", "d4", "d5", "d6"}
+	for _, h := range hashes {
+		if err := s.Publish(h, "c", []byte(h)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Republishing an existing version moves it to the front without duplicating it.
+	if err := s.Publish("d6", "c", []byte("d6")); err != nil {
+		t.Fatal(err)
+	}
+
+	m, _ := s.Manifest()
+	if len(m.Entries) != maxSharedEntries || m.Entries[0].DataHash != "d6" {
+		t.Fatalf("expected %d entries newest first, got %+v", maxSharedEntries, m.Entries)
+	}
+	if _, err := s.Lookup("d1", "c"); !errors.Is(err, ErrSharedMiss) {
+		t.Error("oldest entry should be pruned")
+	}
+	if _, err := os.Stat(filepath.Join(s.Dir(), sharedEntryFile("d1", "c"))); !os.IsNotExist(err) {
+		t.Error("pruned payload file should be removed")
+	}
+}
+
+func TestSharedAnalysis_WaitReceivesPublish(t *testing.T) {
+	s := newTestSharedAnalysis(t)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_ = s.Publish("d2", "c", []byte("fresh"))
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	got, err := s.Wait(ctx, os.Getpid(), "d2", "c")
+	if err != nil || string(got) != "fresh" {
+		t.Fatalf("Wait = %q, %v", got, err)
+	}
+}
+
+func TestSharedAnalysis_WaitHolderGone(t *testing.T) {
+	s := newTestSharedAnalysis(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := s.Wait(ctx, 99999999, "d", "c"); !errors.Is(err, ErrHolderGone) {
+		t.Fatalf("expected ErrHolderGone for a dead holder, got %v", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := s.Wait(ctx, os.Getpid(), "d", "c"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline with a live holder, got %v", err)
+	}
+}
+
+func TestSharedAnalysis_WaitIgnoresPIDOfRemoteHolder(t *testing.T) {
+	s := newTestSharedAnalysis(t)
+	if err := s.Publish("d", "c", []byte("old")); err != nil {
+		t.Fatal(err)
+	}
+	m, err := s.Manifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.HolderPID = 99999999
+	m.Hostname = "some-other-host"
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(s.manifestPath(), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The PID means nothing on this host, so it must not end the wait.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := s.Wait(ctx, 99999999, "d2", "c"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline with a remote holder, got %v", err)
+	}
+}
+
+func TestLock_TryPromote(t *testing.T) {
+	tmpDir := t.TempDir()
+	primary, err := NewLock(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondary, err := NewLock(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer secondary.Release()
+
+	if secondary.TryPromote() {
+		t.Fatal("secondary must not promote while the holder is alive")
+	}
+	if !secondary.HolderAlive() {
+		t.Error("holder should be reported alive")
+	}
+
+	// Simulate the primary dying without releasing: point the lock at a dead PID.
+	primary.lockFile.Close()
+	primary.lockFile = nil
+	if err := os.WriteFile(primary.Path(), []byte(`{"pid":99999999}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	secondary.pid = 99999999
+	if secondary.HolderAlive() {
+		t.Error("dead holder should not be reported alive")
+	}
+	if !secondary.TryPromote() {
+		t.Fatal("secondary should take over after the holder died")
+	}
+}
+
+func TestLock_TryPromoteAfterCleanRelease(t *testing.T) {
+	tmpDir := t.TempDir()
+	primary, err := NewLock(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondary, err := NewLock(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer secondary.Release()
+
+	primary.Release()
+	if !secondary.TryPromote() {
+		t.Fatal("secondary should claim a lock released by the primary")
+	}
+	if secondary.HolderPID() != os.Getpid() {
+		t.Errorf("expected our PID after promotion, got %d", secondary.HolderPID())
+	}
+}
@@ -142,10 +142,76 @@ func (g *GitLoader) ListRevisions(limit int) ([]RevisionInfo, error) {
 	return revisions, nil
 }
 
+// ListCommitMessages returns the most recent commits on HEAD, newest first,
+// with their author and full message rather than just the subject.
+func (g *GitLoader) ListCommitMessages(limit int) ([]RevisionInfo, error) {
+	args := []string{"log", "--format=%H%x1f%aI%x1f%an%x1f%B%x1e"}
+	if limit > 0 {
+		args = append(args, fmt.Sprintf("-n%d", limit))
+	}
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = g.repoPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing commit messages: %w", err)
+	}
+	return parseCommitMessages(out), nil
+}
+
+// ListCommitMessagesBetween returns the non-merge commits reachable from
+// toRev but not fromRev, newest first, with author and full message. Unlike
+// GetCommitsBetween it is not limited to commits touching the beads files.
+func (g *GitLoader) ListCommitMessagesBetween(fromRev, toRev string) ([]RevisionInfo, error) {
+	fromSHA, err := g.resolveRevision(fromRev)
+	if err != nil {
+		return nil, fmt.Errorf("resolving from revision: %w", err)
+	}
+	toSHA, err := g.resolveRevision(toRev)
+	if err != nil {
+		return nil, fmt.Errorf("resolving to revision: %w", err)
+	}
+
+	cmd := exec.Command("git", "log", "--no-merges", "--format=%H%x1f%aI%x1f%an%x1f%B%x1e",
+		fmt.Sprintf("%s..%s", fromSHA, toSHA))
+	cmd.Dir = g.repoPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing commits between revisions: %w", err)
+	}
+	return parseCommitMessages(out), nil
+}
+
+// parseCommitMessages parses git log records written with the
+// %H%x1f%aI%x1f%an%x1f%B%x1e format.
+func parseCommitMessages(out []byte) []RevisionInfo {
+	var revisions []RevisionInfo
+	for _, record := range strings.Split(string(out), "\x1e") {
+		parts := strings.SplitN(strings.TrimLeft(record, "\n"), "\x1f", 4)
+		if len(parts) != 4 {
+			continue
+		}
+		timestamp, err := time.Parse(time.RFC3339, parts[1])
+		if err != nil {
+			continue // skip revisions with unparseable timestamps
+		}
+		revisions = append(revisions, RevisionInfo{
+			SHA:       parts[0],
+			Timestamp: timestamp,
+			Author:    parts[2],
+			Message:   strings.TrimSpace(parts[3]),
+		})
+	}
+	return revisions
+}
+
 // RevisionInfo describes a git commit
 type RevisionInfo struct {
 	SHA       string    `json:"sha"`
 	Timestamp time.Time `json:"timestamp"`
+	Author    string    `json:"author,omitempty"`
 	Message   string    `json:"message"`
 }
 
@@ -331,6 +331,63 @@ func TestGitLoader_ListRevisions(t *testing.T) {
 	}
 }
 
+func TestGitLoader_ListCommitMessages(t *testing.T) {
+	repoDir, cleanup := setupTestGitRepo(t)
+	defer cleanup()
+
+	if err := os.WriteFile(filepath.Join(repoDir, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repoDir, "add", ".")
+	runGit(t, repoDir, "commit", "-m", "Add entry point", "-m", "Refs ISSUE-1.\nSecond body line.")
+
+	loader := NewGitLoader(repoDir)
+	commits, err := loader.ListCommitMessages(0)
+	if err != nil {
+		t.Fatalf("ListCommitMessages failed: %v", err)
+	}
+	if len(commits) != 3 {
+		t.Fatalf("expected 3 commits (including non-beads), got %d", len(commits))
+	}
+	want := "Add entry point\n\nRefs ISSUE-1.\nSecond body line."
+	if commits[0].Message != want {
+		t.Errorf("expected full message %q, got %q", want, commits[0].Message)
+	}
+	if commits[0].Author != "Test User" || commits[2].Message != "Initial commit" {
+		t.Errorf("unexpected commits: %+v", commits)
+	}
+
+	limited, err := loader.ListCommitMessages(1)
+	if err != nil || len(limited) != 1 {
+		t.Fatalf("expected 1 commit with limit, got %d (%v)", len(limited), err)
+	}
+}
+
+func TestGitLoader_ListCommitMessagesBetween(t *testing.T) {
+	repoDir, cleanup := setupTestGitRepo(t)
+	defer cleanup()
+
+	runGit(t, repoDir, "tag", "v1")
+	if err := os.WriteFile(filepath.Join(repoDir, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repoDir, "add", ".")
+	runGit(t, repoDir, "commit", "-m", "Add entry point", "-m", "Refs ISSUE-1.")
+
+	loader := NewGitLoader(repoDir)
+	commits, err := loader.ListCommitMessagesBetween("v1", "HEAD")
+	if err != nil {
+		t.Fatalf("ListCommitMessagesBetween failed: %v", err)
+	}
+	if len(commits) != 1 || commits[0].Message != "Add entry point\n\nRefs ISSUE-1." {
+		t.Fatalf("expected only the non-beads commit after v1, got %+v", commits)
+	}
+
+	if _, err := loader.ListCommitMessagesBetween("no-such-ref", "HEAD"); err == nil {
+		t.Error("expected error for unknown revision")
+	}
+}
+
 func TestGitLoader_HasBeadsAtRevision(t *testing.T) {
 	repoDir, cleanup := setupTestGitRepo(t)
 	defer cleanup()
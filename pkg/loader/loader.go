@@ -229,6 +229,10 @@ type ParseOptions struct {
 	// IssueFilter optionally filters parsed issues. Return true to include.
 	// When nil, all valid issues are included.
 	IssueFilter func(*model.Issue) bool
+
+	// Schema optionally maps aliased and custom fields (see .bv/schema.yaml).
+	// The file-based loaders fill it from the project when left nil.
+	Schema *Schema
 }
 
 // LoadIssuesFromFileWithOptions reads issues from a file with custom options.
@@ -244,7 +248,7 @@ func LoadIssuesFromFileWithOptions(path string, opts ParseOptions) ([]model.Issu
 	}
 	defer file.Close()
 
-	return ParseIssuesWithOptions(file, opts)
+	return ParseIssuesWithOptions(file, withProjectSchema(path, opts))
 }
 
 // LoadIssuesFromFileWithOptionsPooled reads issues from a file with pooling enabled.
@@ -261,7 +265,7 @@ func LoadIssuesFromFileWithOptionsPooled(path string, opts ParseOptions) (Pooled
 	}
 	defer file.Close()
 
-	return ParseIssuesWithOptionsPooled(file, opts)
+	return ParseIssuesWithOptionsPooled(file, withProjectSchema(path, opts))
 }
 
 // LoadIssuesFromFile reads issues directly from a specific JSONL file path.
@@ -332,16 +336,7 @@ func parseIssuesWithOptions(r io.Reader, opts ParseOptions, usePool bool) ([]mod
 	reader := bufio.NewReaderSize(r, maxCapacity)
 
 	// Default warning handler prints to stderr (suppressed in robot mode).
-	warn := opts.WarningHandler
-	if warn == nil {
-		if os.Getenv("BV_ROBOT") == "1" {
-			warn = func(string) {}
-		} else {
-			warn = func(msg string) {
-				fmt.Fprintf(os.Stderr, "Warning: %s\n", msg)
-			}
-		}
-	}
+	warn := resolveWarningHandler(opts.WarningHandler)
 
 	lineNum := 0
 	for {
@@ -387,6 +382,12 @@ func parseIssuesWithOptions(r io.Reader, opts ParseOptions, usePool bool) ([]mod
 			line = stripBOM(line)
 		}
 
+		line, custom, err := opts.Schema.Remap(line, warn)
+		if err != nil {
+			warn(fmt.Sprintf("skipping malformed JSON on line %d: %v", lineNum, err))
+			continue
+		}
+
 		if usePool {
 			issue := GetIssue()
 			if err := json.Unmarshal(line, issue); err != nil {
@@ -395,6 +396,7 @@ func parseIssuesWithOptions(r io.Reader, opts ParseOptions, usePool bool) ([]mod
 				warn(fmt.Sprintf("skipping malformed JSON on line %d: %v", lineNum, err))
 				continue
 			}
+			mergeCustom(issue, custom)
 
 			issue.Status = normalizeIssueStatus(issue.Status)
 
@@ -420,6 +422,7 @@ func parseIssuesWithOptions(r io.Reader, opts ParseOptions, usePool bool) ([]mod
 				warn(fmt.Sprintf("skipping malformed JSON on line %d: %v", lineNum, err))
 				continue
 			}
+			mergeCustom(&issue, custom)
 
 			issue.Status = normalizeIssueStatus(issue.Status)
 
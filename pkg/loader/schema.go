@@ -0,0 +1,430 @@
+package loader
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	json "github.com/goccy/go-json"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// SchemaFilename is the name of the field-mapping file inside .bv/.
+const SchemaFilename = "schema.yaml"
+
+// CustomFieldType is the declared type of a custom field.
+type CustomFieldType string
+
+const (
+	CustomString CustomFieldType = "string"
+	CustomNumber CustomFieldType = "number"
+	CustomDate   CustomFieldType = "date"
+	CustomEnum   CustomFieldType = "enum"
+)
+
+// IsValid returns true if the type is one of the supported custom field types.
+func (t CustomFieldType) IsValid() bool {
+	switch t {
+	case CustomString, CustomNumber, CustomDate, CustomEnum:
+		return true
+	}
+	return false
+}
+
+// CustomField declares a field outside the beads format that should be kept
+// on Issue.Custom instead of being dropped by the decoder.
+type CustomField struct {
+	Name   string          `yaml:"name" json:"name"`
+	Type   CustomFieldType `yaml:"type" json:"type"`
+	From   string          `yaml:"from,omitempty" json:"from,omitempty"`     // Record key, when it differs from Name
+	Values []string        `yaml:"values,omitempty" json:"values,omitempty"` // Allowed values for enums, in display order
+}
+
+// sourceKey returns the record key the field is read from.
+func (f CustomField) sourceKey() string {
+	if f.From != "" {
+		return f.From
+	}
+	return f.Name
+}
+
+// Schema maps a fork of the beads JSONL format onto model.Issue. It is
+// loaded from .bv/schema.yaml:
+//
+//	aliases:
+//	  summary: title      # record key -> beads field
+//	  owner: assignee
+//	fields:
+//	  - name: severity
+//	    type: enum
+//	    values: [low, medium, high, critical]
+//	  - name: points
+//	    type: number
+//	    from: story_points
+//	swimlane: severity    # custom field used for the board swimlane
+type Schema struct {
+	Aliases  map[string]string `yaml:"aliases,omitempty" json:"aliases,omitempty"`
+	Fields   []CustomField     `yaml:"fields,omitempty" json:"fields,omitempty"`
+	Swimlane string            `yaml:"swimlane,omitempty" json:"swimlane,omitempty"`
+
+	// keys are the quoted record keys the schema reacts to, used to skip
+	// records that need no remapping without decoding them twice.
+	keys [][]byte
+}
+
+// SchemaPath returns the schema file location for a project.
+func SchemaPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", SchemaFilename)
+}
+
+// LoadSchema loads .bv/schema.yaml from projectDir.
+// Returns nil without error if the file doesn't exist.
+func LoadSchema(projectDir string) (*Schema, error) {
+	data, err := os.ReadFile(SchemaPath(projectDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading schema: %w", err)
+	}
+	return ParseSchema(data)
+}
+
+// ParseSchema parses and validates schema YAML.
+func ParseSchema(data []byte) (*Schema, error) {
+	var s Schema
+	if err := yaml.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing schema: %w", err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	s.keys = s.quotedKeys()
+	return &s, nil
+}
+
+// ProjectSchemaForJSONL loads the schema for the project owning a beads
+// JSONL file (<project>/.beads/beads.jsonl -> <project>/.bv/schema.yaml).
+func ProjectSchemaForJSONL(jsonlPath string) (*Schema, error) {
+	abs, err := filepath.Abs(jsonlPath)
+	if err != nil {
+		abs = jsonlPath
+	}
+	return LoadSchema(filepath.Dir(filepath.Dir(abs)))
+}
+
+// Validate checks aliases and custom field declarations.
+func (s *Schema) Validate() error {
+	known := issueFieldKeys()
+	for from, to := range s.Aliases {
+		if from == "" || to == "" {
+			return fmt.Errorf("alias %q -> %q: both sides must be non-empty", from, to)
+		}
+		if !known[to] {
+			return fmt.Errorf("alias %q -> %q: target is not a beads field", from, to)
+		}
+		if known[from] {
+			return fmt.Errorf("alias %q -> %q: source is already a beads field", from, to)
+		}
+	}
+
+	seen := make(map[string]bool, len(s.Fields))
+	for _, f := range s.Fields {
+		if f.Name == "" {
+			return fmt.Errorf("custom field without a name")
+		}
+		if seen[f.Name] {
+			return fmt.Errorf("custom field %q declared twice", f.Name)
+		}
+		seen[f.Name] = true
+		if !f.Type.IsValid() {
+			return fmt.Errorf("custom field %q: unknown type %q (want string, number, date or enum)", f.Name, f.Type)
+		}
+		if f.Type == CustomEnum && len(f.Values) == 0 {
+			return fmt.Errorf("custom field %q: enum needs at least one value", f.Name)
+		}
+		if known[f.sourceKey()] {
+			return fmt.Errorf("custom field %q: key %q is already a beads field", f.Name, f.sourceKey())
+		}
+		if _, aliased := s.Aliases[f.sourceKey()]; aliased {
+			return fmt.Errorf("custom field %q: key %q is also an alias", f.Name, f.sourceKey())
+		}
+	}
+
+	if s.Swimlane != "" {
+		f, ok := s.Field(s.Swimlane)
+		if !ok {
+			return fmt.Errorf("swimlane %q is not a declared custom field", s.Swimlane)
+		}
+		if f.Type != CustomEnum {
+			return fmt.Errorf("swimlane %q must be an enum field", s.Swimlane)
+		}
+	}
+	return nil
+}
+
+// Field returns the declaration of a custom field by name.
+func (s *Schema) Field(name string) (CustomField, bool) {
+	if s == nil {
+		return CustomField{}, false
+	}
+	for _, f := range s.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return CustomField{}, false
+}
+
+// EnumValues maps each enum field to its declared values, in order.
+func (s *Schema) EnumValues() map[string][]string {
+	if s == nil {
+		return nil
+	}
+	enums := make(map[string][]string)
+	for _, f := range s.Fields {
+		if f.Type == CustomEnum {
+			enums[f.Name] = f.Values
+		}
+	}
+	return enums
+}
+
+// SwimlaneField returns the enum field used for the board swimlane: the
+// configured one, otherwise the first declared enum.
+func (s *Schema) SwimlaneField() (CustomField, bool) {
+	if s == nil {
+		return CustomField{}, false
+	}
+	if s.Swimlane != "" {
+		return s.Field(s.Swimlane)
+	}
+	for _, f := range s.Fields {
+		if f.Type == CustomEnum {
+			return f, true
+		}
+	}
+	return CustomField{}, false
+}
+
+func (s *Schema) quotedKeys() [][]byte {
+	keys := make([][]byte, 0, len(s.Aliases)+len(s.Fields))
+	for from := range s.Aliases {
+		keys = append(keys, []byte(strconv.Quote(from)))
+	}
+	for _, f := range s.Fields {
+		keys = append(keys, []byte(strconv.Quote(f.sourceKey())))
+	}
+	return keys
+}
+
+// mentionsKeys reports whether line may contain a key the schema handles.
+func (s *Schema) mentionsKeys(line []byte) bool {
+	keys := s.keys
+	if keys == nil {
+		keys = s.quotedKeys()
+	}
+	for _, k := range keys {
+		if bytes.Contains(line, k) {
+			return true
+		}
+	}
+	return false
+}
+
+// Remap rewrites aliased keys in a JSONL record to their beads names and
+// extracts declared custom fields. The returned line is safe to decode into
+// model.Issue. Values that don't fit their declared type are reported via
+// warn and dropped; only malformed JSON is returned as an error.
+func (s *Schema) Remap(line []byte, warn func(string)) ([]byte, map[string]any, error) {
+	if s == nil || !s.mentionsKeys(line) {
+		return line, nil, nil
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(line, &raw); err != nil {
+		return nil, nil, err
+	}
+
+	renamed := false
+	for _, from := range sortedKeys(s.Aliases) {
+		to := s.Aliases[from]
+		v, ok := raw[from]
+		if !ok {
+			continue
+		}
+		if _, exists := raw[to]; !exists {
+			raw[to] = v
+		}
+		delete(raw, from)
+		renamed = true
+	}
+
+	var custom map[string]any
+	for _, f := range s.Fields {
+		v, ok := raw[f.sourceKey()]
+		if !ok {
+			continue
+		}
+		val, set, err := f.decode(v)
+		if err != nil {
+			if warn != nil {
+				warn(fmt.Sprintf("custom field %q: %v", f.Name, err))
+			}
+			continue
+		}
+		if !set {
+			continue
+		}
+		if custom == nil {
+			custom = make(map[string]any, len(s.Fields))
+		}
+		custom[f.Name] = val
+	}
+
+	if renamed {
+		out, err := json.Marshal(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		line = out
+	}
+	return line, custom, nil
+}
+
+// decode converts a raw JSON value to the field's Go representation.
+// set is false for JSON null.
+func (f CustomField) decode(v json.RawMessage) (val any, set bool, err error) {
+	trimmed := bytes.TrimSpace(v)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil, false, nil
+	}
+
+	var str string
+	isString := trimmed[0] == '"'
+	if isString {
+		if err := json.Unmarshal(trimmed, &str); err != nil {
+			return nil, false, err
+		}
+		str = strings.TrimSpace(str)
+	}
+
+	switch f.Type {
+	case CustomNumber:
+		if isString {
+			n, err := strconv.ParseFloat(str, 64)
+			if err != nil {
+				return nil, false, fmt.Errorf("%q is not a number", str)
+			}
+			return n, true, nil
+		}
+		var n float64
+		if err := json.Unmarshal(trimmed, &n); err != nil {
+			return nil, false, fmt.Errorf("%s is not a number", trimmed)
+		}
+		return n, true, nil
+
+	case CustomDate:
+		if !isString {
+			return nil, false, fmt.Errorf("%s is not a date string", trimmed)
+		}
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02"} {
+			if t, err := time.ParseInLocation(layout, str, time.UTC); err == nil {
+				return t, true, nil
+			}
+		}
+		return nil, false, fmt.Errorf("%q is not a date (want RFC 3339 or YYYY-MM-DD)", str)
+
+	case CustomEnum:
+		if !isString {
+			str = string(trimmed)
+		}
+		for _, allowed := range f.Values {
+			if strings.EqualFold(allowed, str) {
+				return allowed, true, nil
+			}
+		}
+		return nil, false, fmt.Errorf("%q is not one of %s", str, strings.Join(f.Values, ", "))
+
+	default: // CustomString
+		if !isString {
+			// Keep scalars such as numbers and booleans in their literal form.
+			if trimmed[0] == '{' || trimmed[0] == '[' {
+				return nil, false, fmt.Errorf("%s is not a scalar", trimmed)
+			}
+			str = string(trimmed)
+		}
+		return strings.Clone(str), true, nil
+	}
+}
+
+// sortedKeys returns alias sources in a stable order so that when two aliases
+// target the same field the winner doesn't depend on map iteration.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var (
+	issueKeysOnce sync.Once
+	issueKeys     map[string]bool
+)
+
+// issueFieldKeys returns the JSON keys model.Issue decodes.
+func issueFieldKeys() map[string]bool {
+	issueKeysOnce.Do(func() {
+		t := reflect.TypeOf(model.Issue{})
+		issueKeys = make(map[string]bool, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+			if name != "" && name != "-" {
+				issueKeys[name] = true
+			}
+		}
+	})
+	return issueKeys
+}
+
+// mergeCustom overlays schema-extracted values onto any custom map the
+// record already carried (e.g. a bv export read back in).
+func mergeCustom(issue *model.Issue, custom map[string]any) {
+	if len(custom) == 0 {
+		return
+	}
+	if issue.Custom == nil {
+		issue.Custom = custom
+		return
+	}
+	for k, v := range custom {
+		issue.Custom[k] = v
+	}
+}
+
+// withProjectSchema fills opts.Schema from the project's .bv/schema.yaml when
+// the caller did not supply one. A broken schema file is reported and ignored
+// so that a typo never prevents issues from loading.
+func withProjectSchema(jsonlPath string, opts ParseOptions) ParseOptions {
+	if opts.Schema != nil {
+		return opts
+	}
+	schema, err := ProjectSchemaForJSONL(jsonlPath)
+	if err != nil {
+		resolveWarningHandler(opts.WarningHandler)(err.Error())
+		return opts
+	}
+	opts.Schema = schema
+	return opts
+}
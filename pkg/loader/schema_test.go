@@ -0,0 +1,198 @@
+package loader_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+const forkSchemaYAML = `
+aliases:
+  summary: title
+  state: status
+  kind: issue_type
+fields:
+  - name: severity
+    type: enum
+    values: [Low, Medium, High]
+  - name: points
+    type: number
+    from: story_points
+  - name: target
+    type: date
+  - name: team
+    type: string
+`
+
+const forkFixture = `{"id":"F-1","summary":"Renamed title","state":"open","kind":"task","severity":"high","story_points":"5","target":"2025-03-01","team":"core"}
+{"id":"F-2","title":"Canonical title","summary":"ignored","status":"closed","issue_type":"bug","severity":"bogus","story_points":3}
+{"id":"F-3","title":"Untouched","status":"open","issue_type":"task"}
+`
+
+func mustParseSchema(t *testing.T, src string) *loader.Schema {
+	t.Helper()
+	schema, err := loader.ParseSchema([]byte(src))
+	if err != nil {
+		t.Fatalf("ParseSchema: %v", err)
+	}
+	return schema
+}
+
+func TestSchema_AliasesAndCustomFields(t *testing.T) {
+	var warnings []string
+	issues, err := loader.ParseIssuesWithOptions(strings.NewReader(forkFixture), loader.ParseOptions{
+		WarningHandler: func(msg string) { warnings = append(warnings, msg) },
+		Schema:         mustParseSchema(t, forkSchemaYAML),
+	})
+	if err != nil {
+		t.Fatalf("ParseIssuesWithOptions: %v", err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("got %d issues, want 3 (warnings: %v)", len(issues), warnings)
+	}
+
+	f1 := issues[0]
+	if f1.Title != "Renamed title" || f1.Status != "open" || f1.IssueType != "task" {
+		t.Errorf("aliases not applied: %+v", f1)
+	}
+	if got := f1.Custom["severity"]; got != "High" {
+		t.Errorf("enum should take declared casing, got %v", got)
+	}
+	if got := f1.Custom["points"]; got != 5.0 {
+		t.Errorf("points from story_points = %v, want 5", got)
+	}
+	if got, ok := f1.Custom["target"].(time.Time); !ok || !got.Equal(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("target = %v, want 2025-03-01", f1.Custom["target"])
+	}
+	if got := f1.Custom["team"]; got != "core" {
+		t.Errorf("team = %v, want core", got)
+	}
+
+	f2 := issues[1]
+	if f2.Title != "Canonical title" {
+		t.Errorf("canonical key must win over alias, got %q", f2.Title)
+	}
+	if _, ok := f2.Custom["severity"]; ok {
+		t.Errorf("invalid enum value should be dropped")
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "severity") {
+		t.Errorf("expected one severity warning, got %v", warnings)
+	}
+
+	if issues[2].Custom != nil {
+		t.Errorf("issue without custom keys should have nil Custom, got %v", issues[2].Custom)
+	}
+}
+
+func TestSchema_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"unknown alias target", "aliases: {summary: headline}", "not a beads field"},
+		{"alias shadows field", "aliases: {title: description}", "already a beads field"},
+		{"bad type", "fields: [{name: x, type: blob}]", "unknown type"},
+		{"enum without values", "fields: [{name: x, type: enum}]", "at least one value"},
+		{"duplicate field", "fields: [{name: x, type: string}, {name: x, type: number}]", "declared twice"},
+		{"field shadows beads key", "fields: [{name: priority, type: number}]", "already a beads field"},
+		{"swimlane not enum", "fields: [{name: x, type: string}]\nswimlane: x", "must be an enum"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loader.ParseSchema([]byte(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseSchema error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchema_SwimlaneField(t *testing.T) {
+	schema := mustParseSchema(t, forkSchemaYAML)
+	lane, ok := schema.SwimlaneField()
+	if !ok || lane.Name != "severity" {
+		t.Errorf("default swimlane should be the first enum, got %+v", lane)
+	}
+
+	var nilSchema *loader.Schema
+	if _, ok := nilSchema.SwimlaneField(); ok {
+		t.Errorf("nil schema should have no swimlane")
+	}
+}
+
+func TestSchema_EnumValues(t *testing.T) {
+	enums := mustParseSchema(t, forkSchemaYAML).EnumValues()
+	if len(enums) != 1 || !reflect.DeepEqual(enums["severity"], []string{"Low", "Medium", "High"}) {
+		t.Errorf("EnumValues() = %v, want only severity in declared order", enums)
+	}
+
+	var nilSchema *loader.Schema
+	if nilSchema.EnumValues() != nil {
+		t.Errorf("nil schema should have no enums")
+	}
+}
+
+func TestSchema_DiscoveredFromProject(t *testing.T) {
+	project := t.TempDir()
+	beadsDir := filepath.Join(project, ".beads")
+	bvDir := filepath.Join(project, ".bv")
+	for _, dir := range []string{beadsDir, bvDir} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	jsonlPath := filepath.Join(beadsDir, "beads.jsonl")
+	if err := os.WriteFile(jsonlPath, []byte(forkFixture), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(loader.SchemaPath(project), []byte(forkSchemaYAML), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := loader.LoadIssuesFromFileWithOptions(jsonlPath, loader.ParseOptions{WarningHandler: func(string) {}})
+	if err != nil {
+		t.Fatalf("LoadIssuesFromFile: %v", err)
+	}
+	if len(issues) != 3 || issues[0].Title != "Renamed title" {
+		t.Fatalf("project schema not applied: %+v", issues)
+	}
+
+	slim, err := loader.LoadIssuesSlimFromFile(jsonlPath, loader.ParseOptions{WarningHandler: func(string) {}})
+	if err != nil {
+		t.Fatalf("LoadIssuesSlimFromFile: %v", err)
+	}
+	if slim.Issues[0].Title != "Renamed title" || slim.Issues[0].Custom["team"] != "core" {
+		t.Errorf("slim load ignored schema: %+v", slim.Issues[0])
+	}
+	full, err := slim.Details.LoadIssueDetails("F-1")
+	if err != nil {
+		t.Fatalf("LoadIssueDetails: %v", err)
+	}
+	if full.Title != "Renamed title" || full.Custom["severity"] != "High" {
+		t.Errorf("detail reload ignored schema: %+v", full)
+	}
+
+	// A broken schema is reported and ignored rather than failing the load.
+	if err := os.WriteFile(loader.SchemaPath(project), []byte("fields: [{name: x, type: blob}]"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var warnings []string
+	issues, err = loader.LoadIssuesFromFileWithOptions(jsonlPath, loader.ParseOptions{
+		WarningHandler: func(msg string) { warnings = append(warnings, msg) },
+	})
+	if err != nil {
+		t.Fatalf("load with broken schema: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Errorf("without schema only canonical records load, got %d", len(issues))
+	}
+	if len(warnings) == 0 || !strings.Contains(warnings[0], "invalid schema") {
+		t.Errorf("expected invalid schema warning, got %v", warnings)
+	}
+}
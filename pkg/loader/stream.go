@@ -0,0 +1,515 @@
+package loader
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// StreamFields selects which issue fields an IssueStream decodes.
+type StreamFields int
+
+const (
+	// StreamFieldsFull decodes every field, matching ParseIssues.
+	StreamFieldsFull StreamFields = iota
+	// StreamFieldsGraph decodes only what graph analysis and triage need.
+	// Long-form text (description, design, acceptance criteria, notes) and
+	// comment bodies are skipped by the decoder and never allocated. Comments
+	// are kept as stubs (ID, author, timestamp) so activity signals still count them.
+	StreamFieldsGraph
+)
+
+// StreamOptions configures an IssueStream.
+type StreamOptions struct {
+	ParseOptions
+
+	// Fields selects full or graph-only decoding.
+	Fields StreamFields
+}
+
+// RecordSpan locates a single JSONL record inside its source.
+type RecordSpan struct {
+	Offset int64 // Byte offset of the first byte of the record
+	Length int   // Record length in bytes, excluding the line terminator
+	Line   int   // 1-based line number
+}
+
+// IssueStream reads issues from JSONL one record at a time so callers can
+// process files far larger than would fit comfortably in memory.
+//
+// Usage mirrors bufio.Scanner:
+//
+//	s := loader.NewIssueStream(r, opts)
+//	for s.Next() {
+//	    issue := s.Issue()
+//	}
+//	if err := s.Err(); err != nil { ... }
+//
+// Malformed, invalid and filtered records are skipped exactly as in
+// ParseIssuesWithOptions.
+type IssueStream struct {
+	reader  *bufio.Reader
+	opts    StreamOptions
+	warn    func(string)
+	maxLine int
+
+	offset  int64 // offset of the next unread byte
+	lineNum int
+
+	issue *model.Issue
+	span  RecordSpan
+	err   error
+	done  bool
+
+	hasher   hash.Hash
+	interned map[string]string
+}
+
+// NewIssueStream returns a stream over JSONL content read from r.
+func NewIssueStream(r io.Reader, opts StreamOptions) *IssueStream {
+	maxCapacity := opts.BufferSize
+	if maxCapacity <= 0 {
+		maxCapacity = DefaultMaxBufferSize
+	}
+	return &IssueStream{
+		reader:   bufio.NewReaderSize(r, maxCapacity),
+		opts:     opts,
+		warn:     resolveWarningHandler(opts.WarningHandler),
+		maxLine:  maxCapacity,
+		hasher:   sha256.New(),
+		interned: make(map[string]string),
+	}
+}
+
+// Next advances to the next valid issue. It returns false at end of input
+// or on a read error; call Err to distinguish the two.
+func (s *IssueStream) Next() bool {
+	s.issue = nil
+	for !s.done {
+		line, start, err := s.readRecord()
+		if err != nil {
+			s.done = true
+			if err != io.EOF {
+				s.err = err
+			}
+			if line == nil {
+				return false
+			}
+		}
+		if line == nil {
+			continue
+		}
+
+		lineNum := s.lineNum
+		if lineNum == 1 {
+			if stripped := stripBOM(line); len(stripped) != len(line) {
+				start += int64(len(line) - len(stripped))
+				line = stripped
+			}
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		s.hasher.Write(line)
+		s.hasher.Write([]byte{'\n'})
+
+		issue, err := s.decode(line)
+		if err != nil {
+			s.warn(fmt.Sprintf("skipping malformed JSON on line %d: %v", lineNum, err))
+			continue
+		}
+
+		issue.Status = normalizeIssueStatus(issue.Status)
+		if err := issue.Validate(); err != nil {
+			s.warn(fmt.Sprintf("skipping invalid issue on line %d: %v", lineNum, err))
+			continue
+		}
+		if s.opts.IssueFilter != nil && !s.opts.IssueFilter(issue) {
+			continue
+		}
+
+		s.issue = issue
+		s.span = RecordSpan{Offset: start, Length: len(line), Line: lineNum}
+		return true
+	}
+	return false
+}
+
+// Issue returns the issue produced by the last successful call to Next.
+// Each call to Next allocates a fresh issue, so callers may retain it.
+func (s *IssueStream) Issue() *model.Issue {
+	return s.issue
+}
+
+// Span returns the location of the current issue's record.
+func (s *IssueStream) Span() RecordSpan {
+	return s.span
+}
+
+// Err returns the first non-EOF error encountered by the stream.
+func (s *IssueStream) Err() error {
+	return s.err
+}
+
+// SourceHash returns a hex digest over every non-empty record read so far.
+// Once the stream is exhausted this changes whenever any byte of any record
+// changes, including fields skipped by StreamFieldsGraph, which makes it a
+// suitable change detector when only graph fields were decoded.
+func (s *IssueStream) SourceHash() string {
+	return hex.EncodeToString(s.hasher.Sum(nil))
+}
+
+// readRecord returns the next line without its terminator together with
+// the offset of its first byte. Over-long lines are drained and reported
+// as a nil line so the caller can continue.
+func (s *IssueStream) readRecord() ([]byte, int64, error) {
+	start := s.offset
+	s.lineNum++
+
+	chunk, err := s.reader.ReadSlice('\n')
+	s.offset += int64(len(chunk))
+	if err == bufio.ErrBufferFull {
+		for err == bufio.ErrBufferFull {
+			chunk, err = s.reader.ReadSlice('\n')
+			s.offset += int64(len(chunk))
+		}
+		if err != nil && err != io.EOF {
+			return nil, start, fmt.Errorf("error skipping long line at line %d: %w", s.lineNum, err)
+		}
+		s.warn(fmt.Sprintf("skipping line %d: line too long (exceeds %d bytes)", s.lineNum, s.maxLine))
+		return nil, start, err
+	}
+	if err != nil && err != io.EOF {
+		return nil, start, fmt.Errorf("error reading issues stream at line %d: %w", s.lineNum, err)
+	}
+	if err == io.EOF && len(chunk) == 0 {
+		return nil, start, io.EOF
+	}
+
+	line := bytes.TrimRight(chunk, "\r\n")
+	// ReadSlice's buffer is reused on the next read; copy before handing out.
+	return append([]byte(nil), line...), start, err
+}
+
+func (s *IssueStream) decode(line []byte) (*model.Issue, error) {
+	line, custom, err := s.opts.Schema.Remap(line, s.warn)
+	if err != nil {
+		return nil, err
+	}
+	if s.opts.Fields == StreamFieldsGraph {
+		var gi graphIssue
+		if err := json.Unmarshal(line, &gi); err != nil {
+			return nil, err
+		}
+		issue := s.graphToIssue(&gi)
+		mergeCustom(issue, custom)
+		return issue, nil
+	}
+	var issue model.Issue
+	if err := json.Unmarshal(line, &issue); err != nil {
+		return nil, err
+	}
+	mergeCustom(&issue, custom)
+	return &issue, nil
+}
+
+// graphIssue is the subset of model.Issue decoded by StreamFieldsGraph.
+// Keys absent from this struct are skipped by the decoder without
+// allocating, which is where the memory savings come from.
+type graphIssue struct {
+	ID               string              `json:"id"`
+	Title            string              `json:"title"`
+	Status           model.Status        `json:"status"`
+	Priority         int                 `json:"priority"`
+	IssueType        model.IssueType     `json:"issue_type"`
+	Assignee         string              `json:"assignee"`
+	EstimatedMinutes *int                `json:"estimated_minutes"`
+	CreatedAt        time.Time           `json:"created_at"`
+	UpdatedAt        time.Time           `json:"updated_at"`
+	DueDate          *time.Time          `json:"due_date"`
+	ClosedAt         *time.Time          `json:"closed_at"`
+	CompactionLevel  int                 `json:"compaction_level"`
+	Labels           []string            `json:"labels"`
+	Dependencies     []*model.Dependency `json:"dependencies"`
+	Comments         []graphComment      `json:"comments"`
+	SourceRepo       string              `json:"source_repo"`
+}
+
+// graphComment keeps comment metadata while skipping the text body.
+type graphComment struct {
+	ID        int64     `json:"id"`
+	Author    string    `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// graphToIssue converts a decoded graphIssue into a model.Issue that owns
+// its strings. The decoder may return strings aliasing the record buffer,
+// so retaining them would pin every full line (descriptions included) in
+// memory; short identifiers are cloned and low-cardinality values interned.
+func (s *IssueStream) graphToIssue(g *graphIssue) *model.Issue {
+	id := strings.Clone(g.ID)
+
+	var labels []string
+	if len(g.Labels) > 0 {
+		labels = make([]string, len(g.Labels))
+		for i, l := range g.Labels {
+			labels[i] = s.intern(l)
+		}
+	}
+
+	var deps []*model.Dependency
+	if len(g.Dependencies) > 0 {
+		deps = make([]*model.Dependency, 0, len(g.Dependencies))
+		for _, d := range g.Dependencies {
+			if d == nil {
+				continue
+			}
+			issueID := id
+			if d.IssueID != g.ID {
+				issueID = strings.Clone(d.IssueID)
+			}
+			deps = append(deps, &model.Dependency{
+				IssueID:     issueID,
+				DependsOnID: strings.Clone(d.DependsOnID),
+				Type:        model.DependencyType(s.intern(string(d.Type))),
+				CreatedAt:   d.CreatedAt,
+				CreatedBy:   s.intern(d.CreatedBy),
+			})
+		}
+	}
+
+	var comments []*model.Comment
+	if len(g.Comments) > 0 {
+		comments = make([]*model.Comment, len(g.Comments))
+		for i, c := range g.Comments {
+			comments[i] = &model.Comment{ID: c.ID, IssueID: id, Author: s.intern(c.Author), CreatedAt: c.CreatedAt}
+		}
+	}
+
+	return &model.Issue{
+		ID:               id,
+		Title:            strings.Clone(g.Title),
+		Status:           model.Status(s.intern(string(g.Status))),
+		Priority:         g.Priority,
+		IssueType:        model.IssueType(s.intern(string(g.IssueType))),
+		Assignee:         s.intern(g.Assignee),
+		EstimatedMinutes: g.EstimatedMinutes,
+		CreatedAt:        g.CreatedAt,
+		UpdatedAt:        g.UpdatedAt,
+		DueDate:          g.DueDate,
+		ClosedAt:         g.ClosedAt,
+		CompactionLevel:  g.CompactionLevel,
+		Labels:           labels,
+		Dependencies:     deps,
+		Comments:         comments,
+		SourceRepo:       s.intern(g.SourceRepo),
+	}
+}
+
+// maxInternedStrings bounds the intern table; values past the limit are cloned.
+const maxInternedStrings = 4096
+
+// intern returns a canonical copy of a low-cardinality string (status, type,
+// label, assignee) so repeated values share one allocation.
+func (s *IssueStream) intern(v string) string {
+	if v == "" {
+		return ""
+	}
+	if canon, ok := s.interned[v]; ok {
+		return canon
+	}
+	canon := strings.Clone(v)
+	if len(s.interned) < maxInternedStrings {
+		s.interned[canon] = canon
+	}
+	return canon
+}
+
+// resolveWarningHandler applies the default warning behavior shared by all
+// parsers: print to stderr, except in robot mode where stdout must stay clean.
+func resolveWarningHandler(warn func(string)) func(string) {
+	if warn != nil {
+		return warn
+	}
+	if os.Getenv("BV_ROBOT") == "1" {
+		return func(string) {}
+	}
+	return func(msg string) {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", msg)
+	}
+}
+
+// DetailLoader loads the complete form of a single issue on demand. It is
+// used to fill in long-form fields that were skipped during a slim load.
+type DetailLoader interface {
+	LoadIssueDetails(id string) (*model.Issue, error)
+}
+
+// ErrDetailIndexStale is returned when the indexed file has changed since
+// the index was built. Callers should reload rather than trust old offsets.
+var ErrDetailIndexStale = errors.New("beads file changed since it was indexed")
+
+// DetailIndex maps issue IDs to record spans in a JSONL file so that a
+// single issue can be re-read in full without rescanning the file.
+// The index is immutable once built and safe for concurrent use.
+type DetailIndex struct {
+	path    string
+	size    int64
+	modTime time.Time
+	spans   map[string]RecordSpan
+	schema  *Schema // Field mapping used for the slim pass, reapplied on re-read
+}
+
+// Len returns the number of indexed issues.
+func (d *DetailIndex) Len() int {
+	if d == nil {
+		return 0
+	}
+	return len(d.spans)
+}
+
+// Span returns the record location for an issue ID.
+func (d *DetailIndex) Span(id string) (RecordSpan, bool) {
+	if d == nil {
+		return RecordSpan{}, false
+	}
+	span, ok := d.spans[id]
+	return span, ok
+}
+
+// LoadIssueDetails re-reads and fully decodes the record for id.
+func (d *DetailIndex) LoadIssueDetails(id string) (*model.Issue, error) {
+	span, ok := d.Span(id)
+	if !ok {
+		return nil, fmt.Errorf("issue %s not found in detail index", id)
+	}
+
+	f, err := os.Open(d.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open issues file: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat issues file: %w", err)
+	}
+	if info.Size() != d.size || !info.ModTime().Equal(d.modTime) {
+		return nil, ErrDetailIndexStale
+	}
+
+	buf := make([]byte, span.Length)
+	if _, err := f.ReadAt(buf, span.Offset); err != nil {
+		return nil, fmt.Errorf("failed to read issue %s: %w", id, err)
+	}
+
+	buf, custom, err := d.schema.Remap(buf, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode issue %s: %w", id, err)
+	}
+	var issue model.Issue
+	if err := json.Unmarshal(buf, &issue); err != nil {
+		return nil, fmt.Errorf("failed to decode issue %s: %w", id, err)
+	}
+	mergeCustom(&issue, custom)
+	if issue.ID != id {
+		return nil, ErrDetailIndexStale
+	}
+	issue.Status = normalizeIssueStatus(issue.Status)
+	return &issue, nil
+}
+
+// SlimIssues is the result of a graph-only load.
+type SlimIssues struct {
+	// Issues hold graph fields only; long-form text and comments are empty.
+	Issues []model.Issue
+	// Details re-reads the full form of any issue in Issues.
+	Details *DetailIndex
+	// SourceHash changes whenever any record in the file changes.
+	SourceHash string
+}
+
+// LoadIssuesSlimFromFile streams a JSONL file decoding only graph fields and
+// builds a DetailIndex for on-demand access to the skipped fields.
+//
+// Memory is proportional to the graph (IDs, titles, labels, dependencies)
+// rather than to the file, so 200k-issue archives with long descriptions
+// load in a fraction of the space ParseIssues needs.
+func LoadIssuesSlimFromFile(path string, opts ParseOptions) (SlimIssues, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return SlimIssues{}, fmt.Errorf("no beads issues found at %s", path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return SlimIssues{}, fmt.Errorf("failed to open issues file: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return SlimIssues{}, fmt.Errorf("failed to stat issues file: %w", err)
+	}
+	opts = withProjectSchema(path, opts)
+
+	// Graph-only records are much smaller than the ~2KB average full line
+	// assumed by parseIssuesWithOptions; estimate from the same heuristic.
+	est := int(info.Size() / (2 * 1024))
+	if est > 200_000 {
+		est = 200_000
+	}
+	issues := make([]model.Issue, 0, est)
+	index := &DetailIndex{
+		path:    path,
+		size:    info.Size(),
+		modTime: info.ModTime(),
+		spans:   make(map[string]RecordSpan, est),
+		schema:  opts.Schema,
+	}
+
+	stream := NewIssueStream(file, StreamOptions{ParseOptions: opts, Fields: StreamFieldsGraph})
+	for stream.Next() {
+		issue := stream.Issue()
+		issues = append(issues, *issue)
+		index.spans[issue.ID] = stream.Span()
+	}
+	if err := stream.Err(); err != nil {
+		return SlimIssues{}, err
+	}
+
+	return SlimIssues{
+		Issues:     issues,
+		Details:    index,
+		SourceHash: stream.SourceHash(),
+	}, nil
+}
+
+// HydrateIssue copies long-form fields (description, design, acceptance
+// criteria, notes, comments) from full into dst, leaving graph fields as-is.
+func HydrateIssue(dst *model.Issue, full *model.Issue) {
+	if dst == nil || full == nil {
+		return
+	}
+	dst.Description = full.Description
+	dst.Design = full.Design
+	dst.AcceptanceCriteria = full.AcceptanceCriteria
+	dst.Notes = full.Notes
+	dst.Comments = full.Comments
+	dst.ExternalRef = full.ExternalRef
+	dst.CompactedAt = full.CompactedAt
+	dst.CompactedAtCommit = full.CompactedAtCommit
+	dst.OriginalSize = full.OriginalSize
+}
@@ -0,0 +1,198 @@
+package loader_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+const streamFixture = "\xEF\xBB\xBF" + `{"id":"A-1","title":"First","description":"long text A","status":"OPEN","issue_type":"task","comments":[{"id":1,"issue_id":"A-1","author":"x","text":"hello"}]}
+{"id":"A-2","title":"Second","description":"long text B","notes":"n","status":"closed","issue_type":"bug","dependencies":[{"issue_id":"A-2","depends_on_id":"A-1","type":"blocks"}]}
+
+not json
+{"id":"","title":"missing id","status":"open","issue_type":"task"}
+{"id":"A-3","title":"Third","design":"d","status":"blocked","issue_type":"feature","labels":["api"]}` + "\r\n"
+
+func TestIssueStream_FullMatchesParseIssues(t *testing.T) {
+	opts := loader.ParseOptions{WarningHandler: func(string) {}}
+	want, err := loader.ParseIssuesWithOptions(strings.NewReader(streamFixture), opts)
+	if err != nil {
+		t.Fatalf("ParseIssuesWithOptions: %v", err)
+	}
+
+	s := loader.NewIssueStream(strings.NewReader(streamFixture), loader.StreamOptions{ParseOptions: opts})
+	var got []string
+	for s.Next() {
+		got = append(got, s.Issue().ID)
+		if s.Issue().ID == "A-1" && len(s.Issue().Comments) != 1 {
+			t.Errorf("full stream should decode comments")
+		}
+	}
+	if err := s.Err(); err != nil {
+		t.Fatalf("stream error: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("stream returned %d issues, parse returned %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i].ID {
+			t.Errorf("issue %d: got %s want %s", i, got[i], want[i].ID)
+		}
+	}
+}
+
+func TestIssueStream_GraphFieldsSkipLongText(t *testing.T) {
+	var warnings []string
+	s := loader.NewIssueStream(strings.NewReader(streamFixture), loader.StreamOptions{
+		ParseOptions: loader.ParseOptions{WarningHandler: func(msg string) { warnings = append(warnings, msg) }},
+		Fields:       loader.StreamFieldsGraph,
+	})
+
+	count := 0
+	for s.Next() {
+		count++
+		issue := s.Issue()
+		if issue.Description != "" || issue.Notes != "" || issue.Design != "" {
+			t.Errorf("%s: graph stream should not decode long-form fields", issue.ID)
+		}
+		switch issue.ID {
+		case "A-1":
+			if issue.Status != "open" {
+				t.Errorf("status should be normalized, got %q", issue.Status)
+			}
+			if len(issue.Comments) != 1 || issue.Comments[0].Text != "" || issue.Comments[0].Author != "x" {
+				t.Errorf("comments should be kept as text-less stubs, got %+v", issue.Comments)
+			}
+		case "A-2":
+			if len(issue.Dependencies) != 1 || issue.Dependencies[0].DependsOnID != "A-1" {
+				t.Errorf("dependencies should be decoded, got %+v", issue.Dependencies)
+			}
+		case "A-3":
+			if len(issue.Labels) != 1 || issue.Labels[0] != "api" {
+				t.Errorf("labels should be decoded, got %v", issue.Labels)
+			}
+		}
+	}
+	if count != 3 {
+		t.Fatalf("expected 3 issues, got %d", count)
+	}
+	if len(warnings) != 2 {
+		t.Errorf("expected 2 warnings (malformed + invalid), got %d: %v", len(warnings), warnings)
+	}
+}
+
+func TestIssueStream_SpansPointAtRecords(t *testing.T) {
+	s := loader.NewIssueStream(strings.NewReader(streamFixture), loader.StreamOptions{
+		ParseOptions: loader.ParseOptions{WarningHandler: func(string) {}},
+		Fields:       loader.StreamFieldsGraph,
+	})
+	for s.Next() {
+		span := s.Span()
+		record := streamFixture[span.Offset : span.Offset+int64(span.Length)]
+		if !strings.HasPrefix(record, `{"id":"`+s.Issue().ID+`"`) {
+			t.Errorf("%s: span %+v does not start at record: %q", s.Issue().ID, span, record)
+		}
+		if !strings.HasSuffix(record, "}") {
+			t.Errorf("%s: span should exclude line terminator: %q", s.Issue().ID, record)
+		}
+	}
+}
+
+func TestIssueStream_LongLineSkipped(t *testing.T) {
+	content := `{"id":"ok-1","title":"a","status":"open","issue_type":"task"}` + "\n" +
+		`{"id":"big","title":"` + strings.Repeat("x", 512) + `","status":"open","issue_type":"task"}` + "\n" +
+		`{"id":"ok-2","title":"b","status":"open","issue_type":"task"}`
+
+	var warned bool
+	s := loader.NewIssueStream(strings.NewReader(content), loader.StreamOptions{
+		ParseOptions: loader.ParseOptions{
+			BufferSize:     128,
+			WarningHandler: func(msg string) { warned = warned || strings.Contains(msg, "too long") },
+		},
+	})
+	var ids []string
+	for s.Next() {
+		ids = append(ids, s.Issue().ID)
+		span := s.Span()
+		if got := content[span.Offset : span.Offset+int64(span.Length)]; !strings.Contains(got, s.Issue().ID) {
+			t.Errorf("span for %s is off after skipped line: %q", s.Issue().ID, got)
+		}
+	}
+	if len(ids) != 2 || ids[0] != "ok-1" || ids[1] != "ok-2" {
+		t.Fatalf("expected ok-1, ok-2; got %v", ids)
+	}
+	if !warned {
+		t.Error("expected line-too-long warning")
+	}
+}
+
+func TestLoadIssuesSlimFromFile_LazyDetails(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "beads.jsonl")
+	if err := os.WriteFile(path, []byte(streamFixture), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	slim, err := loader.LoadIssuesSlimFromFile(path, loader.ParseOptions{WarningHandler: func(string) {}})
+	if err != nil {
+		t.Fatalf("LoadIssuesSlimFromFile: %v", err)
+	}
+	if len(slim.Issues) != 3 || slim.Details.Len() != 3 {
+		t.Fatalf("expected 3 issues and 3 index entries, got %d/%d", len(slim.Issues), slim.Details.Len())
+	}
+	if slim.SourceHash == "" {
+		t.Error("expected a source hash")
+	}
+
+	full, err := slim.Details.LoadIssueDetails("A-1")
+	if err != nil {
+		t.Fatalf("LoadIssueDetails: %v", err)
+	}
+	if full.Description != "long text A" || len(full.Comments) != 1 {
+		t.Errorf("details not loaded: %+v", full)
+	}
+
+	issue := slim.Issues[0]
+	loader.HydrateIssue(&issue, full)
+	if issue.Description != "long text A" || len(issue.Comments) != 1 {
+		t.Errorf("HydrateIssue did not copy long-form fields")
+	}
+
+	if _, err := slim.Details.LoadIssueDetails("nope"); err == nil {
+		t.Error("expected error for unknown ID")
+	}
+
+	// Rewriting the file invalidates the offsets.
+	later := time.Now().Add(2 * time.Second)
+	if err := os.WriteFile(path, []byte(streamFixture+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_ = os.Chtimes(path, later, later)
+	if _, err := slim.Details.LoadIssueDetails("A-1"); !errors.Is(err, loader.ErrDetailIndexStale) {
+		t.Errorf("expected ErrDetailIndexStale, got %v", err)
+	}
+}
+
+func TestLoadIssuesSlimFromFile_SourceHashTracksSkippedFields(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "beads.jsonl")
+	write := func(desc string) string {
+		line := `{"id":"H-1","title":"t","description":"` + desc + `","status":"open","issue_type":"task"}`
+		if err := os.WriteFile(path, []byte(line), 0644); err != nil {
+			t.Fatal(err)
+		}
+		slim, err := loader.LoadIssuesSlimFromFile(path, loader.ParseOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return slim.SourceHash
+	}
+	if write("one") == write("two") {
+		t.Error("source hash should change when a skipped field changes")
+	}
+}
@@ -0,0 +1,117 @@
+package model
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CustomFieldPrefix marks a sort or group field that refers to Issue.Custom,
+// e.g. "custom.severity".
+const CustomFieldPrefix = "custom."
+
+// CustomFieldName returns the custom field name referenced by field, and
+// whether field uses the "custom." prefix at all.
+func CustomFieldName(field string) (string, bool) {
+	if !strings.HasPrefix(field, CustomFieldPrefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(field, CustomFieldPrefix)
+	return name, name != ""
+}
+
+// CustomValue returns the value of a custom field and whether it is set.
+func (i *Issue) CustomValue(name string) (any, bool) {
+	if i.Custom == nil {
+		return nil, false
+	}
+	v, ok := i.Custom[name]
+	return v, ok && v != nil
+}
+
+// CustomString returns the display form of a custom field, or "" when unset.
+func (i *Issue) CustomString(name string) string {
+	v, ok := i.CustomValue(name)
+	if !ok {
+		return ""
+	}
+	return FormatCustomValue(v)
+}
+
+// FormatCustomValue renders a custom field value for display and matching.
+// Dates without a time-of-day component are shown as YYYY-MM-DD.
+func FormatCustomValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case time.Time:
+		if val.Hour() == 0 && val.Minute() == 0 && val.Second() == 0 && val.Nanosecond() == 0 {
+			return val.Format("2006-01-02")
+		}
+		return val.Format(time.RFC3339)
+	case bool:
+		return strconv.FormatBool(val)
+	default:
+		return ""
+	}
+}
+
+// CompareCustom orders two issues by a custom field in the given direction.
+// Enum values compare by their position in values (the schema declaration),
+// numbers and dates by value, everything else by display string. Issues
+// without the field sort last in either direction.
+func CompareCustom(a, b *Issue, name string, values []string, descending bool) int {
+	av, aok := a.CustomValue(name)
+	bv, bok := b.CustomValue(name)
+	switch {
+	case !aok && !bok:
+		return 0
+	case !aok:
+		return 1
+	case !bok:
+		return -1
+	}
+
+	c := compareCustomValues(av, bv, values)
+	if descending {
+		return -c
+	}
+	return c
+}
+
+func compareCustomValues(av, bv any, values []string) int {
+	if len(values) > 0 {
+		x, xok := av.(string)
+		y, yok := bv.(string)
+		if xok && yok {
+			xi, yi := slices.Index(values, x), slices.Index(values, y)
+			if xi >= 0 && yi >= 0 {
+				return cmp.Compare(xi, yi)
+			}
+		}
+	}
+
+	switch x := av.(type) {
+	case float64:
+		if y, ok := bv.(float64); ok {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	case time.Time:
+		if y, ok := bv.(time.Time); ok {
+			return x.Compare(y)
+		}
+	}
+	return strings.Compare(FormatCustomValue(av), FormatCustomValue(bv))
+}
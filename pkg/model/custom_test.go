@@ -0,0 +1,85 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCustomFieldName(t *testing.T) {
+	if name, ok := CustomFieldName("custom.severity"); !ok || name != "severity" {
+		t.Errorf("CustomFieldName(custom.severity) = %q, %v", name, ok)
+	}
+	for _, field := range []string{"priority", "custom.", ""} {
+		if _, ok := CustomFieldName(field); ok {
+			t.Errorf("CustomFieldName(%q) should not match", field)
+		}
+	}
+}
+
+func TestFormatCustomValue(t *testing.T) {
+	tests := []struct {
+		in   any
+		want string
+	}{
+		{"high", "high"},
+		{3.0, "3"},
+		{2.5, "2.5"},
+		{time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), "2025-03-01"},
+		{time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC), "2025-03-01T09:30:00Z"},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := FormatCustomValue(tt.in); got != tt.want {
+			t.Errorf("FormatCustomValue(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCompareCustom(t *testing.T) {
+	a := &Issue{ID: "a", Custom: map[string]any{"points": 2.0, "due": time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)}}
+	b := &Issue{ID: "b", Custom: map[string]any{"points": 10.0, "due": time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}}
+	unset := &Issue{ID: "c"}
+
+	if CompareCustom(a, b, "points", nil, false) >= 0 {
+		t.Errorf("numbers should compare numerically (2 < 10)")
+	}
+	if CompareCustom(a, b, "points", nil, true) <= 0 {
+		t.Errorf("descending should reverse the order")
+	}
+	if CompareCustom(a, b, "due", nil, false) <= 0 {
+		t.Errorf("dates should compare chronologically")
+	}
+	for _, desc := range []bool{false, true} {
+		if CompareCustom(unset, a, "points", nil, desc) <= 0 || CompareCustom(a, unset, "points", nil, desc) >= 0 {
+			t.Errorf("issues without the field should sort last (descending=%v)", desc)
+		}
+	}
+	if CompareCustom(unset, unset, "points", nil, false) != 0 {
+		t.Errorf("two unset values should be equal")
+	}
+}
+
+func TestCompareCustom_EnumOrder(t *testing.T) {
+	values := []string{"low", "medium", "high", "critical"}
+	low := &Issue{ID: "a", Custom: map[string]any{"severity": "low"}}
+	critical := &Issue{ID: "b", Custom: map[string]any{"severity": "critical"}}
+
+	if CompareCustom(low, critical, "severity", values, false) >= 0 {
+		t.Errorf("enums should follow declaration order (low < critical)")
+	}
+	if CompareCustom(low, critical, "severity", nil, false) <= 0 {
+		t.Errorf("without declared values, strings compare alphabetically")
+	}
+	if CompareCustom(critical, low, "severity", values, true) >= 0 {
+		t.Errorf("descending should put critical first")
+	}
+}
+
+func TestIssue_CloneCustom(t *testing.T) {
+	original := Issue{ID: "x", Custom: map[string]any{"team": "core"}}
+	clone := original.Clone()
+	clone.Custom["team"] = "infra"
+	if original.Custom["team"] != "core" {
+		t.Errorf("Clone should deep-copy Custom")
+	}
+}
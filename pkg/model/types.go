@@ -32,6 +32,10 @@ type Issue struct {
 	Dependencies       []*Dependency `json:"dependencies,omitempty"`
 	Comments           []*Comment    `json:"comments,omitempty"`
 	SourceRepo         string        `json:"source_repo,omitempty"`
+
+	// Custom holds fields declared in .bv/schema.yaml that are not part of
+	// the beads format. Values are string, float64 or time.Time.
+	Custom map[string]any `json:"custom,omitempty"`
 }
 
 // Clone creates a deep copy of the issue
@@ -68,6 +72,13 @@ func (i Issue) Clone() Issue {
 		copy(clone.Labels, i.Labels)
 	}
 
+	if i.Custom != nil {
+		clone.Custom = make(map[string]any, len(i.Custom))
+		for k, v := range i.Custom {
+			clone.Custom[k] = v
+		}
+	}
+
 	if i.Dependencies != nil {
 		clone.Dependencies = make([]*Dependency, len(i.Dependencies))
 		for idx, dep := range i.Dependencies {
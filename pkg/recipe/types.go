@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
 // Recipe defines a reusable view configuration for beads
@@ -32,11 +34,205 @@ type FilterConfig struct {
 	Actionable    *bool    `yaml:"actionable,omitempty" json:"actionable,omitempty"`         // true = no open blockers
 	TitleContains string   `yaml:"title_contains,omitempty" json:"title_contains,omitempty"` // Substring match
 	IDPrefix      string   `yaml:"id_prefix,omitempty" json:"id_prefix,omitempty"`           // e.g., "bv-" for project filtering
+
+	// Custom filters on fields declared in .bv/schema.yaml: field -> accepted values
+	Custom map[string][]string `yaml:"custom,omitempty" json:"custom,omitempty"`
+}
+
+// MatchesCustom reports whether an issue satisfies every custom field filter.
+// A field matches when its display value equals any accepted value (case-insensitive).
+func (f FilterConfig) MatchesCustom(issue *model.Issue) bool {
+	for field, accepted := range f.Custom {
+		if len(accepted) == 0 {
+			continue
+		}
+		value := issue.CustomString(field)
+		if value == "" {
+			return false
+		}
+		match := false
+		for _, want := range accepted {
+			if strings.EqualFold(value, want) {
+				match = true
+				break
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply returns the issues that pass every filter, in input order.
+// Relative dates ("14d") are resolved against now.
+func (f FilterConfig) Apply(issues []model.Issue, now time.Time) []model.Issue {
+	// Build a set of open blocker IDs for actionable filtering
+	openBlockers := make(map[string]bool)
+	for _, issue := range issues {
+		if issue.Status != model.StatusClosed {
+			openBlockers[issue.ID] = true
+		}
+	}
+
+	var result []model.Issue
+	for _, issue := range issues {
+		// Status filter
+		if len(f.Status) > 0 {
+			match := false
+			for _, s := range f.Status {
+				if strings.EqualFold(string(issue.Status), s) {
+					match = true
+					break
+				}
+			}
+			if !match {
+				continue
+			}
+		}
+
+		// Priority filter
+		if len(f.Priority) > 0 {
+			match := false
+			for _, p := range f.Priority {
+				if issue.Priority == p {
+					match = true
+					break
+				}
+			}
+			if !match {
+				continue
+			}
+		}
+
+		// Tags filter (must have all)
+		if len(f.Tags) > 0 {
+			match := true
+			for _, tag := range f.Tags {
+				found := false
+				for _, label := range issue.Labels {
+					if strings.EqualFold(label, tag) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					match = false
+					break
+				}
+			}
+			if !match {
+				continue
+			}
+		}
+
+		// ExcludeTags filter
+		if len(f.ExcludeTags) > 0 {
+			excluded := false
+			for _, excludeTag := range f.ExcludeTags {
+				for _, label := range issue.Labels {
+					if strings.EqualFold(label, excludeTag) {
+						excluded = true
+						break
+					}
+				}
+				if excluded {
+					break
+				}
+			}
+			if excluded {
+				continue
+			}
+		}
+
+		// CreatedAfter filter
+		if f.CreatedAfter != "" {
+			threshold, err := ParseRelativeTime(f.CreatedAfter, now)
+			if err == nil && !issue.CreatedAt.IsZero() && issue.CreatedAt.Before(threshold) {
+				continue
+			}
+		}
+
+		// CreatedBefore filter
+		if f.CreatedBefore != "" {
+			threshold, err := ParseRelativeTime(f.CreatedBefore, now)
+			if err == nil && !issue.CreatedAt.IsZero() && issue.CreatedAt.After(threshold) {
+				continue
+			}
+		}
+
+		// UpdatedAfter filter
+		if f.UpdatedAfter != "" {
+			threshold, err := ParseRelativeTime(f.UpdatedAfter, now)
+			if err == nil && !issue.UpdatedAt.IsZero() && issue.UpdatedAt.Before(threshold) {
+				continue
+			}
+		}
+
+		// UpdatedBefore filter
+		if f.UpdatedBefore != "" {
+			threshold, err := ParseRelativeTime(f.UpdatedBefore, now)
+			if err == nil && !issue.UpdatedAt.IsZero() && issue.UpdatedAt.After(threshold) {
+				continue
+			}
+		}
+
+		// HasBlockers filter
+		if f.HasBlockers != nil {
+			hasOpenBlockers := false
+			for _, dep := range issue.Dependencies {
+				if dep.Type == model.DepBlocks && openBlockers[dep.DependsOnID] {
+					hasOpenBlockers = true
+					break
+				}
+			}
+			if *f.HasBlockers != hasOpenBlockers {
+				continue
+			}
+		}
+
+		// Actionable filter (no open blockers)
+		if f.Actionable != nil && *f.Actionable {
+			hasOpenBlockers := false
+			for _, dep := range issue.Dependencies {
+				if dep.Type == model.DepBlocks && openBlockers[dep.DependsOnID] {
+					hasOpenBlockers = true
+					break
+				}
+			}
+			if hasOpenBlockers {
+				continue
+			}
+		}
+
+		// TitleContains filter
+		if f.TitleContains != "" {
+			if !strings.Contains(strings.ToLower(issue.Title), strings.ToLower(f.TitleContains)) {
+				continue
+			}
+		}
+
+		// IDPrefix filter
+		if f.IDPrefix != "" {
+			if !strings.HasPrefix(issue.ID, f.IDPrefix) {
+				continue
+			}
+		}
+
+		// Custom field filters (.bv/schema.yaml)
+		if !f.MatchesCustom(&issue) {
+			continue
+		}
+
+		result = append(result, issue)
+	}
+
+	return result
 }
 
 // SortConfig defines how to order issues
 type SortConfig struct {
-	Field     string      `yaml:"field" json:"field"`                             // priority, created, updated, title, id, pagerank, betweenness
+	Field     string      `yaml:"field" json:"field"`                             // priority, created, updated, title, id, pagerank, betweenness, custom.<name>
 	Direction string      `yaml:"direction,omitempty" json:"direction,omitempty"` // asc, desc (default: asc for priority, desc for dates)
 	Secondary *SortConfig `yaml:"secondary,omitempty" json:"secondary,omitempty"` // Tie-breaker
 }
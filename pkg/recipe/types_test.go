@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
 )
 
@@ -153,3 +154,26 @@ func TestRecipeStructTags(t *testing.T) {
 		t.Error("Filters.Status should not be nil")
 	}
 }
+
+func TestFilterConfigMatchesCustom(t *testing.T) {
+	issue := &model.Issue{ID: "x", Custom: map[string]any{"severity": "High", "points": 3.0}}
+
+	tests := []struct {
+		name   string
+		filter recipe.FilterConfig
+		want   bool
+	}{
+		{"no custom filters", recipe.FilterConfig{}, true},
+		{"case-insensitive match", recipe.FilterConfig{Custom: map[string][]string{"severity": {"high", "critical"}}}, true},
+		{"number formatted", recipe.FilterConfig{Custom: map[string][]string{"points": {"3"}}}, true},
+		{"value mismatch", recipe.FilterConfig{Custom: map[string][]string{"severity": {"low"}}}, false},
+		{"field unset", recipe.FilterConfig{Custom: map[string][]string{"team": {"core"}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.MatchesCustom(issue); got != tt.want {
+				t.Errorf("MatchesCustom() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
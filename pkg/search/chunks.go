@@ -0,0 +1,436 @@
+package search
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Chunk fields. The head chunk carries the ID, title and labels; every other
+// field is split into overlapping word windows, and each comment is chunked
+// on its own so one long thread cannot dilute the rest.
+const (
+	ChunkFieldHead               = "title"
+	ChunkFieldDescription        = "description"
+	ChunkFieldDesign             = "design"
+	ChunkFieldAcceptanceCriteria = "acceptance_criteria"
+	ChunkFieldNotes              = "notes"
+	ChunkFieldComment            = "comment"
+)
+
+const (
+	chunkWords   = 96
+	chunkOverlap = 24
+	// chunkFanout over-fetches chunk hits so that, after collapsing several
+	// chunks of one issue, enough distinct issues remain.
+	chunkFanout = 4
+	// snippetBytes bounds the length of a match snippet (before ellipses).
+	snippetBytes = 160
+)
+
+// DocumentChunk is one indexed slice of an issue.
+type DocumentChunk struct {
+	IssueID string
+	Field   string
+	Comment int // 1-based comment number for ChunkFieldComment, else 0
+	Text    string
+	context string // issue title, prepended when embedding
+}
+
+// EmbedText is the text sent to the embedder: the chunk prefixed with the
+// issue title so that a bare paragraph keeps its topic.
+func (c DocumentChunk) EmbedText() string {
+	if c.context == "" || c.Field == ChunkFieldHead {
+		return c.Text
+	}
+	return c.context + "\n" + c.Text
+}
+
+// ChunkKey identifies chunk n of a field, e.g. "bv-12#comment.3.0".
+func ChunkKey(issueID, field string, comment, n int) string {
+	if field == ChunkFieldComment {
+		return fmt.Sprintf("%s#%s.%d.%d", issueID, field, comment, n)
+	}
+	return fmt.Sprintf("%s#%s.%d", issueID, field, n)
+}
+
+// IssueIDFromChunkKey returns the issue a chunk key belongs to. Keys without
+// a chunk suffix (indexes built before chunking) are issue IDs already.
+func IssueIDFromChunkKey(key string) string {
+	if i := strings.LastIndexByte(key, '#'); i > 0 {
+		return key[:i]
+	}
+	return key
+}
+
+// ChunkSet holds the chunks of a set of issues.
+type ChunkSet struct {
+	byKey   map[string]DocumentChunk
+	byIssue map[string][]string
+}
+
+// NewChunkSet chunks every issue's searchable fields.
+func NewChunkSet(issues []model.Issue) *ChunkSet {
+	cs := &ChunkSet{
+		byKey:   make(map[string]DocumentChunk),
+		byIssue: make(map[string][]string, len(issues)),
+	}
+	for _, issue := range issues {
+		if issue.ID == "" {
+			continue
+		}
+		title := strings.TrimSpace(issue.Title)
+		head := []string{issue.ID}
+		if title != "" {
+			head = append(head, title)
+		}
+		if labels := strings.TrimSpace(strings.Join(issue.Labels, " ")); labels != "" {
+			head = append(head, labels)
+		}
+		cs.add(DocumentChunk{IssueID: issue.ID, Field: ChunkFieldHead, Text: strings.Join(head, "\n")}, 0)
+
+		fields := []struct{ name, text string }{
+			{ChunkFieldDescription, issue.Description},
+			{ChunkFieldDesign, issue.Design},
+			{ChunkFieldAcceptanceCriteria, issue.AcceptanceCriteria},
+			{ChunkFieldNotes, issue.Notes},
+		}
+		for _, f := range fields {
+			for n, text := range splitChunks(f.text) {
+				cs.add(DocumentChunk{IssueID: issue.ID, Field: f.name, Text: text, context: title}, n)
+			}
+		}
+		for i, c := range issue.Comments {
+			if c == nil {
+				continue
+			}
+			for n, text := range splitChunks(c.Text) {
+				cs.add(DocumentChunk{IssueID: issue.ID, Field: ChunkFieldComment, Comment: i + 1, Text: text, context: title}, n)
+			}
+		}
+	}
+	return cs
+}
+
+func (cs *ChunkSet) add(c DocumentChunk, n int) {
+	key := ChunkKey(c.IssueID, c.Field, c.Comment, n)
+	cs.byKey[key] = c
+	cs.byIssue[c.IssueID] = append(cs.byIssue[c.IssueID], key)
+}
+
+// Len returns the number of chunks.
+func (cs *ChunkSet) Len() int {
+	if cs == nil {
+		return 0
+	}
+	return len(cs.byKey)
+}
+
+// Texts returns chunk key -> embedding text, the input SyncVectorIndex expects.
+func (cs *ChunkSet) Texts() map[string]string {
+	out := make(map[string]string, len(cs.byKey))
+	for key, c := range cs.byKey {
+		out[key] = c.EmbedText()
+	}
+	return out
+}
+
+// Keys returns the chunk keys of an issue in field order.
+func (cs *ChunkSet) Keys(issueID string) []string {
+	if cs == nil {
+		return nil
+	}
+	return cs.byIssue[issueID]
+}
+
+// Chunk looks up a chunk by key.
+func (cs *ChunkSet) Chunk(key string) (DocumentChunk, bool) {
+	if cs == nil {
+		return DocumentChunk{}, false
+	}
+	c, ok := cs.byKey[key]
+	return c, ok
+}
+
+// Indexed reports whether the index holds any vector for the issue, either
+// chunk vectors or one stored under the bare issue ID.
+func (cs *ChunkSet) Indexed(idx *VectorIndex, issueID string) bool {
+	for _, key := range cs.Keys(issueID) {
+		if _, found := idx.Get(key); found {
+			return true
+		}
+	}
+	_, found := idx.Get(issueID)
+	return found
+}
+
+// MaxSim scores an issue as its best-matching chunk. It falls back to a
+// vector stored under the bare issue ID (indexes built before chunking).
+func (cs *ChunkSet) MaxSim(idx *VectorIndex, q []float32, issueID string) (score float64, bestKey string, ok bool) {
+	for _, key := range cs.Keys(issueID) {
+		entry, found := idx.Get(key)
+		if !found {
+			continue
+		}
+		if sim := dotFloat32(q, entry.Vector); !ok || sim > score {
+			score, bestKey, ok = sim, key, true
+		}
+	}
+	if !ok {
+		if entry, found := idx.Get(issueID); found {
+			return dotFloat32(q, entry.Vector), "", true
+		}
+	}
+	return score, bestKey, ok
+}
+
+// SearchChunks runs a vector search over chunks and collapses the hits to
+// the top k issues by max-sim. best maps each issue to its winning chunk key.
+func SearchChunks(idx *VectorIndex, q []float32, k int) (results []SearchResult, best map[string]string, err error) {
+	hits, err := idx.SearchTopK(q, k*chunkFanout)
+	if err != nil {
+		return nil, nil, err
+	}
+	results, best = AggregateChunkResults(hits)
+	if len(results) > k {
+		results = results[:k]
+	}
+	return results, best, nil
+}
+
+// AggregateChunkResults collapses chunk hits to issues, keeping each issue's
+// highest score, ordered by score then ID.
+func AggregateChunkResults(hits []SearchResult) ([]SearchResult, map[string]string) {
+	scores := make(map[string]float64, len(hits))
+	best := make(map[string]string, len(hits))
+	for _, h := range hits {
+		id := IssueIDFromChunkKey(h.IssueID)
+		if prev, ok := scores[id]; !ok || h.Score > prev {
+			scores[id] = h.Score
+			if id != h.IssueID {
+				best[id] = h.IssueID
+			}
+		}
+	}
+	out := make([]SearchResult, 0, len(scores))
+	for id, score := range scores {
+		out = append(out, SearchResult{IssueID: id, Score: score})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].IssueID < out[j].IssueID
+	})
+	return out, best
+}
+
+// ChunkMatch explains which part of an issue matched a query.
+type ChunkMatch struct {
+	Field      string   `json:"field"`
+	Comment    int      `json:"comment,omitempty"`
+	Snippet    string   `json:"snippet"`
+	Highlights [][2]int `json:"highlights,omitempty"` // byte ranges in Snippet
+}
+
+// Label describes the matched field for display, e.g. "comment #2".
+func (m *ChunkMatch) Label() string {
+	if m.Field == ChunkFieldComment && m.Comment > 0 {
+		return fmt.Sprintf("comment #%d", m.Comment)
+	}
+	return strings.ReplaceAll(m.Field, "_", " ")
+}
+
+// Highlighted returns the snippet with each highlight wrapped in open/close.
+func (m *ChunkMatch) Highlighted(open, close string) string {
+	var sb strings.Builder
+	last := 0
+	for _, h := range m.Highlights {
+		sb.WriteString(m.Snippet[last:h[0]])
+		sb.WriteString(open)
+		sb.WriteString(m.Snippet[h[0]:h[1]])
+		sb.WriteString(close)
+		last = h[1]
+	}
+	sb.WriteString(m.Snippet[last:])
+	return sb.String()
+}
+
+// MatchFor picks the chunk to show for an issue: the vector winner if it
+// also contains a query term, otherwise the chunk with the most query terms,
+// otherwise the vector winner. It returns nil when nothing sensible matched.
+func (cs *ChunkSet) MatchFor(issueID, vectorKey, query string) *ChunkMatch {
+	terms := queryTermSet(query)
+	chosen := ""
+	if c, ok := cs.Chunk(vectorKey); ok && countTermHits(c.Text, terms) > 0 {
+		chosen = vectorKey
+	}
+	if chosen == "" {
+		bestHits := 0
+		for _, key := range cs.Keys(issueID) {
+			c := cs.byKey[key]
+			if hits := countTermHits(c.Text, terms); hits > bestHits {
+				chosen, bestHits = key, hits
+			}
+		}
+	}
+	if chosen == "" {
+		chosen = vectorKey
+	}
+	c, ok := cs.Chunk(chosen)
+	if !ok {
+		return nil
+	}
+	snippet, highlights := buildSnippet(c.Text, terms)
+	return &ChunkMatch{Field: c.Field, Comment: c.Comment, Snippet: snippet, Highlights: highlights}
+}
+
+// splitChunks splits text into overlapping windows of chunkWords words.
+// Whitespace inside a window is preserved.
+func splitChunks(text string) []string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	type span struct{ start, end int }
+	var words []span
+	start := -1
+	for i, r := range text {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				words = append(words, span{start, i})
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, span{start, len(text)})
+	}
+	if len(words) <= chunkWords {
+		return []string{text}
+	}
+
+	var out []string
+	step := chunkWords - chunkOverlap
+	for first := 0; first < len(words); first += step {
+		last := min(first+chunkWords, len(words)) - 1
+		out = append(out, text[words[first].start:words[last].end])
+		if last == len(words)-1 {
+			break
+		}
+	}
+	return out
+}
+
+func queryTermSet(query string) map[string]struct{} {
+	terms := make(map[string]struct{})
+	for _, t := range lexicalTokens(query) {
+		terms[t] = struct{}{}
+	}
+	return terms
+}
+
+// forEachWord calls fn with the byte range of every letter/digit run.
+func forEachWord(text string, fn func(start, end int)) {
+	start := -1
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			fn(start, i)
+			start = -1
+		}
+	}
+	if start >= 0 {
+		fn(start, len(text))
+	}
+}
+
+func countTermHits(text string, terms map[string]struct{}) int {
+	if len(terms) == 0 {
+		return 0
+	}
+	hits := 0
+	forEachWord(text, func(s, e int) {
+		if _, ok := terms[strings.ToLower(text[s:e])]; ok {
+			hits++
+		}
+	})
+	return hits
+}
+
+// buildSnippet cuts a window of about snippetBytes around the first query
+// term (or the start of text) on word boundaries, flattens newlines, and
+// returns highlight ranges for every query term inside the window.
+func buildSnippet(text string, terms map[string]struct{}) (string, [][2]int) {
+	type span struct{ start, end int }
+	var words, hits []span
+	forEachWord(text, func(s, e int) {
+		words = append(words, span{s, e})
+		if _, ok := terms[strings.ToLower(text[s:e])]; ok {
+			hits = append(hits, span{s, e})
+		}
+	})
+
+	from, to := 0, len(text)
+	if len(text) > snippetBytes {
+		anchor := 0
+		if len(hits) > 0 {
+			anchor = max(0, hits[0].start-snippetBytes/3)
+		}
+		from, to = anchor, min(len(text), anchor+snippetBytes)
+		// Snap to word boundaries so words are never cut.
+		for _, w := range words {
+			if w.start <= from && from < w.end {
+				from = w.start
+				break
+			}
+		}
+		for _, w := range words {
+			if w.start < to && to < w.end {
+				to = w.start
+				break
+			}
+		}
+		for from < to && !utf8.RuneStart(text[from]) {
+			from++
+		}
+	}
+
+	var sb strings.Builder
+	prefix := ""
+	if from > 0 {
+		prefix = "…"
+	}
+	sb.WriteString(prefix)
+	body := strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\r' || r == '\t' {
+			return ' '
+		}
+		return r
+	}, text[from:to])
+	sb.WriteString(strings.TrimRight(body, " "))
+	trimmed := len(body) - len(strings.TrimRight(body, " "))
+	if to < len(text) {
+		sb.WriteString("…")
+	}
+
+	var highlights [][2]int
+	shift := len(prefix) - from
+	for _, h := range hits {
+		if h.start >= from && h.end <= to-trimmed {
+			highlights = append(highlights, [2]int{h.start + shift, h.end + shift})
+		}
+	}
+	return sb.String(), highlights
+}
@@ -0,0 +1,197 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func wordsText(prefix string, n int) string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = fmt.Sprintf("%s%d", prefix, i)
+	}
+	return strings.Join(words, " ")
+}
+
+func TestSplitChunks_OverlappingWindows(t *testing.T) {
+	if got := splitChunks("  short text  "); len(got) != 1 || got[0] != "short text" {
+		t.Fatalf("short text chunks = %q", got)
+	}
+	if got := splitChunks(" \n "); got != nil {
+		t.Fatalf("blank text chunks = %q", got)
+	}
+
+	chunks := splitChunks(wordsText("w", 200))
+	if len(chunks) != 3 {
+		t.Fatalf("got %d chunks, want 3", len(chunks))
+	}
+	first := strings.Fields(chunks[0])
+	second := strings.Fields(chunks[1])
+	if len(first) != chunkWords || first[0] != "w0" {
+		t.Fatalf("first chunk has %d words starting %q", len(first), first[0])
+	}
+	if second[0] != fmt.Sprintf("w%d", chunkWords-chunkOverlap) {
+		t.Fatalf("second chunk starts at %q, want overlap of %d words", second[0], chunkOverlap)
+	}
+	last := strings.Fields(chunks[2])
+	if last[len(last)-1] != "w199" {
+		t.Fatalf("last chunk ends at %q", last[len(last)-1])
+	}
+}
+
+func TestNewChunkSet_FieldsAndComments(t *testing.T) {
+	cs := NewChunkSet([]model.Issue{{
+		ID:          "bv-1",
+		Title:       "Sync engine",
+		Labels:      []string{"backend"},
+		Description: wordsText("d", 150),
+		Design:      "Use a write-ahead log.",
+		Comments:    []*model.Comment{{Text: "first"}, nil, {Text: "third comment"}},
+	}})
+
+	want := []string{
+		"bv-1#title.0",
+		"bv-1#description.0",
+		"bv-1#description.1",
+		"bv-1#design.0",
+		"bv-1#comment.1.0",
+		"bv-1#comment.3.0",
+	}
+	got := cs.Keys("bv-1")
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("keys = %v, want %v", got, want)
+	}
+	if cs.Len() != len(want) {
+		t.Fatalf("Len = %d, want %d", cs.Len(), len(want))
+	}
+
+	texts := cs.Texts()
+	if texts["bv-1#title.0"] != "bv-1\nSync engine\nbackend" {
+		t.Fatalf("head text = %q", texts["bv-1#title.0"])
+	}
+	if texts["bv-1#design.0"] != "Sync engine\nUse a write-ahead log." {
+		t.Fatalf("design text = %q, want title context prefix", texts["bv-1#design.0"])
+	}
+}
+
+func TestIssueIDFromChunkKey(t *testing.T) {
+	cases := map[string]string{
+		"bv-12#comment.3.0":  "bv-12",
+		"bv-1#description.0": "bv-1",
+		"bv-1":               "bv-1",
+	}
+	for key, want := range cases {
+		if got := IssueIDFromChunkKey(key); got != want {
+			t.Errorf("IssueIDFromChunkKey(%q) = %q, want %q", key, got, want)
+		}
+	}
+	if key := ChunkKey("bv-12", ChunkFieldComment, 3, 0); key != "bv-12#comment.3.0" {
+		t.Errorf("ChunkKey comment = %q", key)
+	}
+}
+
+func TestAggregateChunkResults_MaxSimPerIssue(t *testing.T) {
+	hits := []SearchResult{
+		{IssueID: "bv-1#description.0", Score: 0.4},
+		{IssueID: "bv-2#comment.1.0", Score: 0.9},
+		{IssueID: "bv-1#notes.0", Score: 0.7},
+		{IssueID: "bv-3", Score: 0.7},
+	}
+	results, best := AggregateChunkResults(hits)
+	if got := resultIDs(results); strings.Join(got, ",") != "bv-2,bv-1,bv-3" {
+		t.Fatalf("order = %v", got)
+	}
+	if results[1].Score != 0.7 {
+		t.Fatalf("bv-1 score = %v, want max 0.7", results[1].Score)
+	}
+	if best["bv-1"] != "bv-1#notes.0" || best["bv-2"] != "bv-2#comment.1.0" {
+		t.Fatalf("best = %v", best)
+	}
+	if _, ok := best["bv-3"]; ok {
+		t.Fatalf("legacy whole-issue hit should have no best chunk")
+	}
+}
+
+func TestChunkSet_MaxSimAndSearchChunks(t *testing.T) {
+	cs := NewChunkSet([]model.Issue{
+		{ID: "bv-1", Title: "alpha", Description: "beta"},
+		{ID: "bv-2", Title: "gamma"},
+	})
+	idx := NewVectorIndex(2)
+	mustUpsert := func(key string, vec []float32) {
+		t.Helper()
+		if err := idx.Upsert(key, ContentHash{}, vec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mustUpsert("bv-1#title.0", []float32{1, 0})
+	mustUpsert("bv-1#description.0", []float32{0, 1})
+	mustUpsert("bv-2", []float32{0.6, 0.8}) // index built before chunking
+
+	q := []float32{0, 1}
+	score, key, ok := cs.MaxSim(idx, q, "bv-1")
+	if !ok || key != "bv-1#description.0" || score != 1 {
+		t.Fatalf("MaxSim bv-1 = %v %q %v", score, key, ok)
+	}
+	score, key, ok = cs.MaxSim(idx, q, "bv-2")
+	if !ok || key != "" || score < 0.79 || score > 0.81 {
+		t.Fatalf("MaxSim bv-2 fallback = %v %q %v", score, key, ok)
+	}
+	if !cs.Indexed(idx, "bv-1") || !cs.Indexed(idx, "bv-2") || cs.Indexed(idx, "bv-3") {
+		t.Fatal("Indexed should report chunk and bare-ID vectors only")
+	}
+
+	results, best, err := SearchChunks(idx, q, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].IssueID != "bv-1" || best["bv-1"] != "bv-1#description.0" {
+		t.Fatalf("SearchChunks = %v best=%v", results, best)
+	}
+}
+
+func TestChunkSet_MatchForSnippetHighlights(t *testing.T) {
+	long := wordsText("pad", 60) + " the retry budget is exhausted\nafter timeouts " + wordsText("tail", 60)
+	cs := NewChunkSet([]model.Issue{{
+		ID:       "bv-1",
+		Title:    "Flaky uploads",
+		Comments: []*model.Comment{{Text: "unrelated"}, {Text: long}},
+	}})
+
+	// The vector winner has no query term, so the lexical best chunk wins.
+	m := cs.MatchFor("bv-1", "bv-1#comment.1.0", "Retry budget")
+	if m == nil {
+		t.Fatal("MatchFor returned nil")
+	}
+	if m.Field != ChunkFieldComment || m.Comment != 2 || m.Label() != "comment #2" {
+		t.Fatalf("match = %+v label=%q", m, m.Label())
+	}
+	if strings.Contains(m.Snippet, "\n") {
+		t.Fatalf("snippet should be single-line: %q", m.Snippet)
+	}
+	if !strings.HasPrefix(m.Snippet, "…") || !strings.HasSuffix(m.Snippet, "…") {
+		t.Fatalf("snippet should be elided on both sides: %q", m.Snippet)
+	}
+	if len(m.Highlights) != 2 {
+		t.Fatalf("highlights = %v in %q", m.Highlights, m.Snippet)
+	}
+	for _, h := range m.Highlights {
+		word := strings.ToLower(m.Snippet[h[0]:h[1]])
+		if word != "retry" && word != "budget" {
+			t.Fatalf("highlight %v covers %q", h, word)
+		}
+	}
+	if hl := m.Highlighted("[", "]"); !strings.Contains(hl, "[retry] [budget]") {
+		t.Fatalf("Highlighted = %q", hl)
+	}
+
+	if m := cs.MatchFor("bv-1", "", "nothing here"); m != nil {
+		t.Fatalf("expected nil match, got %+v", m)
+	}
+	if m := cs.MatchFor("bv-1", "bv-1#title.0", "nothing here"); m == nil || m.Label() != "title" {
+		t.Fatalf("vector winner fallback = %+v", m)
+	}
+}
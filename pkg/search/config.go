@@ -13,12 +13,19 @@ import (
 // Supported variables:
 //   - BV_SEMANTIC_EMBEDDER: embedding provider (default: "hash")
 //   - BV_SEMANTIC_MODEL: model identifier (provider-specific, optional)
-//   - BV_SEMANTIC_DIM: embedding dimension (default: DefaultEmbeddingDim)
+//   - BV_SEMANTIC_DIM: embedding dimension (default: DefaultEmbeddingDim; openai: detected)
+//   - BV_SEMANTIC_BASE_URL: OpenAI-compatible endpoint (default: DefaultOpenAIBaseURL)
+//   - BV_SEMANTIC_API_KEY: bearer token for the endpoint (falls back to OPENAI_API_KEY)
 func EmbeddingConfigFromEnv() EmbeddingConfig {
 	provider := strings.ToLower(strings.TrimSpace(os.Getenv(EnvSemanticEmbedder)))
 	cfg := EmbeddingConfig{
 		Provider: Provider(provider),
 		Model:    strings.TrimSpace(os.Getenv(EnvSemanticModel)),
+		BaseURL:  strings.TrimSpace(os.Getenv(EnvSemanticBaseURL)),
+		APIKey:   strings.TrimSpace(os.Getenv(EnvSemanticAPIKey)),
+	}
+	if cfg.APIKey == "" {
+		cfg.APIKey = strings.TrimSpace(os.Getenv(EnvOpenAIAPIKey))
 	}
 	if dimStr := os.Getenv(EnvSemanticDim); dimStr != "" {
 		if dim, err := strconv.Atoi(dimStr); err == nil {
@@ -40,9 +47,9 @@ func NewEmbedderFromConfig(cfg EmbeddingConfig) (Embedder, error) {
 	case ProviderPythonSentenceTransformers:
 		return nil, fmt.Errorf("semantic embedder %q not implemented (mvp placeholder); set %s=%q for deterministic fallback", cfg.Provider, EnvSemanticEmbedder, ProviderHash)
 	case ProviderOpenAI:
-		return nil, fmt.Errorf("semantic embedder %q not implemented (placeholder); set %s=%q for deterministic fallback", cfg.Provider, EnvSemanticEmbedder, ProviderHash)
+		return NewOpenAIEmbedder(cfg)
 	default:
-		return nil, fmt.Errorf("unknown semantic embedder %q; expected %q", cfg.Provider, ProviderHash)
+		return nil, fmt.Errorf("unknown semantic embedder %q; expected %q or %q", cfg.Provider, ProviderHash, ProviderOpenAI)
 	}
 }
 
@@ -87,8 +94,8 @@ func SearchConfigFromEnv() (SearchConfig, error) {
 
 	if preset := strings.TrimSpace(os.Getenv(EnvSearchPreset)); preset != "" {
 		name := PresetName(strings.ToLower(preset))
-		if _, err := GetPreset(name); err != nil {
-			return SearchConfig{}, err
+		if !IsKnownPreset(name) {
+			return SearchConfig{}, fmt.Errorf("unknown preset %q", name)
 		}
 		cfg.Preset = name
 	}
@@ -111,16 +118,21 @@ func ParseWeightsJSON(raw string) (Weights, error) {
 	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
 		return Weights{}, fmt.Errorf("invalid weights JSON: %w", err)
 	}
+	return WeightsFromMap(payload)
+}
 
+// WeightsFromMap builds Weights from text/pagerank/status/impact/priority/recency
+// keys, all of which are required.
+func WeightsFromMap(payload map[string]float64) (Weights, error) {
 	required := []string{"text", "pagerank", "status", "impact", "priority", "recency"}
 	for _, key := range required {
 		if _, ok := payload[key]; !ok {
-			return Weights{}, fmt.Errorf("weights JSON missing %q", key)
+			return Weights{}, fmt.Errorf("weights missing %q", key)
 		}
 	}
 	for key := range payload {
 		if !isWeightKey(key) {
-			return Weights{}, fmt.Errorf("weights JSON has unknown key %q", key)
+			return Weights{}, fmt.Errorf("weights have unknown key %q", key)
 		}
 	}
 
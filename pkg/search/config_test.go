@@ -342,10 +342,10 @@ func TestNewEmbedderFromConfig(t *testing.T) {
 			errContains: "not implemented",
 		},
 		{
-			name:        "openai not implemented",
+			name:        "openai without key or base URL",
 			cfg:         EmbeddingConfig{Provider: ProviderOpenAI, Dim: 1536},
 			wantErr:     true,
-			errContains: "not implemented",
+			errContains: EnvSemanticAPIKey,
 		},
 		{
 			name:        "unknown provider error",
@@ -355,7 +355,7 @@ func TestNewEmbedderFromConfig(t *testing.T) {
 		},
 		{
 			name:        "error message suggests hash fallback",
-			cfg:         EmbeddingConfig{Provider: ProviderOpenAI},
+			cfg:         EmbeddingConfig{Provider: ProviderPythonSentenceTransformers},
 			wantErr:     true,
 			errContains: ProviderHash.String(),
 		},
@@ -14,7 +14,8 @@ const (
 	// sentence-transformers to generate high-quality embeddings (MVP choice for bv-9gf).
 	ProviderPythonSentenceTransformers Provider = "python-sentence-transformers"
 
-	// ProviderOpenAI uses a hosted embedding API.
+	// ProviderOpenAI speaks the OpenAI /embeddings wire format to a configurable
+	// endpoint (hosted API or a local OpenAI-compatible server).
 	ProviderOpenAI Provider = "openai"
 )
 
@@ -24,6 +25,9 @@ const (
 	EnvSemanticEmbedder = "BV_SEMANTIC_EMBEDDER"
 	EnvSemanticModel    = "BV_SEMANTIC_MODEL"
 	EnvSemanticDim      = "BV_SEMANTIC_DIM"
+	EnvSemanticBaseURL  = "BV_SEMANTIC_BASE_URL"
+	EnvSemanticAPIKey   = "BV_SEMANTIC_API_KEY"
+	EnvOpenAIAPIKey     = "OPENAI_API_KEY"
 )
 
 // EmbeddingConfig captures embedder selection/configuration.
@@ -32,11 +36,23 @@ type EmbeddingConfig struct {
 	Provider Provider
 	Model    string
 	Dim      int
+
+	// BaseURL and APIKey are used by ProviderOpenAI.
+	BaseURL string
+	APIKey  string
 }
 
+// Normalized fills in defaults. ProviderOpenAI keeps a zero Dim, meaning
+// "use the model's native dimension", which is detected on first contact.
 func (c EmbeddingConfig) Normalized() EmbeddingConfig {
 	if c.Dim <= 0 {
-		c.Dim = DefaultEmbeddingDim
+		c.Dim = 0
+		if c.Provider != ProviderOpenAI {
+			c.Dim = DefaultEmbeddingDim
+		}
+	}
+	if c.Provider == ProviderOpenAI && c.Model == "" {
+		c.Model = DefaultOpenAIModel
 	}
 	return c
 }
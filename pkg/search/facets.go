@@ -0,0 +1,238 @@
+package search
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// FacetField is an issue attribute search results can be counted and refined by.
+type FacetField string
+
+const (
+	FacetStatus   FacetField = "status"
+	FacetType     FacetField = "type"
+	FacetPriority FacetField = "priority"
+	FacetLabel    FacetField = "label"
+	FacetAssignee FacetField = "assignee"
+	FacetRepo     FacetField = "repo"
+)
+
+// FacetFields lists the supported facets in display order.
+var FacetFields = []FacetField{FacetStatus, FacetType, FacetPriority, FacetLabel, FacetAssignee, FacetRepo}
+
+// FacetNone is the bucket for issues without an assignee or source repo.
+const FacetNone = "(none)"
+
+// IsKnownFacet reports whether f is a supported facet field.
+func IsKnownFacet(f FacetField) bool {
+	for _, known := range FacetFields {
+		if f == known {
+			return true
+		}
+	}
+	return false
+}
+
+// FacetBucket is one value of a facet and how many results carry it.
+type FacetBucket struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// Facets maps each facet field to its buckets, largest first.
+type Facets map[FacetField][]FacetBucket
+
+// FacetValues returns the values issue contributes to field. Labels are
+// multi-valued; an issue without labels contributes none.
+func FacetValues(issue *model.Issue, field FacetField) []string {
+	switch field {
+	case FacetStatus:
+		return []string{string(issue.Status)}
+	case FacetType:
+		return []string{string(issue.IssueType)}
+	case FacetPriority:
+		return []string{fmt.Sprintf("P%d", issue.Priority)}
+	case FacetLabel:
+		return issue.Labels
+	case FacetAssignee:
+		if issue.Assignee == "" {
+			return []string{FacetNone}
+		}
+		return []string{issue.Assignee}
+	case FacetRepo:
+		if issue.SourceRepo == "" {
+			return []string{FacetNone}
+		}
+		return []string{issue.SourceRepo}
+	}
+	return nil
+}
+
+// ComputeFacets counts facet values over issues. The repo facet is omitted
+// when no issue carries a source repo (single-repo projects).
+func ComputeFacets(issues []*model.Issue) Facets {
+	counts := make(map[FacetField]map[string]int, len(FacetFields))
+	for _, field := range FacetFields {
+		counts[field] = make(map[string]int)
+	}
+	for _, issue := range issues {
+		if issue == nil {
+			continue
+		}
+		for _, field := range FacetFields {
+			for _, v := range FacetValues(issue, field) {
+				counts[field][v]++
+			}
+		}
+	}
+
+	out := make(Facets, len(FacetFields))
+	for _, field := range FacetFields {
+		c := counts[field]
+		if field == FacetRepo && len(c) == 1 && c[FacetNone] > 0 {
+			continue
+		}
+		buckets := make([]FacetBucket, 0, len(c))
+		for v, n := range c {
+			buckets = append(buckets, FacetBucket{Value: v, Count: n})
+		}
+		sort.Slice(buckets, func(i, j int) bool {
+			if buckets[i].Count == buckets[j].Count {
+				return buckets[i].Value < buckets[j].Value
+			}
+			return buckets[i].Count > buckets[j].Count
+		})
+		out[field] = buckets
+	}
+	return out
+}
+
+// FacetFilter restricts results to issues matching every field, where a
+// field matches when the issue carries any of its values.
+type FacetFilter map[FacetField][]string
+
+// ParseFacetFilter parses "status=open,label=api,label=ui". Repeating a
+// field ORs its values; different fields AND.
+func ParseFacetFilter(spec string) (FacetFilter, error) {
+	f := FacetFilter{}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		field := FacetField(strings.ToLower(strings.TrimSpace(name)))
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid facet filter %q (expected field=value)", part)
+		}
+		if !IsKnownFacet(field) {
+			return nil, fmt.Errorf("unknown facet %q (expected status|type|priority|label|assignee|repo)", field)
+		}
+		if field == FacetPriority {
+			p := strings.TrimPrefix(strings.ToUpper(value), "P")
+			if _, err := strconv.Atoi(p); err != nil {
+				return nil, fmt.Errorf("invalid priority %q", value)
+			}
+			value = "P" + p
+		}
+		f.Toggle(field, value)
+	}
+	return f, nil
+}
+
+// Empty reports whether the filter has no constraints.
+func (f FacetFilter) Empty() bool {
+	return len(f) == 0
+}
+
+// Has reports whether value is selected for field.
+func (f FacetFilter) Has(field FacetField, value string) bool {
+	for _, v := range f[field] {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Toggle adds value to field, or removes it when already present.
+func (f FacetFilter) Toggle(field FacetField, value string) {
+	values := f[field]
+	for i, v := range values {
+		if v == value {
+			values = append(values[:i:i], values[i+1:]...)
+			if len(values) == 0 {
+				delete(f, field)
+			} else {
+				f[field] = values
+			}
+			return
+		}
+	}
+	f[field] = append(values, value)
+}
+
+// Match reports whether issue satisfies the filter.
+func (f FacetFilter) Match(issue *model.Issue) bool {
+	for field, want := range f {
+		matched := false
+		for _, v := range FacetValues(issue, field) {
+			for _, w := range want {
+				if strings.EqualFold(v, w) {
+					matched = true
+					break
+				}
+			}
+			if matched {
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// String renders the filter in ParseFacetFilter syntax, fields in display order.
+func (f FacetFilter) String() string {
+	var parts []string
+	for _, field := range FacetFields {
+		values := append([]string(nil), f[field]...)
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, string(field)+"="+v)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// FilterResults keeps the results whose issue satisfies f, preserving order.
+func FilterResults(results []SearchResult, byID map[string]*model.Issue, f FacetFilter) []SearchResult {
+	if f.Empty() {
+		return results
+	}
+	out := make([]SearchResult, 0, len(results))
+	for _, r := range results {
+		if issue, ok := byID[r.IssueID]; ok && f.Match(issue) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// ResultFacets counts facet values over the issues behind results.
+func ResultFacets(results []SearchResult, byID map[string]*model.Issue) Facets {
+	issues := make([]*model.Issue, 0, len(results))
+	for _, r := range results {
+		if issue, ok := byID[r.IssueID]; ok {
+			issues = append(issues, issue)
+		}
+	}
+	return ComputeFacets(issues)
+}
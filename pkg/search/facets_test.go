@@ -0,0 +1,68 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func facetIssues() []*model.Issue {
+	return []*model.Issue{
+		{ID: "a", Status: model.StatusOpen, IssueType: model.TypeBug, Priority: 1, Labels: []string{"api", "auth"}, Assignee: "ana"},
+		{ID: "b", Status: model.StatusOpen, IssueType: model.TypeTask, Priority: 2, Labels: []string{"api"}},
+		{ID: "c", Status: model.StatusClosed, IssueType: model.TypeBug, Priority: 1, Assignee: "ana"},
+	}
+}
+
+func TestComputeFacets(t *testing.T) {
+	f := ComputeFacets(facetIssues())
+	if want := []FacetBucket{{"open", 2}, {"closed", 1}}; !reflect.DeepEqual(f[FacetStatus], want) {
+		t.Fatalf("status = %+v", f[FacetStatus])
+	}
+	if want := []FacetBucket{{"P1", 2}, {"P2", 1}}; !reflect.DeepEqual(f[FacetPriority], want) {
+		t.Fatalf("priority = %+v", f[FacetPriority])
+	}
+	if want := []FacetBucket{{"api", 2}, {"auth", 1}}; !reflect.DeepEqual(f[FacetLabel], want) {
+		t.Fatalf("label = %+v", f[FacetLabel])
+	}
+	if want := []FacetBucket{{"ana", 2}, {FacetNone, 1}}; !reflect.DeepEqual(f[FacetAssignee], want) {
+		t.Fatalf("assignee = %+v", f[FacetAssignee])
+	}
+	if _, ok := f[FacetRepo]; ok {
+		t.Fatalf("repo facet should be omitted without source repos: %+v", f[FacetRepo])
+	}
+}
+
+func TestFacetFilter(t *testing.T) {
+	f, err := ParseFacetFilter("status=open, label=auth,label=missing,priority=p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.String(); got != "status=open,priority=P1,label=auth,label=missing" {
+		t.Fatalf("String() = %q", got)
+	}
+	issues := facetIssues()
+	if !f.Match(issues[0]) || f.Match(issues[1]) || f.Match(issues[2]) {
+		t.Fatal("filter should match only issue a")
+	}
+
+	f.Toggle(FacetLabel, "auth")
+	f.Toggle(FacetLabel, "missing")
+	if _, ok := f[FacetLabel]; ok || f.Has(FacetLabel, "auth") {
+		t.Fatalf("toggling off every value should drop the field: %+v", f)
+	}
+
+	byID := map[string]*model.Issue{"a": issues[0], "b": issues[1], "c": issues[2]}
+	results := []SearchResult{{IssueID: "c"}, {IssueID: "b"}, {IssueID: "a"}}
+	got := FilterResults(results, byID, FacetFilter{FacetType: {"bug"}})
+	if len(got) != 2 || got[0].IssueID != "c" || got[1].IssueID != "a" {
+		t.Fatalf("FilterResults = %+v", got)
+	}
+
+	for _, bad := range []string{"status", "color=red", "priority=high"} {
+		if _, err := ParseFacetFilter(bad); err == nil {
+			t.Errorf("ParseFacetFilter(%q) should fail", bad)
+		}
+	}
+}
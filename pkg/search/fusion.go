@@ -0,0 +1,54 @@
+package search
+
+import "sort"
+
+// DefaultRRFK is the rank constant from the original reciprocal rank fusion
+// paper; larger values flatten the advantage of top-ranked items.
+const DefaultRRFK = 60
+
+// FuseRRF merges ranked result lists with reciprocal rank fusion:
+//
+//	score(d) = Σ 1 / (k + rank_i(d))
+//
+// Only ranks matter, so lists with incomparable score scales (BM25 vs cosine)
+// combine cleanly. Scores are normalized to [0,1], where 1 means ranked first
+// in every non-empty list. Ties are broken by issue ID.
+func FuseRRF(k int, lists ...[]SearchResult) []SearchResult {
+	if k <= 0 {
+		k = DefaultRRFK
+	}
+	scores := make(map[string]float64)
+	active := 0
+	for _, list := range lists {
+		if len(list) == 0 {
+			continue
+		}
+		active++
+		seen := make(map[string]struct{}, len(list))
+		rank := 0
+		for _, r := range list {
+			if _, dup := seen[r.IssueID]; dup || r.IssueID == "" {
+				continue
+			}
+			seen[r.IssueID] = struct{}{}
+			rank++
+			scores[r.IssueID] += 1 / float64(k+rank)
+		}
+	}
+	if active == 0 {
+		return nil
+	}
+
+	best := float64(active) / float64(k+1)
+	out := make([]SearchResult, 0, len(scores))
+	for id, score := range scores {
+		out = append(out, SearchResult{IssueID: id, Score: score / best})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].IssueID < out[j].IssueID
+	})
+	return out
+}
@@ -0,0 +1,371 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// History document kinds.
+const (
+	HistoryKindCommit = "commit"
+	HistoryKindIssue  = "issue"
+)
+
+// HistoryDoc is one searchable piece of git history: a commit message, or
+// the text an issue field had when it first appeared at some commit.
+type HistoryDoc struct {
+	Kind            string    `json:"kind"`
+	CommitSHA       string    `json:"commit_sha"`
+	Timestamp       time.Time `json:"timestamp"`
+	Author          string    `json:"author,omitempty"`
+	IssueIDs        []string  `json:"issue_ids,omitempty"` // owning bead, or beads a commit mentions
+	Title           string    `json:"title,omitempty"`     // issue title at that commit, or commit subject
+	Field           string    `json:"field,omitempty"`     // chunk field name for issue docs
+	Comment         int       `json:"comment,omitempty"`   // 1-based comment number
+	Text            string    `json:"text"`
+	CompactionLevel int       `json:"compaction_level,omitempty"`
+}
+
+// HistoryOptions bounds how much history is indexed.
+type HistoryOptions struct {
+	MaxCommits   int // commit messages, newest first (0 = all)
+	MaxRevisions int // beads file revisions, newest first (0 = all)
+}
+
+// DefaultHistoryOptions indexes the last 500 commits and beads revisions.
+func DefaultHistoryOptions() HistoryOptions {
+	return HistoryOptions{MaxCommits: 500, MaxRevisions: 500}
+}
+
+// historyCorpusVersion invalidates cached corpora when the format changes.
+const historyCorpusVersion = 1
+
+// HistoryCorpus is the cached set of history documents for a repository.
+// It is extended incrementally: only commits and beads revisions not yet
+// seen are read from git.
+type HistoryCorpus struct {
+	Version   int             `json:"version"`
+	Commits   map[string]bool `json:"commits"`   // commit messages indexed
+	Revisions map[string]bool `json:"revisions"` // beads revisions loaded
+	Docs      []HistoryDoc    `json:"docs"`
+}
+
+// HistoryCorpusPath returns where the history corpus is cached.
+func HistoryCorpusPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", "search-history.json")
+}
+
+// LoadHistoryCorpus reads the cached corpus, returning an empty one when the
+// cache is missing, unreadable or from an older format.
+func LoadHistoryCorpus(path string) *HistoryCorpus {
+	empty := &HistoryCorpus{
+		Version:   historyCorpusVersion,
+		Commits:   make(map[string]bool),
+		Revisions: make(map[string]bool),
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return empty
+	}
+	var c HistoryCorpus
+	if err := json.Unmarshal(data, &c); err != nil || c.Version != historyCorpusVersion {
+		return empty
+	}
+	if c.Commits == nil {
+		c.Commits = make(map[string]bool)
+	}
+	if c.Revisions == nil {
+		c.Revisions = make(map[string]bool)
+	}
+	return &c
+}
+
+// Save writes the corpus atomically. Each writer uses its own temp file, so
+// concurrent bv processes never rename each other's partial writes.
+func (c *HistoryCorpus) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("mkdir %s: %w", filepath.Dir(path), err)
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op after a successful rename
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// HistoryUpdateStats reports what an Update read from git.
+type HistoryUpdateStats struct {
+	Commits   int `json:"commits"`   // new commit messages indexed
+	Revisions int `json:"revisions"` // new beads revisions loaded
+	Docs      int `json:"docs"`      // documents added
+}
+
+// Update adds commits and beads revisions that are not yet in the corpus.
+// Issue field texts are recorded once, at the oldest loaded revision where
+// they appear, so text later edited or compacted away stays searchable.
+// current supplies the bead IDs that commit messages are linked to.
+func (c *HistoryCorpus) Update(gl *loader.GitLoader, current []model.Issue, opts HistoryOptions) (HistoryUpdateStats, error) {
+	var stats HistoryUpdateStats
+	before := len(c.Docs)
+
+	revisions, err := gl.ListRevisions(opts.MaxRevisions)
+	if err != nil {
+		return stats, err
+	}
+	seen := make(map[string]bool, len(c.Docs))
+	for _, d := range c.Docs {
+		if d.Kind == HistoryKindIssue {
+			seen[historyTextKey(d)] = true
+		}
+	}
+	for i := len(revisions) - 1; i >= 0; i-- {
+		rev := revisions[i]
+		if c.Revisions[rev.SHA] {
+			continue
+		}
+		issues, err := gl.LoadAt(rev.SHA)
+		if err != nil {
+			return stats, fmt.Errorf("loading beads at %s: %w", shortSHA(rev.SHA), err)
+		}
+		for _, iss := range issues {
+			for _, d := range issueHistoryDocs(iss, rev) {
+				if key := historyTextKey(d); !seen[key] {
+					seen[key] = true
+					c.Docs = append(c.Docs, d)
+				}
+			}
+		}
+		c.Revisions[rev.SHA] = true
+		stats.Revisions++
+	}
+
+	commits, err := gl.ListCommitMessages(opts.MaxCommits)
+	if err != nil {
+		return stats, err
+	}
+	known := make(map[string]string)
+	for _, iss := range current {
+		known[strings.ToLower(iss.ID)] = iss.ID
+	}
+	for _, d := range c.Docs {
+		if d.Kind == HistoryKindIssue {
+			known[strings.ToLower(d.IssueIDs[0])] = d.IssueIDs[0]
+		}
+	}
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+		if c.Commits[commit.SHA] || commit.Message == "" {
+			continue
+		}
+		subject, _, _ := strings.Cut(commit.Message, "\n")
+		c.Docs = append(c.Docs, HistoryDoc{
+			Kind:      HistoryKindCommit,
+			CommitSHA: commit.SHA,
+			Timestamp: commit.Timestamp,
+			Author:    commit.Author,
+			IssueIDs:  mentionedIssueIDs(commit.Message, known),
+			Title:     subject,
+			Text:      commit.Message,
+		})
+		c.Commits[commit.SHA] = true
+		stats.Commits++
+	}
+
+	stats.Docs = len(c.Docs) - before
+	return stats, nil
+}
+
+// OpenHistoryCorpus loads the cached corpus for the git repository at
+// projectDir, brings it up to date with HEAD and saves it back.
+func OpenHistoryCorpus(projectDir string, current []model.Issue, opts HistoryOptions) (*HistoryCorpus, HistoryUpdateStats, error) {
+	path := HistoryCorpusPath(projectDir)
+	c := LoadHistoryCorpus(path)
+	stats, err := c.Update(loader.NewGitLoader(projectDir), current, opts)
+	if err != nil {
+		return nil, stats, err
+	}
+	if stats.Commits > 0 || stats.Revisions > 0 {
+		if err := c.Save(path); err != nil {
+			return nil, stats, fmt.Errorf("saving history index: %w", err)
+		}
+	}
+	return c, stats, nil
+}
+
+// issueHistoryDocs splits an issue version into one doc per non-empty field
+// and comment.
+func issueHistoryDocs(iss model.Issue, rev loader.RevisionInfo) []HistoryDoc {
+	base := HistoryDoc{
+		Kind:            HistoryKindIssue,
+		CommitSHA:       rev.SHA,
+		Timestamp:       rev.Timestamp,
+		IssueIDs:        []string{iss.ID},
+		Title:           iss.Title,
+		CompactionLevel: iss.CompactionLevel,
+	}
+	var docs []HistoryDoc
+	add := func(field string, comment int, text string) {
+		if text = strings.TrimSpace(text); text == "" {
+			return
+		}
+		d := base
+		d.Field, d.Comment, d.Text = field, comment, text
+		docs = append(docs, d)
+	}
+	add(ChunkFieldHead, 0, iss.Title)
+	add(ChunkFieldDescription, 0, iss.Description)
+	add(ChunkFieldDesign, 0, iss.Design)
+	add(ChunkFieldAcceptanceCriteria, 0, iss.AcceptanceCriteria)
+	add(ChunkFieldNotes, 0, iss.Notes)
+	for i, cm := range iss.Comments {
+		if cm != nil {
+			add(ChunkFieldComment, i+1, cm.Text)
+		}
+	}
+	return docs
+}
+
+// historyTextKey identifies a distinct field value of an issue. Comments are
+// keyed by text alone so renumbering after a compaction is not a new version.
+func historyTextKey(d HistoryDoc) string {
+	return d.IssueIDs[0] + "\x00" + d.Field + "\x00" + d.Text
+}
+
+// historyIDToken matches bead-ID-shaped words such as bv-12 or bv-9gf.3.
+var historyIDToken = regexp.MustCompile(`[A-Za-z][A-Za-z0-9_]*-[A-Za-z0-9]+(?:\.[A-Za-z0-9]+)*`)
+
+// mentionedIssueIDs returns the known bead IDs a commit message mentions, in
+// order of first mention.
+func mentionedIssueIDs(message string, known map[string]string) []string {
+	var ids []string
+	added := make(map[string]bool)
+	for _, tok := range historyIDToken.FindAllString(message, -1) {
+		id, ok := known[strings.ToLower(tok)]
+		if ok && !added[id] {
+			added[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
+
+// HistoryHit is a history document matching a query.
+type HistoryHit struct {
+	HistoryDoc
+	Score float64     `json:"score"`
+	Match *ChunkMatch `json:"match,omitempty"`
+	// Current is true when an issue doc's text is still present in the
+	// issue today; false means it was edited, compacted or deleted since.
+	Current bool `json:"current"`
+}
+
+// SearchHistory ranks the corpus against query with BM25 over bead IDs,
+// titles and text, using the same query syntax as issue search.
+func SearchHistory(c *HistoryCorpus, query string, current []model.Issue, limit int) []HistoryHit {
+	if c == nil || len(c.Docs) == 0 || limit <= 0 {
+		return nil
+	}
+	docs := make([]lexicalDoc, len(c.Docs))
+	for i, d := range c.Docs {
+		ld := lexicalDoc{id: strconv.Itoa(i)}
+		ld.fields[FieldID] = append(append([]string(nil), d.IssueIDs...), shortSHA(d.CommitSHA))
+		if d.Kind == HistoryKindIssue && d.Field != ChunkFieldHead {
+			ld.fields[FieldTitle] = []string{d.Title}
+		}
+		if d.Field == ChunkFieldComment {
+			ld.fields[FieldComments] = []string{d.Text}
+		} else {
+			ld.fields[FieldDescription] = []string{d.Text}
+		}
+		docs[i] = ld
+	}
+	results := newLexicalIndex(docs, DefaultFieldBoosts()).Search(query, limit)
+
+	byID := make(map[string]model.Issue, len(current))
+	for _, iss := range current {
+		byID[iss.ID] = iss
+	}
+	terms := queryTermSet(query)
+	hits := make([]HistoryHit, 0, len(results))
+	for _, r := range results {
+		i, err := strconv.Atoi(r.IssueID)
+		if err != nil || i < 0 || i >= len(c.Docs) {
+			continue
+		}
+		d := c.Docs[i]
+		snippet, highlights := buildSnippet(d.Text, terms)
+		hit := HistoryHit{
+			HistoryDoc: d,
+			Score:      r.Score,
+			Match:      &ChunkMatch{Field: d.Field, Comment: d.Comment, Snippet: snippet, Highlights: highlights},
+		}
+		if d.Kind == HistoryKindCommit {
+			hit.Match.Field = HistoryKindCommit
+			hit.Current = true
+		} else if iss, ok := byID[d.IssueIDs[0]]; ok {
+			hit.Current = issueHasText(iss, d.Field, d.Text)
+		}
+		hits = append(hits, hit)
+	}
+	sort.SliceStable(hits, func(a, b int) bool {
+		if hits[a].Score != hits[b].Score {
+			return hits[a].Score > hits[b].Score
+		}
+		return hits[a].Timestamp.After(hits[b].Timestamp)
+	})
+	return hits
+}
+
+// issueHasText reports whether an issue's field still holds text.
+func issueHasText(iss model.Issue, field, text string) bool {
+	switch field {
+	case ChunkFieldHead:
+		return strings.TrimSpace(iss.Title) == text
+	case ChunkFieldDescription:
+		return strings.TrimSpace(iss.Description) == text
+	case ChunkFieldDesign:
+		return strings.TrimSpace(iss.Design) == text
+	case ChunkFieldAcceptanceCriteria:
+		return strings.TrimSpace(iss.AcceptanceCriteria) == text
+	case ChunkFieldNotes:
+		return strings.TrimSpace(iss.Notes) == text
+	case ChunkFieldComment:
+		for _, cm := range iss.Comments {
+			if cm != nil && strings.TrimSpace(cm.Text) == text {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package search
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func historyGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func historyCommit(t *testing.T, dir, beads, msg string) string {
+	t.Helper()
+	if beads != "" {
+		if err := os.WriteFile(filepath.Join(dir, ".beads", "issues.jsonl"), []byte(beads), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	} else if err := os.WriteFile(filepath.Join(dir, "code.txt"), []byte(msg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	historyGit(t, dir, "add", ".")
+	historyGit(t, dir, "commit", "-q", "-m", msg)
+	return historyGit(t, dir, "rev-parse", "HEAD")
+}
+
+func TestHistoryCorpus_FindsCompactedTextAndCommits(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	historyGit(t, dir, "init", "-q")
+	historyGit(t, dir, "config", "user.email", "test@test.com")
+	historyGit(t, dir, "config", "user.name", "Test User")
+	if err := os.MkdirAll(filepath.Join(dir, ".beads"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	first := historyCommit(t, dir, `{"id":"bv-1","title":"Cache layer","description":"We discussed using redis with a five minute TTL","status":"open","priority":1,"issue_type":"task"}
+`, "Add cache issue")
+	historyCommit(t, dir, `{"id":"bv-1","title":"Cache layer","description":"Summary: in-process cache","status":"closed","priority":1,"issue_type":"task","compaction_level":1}
+`, "Compact bv-1")
+	codeSHA := historyCommit(t, dir, "", "Switch cache to memcached\n\nCloses bv-1.")
+
+	current := []model.Issue{{ID: "bv-1", Title: "Cache layer", Description: "Summary: in-process cache"}}
+	corpus := LoadHistoryCorpus(HistoryCorpusPath(dir))
+	stats, err := corpus.Update(loader.NewGitLoader(dir), current, DefaultHistoryOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Revisions != 2 || stats.Commits != 3 {
+		t.Fatalf("stats = %+v", stats)
+	}
+
+	hits := SearchHistory(corpus, "redis", current, 5)
+	if len(hits) != 1 {
+		t.Fatalf("redis hits = %+v", hits)
+	}
+	h := hits[0]
+	if h.Kind != HistoryKindIssue || h.CommitSHA != first || h.IssueIDs[0] != "bv-1" || h.Current || h.Field != ChunkFieldDescription {
+		t.Fatalf("redis hit = %+v", h)
+	}
+	if h.Match == nil || !strings.Contains(h.Match.Snippet, "redis") {
+		t.Fatalf("match = %+v", h.Match)
+	}
+
+	hits = SearchHistory(corpus, "memcached", current, 5)
+	if len(hits) != 1 || hits[0].Kind != HistoryKindCommit || hits[0].CommitSHA != codeSHA || len(hits[0].IssueIDs) != 1 || hits[0].IssueIDs[0] != "bv-1" {
+		t.Fatalf("memcached hits = %+v", hits)
+	}
+
+	hits = SearchHistory(corpus, "in-process", current, 5)
+	if len(hits) == 0 || !hits[0].Current || hits[0].CompactionLevel != 1 {
+		t.Fatalf("current text hits = %+v", hits)
+	}
+
+	// A saved corpus is extended incrementally.
+	if err := corpus.Save(HistoryCorpusPath(dir)); err != nil {
+		t.Fatal(err)
+	}
+	historyCommit(t, dir, "", "Tune eviction policy")
+	reopened, stats, err := OpenHistoryCorpus(dir, current, DefaultHistoryOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Revisions != 0 || stats.Commits != 1 || len(reopened.Docs) != len(corpus.Docs)+1 {
+		t.Fatalf("incremental stats = %+v, docs %d -> %d", stats, len(corpus.Docs), len(reopened.Docs))
+	}
+}
+
+func TestMentionedIssueIDs(t *testing.T) {
+	known := map[string]string{"bv-1": "bv-1", "bv-9gf.3": "bv-9gf.3"}
+	got := mentionedIssueIDs("Fix BV-1 and bv-9gf.3, not bv-2 (again bv-1)", known)
+	if len(got) != 2 || got[0] != "bv-1" || got[1] != "bv-9gf.3" {
+		t.Fatalf("ids = %v", got)
+	}
+}
@@ -0,0 +1,498 @@
+package search
+
+import (
+	"container/heap"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/util/topk"
+)
+
+// HNSW parameters. M bounds the out-degree on upper layers (layer 0 allows
+// 2*M); efConstruction is the candidate list size while inserting. Search
+// uses max(hnswMinEfSearch, 2k) candidates.
+const (
+	hnswM              = 16
+	hnswEfConstruction = 100
+	hnswMinEfSearch    = 64
+	hnswSeed           = 0x6276 // deterministic graphs for identical inputs
+	hnswMaxLevel       = 16
+)
+
+// hnswNode is one vector in the graph. Removed or superseded vectors stay as
+// tombstones so the graph remains navigable; they are never returned and are
+// dropped on the next rebuild or Save.
+type hnswNode struct {
+	id      string
+	vec     []float32
+	links   [][]int32 // links[layer] = neighbour slots
+	deleted bool
+}
+
+// hnswGraph is a Hierarchical Navigable Small World graph (Malkov & Yashunin)
+// over inner-product similarity. Vectors are expected to be L2-normalized,
+// which every Embedder in this package guarantees, so inner product equals
+// cosine similarity.
+//
+// The graph is not safe for concurrent mutation; VectorIndex guards it with
+// its own lock and only calls search under a read lock.
+type hnswGraph struct {
+	m              int
+	efConstruction int
+	levelMult      float64
+
+	nodes    []hnswNode
+	slots    map[string]int32 // live id -> slot
+	entry    int32            // -1 when empty
+	maxLevel int
+	deleted  int
+
+	rng *rand.Rand
+}
+
+func newHNSWGraph(m, efConstruction int) *hnswGraph {
+	if m < 2 {
+		m = hnswM
+	}
+	if efConstruction < m {
+		efConstruction = hnswEfConstruction
+	}
+	return &hnswGraph{
+		m:              m,
+		efConstruction: efConstruction,
+		levelMult:      1 / math.Log(float64(m)),
+		slots:          make(map[string]int32),
+		entry:          -1,
+		rng:            rand.New(rand.NewSource(hnswSeed)),
+	}
+}
+
+// buildHNSW indexes entries in ID order so the same input yields the same graph.
+func buildHNSW(entries map[string]VectorEntry) *hnswGraph {
+	ids := make([]string, 0, len(entries))
+	for id := range entries {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	g := newHNSWGraph(hnswM, hnswEfConstruction)
+	g.nodes = make([]hnswNode, 0, len(ids))
+	for _, id := range ids {
+		g.insert(id, entries[id].Vector)
+	}
+	return g
+}
+
+func (g *hnswGraph) live() int { return len(g.slots) }
+
+func (g *hnswGraph) maxLinks(layer int) int {
+	if layer == 0 {
+		return 2 * g.m
+	}
+	return g.m
+}
+
+func (g *hnswGraph) randomLevel() int {
+	level := int(-math.Log(1-g.rng.Float64()) * g.levelMult)
+	return min(level, hnswMaxLevel)
+}
+
+// upsert inserts or replaces the vector for id.
+func (g *hnswGraph) upsert(id string, vec []float32) {
+	g.remove(id)
+	g.insert(id, vec)
+}
+
+// remove tombstones id. The graph is rebuilt once tombstones outnumber live
+// nodes, keeping search cost proportional to the live set.
+func (g *hnswGraph) remove(id string) {
+	slot, ok := g.slots[id]
+	if !ok {
+		return
+	}
+	g.nodes[slot].deleted = true
+	delete(g.slots, id)
+	g.deleted++
+	if g.deleted > 64 && g.deleted > g.live() {
+		g.rebuild()
+	}
+}
+
+func (g *hnswGraph) rebuild() {
+	entries := make(map[string]VectorEntry, g.live())
+	for id, slot := range g.slots {
+		entries[id] = VectorEntry{Vector: g.nodes[slot].vec}
+	}
+	*g = *buildHNSW(entries)
+}
+
+func (g *hnswGraph) insert(id string, vec []float32) {
+	level := g.randomLevel()
+	slot := int32(len(g.nodes))
+	g.nodes = append(g.nodes, hnswNode{id: id, vec: vec, links: make([][]int32, level+1)})
+	g.slots[id] = slot
+
+	if g.entry < 0 {
+		g.entry = slot
+		g.maxLevel = level
+		return
+	}
+
+	ep := g.entry
+	for layer := g.maxLevel; layer > level; layer-- {
+		ep = g.greedy(vec, ep, layer)
+	}
+	eps := []int32{ep}
+	for layer := min(level, g.maxLevel); layer >= 0; layer-- {
+		candidates := g.searchLayer(vec, eps, g.efConstruction, layer)
+		neighbours := g.selectNeighbours(candidates, g.maxLinks(layer), slot)
+		g.nodes[slot].links[layer] = neighbours
+		for _, n := range neighbours {
+			g.link(n, slot, layer)
+		}
+		eps = eps[:0]
+		for _, c := range candidates {
+			eps = append(eps, c.slot)
+		}
+	}
+	if level > g.maxLevel {
+		g.entry = slot
+		g.maxLevel = level
+	}
+}
+
+// link adds from->to on layer. When the list overflows, tombstones and then
+// the least similar neighbour are dropped; re-running the diversity heuristic
+// here dominated build time for little recall gain.
+func (g *hnswGraph) link(from, to int32, layer int) {
+	node := &g.nodes[from]
+	links := append(node.links[layer], to)
+	if len(links) > g.maxLinks(layer) {
+		live := links[:0]
+		for _, n := range links {
+			if !g.nodes[n].deleted {
+				live = append(live, n)
+			}
+		}
+		links = live
+	}
+	if len(links) > g.maxLinks(layer) {
+		worst, worstSim := 0, math.Inf(1)
+		for i, n := range links {
+			if sim := dot32(node.vec, g.nodes[n].vec); sim < worstSim {
+				worst, worstSim = i, sim
+			}
+		}
+		links = append(links[:worst], links[worst+1:]...)
+	}
+	node.links[layer] = links
+}
+
+// selectNeighbours applies the HNSW diversity heuristic: a candidate is kept
+// only if it is closer to the new node than to any neighbour already kept.
+// Pruned candidates backfill remaining slots. cands must be sorted by
+// descending similarity.
+func (g *hnswGraph) selectNeighbours(cands []hnswCandidate, limit int, self int32) []int32 {
+	out := make([]int32, 0, limit)
+	var pruned []int32
+	for _, c := range cands {
+		if len(out) >= limit {
+			break
+		}
+		if c.slot == self || g.nodes[c.slot].deleted {
+			continue
+		}
+		keep := true
+		for _, kept := range out {
+			if dot32(g.nodes[c.slot].vec, g.nodes[kept].vec) > c.sim {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			out = append(out, c.slot)
+		} else {
+			pruned = append(pruned, c.slot)
+		}
+	}
+	for _, p := range pruned {
+		if len(out) >= limit {
+			break
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// greedy walks layer towards q and returns the closest node found.
+func (g *hnswGraph) greedy(q []float32, ep int32, layer int) int32 {
+	best := ep
+	bestSim := dot32(q, g.nodes[ep].vec)
+	for changed := true; changed; {
+		changed = false
+		for _, n := range g.nodes[best].links[layer] {
+			if sim := dot32(q, g.nodes[n].vec); sim > bestSim {
+				best, bestSim, changed = n, sim, true
+			}
+		}
+	}
+	return best
+}
+
+// searchLayer returns up to ef nodes nearest q on layer, sorted by descending
+// similarity. Tombstones are traversed and returned; callers filter them.
+func (g *hnswGraph) searchLayer(q []float32, eps []int32, ef int, layer int) []hnswCandidate {
+	visited := make(hnswVisited, (len(g.nodes)+63)/64)
+	candidates := &hnswMaxHeap{}
+	results := &hnswMinHeap{}
+	for _, ep := range eps {
+		if visited.testAndSet(ep) {
+			continue
+		}
+		c := hnswCandidate{slot: ep, sim: dot32(q, g.nodes[ep].vec)}
+		heap.Push(candidates, c)
+		heap.Push(results, c)
+		if results.Len() > ef {
+			heap.Pop(results)
+		}
+	}
+
+	for candidates.Len() > 0 {
+		c := heap.Pop(candidates).(hnswCandidate)
+		if results.Len() >= ef && c.sim < (*results)[0].sim {
+			break
+		}
+		node := &g.nodes[c.slot]
+		if layer >= len(node.links) {
+			continue
+		}
+		for _, n := range node.links[layer] {
+			if visited.testAndSet(n) {
+				continue
+			}
+			sim := dot32(q, g.nodes[n].vec)
+			if results.Len() < ef || sim > (*results)[0].sim {
+				heap.Push(candidates, hnswCandidate{slot: n, sim: sim})
+				heap.Push(results, hnswCandidate{slot: n, sim: sim})
+				if results.Len() > ef {
+					heap.Pop(results)
+				}
+			}
+		}
+	}
+
+	out := make([]hnswCandidate, len(*results))
+	copy(out, *results)
+	sortCandidates(out)
+	return out
+}
+
+// search returns the approximate top k live nodes for q.
+func (g *hnswGraph) search(q []float32, k int) []SearchResult {
+	if g.entry < 0 || k <= 0 || g.live() == 0 {
+		return nil
+	}
+	ep := g.entry
+	for layer := g.maxLevel; layer > 0; layer-- {
+		ep = g.greedy(q, ep, layer)
+	}
+	ef := max(hnswMinEfSearch, 2*k)
+	collector := topk.New[SearchResult](k, func(a, b SearchResult) bool {
+		return a.IssueID < b.IssueID
+	})
+	for _, c := range g.searchLayer(q, []int32{ep}, ef, 0) {
+		node := &g.nodes[c.slot]
+		if node.deleted {
+			continue
+		}
+		// Report the same float64 score the exact scan would.
+		score := dotFloat32(q, node.vec)
+		collector.Add(SearchResult{IssueID: node.id, Score: score}, score)
+	}
+	return collector.Results()
+}
+
+// dot32 is a float32 inner product used for graph navigation, where speed
+// matters more than the last bits of precision.
+func dot32(a, b []float32) float64 {
+	n := min(len(a), len(b))
+	a, b = a[:n], b[:n]
+	var s0, s1, s2, s3 float32
+	i := 0
+	for ; i+4 <= n; i += 4 {
+		s0 += a[i] * b[i]
+		s1 += a[i+1] * b[i+1]
+		s2 += a[i+2] * b[i+2]
+		s3 += a[i+3] * b[i+3]
+	}
+	for ; i < n; i++ {
+		s0 += a[i] * b[i]
+	}
+	return float64(s0 + s1 + s2 + s3)
+}
+
+// hnswVisited is a per-search bitset over node slots.
+type hnswVisited []uint64
+
+func (v hnswVisited) testAndSet(slot int32) bool {
+	word, bit := slot>>6, uint64(1)<<(slot&63)
+	seen := v[word]&bit != 0
+	v[word] |= bit
+	return seen
+}
+
+type hnswCandidate struct {
+	slot int32
+	sim  float64
+}
+
+func sortCandidates(c []hnswCandidate) {
+	sort.Slice(c, func(i, j int) bool {
+		if c[i].sim != c[j].sim {
+			return c[i].sim > c[j].sim
+		}
+		return c[i].slot < c[j].slot
+	})
+}
+
+type hnswMaxHeap []hnswCandidate
+
+func (h hnswMaxHeap) Len() int           { return len(h) }
+func (h hnswMaxHeap) Less(i, j int) bool { return h[i].sim > h[j].sim }
+func (h hnswMaxHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *hnswMaxHeap) Push(x any)        { *h = append(*h, x.(hnswCandidate)) }
+func (h *hnswMaxHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+type hnswMinHeap []hnswCandidate
+
+func (h hnswMinHeap) Len() int           { return len(h) }
+func (h hnswMinHeap) Less(i, j int) bool { return h[i].sim < h[j].sim }
+func (h hnswMinHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *hnswMinHeap) Push(x any)        { *h = append(*h, x.(hnswCandidate)) }
+func (h *hnswMinHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// writeGraph serializes the live graph with neighbours referenced by position
+// in order (the entry order of the index file). It reports false, writing
+// nothing, if the graph does not cover exactly the ids in order.
+func (g *hnswGraph) writeGraph(w io.Writer, order []string) (bool, error) {
+	if g.live() != len(order) || g.live() == 0 {
+		return false, nil
+	}
+	pos := make(map[int32]uint32, len(order))
+	for i, id := range order {
+		slot, ok := g.slots[id]
+		if !ok {
+			return false, nil
+		}
+		pos[slot] = uint32(i)
+	}
+
+	entry, maxLevel := g.entry, g.maxLevel
+	if g.nodes[entry].deleted {
+		entry, maxLevel = -1, -1
+		for _, slot := range g.slots {
+			if l := len(g.nodes[slot].links) - 1; l > maxLevel || (l == maxLevel && slot < entry) {
+				entry, maxLevel = slot, l
+			}
+		}
+	}
+
+	header := []any{uint16(g.m), uint16(g.efConstruction), pos[entry], uint8(maxLevel)}
+	for _, v := range header {
+		if err := binary.Write(w, binary.LittleEndian, v); err != nil {
+			return false, fmt.Errorf("write graph header: %w", err)
+		}
+	}
+	var buf []uint32
+	for _, id := range order {
+		node := &g.nodes[g.slots[id]]
+		if err := binary.Write(w, binary.LittleEndian, uint8(len(node.links)-1)); err != nil {
+			return false, fmt.Errorf("write graph level: %w", err)
+		}
+		for _, links := range node.links {
+			buf = buf[:0]
+			for _, n := range links {
+				if p, ok := pos[n]; ok {
+					buf = append(buf, p)
+				}
+			}
+			if err := binary.Write(w, binary.LittleEndian, uint16(len(buf))); err != nil {
+				return false, fmt.Errorf("write graph links: %w", err)
+			}
+			if err := binary.Write(w, binary.LittleEndian, buf); err != nil {
+				return false, fmt.Errorf("write graph links: %w", err)
+			}
+		}
+	}
+	return true, nil
+}
+
+// readGraph restores a graph written by writeGraph. vecs[i] is the vector of
+// ids[i]; the slices are shared with the index, not copied.
+func readGraph(r io.Reader, ids []string, vecs [][]float32) (*hnswGraph, error) {
+	var m, efc uint16
+	var entry uint32
+	var maxLevel uint8
+	for _, v := range []any{&m, &efc, &entry, &maxLevel} {
+		if err := binary.Read(r, binary.LittleEndian, v); err != nil {
+			return nil, fmt.Errorf("read graph header: %w", err)
+		}
+	}
+	n := len(ids)
+	if int(entry) >= n || maxLevel > hnswMaxLevel {
+		return nil, fmt.Errorf("invalid graph header")
+	}
+
+	g := newHNSWGraph(int(m), int(efc))
+	g.nodes = make([]hnswNode, n)
+	for i := range ids {
+		var level uint8
+		if err := binary.Read(r, binary.LittleEndian, &level); err != nil {
+			return nil, fmt.Errorf("read graph level: %w", err)
+		}
+		if level > maxLevel {
+			return nil, fmt.Errorf("graph node level %d exceeds max %d", level, maxLevel)
+		}
+		links := make([][]int32, int(level)+1)
+		for layer := range links {
+			var count uint16
+			if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+				return nil, fmt.Errorf("read graph links: %w", err)
+			}
+			raw := make([]uint32, count)
+			if err := binary.Read(r, binary.LittleEndian, raw); err != nil {
+				return nil, fmt.Errorf("read graph links: %w", err)
+			}
+			links[layer] = make([]int32, count)
+			for j, p := range raw {
+				if int(p) >= n {
+					return nil, fmt.Errorf("graph link %d out of range", p)
+				}
+				links[layer][j] = int32(p)
+			}
+		}
+		g.nodes[i] = hnswNode{id: ids[i], vec: vecs[i], links: links}
+		g.slots[ids[i]] = int32(i)
+	}
+	g.entry = int32(entry)
+	g.maxLevel = int(maxLevel)
+	if len(g.nodes[entry].links)-1 != g.maxLevel {
+		return nil, fmt.Errorf("graph entry point is not on the top layer")
+	}
+	// Keep level sampling independent of how many nodes were loaded.
+	g.rng = rand.New(rand.NewSource(hnswSeed + int64(n)))
+	return g, nil
+}
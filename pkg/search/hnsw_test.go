@@ -0,0 +1,202 @@
+package search
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func randomUnitVector(rng *rand.Rand, dim int) []float32 {
+	v := make([]float32, dim)
+	for i := range v {
+		v[i] = float32(rng.NormFloat64())
+	}
+	normalizeL2(v)
+	return v
+}
+
+func buildRandomIndex(tb testing.TB, n, dim int, seed int64) *VectorIndex {
+	tb.Helper()
+	rng := rand.New(rand.NewSource(seed))
+	idx := NewVectorIndex(dim)
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("bv-%05d", i)
+		if err := idx.Upsert(id, ComputeContentHash(id), randomUnitVector(rng, dim)); err != nil {
+			tb.Fatalf("Upsert: %v", err)
+		}
+	}
+	return idx
+}
+
+// recallAtK measures how many exact top-k results the approximate search finds.
+func recallAtK(tb testing.TB, idx *VectorIndex, queries [][]float32, k int) float64 {
+	tb.Helper()
+	hit, total := 0, 0
+	for _, q := range queries {
+		exact, err := idx.SearchTopKExact(q, k)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		approx, err := idx.SearchTopK(q, k)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		found := make(map[string]bool, len(approx))
+		for _, r := range approx {
+			found[r.IssueID] = true
+		}
+		for _, r := range exact {
+			if found[r.IssueID] {
+				hit++
+			}
+			total++
+		}
+	}
+	return float64(hit) / float64(total)
+}
+
+func randomQueries(n, dim int) [][]float32 {
+	rng := rand.New(rand.NewSource(99))
+	qs := make([][]float32, n)
+	for i := range qs {
+		qs[i] = randomUnitVector(rng, dim)
+	}
+	return qs
+}
+
+func TestVectorIndex_ANNRecall(t *testing.T) {
+	idx := buildRandomIndex(t, 3000, 32, 1)
+	if !idx.Approximate() {
+		t.Fatalf("index of 3000 should use ANN (threshold %d)", DefaultANNThreshold)
+	}
+	if r := recallAtK(t, idx, randomQueries(50, 32), 10); r < 0.9 {
+		t.Fatalf("recall@10 = %.3f, want >= 0.9", r)
+	}
+}
+
+func TestVectorIndex_SmallIndexIsExact(t *testing.T) {
+	idx := buildRandomIndex(t, 200, 16, 2)
+	if idx.Approximate() {
+		t.Fatalf("small index should use exact search")
+	}
+	if r := recallAtK(t, idx, randomQueries(10, 16), 10); r != 1 {
+		t.Fatalf("exact fallback recall = %v, want 1", r)
+	}
+}
+
+func TestVectorIndex_ANNIncrementalUpdates(t *testing.T) {
+	idx := buildRandomIndex(t, 600, 16, 3)
+	idx.SetANNThreshold(100)
+
+	q := randomQueries(1, 16)[0]
+	// Warm the graph, then move a vector onto the query and remove the old winner.
+	before, _ := idx.SearchTopK(q, 1)
+	if err := idx.Upsert("bv-00007", ComputeContentHash("moved"), q); err != nil {
+		t.Fatal(err)
+	}
+	idx.Remove(before[0].IssueID)
+
+	got, err := idx.SearchTopK(q, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[0].IssueID != "bv-00007" {
+		t.Fatalf("updated vector should be the top hit, got %+v", got)
+	}
+	for _, r := range got {
+		if r.IssueID == before[0].IssueID && before[0].IssueID != "bv-00007" {
+			t.Fatalf("removed entry %s returned", r.IssueID)
+		}
+	}
+
+	// Heavy churn triggers a rebuild without losing live entries.
+	for i := 0; i < 400; i++ {
+		idx.Remove(fmt.Sprintf("bv-%05d", i+100))
+	}
+	if r := recallAtK(t, idx, randomQueries(20, 16), 5); r < 0.9 {
+		t.Fatalf("recall after churn = %.3f", r)
+	}
+}
+
+func TestVectorIndex_SaveLoadPersistsGraph(t *testing.T) {
+	idx := buildRandomIndex(t, 500, 16, 4)
+	idx.SetANNThreshold(100)
+	idx.Remove("bv-00003")
+
+	path := filepath.Join(t.TempDir(), "index.bvvi")
+	if err := idx.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, err := LoadVectorIndex(path)
+	if err != nil {
+		t.Fatalf("LoadVectorIndex: %v", err)
+	}
+	if loaded.ann == nil || loaded.ann.live() != 499 {
+		t.Fatalf("graph not restored: %+v", loaded.ann)
+	}
+	loaded.SetANNThreshold(100)
+
+	queries := randomQueries(10, 16)
+	for _, q := range queries {
+		want, _ := idx.SearchTopK(q, 5)
+		got, _ := loaded.SearchTopK(q, 5)
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("loaded graph answers differently:\n got %v\nwant %v", got, want)
+		}
+	}
+}
+
+func TestLoadVectorIndex_Version1(t *testing.T) {
+	idx := buildRandomIndex(t, 5, 4, 5)
+	path := filepath.Join(t.TempDir(), "index.bvvi")
+	if err := idx.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A v1 file is the v2 layout without the trailing graph flag.
+	raw[4], raw[5] = 1, 0
+	if err := os.WriteFile(path, raw[:len(raw)-1], 0o644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadVectorIndex(path)
+	if err != nil {
+		t.Fatalf("LoadVectorIndex(v1): %v", err)
+	}
+	if loaded.Size() != 5 {
+		t.Fatalf("Size = %d, want 5", loaded.Size())
+	}
+}
+
+// BenchmarkVectorIndex_SearchTopK compares exact scanning with HNSW search
+// and reports recall@10 alongside latency.
+func BenchmarkVectorIndex_SearchTopK(b *testing.B) {
+	for _, n := range []int{10_000, 100_000} {
+		if testing.Short() && n > 10_000 {
+			continue
+		}
+		idx := buildRandomIndex(b, n, 64, 7)
+		start := time.Now()
+		idx.ensureANN()
+		b.Logf("n=%d graph build %v", n, time.Since(start))
+		queries := randomQueries(64, 64)
+		recall := recallAtK(b, idx, queries, 10)
+
+		b.Run(fmt.Sprintf("exact/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = idx.SearchTopKExact(queries[i%len(queries)], 10)
+			}
+		})
+		b.Run(fmt.Sprintf("hnsw/n=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = idx.SearchTopK(queries[i%len(queries)], 10)
+			}
+			b.ReportMetric(recall, "recall@10")
+		})
+	}
+}
@@ -11,15 +11,20 @@ import (
 )
 
 // DefaultIndexPath returns the default semantic index path under the given project directory.
-// The filename is keyed by provider+dim to avoid mixing incompatible embeddings.
+// The filename is keyed by provider, model and dim to avoid mixing incompatible embeddings.
+// Callers of auto-detecting providers should pass the embedder's actual Dim.
 func DefaultIndexPath(projectDir string, cfg EmbeddingConfig) string {
 	cfg = cfg.Normalized()
 	provider := cfg.Provider
 	if provider == "" {
 		provider = ProviderHash
 	}
-	safeProvider := strings.NewReplacer("/", "_", "\\", "_", " ", "_").Replace(string(provider))
-	return filepath.Join(projectDir, ".bv", "semantic", fmt.Sprintf("index-%s-%d.bvvi", safeProvider, cfg.Dim))
+	safe := strings.NewReplacer("/", "_", "\\", "_", " ", "_", ":", "_").Replace
+	name := safe(string(provider))
+	if cfg.Model != "" {
+		name += "-" + safe(cfg.Model)
+	}
+	return filepath.Join(projectDir, ".bv", "semantic", fmt.Sprintf("index-%s-%d.bvvi", name, cfg.Dim))
 }
 
 type IndexSyncStats struct {
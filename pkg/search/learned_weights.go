@@ -0,0 +1,314 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// RankingFeatures are the hybrid score inputs for one result, in the order
+// text, pagerank, status, impact, priority, recency.
+type RankingFeatures [6]float64
+
+func (w Weights) vector() RankingFeatures {
+	return RankingFeatures{w.TextRelevance, w.PageRank, w.Status, w.Impact, w.Priority, w.Recency}
+}
+
+func weightsFromVector(v RankingFeatures) Weights {
+	return Weights{
+		TextRelevance: v[0],
+		PageRank:      v[1],
+		Status:        v[2],
+		Impact:        v[3],
+		Priority:      v[4],
+		Recency:       v[5],
+	}
+}
+
+func (f RankingFeatures) dot(w RankingFeatures) float64 {
+	var sum float64
+	for i := range f {
+		sum += f[i] * w[i]
+	}
+	return sum
+}
+
+// FeedbackSample is a logged query with features for every result shown.
+type FeedbackSample struct {
+	Query    string
+	IDs      []string
+	Features []RankingFeatures
+	Clicked  int // index into IDs
+}
+
+// FeedbackSamples recomputes ranking features for logged events from the
+// current index and graph metrics. Events whose clicked issue no longer
+// exists, or that showed a single result, are skipped and counted.
+func (e *SearchEngine) FeedbackSamples(ctx context.Context, events []SearchFeedbackEvent) ([]FeedbackSample, int, error) {
+	cache, err := e.metricsCache()
+	if err != nil {
+		return nil, 0, fmt.Errorf("computing hybrid metrics: %w", err)
+	}
+	known := make(map[string]bool, len(e.issues))
+	for _, iss := range e.issues {
+		known[iss.ID] = true
+	}
+
+	var samples []FeedbackSample
+	skipped := 0
+	for _, ev := range events {
+		if !known[ev.Clicked] {
+			skipped++
+			continue
+		}
+		sample := FeedbackSample{Query: ev.Query, Clicked: -1}
+		allowed := make(map[string]bool, len(ev.Shown))
+		for _, id := range ev.Shown {
+			if !known[id] || allowed[id] {
+				continue
+			}
+			allowed[id] = true
+			if id == ev.Clicked {
+				sample.Clicked = len(sample.IDs)
+			}
+			sample.IDs = append(sample.IDs, id)
+		}
+		if sample.Clicked < 0 || len(sample.IDs) < 2 {
+			skipped++
+			continue
+		}
+
+		text, err := e.Rank(ctx, ev.Query, SearchModeText, Weights{}, allowed, len(sample.IDs))
+		if err != nil {
+			return nil, 0, err
+		}
+		textScore := make(map[string]float64, len(text))
+		for _, r := range text {
+			textScore[r.IssueID] = r.Score
+		}
+
+		sample.Features = make([]RankingFeatures, len(sample.IDs))
+		for i, id := range sample.IDs {
+			f := RankingFeatures{textScore[id]}
+			if m, ok := cache.Get(id); ok {
+				f[1] = m.PageRank
+				f[2] = normalizeStatus(m.Status)
+				f[3] = normalizeImpact(m.BlockerCount, cache.MaxBlockerCount())
+				f[4] = normalizePriority(m.Priority)
+				f[5] = normalizeRecency(m.UpdatedAt)
+			}
+			sample.Features[i] = f
+		}
+		samples = append(samples, sample)
+	}
+	return samples, skipped, nil
+}
+
+// LearnConfig tunes the pairwise ranking fit.
+type LearnConfig struct {
+	Iterations    int
+	LearningRate  float64
+	L2            float64 // pull towards the baseline weights
+	MinTextWeight float64 // keep results anchored to the query
+	MinSamples    int
+}
+
+// DefaultLearnConfig returns settings that converge on a few hundred clicks.
+func DefaultLearnConfig() LearnConfig {
+	return LearnConfig{
+		Iterations:    500,
+		LearningRate:  0.5,
+		L2:            0.05,
+		MinTextWeight: 0.1,
+		MinSamples:    5,
+	}
+}
+
+// learnPriorScale converts simplex weights into logistic-model units, so the
+// baseline already separates pairs whose features differ noticeably.
+const learnPriorScale = 10.0
+
+// rankingPairs returns (clicked, other) index pairs: every result ranked above
+// the click was skipped, and the one right below was seen but not chosen.
+func rankingPairs(s FeedbackSample) [][2]int {
+	var pairs [][2]int
+	for i := 0; i < s.Clicked; i++ {
+		pairs = append(pairs, [2]int{s.Clicked, i})
+	}
+	if s.Clicked+1 < len(s.IDs) {
+		pairs = append(pairs, [2]int{s.Clicked, s.Clicked + 1})
+	}
+	return pairs
+}
+
+// FitRankingWeights fits hybrid weights to click feedback with pairwise
+// logistic regression (RankNet with a linear scorer), regularized towards
+// baseline. Weights stay non-negative and are normalized to sum to 1.
+// It returns the fitted weights and the number of preference pairs used.
+func FitRankingWeights(samples []FeedbackSample, baseline Weights, cfg LearnConfig) (Weights, int) {
+	var diffs []RankingFeatures
+	for _, s := range samples {
+		for _, p := range rankingPairs(s) {
+			var d RankingFeatures
+			for k := range d {
+				d[k] = s.Features[p[0]][k] - s.Features[p[1]][k]
+			}
+			diffs = append(diffs, d)
+		}
+	}
+	prior := baseline.Normalize().vector()
+	if len(diffs) == 0 {
+		return weightsFromVector(prior), 0
+	}
+
+	var theta, theta0 RankingFeatures
+	for k := range prior {
+		theta0[k] = prior[k] * learnPriorScale
+	}
+	theta = theta0
+	n := float64(len(diffs))
+	for iter := 0; iter < cfg.Iterations; iter++ {
+		var grad RankingFeatures
+		for _, d := range diffs {
+			// d/dθ log(1+exp(-θ·d)) = -d·σ(-θ·d)
+			g := 1 / (1 + math.Exp(theta.dot(d)))
+			for k := range grad {
+				grad[k] -= d[k] * g / n
+			}
+		}
+		for k := range theta {
+			grad[k] += cfg.L2 * (theta[k] - theta0[k])
+			theta[k] = math.Max(0, theta[k]-cfg.LearningRate*grad[k])
+		}
+	}
+
+	w := weightsFromVector(theta).Normalize()
+	if w.sum() == 0 {
+		return weightsFromVector(prior), len(diffs)
+	}
+	if w.TextRelevance < cfg.MinTextWeight {
+		rest := 1 - w.TextRelevance
+		scale := (1 - cfg.MinTextWeight) / rest
+		w = Weights{
+			TextRelevance: cfg.MinTextWeight,
+			PageRank:      w.PageRank * scale,
+			Status:        w.Status * scale,
+			Impact:        w.Impact * scale,
+			Priority:      w.Priority * scale,
+			Recency:       w.Recency * scale,
+		}
+	}
+	return w, len(diffs)
+}
+
+// RankingNDCG is the mean NDCG@k of the clicked result when each sample's
+// results are re-ranked by w. Ties keep the logged display order.
+func RankingNDCG(samples []FeedbackSample, w Weights, k int) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	wv := w.vector()
+	var total float64
+	for _, s := range samples {
+		order := make([]int, len(s.IDs))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return s.Features[order[a]].dot(wv) > s.Features[order[b]].dot(wv)
+		})
+		for pos, idx := range order {
+			if idx == s.Clicked {
+				if pos < k {
+					total += 1 / math.Log2(float64(pos)+2)
+				}
+				break
+			}
+		}
+	}
+	return total / float64(len(samples))
+}
+
+// LearnedNDCGCutoff is the rank cutoff for learned-weight NDCG reports.
+const LearnedNDCGCutoff = 10
+
+// LearnedWeights is the per-project "learned" preset and how it was fitted.
+type LearnedWeights struct {
+	Weights        Weights    `json:"weights"`
+	Baseline       Weights    `json:"baseline"`
+	BaselinePreset PresetName `json:"baseline_preset"`
+	FittedAt       time.Time  `json:"fitted_at"`
+	Events         int        `json:"events"`
+	Skipped        int        `json:"skipped"`
+	Pairs          int        `json:"pairs"`
+	NDCGBefore     float64    `json:"ndcg_before"`
+	NDCGAfter      float64    `json:"ndcg_after"`
+}
+
+// LearnedWeightsPath returns where the learned preset is stored.
+func LearnedWeightsPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", "search-weights.json")
+}
+
+// LearnWeights fits the learned preset for the logged events. NDCG is
+// measured on the same events before (baseline) and after fitting.
+func LearnWeights(ctx context.Context, engine *SearchEngine, events []SearchFeedbackEvent, baseline Weights, baselinePreset PresetName, cfg LearnConfig, now time.Time) (*LearnedWeights, error) {
+	samples, skipped, err := engine.FeedbackSamples(ctx, events)
+	if err != nil {
+		return nil, err
+	}
+	if len(samples) < cfg.MinSamples {
+		return nil, fmt.Errorf("need at least %d usable search clicks to learn weights, have %d", cfg.MinSamples, len(samples))
+	}
+	baseline = baseline.Normalize()
+	learned, pairs := FitRankingWeights(samples, baseline, cfg)
+	return &LearnedWeights{
+		Weights:        learned,
+		Baseline:       baseline,
+		BaselinePreset: baselinePreset,
+		FittedAt:       now.UTC(),
+		Events:         len(samples),
+		Skipped:        skipped,
+		Pairs:          pairs,
+		NDCGBefore:     RankingNDCG(samples, baseline, LearnedNDCGCutoff),
+		NDCGAfter:      RankingNDCG(samples, learned, LearnedNDCGCutoff),
+	}, nil
+}
+
+// LoadLearnedWeights reads the project's learned preset, or nil if none.
+func LoadLearnedWeights(projectDir string) (*LearnedWeights, error) {
+	path := LearnedWeightsPath(projectDir)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var lw LearnedWeights
+	if err := json.Unmarshal(data, &lw); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if err := lw.Weights.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &lw, nil
+}
+
+// Save writes the learned preset under projectDir.
+func (lw *LearnedWeights) Save(projectDir string) error {
+	path := LearnedWeightsPath(projectDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("mkdir %s: %w", filepath.Dir(path), err)
+	}
+	data, err := json.MarshalIndent(lw, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,119 @@
+package search
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRankingPairs(t *testing.T) {
+	s := FeedbackSample{IDs: []string{"a", "b", "c", "d"}, Clicked: 2}
+	got := rankingPairs(s)
+	want := [][2]int{{2, 0}, {2, 1}, {2, 3}}
+	if len(got) != len(want) {
+		t.Fatalf("pairs = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("pairs = %v, want %v", got, want)
+		}
+	}
+
+	s.Clicked = 3
+	if got := rankingPairs(s); len(got) != 3 || got[2] != [2]int{3, 2} {
+		t.Fatalf("last-result click pairs = %v", got)
+	}
+}
+
+// prioritySamples shows results ranked by text relevance where users always
+// click the highest-priority one.
+func prioritySamples(n int) []FeedbackSample {
+	samples := make([]FeedbackSample, 0, n)
+	for i := 0; i < n; i++ {
+		s := FeedbackSample{IDs: []string{"a", "b", "c", "d"}, Clicked: 2 + i%2}
+		s.Features = []RankingFeatures{
+			{0.9, 0.5, 0.5, 0, 0.25, 0.5},
+			{0.8, 0.5, 0.5, 0, 0.25, 0.5},
+			{0.7, 0.5, 0.5, 0, 0.25, 0.5},
+			{0.6, 0.5, 0.5, 0, 0.25, 0.5},
+		}
+		s.Features[s.Clicked][4] = 1
+		samples = append(samples, s)
+	}
+	return samples
+}
+
+func TestFitRankingWeights_LearnsFromClicks(t *testing.T) {
+	baseline, err := GetPreset(PresetDefault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := DefaultLearnConfig()
+	samples := prioritySamples(20)
+
+	learned, pairs := FitRankingWeights(samples, baseline, cfg)
+	if pairs != 60 {
+		t.Fatalf("pairs = %d, want 60", pairs)
+	}
+	if err := learned.Validate(); err != nil {
+		t.Fatalf("learned weights invalid: %v (%+v)", err, learned)
+	}
+	if learned.Priority <= baseline.Normalize().Priority {
+		t.Fatalf("priority weight should grow: baseline %.3f learned %.3f", baseline.Priority, learned.Priority)
+	}
+	if learned.TextRelevance < cfg.MinTextWeight-1e-9 {
+		t.Fatalf("text weight %.3f below floor", learned.TextRelevance)
+	}
+	before := RankingNDCG(samples, baseline, LearnedNDCGCutoff)
+	after := RankingNDCG(samples, learned, LearnedNDCGCutoff)
+	if after <= before {
+		t.Fatalf("NDCG did not improve: before %.3f after %.3f", before, after)
+	}
+
+	if w, pairs := FitRankingWeights(nil, baseline, cfg); pairs != 0 || w != baseline.Normalize() {
+		t.Fatalf("no feedback should return the baseline, got %+v", w)
+	}
+}
+
+func TestRankingNDCG(t *testing.T) {
+	s := FeedbackSample{
+		IDs:      []string{"a", "b"},
+		Clicked:  1,
+		Features: []RankingFeatures{{1}, {0, 0, 0, 0, 1}},
+	}
+	if got := RankingNDCG([]FeedbackSample{s}, Weights{TextRelevance: 1}, 10); got < 0.63 || got > 0.64 {
+		t.Fatalf("clicked at rank 2: NDCG = %.3f, want 1/log2(3)", got)
+	}
+	if got := RankingNDCG([]FeedbackSample{s}, Weights{Priority: 1}, 10); got != 1 {
+		t.Fatalf("clicked at rank 1: NDCG = %.3f, want 1", got)
+	}
+	if got := RankingNDCG([]FeedbackSample{s}, Weights{TextRelevance: 1}, 1); got != 0 {
+		t.Fatalf("clicked below cutoff: NDCG = %.3f, want 0", got)
+	}
+}
+
+func TestLearnedWeightsPreset(t *testing.T) {
+	dir := t.TempDir()
+	if !IsKnownPreset(PresetLearned) || IsKnownPreset("nope") {
+		t.Fatal("IsKnownPreset should accept learned and reject unknown names")
+	}
+	if _, err := ResolvePreset(PresetLearned, dir); err == nil || !strings.Contains(err.Error(), "--robot-search-learn") {
+		t.Fatalf("missing learned preset error = %v", err)
+	}
+	if lw, err := LoadLearnedWeights(dir); err != nil || lw != nil {
+		t.Fatalf("missing file = %v, %v", lw, err)
+	}
+
+	want := Weights{TextRelevance: 0.5, PageRank: 0.1, Status: 0.1, Impact: 0.1, Priority: 0.1, Recency: 0.1}
+	lw := &LearnedWeights{Weights: want, BaselinePreset: PresetDefault, FittedAt: time.Now().UTC()}
+	if err := lw.Save(dir); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ResolvePreset(PresetLearned, dir)
+	if err != nil || got != want {
+		t.Fatalf("ResolvePreset(learned) = %+v, %v", got, err)
+	}
+	if w, err := ResolvePreset(PresetBugHunting, dir); err != nil || w.sum() == 0 {
+		t.Fatalf("built-in presets still resolve: %+v, %v", w, err)
+	}
+}
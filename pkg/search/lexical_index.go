@@ -0,0 +1,424 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/util/topk"
+)
+
+// LexicalField identifies an indexed issue field.
+type LexicalField uint8
+
+const (
+	FieldID LexicalField = iota
+	FieldTitle
+	FieldLabels
+	FieldDescription
+	FieldComments
+
+	lexicalFieldCount
+)
+
+var lexicalFieldNames = [lexicalFieldCount]string{"id", "title", "labels", "description", "comments"}
+
+func (f LexicalField) String() string {
+	if f < lexicalFieldCount {
+		return lexicalFieldNames[f]
+	}
+	return "unknown"
+}
+
+// ParseLexicalField resolves a field qualifier used in queries (e.g. "title:auth").
+func ParseLexicalField(name string) (LexicalField, bool) {
+	switch strings.ToLower(name) {
+	case "id":
+		return FieldID, true
+	case "title":
+		return FieldTitle, true
+	case "label", "labels", "tag", "tags":
+		return FieldLabels, true
+	case "desc", "description":
+		return FieldDescription, true
+	case "comment", "comments":
+		return FieldComments, true
+	}
+	return 0, false
+}
+
+// FieldBoosts weights each field's BM25 contribution.
+type FieldBoosts [lexicalFieldCount]float64
+
+// DefaultFieldBoosts mirrors the emphasis IssueDocument gives each field.
+func DefaultFieldBoosts() FieldBoosts {
+	var b FieldBoosts
+	b[FieldID] = 4.0
+	b[FieldTitle] = 2.5
+	b[FieldLabels] = 2.0
+	b[FieldDescription] = 1.0
+	b[FieldComments] = 0.6
+	return b
+}
+
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	// commentPositionGap separates comments so phrases never span two of them.
+	commentPositionGap = 16
+	// maxPrefixExpansions bounds how many vocabulary terms a prefix query visits.
+	maxPrefixExpansions = 64
+)
+
+// posting records where a term occurs in one field of one document.
+type posting struct {
+	doc       int32
+	positions []int32
+}
+
+// LexicalIndex is a BM25 inverted index over issue fields. It supports plain
+// terms, quoted phrases, trailing-* prefixes and field qualifiers:
+//
+//	auth token "session expiry" title:login lab* id:bv-12
+//
+// An index is immutable once built and safe for concurrent searches.
+type LexicalIndex struct {
+	ids      []string
+	boosts   FieldBoosts
+	postings [lexicalFieldCount]map[string][]posting
+	fieldLen [lexicalFieldCount][]int32
+	avgLen   [lexicalFieldCount]float64
+	vocab    []string // sorted union of all terms, for prefix expansion
+}
+
+// NewLexicalIndex indexes issues with DefaultFieldBoosts.
+func NewLexicalIndex(issues []model.Issue) *LexicalIndex {
+	return NewLexicalIndexWithBoosts(issues, DefaultFieldBoosts())
+}
+
+// NewLexicalIndexWithBoosts indexes issues with custom field boosts.
+func NewLexicalIndexWithBoosts(issues []model.Issue, boosts FieldBoosts) *LexicalIndex {
+	docs := make([]lexicalDoc, 0, len(issues))
+	for _, issue := range issues {
+		if issue.ID == "" {
+			continue
+		}
+		doc := lexicalDoc{id: issue.ID}
+		doc.fields[FieldID] = []string{issue.ID}
+		doc.fields[FieldTitle] = []string{issue.Title}
+		doc.fields[FieldLabels] = issue.Labels
+		doc.fields[FieldDescription] = []string{issue.Description}
+		for _, c := range issue.Comments {
+			if c != nil {
+				doc.fields[FieldComments] = append(doc.fields[FieldComments], c.Text)
+			}
+		}
+		docs = append(docs, doc)
+	}
+	return newLexicalIndex(docs, boosts)
+}
+
+// lexicalDoc is one document to index: its result ID and the values of
+// each field.
+type lexicalDoc struct {
+	id     string
+	fields [lexicalFieldCount][]string
+}
+
+func newLexicalIndex(docs []lexicalDoc, boosts FieldBoosts) *LexicalIndex {
+	x := &LexicalIndex{
+		ids:    make([]string, 0, len(docs)),
+		boosts: boosts,
+	}
+	for f := range x.postings {
+		x.postings[f] = make(map[string][]posting)
+		x.fieldLen[f] = make([]int32, 0, len(docs))
+	}
+
+	var totals [lexicalFieldCount]int64
+	for _, d := range docs {
+		doc := int32(len(x.ids))
+		x.ids = append(x.ids, d.id)
+		for f := LexicalField(0); f < lexicalFieldCount; f++ {
+			n := x.addField(f, doc, d.fields[f])
+			x.fieldLen[f] = append(x.fieldLen[f], n)
+			totals[f] += int64(n)
+		}
+	}
+
+	seen := make(map[string]struct{})
+	for f := range x.postings {
+		if len(x.ids) > 0 {
+			x.avgLen[f] = float64(totals[f]) / float64(len(x.ids))
+		}
+		for term := range x.postings[f] {
+			if _, ok := seen[term]; !ok {
+				seen[term] = struct{}{}
+				x.vocab = append(x.vocab, term)
+			}
+		}
+	}
+	sort.Strings(x.vocab)
+	return x
+}
+
+// addField tokenizes the values of one field and appends postings. Separate
+// values (labels, comments) are spaced apart so phrases don't straddle them.
+func (x *LexicalIndex) addField(f LexicalField, doc int32, values []string) int32 {
+	var pos int32
+	for i, v := range values {
+		if i > 0 {
+			pos += commentPositionGap
+		}
+		for _, tok := range lexicalTokens(v) {
+			list := x.postings[f][tok]
+			if n := len(list); n > 0 && list[n-1].doc == doc {
+				list[n-1].positions = append(list[n-1].positions, pos)
+			} else {
+				list = append(list, posting{doc: doc, positions: []int32{pos}})
+			}
+			x.postings[f][tok] = list
+			pos++
+		}
+	}
+	return pos
+}
+
+// Size returns the number of indexed issues.
+func (x *LexicalIndex) Size() int {
+	if x == nil {
+		return 0
+	}
+	return len(x.ids)
+}
+
+// lexicalClause is one parsed query element.
+type lexicalClause struct {
+	field  *LexicalField // nil = all fields
+	terms  []string
+	prefix bool // last term matches as a prefix
+}
+
+// parseLexicalQuery splits a query into clauses. Bare words that tokenize to
+// several terms (e.g. "bv-123") become implicit phrases.
+func parseLexicalQuery(query string) []lexicalClause {
+	var clauses []lexicalClause
+	rest := strings.TrimSpace(query)
+	for rest != "" {
+		var raw string
+		var field *LexicalField
+
+		// Optional field qualifier.
+		if colon := strings.IndexByte(rest, ':'); colon > 0 && !strings.ContainsAny(rest[:colon], " \t\"") {
+			if f, ok := ParseLexicalField(rest[:colon]); ok {
+				field = &f
+				rest = rest[colon+1:]
+			}
+		}
+
+		quoted := strings.HasPrefix(rest, "\"")
+		if quoted {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				raw, rest = rest[1:], ""
+			} else {
+				raw, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			end := strings.IndexFunc(rest, unicode.IsSpace)
+			if end < 0 {
+				raw, rest = rest, ""
+			} else {
+				raw, rest = rest[:end], rest[end:]
+			}
+		}
+		rest = strings.TrimSpace(rest)
+
+		prefix := !quoted && strings.HasSuffix(raw, "*")
+		terms := lexicalTokens(strings.TrimSuffix(raw, "*"))
+		if len(terms) == 0 {
+			continue
+		}
+		clauses = append(clauses, lexicalClause{field: field, terms: terms, prefix: prefix})
+	}
+	return clauses
+}
+
+// Search returns the top k issues for query ranked by boosted BM25.
+func (x *LexicalIndex) Search(query string, k int) []SearchResult {
+	if x == nil || k <= 0 || len(x.ids) == 0 {
+		return nil
+	}
+	clauses := parseLexicalQuery(query)
+	if len(clauses) == 0 {
+		return nil
+	}
+
+	scores := make(map[int32]float64)
+	for _, c := range clauses {
+		for f := LexicalField(0); f < lexicalFieldCount; f++ {
+			if c.field != nil && *c.field != f {
+				continue
+			}
+			if x.boosts[f] <= 0 {
+				continue
+			}
+			x.scoreClause(c, f, scores)
+		}
+	}
+
+	collector := topk.New[SearchResult](k, func(a, b SearchResult) bool {
+		return a.IssueID < b.IssueID
+	})
+	for doc, score := range scores {
+		collector.Add(SearchResult{IssueID: x.ids[doc], Score: score}, score)
+	}
+	return collector.Results()
+}
+
+// scoreClause adds one clause's contribution within field f.
+func (x *LexicalIndex) scoreClause(c lexicalClause, f LexicalField, scores map[int32]float64) {
+	if len(c.terms) == 1 {
+		if c.prefix {
+			for _, term := range x.expandPrefix(c.terms[0]) {
+				x.scoreTerm(term, f, scores)
+			}
+			return
+		}
+		x.scoreTerm(c.terms[0], f, scores)
+		return
+	}
+	x.scorePhrase(c, f, scores)
+}
+
+func (x *LexicalIndex) scoreTerm(term string, f LexicalField, scores map[int32]float64) {
+	list := x.postings[f][term]
+	if len(list) == 0 {
+		return
+	}
+	idf := x.idf(len(list))
+	for _, p := range list {
+		scores[p.doc] += x.boosts[f] * idf * x.tfNorm(float64(len(p.positions)), f, p.doc)
+	}
+}
+
+// scorePhrase counts consecutive occurrences of the clause terms. The phrase
+// is scored like a single term whose IDF is the sum of its parts.
+func (x *LexicalIndex) scorePhrase(c lexicalClause, f LexicalField, scores map[int32]float64) {
+	last := len(c.terms) - 1
+	lists := make([][]posting, len(c.terms))
+	idf := 0.0
+	for i, term := range c.terms {
+		if i == last && c.prefix {
+			lists[i] = x.mergePrefixPostings(term, f)
+		} else {
+			lists[i] = x.postings[f][term]
+		}
+		if len(lists[i]) == 0 {
+			return
+		}
+		idf += x.idf(len(lists[i]))
+	}
+
+	// Candidate docs come from the first term; the others are looked up by doc.
+	byDoc := make([]map[int32][]int32, len(lists))
+	for i, list := range lists {
+		byDoc[i] = make(map[int32][]int32, len(list))
+		for _, p := range list {
+			byDoc[i][p.doc] = p.positions
+		}
+	}
+	for _, p := range lists[0] {
+		freq := 0
+		for _, start := range p.positions {
+			match := true
+			for i := 1; i < len(lists); i++ {
+				if !containsPosition(byDoc[i][p.doc], start+int32(i)) {
+					match = false
+					break
+				}
+			}
+			if match {
+				freq++
+			}
+		}
+		if freq > 0 {
+			scores[p.doc] += x.boosts[f] * idf * x.tfNorm(float64(freq), f, p.doc)
+		}
+	}
+}
+
+func (x *LexicalIndex) idf(df int) float64 {
+	n := float64(len(x.ids))
+	return math.Log(1 + (n-float64(df)+0.5)/(float64(df)+0.5))
+}
+
+func (x *LexicalIndex) tfNorm(tf float64, f LexicalField, doc int32) float64 {
+	avg := x.avgLen[f]
+	if avg <= 0 {
+		avg = 1
+	}
+	length := float64(x.fieldLen[f][doc])
+	return tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*length/avg))
+}
+
+// expandPrefix returns vocabulary terms starting with prefix in lexical order,
+// capped at maxPrefixExpansions.
+func (x *LexicalIndex) expandPrefix(prefix string) []string {
+	start := sort.SearchStrings(x.vocab, prefix)
+	var out []string
+	for i := start; i < len(x.vocab) && strings.HasPrefix(x.vocab[i], prefix); i++ {
+		out = append(out, x.vocab[i])
+		if len(out) >= maxPrefixExpansions {
+			break
+		}
+	}
+	return out
+}
+
+// mergePrefixPostings unions the postings of all expansions of prefix in f.
+func (x *LexicalIndex) mergePrefixPostings(prefix string, f LexicalField) []posting {
+	merged := make(map[int32][]int32)
+	for _, term := range x.expandPrefix(prefix) {
+		for _, p := range x.postings[f][term] {
+			merged[p.doc] = append(merged[p.doc], p.positions...)
+		}
+	}
+	out := make([]posting, 0, len(merged))
+	for doc, positions := range merged {
+		sort.Slice(positions, func(i, j int) bool { return positions[i] < positions[j] })
+		out = append(out, posting{doc: doc, positions: positions})
+	}
+	return out
+}
+
+func containsPosition(positions []int32, want int32) bool {
+	i := sort.Search(len(positions), func(i int) bool { return positions[i] >= want })
+	return i < len(positions) && positions[i] == want
+}
+
+// lexicalTokens lowercases text and splits it on anything that isn't a
+// letter or digit, matching the tokenization of the hash embedder.
+func lexicalTokens(text string) []string {
+	var out []string
+	start := -1
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			out = append(out, strings.ToLower(text[start:i]))
+			start = -1
+		}
+	}
+	if start >= 0 {
+		out = append(out, strings.ToLower(text[start:]))
+	}
+	return out
+}
@@ -0,0 +1,111 @@
+package search
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func lexicalFixture() []model.Issue {
+	return []model.Issue{
+		{ID: "bv-1", Title: "Login session expiry", Description: "Users are logged out when the session expires early."},
+		{ID: "bv-2", Title: "Session cache", Description: "Expiry of cached entries is wrong; the session layer is fine."},
+		{ID: "bv-3", Title: "Render benchmarks", Labels: []string{"performance"}, Description: "Graph rendering is slow."},
+		{ID: "bv-12", Title: "Docs", Description: "Mentions bv-1 in passing.",
+			Comments: []*model.Comment{{Text: "session"}, {Text: "expiry"}}},
+	}
+}
+
+func resultIDs(results []SearchResult) []string {
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r.IssueID
+	}
+	return ids
+}
+
+func TestLexicalIndex_BM25PrefersTitleAndRareTerms(t *testing.T) {
+	idx := NewLexicalIndex(lexicalFixture())
+	if idx.Size() != 4 {
+		t.Fatalf("Size = %d, want 4", idx.Size())
+	}
+	got := idx.Search("login", 10)
+	if len(got) != 1 || got[0].IssueID != "bv-1" {
+		t.Fatalf("login results = %v", resultIDs(got))
+	}
+	got = idx.Search("benchmarks", 10)
+	if len(got) == 0 || got[0].IssueID != "bv-3" {
+		t.Fatalf("benchmarks results = %v", resultIDs(got))
+	}
+}
+
+func TestLexicalIndex_Phrase(t *testing.T) {
+	idx := NewLexicalIndex(lexicalFixture())
+	got := resultIDs(idx.Search(`"session expiry"`, 10))
+	if len(got) != 1 || got[0] != "bv-1" {
+		t.Fatalf("phrase should only match adjacent terms in one field, got %v", got)
+	}
+}
+
+func TestLexicalIndex_PrefixAndField(t *testing.T) {
+	idx := NewLexicalIndex(lexicalFixture())
+	got := resultIDs(idx.Search("bench*", 10))
+	if len(got) != 1 || got[0] != "bv-3" {
+		t.Fatalf("prefix results = %v", got)
+	}
+	got = resultIDs(idx.Search("label:perf*", 10))
+	if len(got) != 1 || got[0] != "bv-3" {
+		t.Fatalf("field+prefix results = %v", got)
+	}
+	if got := idx.Search("title:slow", 10); len(got) != 0 {
+		t.Fatalf("title:slow should not match description text, got %v", resultIDs(got))
+	}
+	if got := idx.Search("nosuchfield:login", 10); len(got) != 0 {
+		t.Fatalf("unknown qualifier is part of the term, got %v", resultIDs(got))
+	}
+}
+
+func TestLexicalIndex_IDBoost(t *testing.T) {
+	idx := NewLexicalIndex(lexicalFixture())
+	got := resultIDs(idx.Search("bv-1", 10))
+	if len(got) < 2 || got[0] != "bv-1" {
+		t.Fatalf("exact ID should outrank mentions, got %v", got)
+	}
+	got = resultIDs(idx.Search("id:bv-1", 10))
+	if len(got) != 1 || got[0] != "bv-1" {
+		t.Fatalf("id: qualifier results = %v", got)
+	}
+}
+
+func TestLexicalIndex_CustomBoosts(t *testing.T) {
+	boosts := DefaultFieldBoosts()
+	boosts[FieldComments] = 0
+	idx := NewLexicalIndexWithBoosts(lexicalFixture(), boosts)
+	for _, r := range idx.Search("expiry", 10) {
+		if r.IssueID == "bv-12" {
+			t.Fatalf("zero-boost field should not contribute, got %v", r)
+		}
+	}
+}
+
+func TestFuseRRF(t *testing.T) {
+	vector := []SearchResult{{IssueID: "a", Score: 0.9}, {IssueID: "b", Score: 0.8}, {IssueID: "c", Score: 0.1}}
+	lexical := []SearchResult{{IssueID: "c", Score: 12}, {IssueID: "a", Score: 3}}
+
+	fused := FuseRRF(DefaultRRFK, vector, lexical)
+	if got := resultIDs(fused); len(got) != 3 || got[0] != "a" || got[1] != "c" || got[2] != "b" {
+		t.Fatalf("fused order = %v, want [a c b]", got)
+	}
+	if fused[0].Score > 1 || fused[len(fused)-1].Score <= 0 {
+		t.Fatalf("scores should be normalized to (0,1], got %v", fused)
+	}
+
+	single := FuseRRF(0, nil, vector)
+	if math.Abs(single[0].Score-1) > 1e-9 {
+		t.Fatalf("top of the only list should score 1, got %v", single[0].Score)
+	}
+	if FuseRRF(DefaultRRFK) != nil {
+		t.Fatalf("no lists should fuse to nil")
+	}
+}
@@ -0,0 +1,302 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultOpenAIBaseURL is used when BV_SEMANTIC_BASE_URL is unset.
+	DefaultOpenAIBaseURL = "https://api.openai.com/v1"
+	// DefaultOpenAIModel is used when BV_SEMANTIC_MODEL is unset.
+	DefaultOpenAIModel = "text-embedding-3-small"
+
+	// openAIMaxBatch caps inputs per request; larger Embed calls are split.
+	openAIMaxBatch       = 256
+	openAIMaxRetries     = 5
+	openAIBaseBackoff    = 500 * time.Millisecond
+	openAIMaxBackoff     = 30 * time.Second
+	openAIProbeTimeout   = 30 * time.Second
+	openAIRequestTimeout = 60 * time.Second
+	openAIMaxErrorBody   = 4 << 10
+)
+
+// OpenAIEmbedder talks to any server implementing the OpenAI
+// POST /embeddings wire format: api.openai.com, or a local llama.cpp,
+// Ollama or vLLM server.
+//
+// Transient failures (network errors, 429 and 5xx) are retried with
+// exponential backoff, honoring Retry-After. Vectors are L2-normalized so the
+// index can rank by dot product regardless of what the server returns.
+type OpenAIEmbedder struct {
+	baseURL string
+	apiKey  string
+	model   string
+	client  *http.Client
+
+	// requestDim is sent as "dimensions" only when the user asked for one;
+	// otherwise dim is learned from the first response.
+	requestDim int
+
+	mu  sync.Mutex
+	dim int
+
+	maxRetries  int
+	baseBackoff time.Duration
+	sleep       func(context.Context, time.Duration) error
+}
+
+// NewOpenAIEmbedder builds an embedder from cfg. When cfg.Dim is zero the
+// dimension is detected by embedding a short probe string, so the call needs
+// the server to be reachable.
+func NewOpenAIEmbedder(cfg EmbeddingConfig) (*OpenAIEmbedder, error) {
+	baseURL := strings.TrimRight(strings.TrimSpace(cfg.BaseURL), "/")
+	if baseURL == "" {
+		baseURL = DefaultOpenAIBaseURL
+	}
+	if baseURL == DefaultOpenAIBaseURL && cfg.APIKey == "" {
+		return nil, fmt.Errorf("semantic embedder %q needs an API key: set %s (or %s), or point %s at a local server",
+			ProviderOpenAI, EnvSemanticAPIKey, EnvOpenAIAPIKey, EnvSemanticBaseURL)
+	}
+	model := cfg.Model
+	if model == "" {
+		model = DefaultOpenAIModel
+	}
+
+	e := &OpenAIEmbedder{
+		baseURL:     baseURL,
+		apiKey:      cfg.APIKey,
+		model:       model,
+		client:      &http.Client{Timeout: openAIRequestTimeout},
+		requestDim:  cfg.Dim,
+		dim:         cfg.Dim,
+		maxRetries:  openAIMaxRetries,
+		baseBackoff: openAIBaseBackoff,
+		sleep:       sleepContext,
+	}
+	if e.dim <= 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), openAIProbeTimeout)
+		defer cancel()
+		if _, err := e.Embed(ctx, []string{"dimension probe"}); err != nil {
+			return nil, fmt.Errorf("detect embedding dimension from %s: %w", baseURL, err)
+		}
+	}
+	return e, nil
+}
+
+func (*OpenAIEmbedder) Provider() Provider { return ProviderOpenAI }
+
+// Model returns the model name sent with each request.
+func (e *OpenAIEmbedder) Model() string { return e.model }
+
+// Dim returns the embedding dimension (detected or configured).
+func (e *OpenAIEmbedder) Dim() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.dim
+}
+
+// Embed returns one normalized vector per input, in input order.
+func (e *OpenAIEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	out := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += openAIMaxBatch {
+		end := min(start+openAIMaxBatch, len(texts))
+		vecs, err := e.embedBatch(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, vecs...)
+	}
+	return out, nil
+}
+
+type openAIEmbeddingRequest struct {
+	Model          string   `json:"model"`
+	Input          []string `json:"input"`
+	EncodingFormat string   `json:"encoding_format"`
+	Dimensions     int      `json:"dimensions,omitempty"`
+}
+
+type openAIEmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Error *openAIError `json:"error,omitempty"`
+}
+
+type openAIError struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}
+
+// openAIStatusError is a non-2xx response.
+type openAIStatusError struct {
+	Status     int
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *openAIStatusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("embedding request failed: HTTP %d", e.Status)
+	}
+	return fmt.Sprintf("embedding request failed: HTTP %d: %s", e.Status, e.Message)
+}
+
+func (e *openAIStatusError) retryable() bool {
+	return e.Status == http.StatusTooManyRequests || e.Status >= 500
+}
+
+func (e *OpenAIEmbedder) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	body, err := json.Marshal(openAIEmbeddingRequest{
+		Model:          e.model,
+		Input:          texts,
+		EncodingFormat: "float",
+		Dimensions:     e.requestDim,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= e.maxRetries; attempt++ {
+		if attempt > 0 {
+			wait := e.backoff(attempt, lastErr)
+			if err := e.sleep(ctx, wait); err != nil {
+				return nil, fmt.Errorf("%w (last error: %v)", err, lastErr)
+			}
+		}
+
+		vecs, err := e.post(ctx, body, len(texts))
+		if err == nil {
+			return vecs, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		var statusErr *openAIStatusError
+		if errors.As(err, &statusErr) && !statusErr.retryable() {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("giving up after %d retries: %w", e.maxRetries, lastErr)
+}
+
+// backoff doubles from baseBackoff per attempt, but a server-provided
+// Retry-After always wins.
+func (e *OpenAIEmbedder) backoff(attempt int, lastErr error) time.Duration {
+	var statusErr *openAIStatusError
+	if errors.As(lastErr, &statusErr) && statusErr.RetryAfter > 0 {
+		return min(statusErr.RetryAfter, openAIMaxBackoff)
+	}
+	wait := e.baseBackoff << (attempt - 1)
+	if wait <= 0 || wait > openAIMaxBackoff {
+		wait = openAIMaxBackoff
+	}
+	return wait
+}
+
+func (e *OpenAIEmbedder) post(ctx context.Context, body []byte, n int) ([][]float32, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.apiKey)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, openAIMaxErrorBody))
+		statusErr := &openAIStatusError{
+			Status:     resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
+		var parsed openAIEmbeddingResponse
+		if json.Unmarshal(raw, &parsed) == nil && parsed.Error != nil {
+			statusErr.Message = parsed.Error.Message
+		} else {
+			statusErr.Message = strings.TrimSpace(string(raw))
+		}
+		return nil, statusErr
+	}
+
+	var parsed openAIEmbeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("decode embedding response: %w", err)
+	}
+	if len(parsed.Data) != n {
+		return nil, fmt.Errorf("embedding response has %d vectors for %d inputs", len(parsed.Data), n)
+	}
+	sort.SliceStable(parsed.Data, func(i, j int) bool { return parsed.Data[i].Index < parsed.Data[j].Index })
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	out := make([][]float32, n)
+	for i, d := range parsed.Data {
+		if len(d.Embedding) == 0 {
+			return nil, fmt.Errorf("embedding %d is empty", i)
+		}
+		if e.dim <= 0 {
+			e.dim = len(d.Embedding)
+		}
+		if len(d.Embedding) != e.dim {
+			return nil, fmt.Errorf("embedding %d has dim %d, want %d", i, len(d.Embedding), e.dim)
+		}
+		normalizeL2(d.Embedding)
+		out[i] = d.Embedding
+	}
+	return out, nil
+}
+
+// parseRetryAfter accepts both delay-seconds and HTTP-date forms.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,185 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeEmbeddingServer answers /embeddings with dim-sized vectors whose first
+// component is the input length, returning data in reverse order to check
+// that the client reorders by index.
+func fakeEmbeddingServer(t *testing.T, dim int, before func(w http.ResponseWriter, r *http.Request) bool) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" || r.Method != http.MethodPost {
+			http.NotFound(w, r)
+			return
+		}
+		if before != nil && !before(w, r) {
+			return
+		}
+		var req openAIEmbeddingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		type item struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		}
+		data := make([]item, 0, len(req.Input))
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			vec := make([]float32, dim)
+			vec[0] = float32(len(req.Input[i]))
+			vec[1] = 1
+			data = append(data, item{Index: i, Embedding: vec})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data, "model": req.Model})
+	}))
+}
+
+func noSleep(context.Context, time.Duration) error { return nil }
+
+func TestOpenAIEmbedder_DetectsDimAndOrders(t *testing.T) {
+	var auth atomic.Value
+	srv := fakeEmbeddingServer(t, 8, func(_ http.ResponseWriter, r *http.Request) bool {
+		auth.Store(r.Header.Get("Authorization"))
+		return true
+	})
+	defer srv.Close()
+
+	e, err := NewEmbedderFromConfig(EmbeddingConfig{Provider: ProviderOpenAI, BaseURL: srv.URL + "/v1/", APIKey: "sk-test"})
+	if err != nil {
+		t.Fatalf("NewEmbedderFromConfig: %v", err)
+	}
+	if e.Dim() != 8 {
+		t.Fatalf("Dim = %d, want detected 8", e.Dim())
+	}
+	if got := auth.Load(); got != "Bearer sk-test" {
+		t.Errorf("Authorization = %v", got)
+	}
+
+	vecs, err := e.Embed(context.Background(), []string{"a", "bbb"})
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if len(vecs) != 2 || vecs[0][0] >= vecs[1][0] {
+		t.Fatalf("vectors out of input order: %v", vecs)
+	}
+	var norm float64
+	for _, v := range vecs[1] {
+		norm += float64(v) * float64(v)
+	}
+	if math.Abs(norm-1) > 1e-5 {
+		t.Errorf("vector not normalized, |v|^2 = %v", norm)
+	}
+}
+
+func TestOpenAIEmbedder_RetriesRateLimit(t *testing.T) {
+	var calls atomic.Int32
+	srv := fakeEmbeddingServer(t, 4, func(w http.ResponseWriter, _ *http.Request) bool {
+		if calls.Add(1) <= 2 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":{"message":"slow down","type":"rate_limit"}}`))
+			return false
+		}
+		return true
+	})
+	defer srv.Close()
+
+	e, err := NewOpenAIEmbedder(EmbeddingConfig{Provider: ProviderOpenAI, BaseURL: srv.URL + "/v1", Dim: 4})
+	if err != nil {
+		t.Fatalf("NewOpenAIEmbedder: %v", err)
+	}
+	var waits []time.Duration
+	e.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	if _, err := e.Embed(context.Background(), []string{"x"}); err != nil {
+		t.Fatalf("Embed after rate limit: %v", err)
+	}
+	if calls.Load() != 3 || len(waits) != 2 || waits[0] != 2*time.Second {
+		t.Errorf("calls=%d waits=%v, want 3 calls honoring Retry-After", calls.Load(), waits)
+	}
+}
+
+func TestOpenAIEmbedder_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := fakeEmbeddingServer(t, 4, func(w http.ResponseWriter, _ *http.Request) bool {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"unknown model","type":"invalid_request_error"}}`))
+		return false
+	})
+	defer srv.Close()
+
+	e, err := NewOpenAIEmbedder(EmbeddingConfig{Provider: ProviderOpenAI, BaseURL: srv.URL + "/v1", Dim: 4})
+	if err != nil {
+		t.Fatalf("NewOpenAIEmbedder: %v", err)
+	}
+	e.sleep = noSleep
+	_, err = e.Embed(context.Background(), []string{"x"})
+	if err == nil || !strings.Contains(err.Error(), "unknown model") {
+		t.Fatalf("error = %v, want server message", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("client error retried %d times", calls.Load())
+	}
+}
+
+func TestOpenAIEmbedder_SyncVectorIndexBatches(t *testing.T) {
+	var calls atomic.Int32
+	srv := fakeEmbeddingServer(t, 4, func(http.ResponseWriter, *http.Request) bool {
+		calls.Add(1)
+		return true
+	})
+	defer srv.Close()
+
+	e, err := NewOpenAIEmbedder(EmbeddingConfig{Provider: ProviderOpenAI, BaseURL: srv.URL + "/v1", Dim: 4})
+	if err != nil {
+		t.Fatalf("NewOpenAIEmbedder: %v", err)
+	}
+	docs := map[string]string{"a": "one", "b": "two", "c": "three", "d": "four", "e": "five"}
+	stats, err := SyncVectorIndex(context.Background(), NewVectorIndex(e.Dim()), e, docs, 2)
+	if err != nil {
+		t.Fatalf("SyncVectorIndex: %v", err)
+	}
+	if stats.Embedded != 5 || calls.Load() != 3 {
+		t.Errorf("embedded=%d calls=%d, want 5 in 3 batches", stats.Embedded, calls.Load())
+	}
+}
+
+func TestDefaultIndexPath_KeyedByModel(t *testing.T) {
+	a := DefaultIndexPath("/p", EmbeddingConfig{Provider: ProviderOpenAI, Model: "text-embedding-3-small", Dim: 1536})
+	b := DefaultIndexPath("/p", EmbeddingConfig{Provider: ProviderOpenAI, Model: "nomic-embed-text", Dim: 1536})
+	if a == b {
+		t.Fatalf("different models share index path %s", a)
+	}
+	if got := filepath.Base(DefaultIndexPath("/p", EmbeddingConfig{Provider: ProviderHash})); got != "index-hash-384.bvvi" {
+		t.Errorf("hash index path changed: %s", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	if got := parseRetryAfter("3", now); got != 3*time.Second {
+		t.Errorf("seconds form = %v", got)
+	}
+	if got := parseRetryAfter(now.Add(5*time.Second).Format(http.TimeFormat), now); got != 5*time.Second {
+		t.Errorf("date form = %v", got)
+	}
+	if got := parseRetryAfter("soon", now); got != 0 {
+		t.Errorf("garbage = %v", got)
+	}
+}
@@ -11,6 +11,10 @@ const (
 	PresetSprintPlanning PresetName = "sprint-planning"
 	PresetImpactFirst    PresetName = "impact-first"
 	PresetTextOnly       PresetName = "text-only"
+
+	// PresetLearned is fitted per project from search click feedback and
+	// stored in .bv/search-weights.json; resolve it with ResolvePreset.
+	PresetLearned PresetName = "learned"
 )
 
 var presets = map[PresetName]Weights{
@@ -75,3 +79,25 @@ func ListPresets() []PresetName {
 		PresetTextOnly,
 	}
 }
+
+// IsKnownPreset reports whether name is a built-in preset or PresetLearned.
+func IsKnownPreset(name PresetName) bool {
+	_, ok := presets[name]
+	return ok || name == PresetLearned
+}
+
+// ResolvePreset returns the weights for name, reading PresetLearned from
+// projectDir.
+func ResolvePreset(name PresetName, projectDir string) (Weights, error) {
+	if name != PresetLearned {
+		return GetPreset(name)
+	}
+	lw, err := LoadLearnedWeights(projectDir)
+	if err != nil {
+		return Weights{}, err
+	}
+	if lw == nil {
+		return Weights{}, fmt.Errorf("no learned preset yet; record search feedback and run bv --robot-search-learn")
+	}
+	return lw.Weights, nil
+}
@@ -0,0 +1,64 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ProjectIndex is a project's chunked vector index, synced with its issues.
+type ProjectIndex struct {
+	ProjectDir string
+	Config     EmbeddingConfig
+	Embedder   Embedder
+	Index      *VectorIndex
+	Chunks     *ChunkSet
+	Path       string
+	Loaded     bool
+	Stats      IndexSyncStats
+}
+
+// OpenProjectIndex loads the index under projectDir/.bv/semantic for the
+// embedder configured in the environment, re-embeds changed chunks and saves
+// it. A build-progress line goes to progress when the index is new and
+// progress is non-nil.
+func OpenProjectIndex(ctx context.Context, projectDir string, issues []model.Issue, progress io.Writer) (*ProjectIndex, error) {
+	cfg := EmbeddingConfigFromEnv()
+	embedder, err := NewEmbedderFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	cfg = cfg.Normalized()
+	cfg.Dim = embedder.Dim()
+	path := DefaultIndexPath(projectDir, cfg)
+	idx, loaded, err := LoadOrNewVectorIndex(path, embedder.Dim())
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := NewChunkSet(issues)
+	if progress != nil && !loaded {
+		fmt.Fprintf(progress, "Building semantic index (%d issues, %d chunks)...\n", len(issues), chunks.Len())
+	}
+	stats, err := SyncVectorIndex(ctx, idx, embedder, chunks.Texts(), 64)
+	if err != nil {
+		return nil, fmt.Errorf("building semantic index: %w", err)
+	}
+	if !loaded || stats.Changed() {
+		if err := idx.Save(path); err != nil {
+			return nil, fmt.Errorf("save semantic index: %w", err)
+		}
+	}
+	return &ProjectIndex{
+		ProjectDir: projectDir,
+		Config:     cfg,
+		Embedder:   embedder,
+		Index:      idx,
+		Chunks:     chunks,
+		Path:       path,
+		Loaded:     loaded,
+		Stats:      stats,
+	}, nil
+}
@@ -0,0 +1,452 @@
+package search
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultSavedSearchLimit is the top-N watched when a saved search has no limit.
+	DefaultSavedSearchLimit = 10
+
+	// DefaultSavedSearchAlertWindow is how long an issue that entered a saved
+	// search's top-N is reported as new.
+	DefaultSavedSearchAlertWindow = 24 * time.Hour
+)
+
+// SavedSearch is a named query from .bv/searches.yaml.
+type SavedSearch struct {
+	Name        string              `yaml:"-" json:"name"`
+	Description string              `yaml:"description,omitempty" json:"description,omitempty"`
+	Query       string              `yaml:"query" json:"query"`
+	Mode        SearchMode          `yaml:"mode,omitempty" json:"mode,omitempty"`       // text (default) or hybrid
+	Preset      PresetName          `yaml:"preset,omitempty" json:"preset,omitempty"`   // hybrid preset (default: default)
+	Weights     map[string]float64  `yaml:"weights,omitempty" json:"weights,omitempty"` // overrides preset; keys as --search-weights
+	Limit       int                 `yaml:"limit,omitempty" json:"limit,omitempty"`     // top-N watched for changes
+	Filters     recipe.FilterConfig `yaml:"filters,omitempty" json:"filters,omitempty"` // recipe filters applied before ranking
+}
+
+// SavedSearchFile is the structure of .bv/searches.yaml.
+type SavedSearchFile struct {
+	Searches map[string]*SavedSearch `yaml:"searches"`
+}
+
+// SavedSearchesPath returns the saved search definitions path for projectDir.
+func SavedSearchesPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", "searches.yaml")
+}
+
+// SavedSearchStatePath returns where saved search membership is tracked.
+func SavedSearchStatePath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", "searches-state.json")
+}
+
+// LoadSavedSearches reads .bv/searches.yaml under projectDir, sorted by name.
+// A missing file yields no searches and no error.
+func LoadSavedSearches(projectDir string) ([]SavedSearch, error) {
+	path := SavedSearchesPath(projectDir)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var file SavedSearchFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	searches := make([]SavedSearch, 0, len(file.Searches))
+	for name, s := range file.Searches {
+		if s == nil {
+			continue
+		}
+		s.Name = name
+		if err := s.Validate(projectDir); err != nil {
+			return nil, fmt.Errorf("%s: search %q: %w", path, name, err)
+		}
+		searches = append(searches, *s)
+	}
+	sort.Slice(searches, func(i, j int) bool { return searches[i].Name < searches[j].Name })
+	return searches, nil
+}
+
+// Validate checks the query, mode, preset and weights. The learned preset is
+// only checked by name: until weights are learned, EvaluateSavedSearches
+// reports the error for that search alone.
+func (s SavedSearch) Validate(projectDir string) error {
+	if strings.TrimSpace(s.Query) == "" {
+		return fmt.Errorf("query is required")
+	}
+	switch s.Mode {
+	case "", SearchModeText, SearchModeHybrid:
+	default:
+		return fmt.Errorf("invalid mode %q (expected text|hybrid)", s.Mode)
+	}
+	if s.Limit < 0 {
+		return fmt.Errorf("limit must be positive")
+	}
+	if len(s.Weights) == 0 && s.Preset == PresetLearned {
+		return nil
+	}
+	_, err := s.ResolvedWeights(projectDir)
+	return err
+}
+
+// SearchMode returns the ranking mode, defaulting to text.
+func (s SavedSearch) SearchMode() SearchMode {
+	if s.Mode == "" {
+		return SearchModeText
+	}
+	return s.Mode
+}
+
+// TopN returns the number of leading results watched for changes.
+func (s SavedSearch) TopN() int {
+	if s.Limit <= 0 {
+		return DefaultSavedSearchLimit
+	}
+	return s.Limit
+}
+
+// ResolvedWeights returns the explicit weights, else the preset's; the
+// learned preset is read from projectDir.
+func (s SavedSearch) ResolvedWeights(projectDir string) (Weights, error) {
+	if len(s.Weights) > 0 {
+		return WeightsFromMap(s.Weights)
+	}
+	preset := s.Preset
+	if preset == "" {
+		preset = PresetDefault
+	}
+	return ResolvePreset(preset, projectDir)
+}
+
+// fingerprint identifies the ranking definition; membership history is reset
+// when it changes so an edited search does not alert on its whole top-N.
+func (s SavedSearch) fingerprint() string {
+	def := s
+	def.Name = ""
+	def.Description = ""
+	data, _ := json.Marshal(def)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// SearchEngine ranks queries over a synced project index, the way
+// --robot-search does: chunk vectors and BM25 fused with RRF, rescored by
+// graph metrics in hybrid mode.
+type SearchEngine struct {
+	Embedder Embedder
+	Index    *VectorIndex
+	Chunks   *ChunkSet
+	Lexical  *LexicalIndex
+
+	issues      []model.Issue
+	metricsOnce sync.Once
+	metrics     MetricsCache
+	metricsErr  error
+}
+
+// NewSearchEngine wraps pi for ranking issues.
+func NewSearchEngine(pi *ProjectIndex, issues []model.Issue) *SearchEngine {
+	return &SearchEngine{
+		Embedder: pi.Embedder,
+		Index:    pi.Index,
+		Chunks:   pi.Chunks,
+		Lexical:  NewLexicalIndex(issues),
+		issues:   issues,
+	}
+}
+
+func (e *SearchEngine) metricsCache() (MetricsCache, error) {
+	e.metricsOnce.Do(func() {
+		loader := NewAnalyzerMetricsLoader(e.issues).WithCache(analysis.GetGlobalCache())
+		e.metrics = NewMetricsCache(loader)
+		e.metricsErr = e.metrics.Refresh()
+	})
+	return e.metrics, e.metricsErr
+}
+
+// Rank returns the top limit issues for query. A non-nil allowed set
+// restricts results to those IDs.
+func (e *SearchEngine) Rank(ctx context.Context, query string, mode SearchMode, weights Weights, allowed map[string]bool, limit int) ([]SearchResult, error) {
+	if limit <= 0 || (allowed != nil && len(allowed) == 0) {
+		return nil, nil
+	}
+	vecs, err := e.Embedder.Embed(ctx, []string{query})
+	if err != nil {
+		return nil, err
+	}
+	if len(vecs) != 1 {
+		return nil, fmt.Errorf("embedder returned %d vectors for query", len(vecs))
+	}
+
+	total := e.Lexical.Size()
+	fetch := HybridCandidateLimit(limit, total, query)
+	if allowed != nil && len(allowed) < total {
+		// Widen the pool so filtering still leaves enough candidates.
+		fetch = min(total, fetch*total/len(allowed))
+	}
+	vector, _, err := SearchChunks(e.Index, vecs[0], fetch)
+	if err != nil {
+		return nil, err
+	}
+	keep := func(results []SearchResult) []SearchResult {
+		if allowed == nil {
+			return results
+		}
+		kept := results[:0]
+		for _, r := range results {
+			if allowed[r.IssueID] {
+				kept = append(kept, r)
+			}
+		}
+		return kept
+	}
+	results := FuseRRF(DefaultRRFK, keep(vector), keep(e.Lexical.Search(query, fetch)))
+
+	if mode == SearchModeHybrid {
+		cache, err := e.metricsCache()
+		if err != nil {
+			return nil, fmt.Errorf("computing hybrid metrics: %w", err)
+		}
+		scorer := NewHybridScorer(AdjustWeightsForQuery(weights.Normalize(), query), cache)
+		for i := range results {
+			hs, err := scorer.Score(results[i].IssueID, results[i].Score)
+			if err != nil {
+				return nil, err
+			}
+			results[i].Score = hs.FinalScore
+		}
+		sort.SliceStable(results, func(i, j int) bool {
+			if results[i].Score != results[j].Score {
+				return results[i].Score > results[j].Score
+			}
+			return results[i].IssueID < results[j].IssueID
+		})
+	}
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// SavedSearchState records when each issue entered each saved search's top-N.
+type SavedSearchState struct {
+	Searches map[string]*savedSearchRecord `json:"searches"`
+}
+
+type savedSearchRecord struct {
+	Fingerprint string               `json:"fingerprint"`
+	EvaluatedAt time.Time            `json:"evaluated_at"`
+	Members     map[string]time.Time `json:"members"` // zero time: present in the baseline
+}
+
+// LoadSavedSearchState reads the state file; a missing file is an empty state.
+func LoadSavedSearchState(path string) (*SavedSearchState, error) {
+	state := &SavedSearchState{Searches: make(map[string]*savedSearchRecord)}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if state.Searches == nil {
+		state.Searches = make(map[string]*savedSearchRecord)
+	}
+	return state, nil
+}
+
+// Save writes the state atomically.
+func (s *SavedSearchState) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("mkdir %s: %w", dir, err)
+	}
+	tmp, err := os.CreateTemp(dir, "searches-state-*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+	}()
+	if _, err := tmp.Write(data); err != nil {
+		return fmt.Errorf("write state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("rename: %w", err)
+	}
+	return nil
+}
+
+// record updates the membership of search to top and reports whether this
+// evaluation established a new baseline (nothing counts as entered).
+func (s *SavedSearchState) record(search SavedSearch, top []SearchResult, now time.Time) (map[string]time.Time, bool) {
+	fp := search.fingerprint()
+	prev := s.Searches[search.Name]
+	baseline := prev == nil || prev.Fingerprint != fp
+
+	members := make(map[string]time.Time, len(top))
+	for _, r := range top {
+		switch {
+		case baseline:
+			members[r.IssueID] = time.Time{}
+		default:
+			if at, ok := prev.Members[r.IssueID]; ok {
+				members[r.IssueID] = at
+			} else {
+				members[r.IssueID] = now
+			}
+		}
+	}
+	s.Searches[search.Name] = &savedSearchRecord{Fingerprint: fp, EvaluatedAt: now, Members: members}
+	return members, baseline
+}
+
+// prune drops records for searches that no longer exist.
+func (s *SavedSearchState) prune(searches []SavedSearch) {
+	keep := make(map[string]bool, len(searches))
+	for _, search := range searches {
+		keep[search.Name] = true
+	}
+	for name := range s.Searches {
+		if !keep[name] {
+			delete(s.Searches, name)
+		}
+	}
+}
+
+// SavedSearchHit is one issue in a saved search's top-N.
+type SavedSearchHit struct {
+	IssueID   string     `json:"issue_id"`
+	Title     string     `json:"title,omitempty"`
+	Rank      int        `json:"rank"`
+	Score     float64    `json:"score"`
+	EnteredAt *time.Time `json:"entered_at,omitempty"` // nil when present since the baseline
+}
+
+// SavedSearchResult is the outcome of evaluating one saved search.
+type SavedSearchResult struct {
+	SavedSearch
+	Top      []SavedSearchHit `json:"top"`
+	New      []SavedSearchHit `json:"new"` // entered the top-N at or after the alert cutoff
+	Baseline bool             `json:"baseline,omitempty"`
+	Error    string           `json:"error,omitempty"`
+}
+
+// EvaluateSavedSearches ranks every search, updates state with the new top-N
+// membership and reports issues that entered it at or after since. Failures
+// are reported per search so one bad query does not hide the others.
+// projectDir locates learned preset weights.
+func EvaluateSavedSearches(ctx context.Context, projectDir string, engine *SearchEngine, searches []SavedSearch, issues []model.Issue, state *SavedSearchState, now, since time.Time) []SavedSearchResult {
+	titles := make(map[string]string, len(issues))
+	for _, iss := range issues {
+		titles[iss.ID] = iss.Title
+	}
+
+	results := make([]SavedSearchResult, 0, len(searches))
+	for _, s := range searches {
+		res := SavedSearchResult{SavedSearch: s, Top: []SavedSearchHit{}, New: []SavedSearchHit{}}
+		weights, err := s.ResolvedWeights(projectDir)
+		if err != nil {
+			res.Error = err.Error()
+			results = append(results, res)
+			continue
+		}
+		filtered := s.Filters.Apply(issues, now)
+		allowed := make(map[string]bool, len(filtered))
+		for _, iss := range filtered {
+			allowed[iss.ID] = true
+		}
+		top, err := engine.Rank(ctx, s.Query, s.SearchMode(), weights, allowed, s.TopN())
+		if err != nil {
+			res.Error = err.Error()
+			results = append(results, res)
+			continue
+		}
+
+		members, baseline := state.record(s, top, now)
+		res.Baseline = baseline
+		for i, r := range top {
+			hit := SavedSearchHit{IssueID: r.IssueID, Title: titles[r.IssueID], Rank: i + 1, Score: r.Score}
+			if at := members[r.IssueID]; !at.IsZero() {
+				hit.EnteredAt = &at
+				if !at.Before(since) {
+					res.New = append(res.New, hit)
+				}
+			}
+			res.Top = append(res.Top, hit)
+		}
+		results = append(results, res)
+	}
+	state.prune(searches)
+	return results
+}
+
+// SavedSearchAlerts maps issue IDs to the names of saved searches they
+// recently entered.
+func SavedSearchAlerts(results []SavedSearchResult) map[string][]string {
+	alerts := make(map[string][]string)
+	for _, res := range results {
+		for _, hit := range res.New {
+			alerts[hit.IssueID] = append(alerts[hit.IssueID], res.Name)
+		}
+	}
+	return alerts
+}
+
+// CheckSavedSearches evaluates projectDir's saved searches against issues and
+// persists the updated membership state. It returns nil when no searches are
+// defined.
+func CheckSavedSearches(ctx context.Context, projectDir string, issues []model.Issue, now, since time.Time) ([]SavedSearchResult, error) {
+	searches, err := LoadSavedSearches(projectDir)
+	if err != nil || len(searches) == 0 {
+		return nil, err
+	}
+	statePath := SavedSearchStatePath(projectDir)
+	state, err := LoadSavedSearchState(statePath)
+	if err != nil {
+		return nil, err
+	}
+	pi, err := OpenProjectIndex(ctx, projectDir, issues, nil)
+	if err != nil {
+		return nil, err
+	}
+	results := EvaluateSavedSearches(ctx, projectDir, NewSearchEngine(pi, issues), searches, issues, state, now, since)
+	if err := state.Save(statePath); err != nil {
+		return nil, fmt.Errorf("save saved search state: %w", err)
+	}
+	return results, nil
+}
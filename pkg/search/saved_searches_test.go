@@ -0,0 +1,205 @@
+package search
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func writeSavedSearches(t *testing.T, dir, body string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(SavedSearchesPath(dir), []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadSavedSearches(t *testing.T) {
+	dir := t.TempDir()
+	if got, err := LoadSavedSearches(dir); err != nil || got != nil {
+		t.Fatalf("missing file = %v, %v", got, err)
+	}
+
+	writeSavedSearches(t, dir, `
+searches:
+  sync-bugs:
+    query: sync conflict
+    mode: hybrid
+    preset: bug-hunting
+    limit: 5
+    filters:
+      status: [open]
+  auth:
+    query: login token
+    weights: {text: 0.5, pagerank: 0.1, status: 0.1, impact: 0.1, priority: 0.1, recency: 0.1}
+  disabled: null
+`)
+	got, err := LoadSavedSearches(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Name != "auth" || got[1].Name != "sync-bugs" {
+		t.Fatalf("searches = %+v", got)
+	}
+	if got[0].SearchMode() != SearchModeText || got[0].TopN() != DefaultSavedSearchLimit {
+		t.Fatalf("auth defaults: mode=%q topN=%d", got[0].SearchMode(), got[0].TopN())
+	}
+	if w, err := got[0].ResolvedWeights(dir); err != nil || w.TextRelevance != 0.5 {
+		t.Fatalf("auth weights = %+v, %v", w, err)
+	}
+	if got[1].TopN() != 5 || len(got[1].Filters.Status) != 1 {
+		t.Fatalf("sync-bugs = %+v", got[1])
+	}
+
+	for _, bad := range []string{
+		"searches:\n  x:\n    mode: hybrid\n",
+		"searches:\n  x:\n    query: a\n    mode: fuzzy\n",
+		"searches:\n  x:\n    query: a\n    preset: nope\n",
+		"searches:\n  x:\n    query: a\n    weights: {text: 1}\n",
+	} {
+		writeSavedSearches(t, dir, bad)
+		if _, err := LoadSavedSearches(dir); err == nil || !strings.Contains(err.Error(), `search "x"`) {
+			t.Errorf("expected validation error for %q, got %v", bad, err)
+		}
+	}
+}
+
+func TestLoadSavedSearches_LearnedPreset(t *testing.T) {
+	dir := t.TempDir()
+	writeSavedSearches(t, dir, `
+searches:
+  tuned:
+    query: sync conflict
+    mode: hybrid
+    preset: learned
+`)
+	got, err := LoadSavedSearches(dir)
+	if err != nil {
+		t.Fatalf("learned preset rejected before learning: %v", err)
+	}
+	if _, err := got[0].ResolvedWeights(dir); err == nil {
+		t.Fatal("expected an error resolving learned weights before learning")
+	}
+
+	learned := Weights{TextRelevance: 0.6, PageRank: 0.1, Status: 0.1, Impact: 0.1, Priority: 0.05, Recency: 0.05}
+	if err := (&LearnedWeights{Weights: learned, BaselinePreset: PresetDefault}).Save(dir); err != nil {
+		t.Fatal(err)
+	}
+	got, err = LoadSavedSearches(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w, err := got[0].ResolvedWeights(dir); err != nil || w != learned {
+		t.Fatalf("learned weights = %+v, %v", w, err)
+	}
+}
+
+func TestSavedSearchState_BaselineThenEntries(t *testing.T) {
+	s := SavedSearch{Name: "watch", Query: "q"}
+	state := &SavedSearchState{Searches: make(map[string]*savedSearchRecord)}
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	members, baseline := state.record(s, []SearchResult{{IssueID: "a"}, {IssueID: "b"}}, t0)
+	if !baseline || !members["a"].IsZero() || !members["b"].IsZero() {
+		t.Fatalf("first evaluation should be a zero-time baseline: %v %v", baseline, members)
+	}
+
+	t1 := t0.Add(time.Hour)
+	members, baseline = state.record(s, []SearchResult{{IssueID: "b"}, {IssueID: "c"}}, t1)
+	if baseline || !members["b"].IsZero() || !members["c"].Equal(t1) {
+		t.Fatalf("c should enter at t1: %v %v", baseline, members)
+	}
+	if _, ok := members["a"]; ok {
+		t.Fatal("a left the top-N and should be dropped")
+	}
+
+	t2 := t1.Add(time.Hour)
+	members, _ = state.record(s, []SearchResult{{IssueID: "c"}}, t2)
+	if !members["c"].Equal(t1) {
+		t.Fatalf("c keeps its entry time, got %v", members["c"])
+	}
+
+	s.Query = "edited"
+	if _, baseline = state.record(s, []SearchResult{{IssueID: "d"}}, t2); !baseline {
+		t.Fatal("editing the search should reset the baseline")
+	}
+
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := state.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadSavedSearchState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Searches["watch"].Fingerprint != state.Searches["watch"].Fingerprint {
+		t.Fatalf("round-trip lost state: %+v", loaded.Searches["watch"])
+	}
+}
+
+func TestEvaluateSavedSearches_FiltersAndAlerts(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Sync conflict on reconnect", Status: model.StatusOpen},
+		{ID: "bv-2", Title: "Sync conflict in offline mode", Status: model.StatusClosed},
+		{ID: "bv-3", Title: "Dark mode colors", Status: model.StatusOpen},
+	}
+	ctx := context.Background()
+	build := func(issues []model.Issue) *SearchEngine {
+		t.Helper()
+		embedder := NewHashEmbedder(DefaultEmbeddingDim)
+		chunks := NewChunkSet(issues)
+		idx := NewVectorIndex(embedder.Dim())
+		if _, err := SyncVectorIndex(ctx, idx, embedder, chunks.Texts(), 16); err != nil {
+			t.Fatal(err)
+		}
+		return NewSearchEngine(&ProjectIndex{Embedder: embedder, Index: idx, Chunks: chunks}, issues)
+	}
+
+	searches := []SavedSearch{{Name: "sync", Query: "sync conflict", Limit: 2}}
+	searches[0].Filters.Status = []string{"open"}
+	state := &SavedSearchState{Searches: make(map[string]*savedSearchRecord)}
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	results := EvaluateSavedSearches(ctx, t.TempDir(), build(issues), searches, issues, state, t0, t0.Add(-DefaultSavedSearchAlertWindow))
+	if len(results) != 1 || results[0].Error != "" || !results[0].Baseline {
+		t.Fatalf("results = %+v", results)
+	}
+	if results[0].Top[0].IssueID != "bv-1" || len(results[0].New) != 0 {
+		t.Fatalf("baseline top = %+v new = %+v", results[0].Top, results[0].New)
+	}
+	for _, hit := range results[0].Top {
+		if hit.IssueID == "bv-2" {
+			t.Fatal("closed issue should be filtered out")
+		}
+	}
+
+	issues = append(issues, model.Issue{ID: "bv-4", Title: "Sync conflict resolution UI", Status: model.StatusOpen})
+	t1 := t0.Add(time.Hour)
+	results = EvaluateSavedSearches(ctx, t.TempDir(), build(issues), searches, issues, state, t1, t1.Add(-DefaultSavedSearchAlertWindow))
+	if len(results[0].New) != 1 || results[0].New[0].IssueID != "bv-4" || !results[0].New[0].EnteredAt.Equal(t1) {
+		t.Fatalf("new = %+v", results[0].New)
+	}
+	if alerts := SavedSearchAlerts(results); len(alerts) != 1 || alerts["bv-4"][0] != "sync" {
+		t.Fatalf("alerts = %v", alerts)
+	}
+
+	// Outside the alert window the entry is still tracked but not reported.
+	t2 := t1.Add(48 * time.Hour)
+	results = EvaluateSavedSearches(ctx, t.TempDir(), build(issues), searches, issues, state, t2, t2.Add(-DefaultSavedSearchAlertWindow))
+	if len(results[0].New) != 0 {
+		t.Fatalf("stale entry reported: %+v", results[0].New)
+	}
+
+	// Removed searches are pruned from state.
+	EvaluateSavedSearches(ctx, t.TempDir(), build(issues), nil, issues, state, t2, t2)
+	if len(state.Searches) != 0 {
+		t.Fatalf("state not pruned: %v", state.Searches)
+	}
+}
@@ -0,0 +1,113 @@
+package search
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Search feedback sources.
+const (
+	FeedbackSourceTUI   = "tui"
+	FeedbackSourceRobot = "robot"
+	FeedbackSourceCLI   = "cli"
+)
+
+// SearchFeedbackEvent records which result was opened or accepted for a query.
+type SearchFeedbackEvent struct {
+	Time    time.Time  `json:"ts"`
+	Source  string     `json:"source"`
+	Query   string     `json:"query"`
+	Mode    SearchMode `json:"mode,omitempty"`
+	Shown   []string   `json:"shown"` // result IDs in display order
+	Clicked string     `json:"clicked"`
+}
+
+// SearchFeedbackPath returns the click log path for projectDir.
+func SearchFeedbackPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", "search-feedback.jsonl")
+}
+
+// NewSearchFeedbackEvent builds an event from the results shown for query.
+// A clicked ID missing from shown is appended, as a result found further down.
+func NewSearchFeedbackEvent(source, query string, mode SearchMode, shown []string, clicked string, now time.Time) SearchFeedbackEvent {
+	ids := make([]string, 0, len(shown)+1)
+	found := false
+	for _, id := range shown {
+		ids = append(ids, id)
+		found = found || id == clicked
+	}
+	if !found {
+		ids = append(ids, clicked)
+	}
+	return SearchFeedbackEvent{
+		Time:    now.UTC(),
+		Source:  source,
+		Query:   strings.TrimSpace(query),
+		Mode:    mode,
+		Shown:   ids,
+		Clicked: clicked,
+	}
+}
+
+// AppendSearchFeedback appends ev to the project's click log.
+func AppendSearchFeedback(projectDir string, ev SearchFeedbackEvent) error {
+	if ev.Query == "" || ev.Clicked == "" {
+		return fmt.Errorf("search feedback needs a query and a clicked result")
+	}
+	path := SearchFeedbackPath(projectDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("mkdir %s: %w", filepath.Dir(path), err)
+	}
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LoadSearchFeedback reads the project's click log. Malformed lines are
+// skipped and counted; a missing log yields no events.
+func LoadSearchFeedback(projectDir string) ([]SearchFeedbackEvent, int, error) {
+	f, err := os.Open(SearchFeedbackPath(projectDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, 0, nil
+		}
+		return nil, 0, err
+	}
+	defer f.Close()
+
+	var events []SearchFeedbackEvent
+	skipped := 0
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var ev SearchFeedbackEvent
+		if err := json.Unmarshal([]byte(line), &ev); err != nil || ev.Query == "" || ev.Clicked == "" {
+			skipped++
+			continue
+		}
+		events = append(events, ev)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, skipped, err
+	}
+	return events, skipped, nil
+}
@@ -0,0 +1,54 @@
+package search
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestNewSearchFeedbackEvent(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	ev := NewSearchFeedbackEvent(FeedbackSourceRobot, "  sync  ", SearchModeHybrid, []string{"a", "b"}, "z", now)
+	if ev.Query != "sync" || len(ev.Shown) != 3 || ev.Shown[2] != "z" {
+		t.Fatalf("event = %+v", ev)
+	}
+	ev = NewSearchFeedbackEvent(FeedbackSourceTUI, "sync", SearchModeText, []string{"a", "b"}, "a", now)
+	if len(ev.Shown) != 2 {
+		t.Fatalf("clicked result already shown should not be appended: %+v", ev.Shown)
+	}
+}
+
+func TestSearchFeedbackRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	if events, skipped, err := LoadSearchFeedback(dir); err != nil || events != nil || skipped != 0 {
+		t.Fatalf("missing log = %v, %d, %v", events, skipped, err)
+	}
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	if err := AppendSearchFeedback(dir, NewSearchFeedbackEvent(FeedbackSourceTUI, "", SearchModeText, nil, "a", now)); err == nil {
+		t.Fatal("expected error for empty query")
+	}
+	for _, clicked := range []string{"a", "b"} {
+		ev := NewSearchFeedbackEvent(FeedbackSourceTUI, "login", SearchModeText, []string{"a", "b"}, clicked, now)
+		if err := AppendSearchFeedback(dir, ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f, err := os.OpenFile(SearchFeedbackPath(dir), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("{not json\n\n")
+	_ = f.Close()
+
+	events, skipped, err := LoadSearchFeedback(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || skipped != 1 {
+		t.Fatalf("events = %d skipped = %d", len(events), skipped)
+	}
+	if events[1].Clicked != "b" || !events[1].Time.Equal(now) || events[1].Source != FeedbackSourceTUI {
+		t.Fatalf("event = %+v", events[1])
+	}
+}
@@ -0,0 +1,389 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// IssueVector returns an issue's position for "more like this": the
+// normalized mean of its chunk vectors, or the vector stored under the bare
+// issue ID for indexes built before chunking.
+func (cs *ChunkSet) IssueVector(idx *VectorIndex, issueID string) ([]float32, bool) {
+	var sum []float32
+	n := 0
+	for _, key := range cs.Keys(issueID) {
+		entry, ok := idx.Get(key)
+		if !ok {
+			continue
+		}
+		if sum == nil {
+			sum = make([]float32, len(entry.Vector))
+		}
+		for i, v := range entry.Vector {
+			sum[i] += v
+		}
+		n++
+	}
+	if n == 0 {
+		entry, ok := idx.Get(issueID)
+		if !ok {
+			return nil, false
+		}
+		return entry.Vector, true
+	}
+	normalizeL2(sum)
+	return sum, true
+}
+
+// SimilarIssues returns the k issues nearest to issueID, excluding itself.
+// cs may be nil when the index holds one vector per issue.
+func SimilarIssues(idx *VectorIndex, cs *ChunkSet, issueID string, k int) ([]SearchResult, error) {
+	if k <= 0 {
+		return nil, nil
+	}
+	q, ok := cs.IssueVector(idx, issueID)
+	if !ok {
+		return nil, fmt.Errorf("issue %s is not in the semantic index", issueID)
+	}
+	results, _, err := SearchChunks(idx, q, k+1)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]SearchResult, 0, k)
+	for _, r := range results {
+		if r.IssueID == issueID {
+			continue
+		}
+		out = append(out, r)
+		if len(out) == k {
+			break
+		}
+	}
+	return out, nil
+}
+
+// Duplicate thresholds are cosine similarities between issue vectors. Hashed
+// token vectors score high on shared vocabulary alone, so they get a lower
+// floor than model embeddings, whose unrelated pairs already sit well above 0.
+const (
+	DuplicateFloorHash  = 0.75
+	DuplicateFloorModel = 0.85
+	duplicateCeiling    = 0.98
+	// duplicateMADs is how far above the typical nearest-neighbour
+	// similarity (in robust standard deviations) a pair must be.
+	duplicateMADs = 3.0
+)
+
+// DuplicateFloor returns the lowest threshold calibration may pick for p.
+func DuplicateFloor(p Provider) float64 {
+	if p == ProviderHash || p == "" {
+		return DuplicateFloorHash
+	}
+	return DuplicateFloorModel
+}
+
+// SemanticDuplicateConfig configures embedding-based duplicate detection.
+type SemanticDuplicateConfig struct {
+	// Threshold is the minimum cosine similarity; 0 calibrates it from the
+	// corpus (see CalibrateDuplicateThreshold).
+	Threshold float64
+	// Floor bounds a calibrated threshold from below.
+	Floor float64
+	// Neighbors is how many nearest neighbours each issue is compared with.
+	Neighbors int
+	// IgnoreClosedVsOpen skips pairs where one is closed and one is open.
+	IgnoreClosedVsOpen bool
+	// MaxGroups limits the number of groups returned (0 = unlimited).
+	MaxGroups int
+}
+
+// DefaultSemanticDuplicateConfig returns calibrated defaults for provider p.
+func DefaultSemanticDuplicateConfig(p Provider) SemanticDuplicateConfig {
+	return SemanticDuplicateConfig{
+		Floor:              DuplicateFloor(p),
+		Neighbors:          5,
+		IgnoreClosedVsOpen: true,
+		MaxGroups:          20,
+	}
+}
+
+// DuplicateGroup is a cluster of issues that all describe the same work.
+// Every pair of members is at least Threshold similar (complete linkage), so
+// groups cannot drift through chains of loosely related issues.
+type DuplicateGroup struct {
+	Canonical     string   `json:"canonical"`
+	Members       []string `json:"members"`
+	MinSimilarity float64  `json:"min_similarity"`
+	MaxSimilarity float64  `json:"max_similarity"`
+}
+
+// CalibrateDuplicateThreshold picks a threshold from each issue's
+// nearest-neighbour similarity: duplicates are outliers, so the cut sits
+// duplicateMADs robust deviations above the median, clamped to
+// [floor, duplicateCeiling].
+func CalibrateDuplicateThreshold(nearest []float64, floor float64) float64 {
+	if len(nearest) == 0 {
+		return floor
+	}
+	med := median(nearest)
+	dev := make([]float64, len(nearest))
+	for i, s := range nearest {
+		dev[i] = math.Abs(s - med)
+	}
+	// 1.4826 scales MAD to a standard deviation for normal data.
+	t := med + duplicateMADs*1.4826*median(dev)
+	return math.Max(floor, math.Min(t, duplicateCeiling))
+}
+
+func median(xs []float64) float64 {
+	s := append([]float64(nil), xs...)
+	sort.Float64s(s)
+	mid := len(s) / 2
+	if len(s)%2 == 0 {
+		return (s[mid-1] + s[mid]) / 2
+	}
+	return s[mid]
+}
+
+// DetectSemanticDuplicates clusters issues whose vectors are near-identical.
+// It returns the groups (largest and tightest first) and the threshold used.
+func DetectSemanticDuplicates(issues []model.Issue, idx *VectorIndex, cs *ChunkSet, cfg SemanticDuplicateConfig) ([]DuplicateGroup, float64) {
+	byID := make(map[string]*model.Issue, len(issues))
+	vecs := make(map[string][]float32, len(issues))
+	issueIdx := NewVectorIndex(idx.Dim)
+	for i := range issues {
+		iss := &issues[i]
+		if iss.ID == "" || iss.Status == model.StatusTombstone {
+			continue
+		}
+		v, ok := cs.IssueVector(idx, iss.ID)
+		if !ok || len(v) != idx.Dim {
+			continue
+		}
+		byID[iss.ID] = iss
+		vecs[iss.ID] = v
+		if err := issueIdx.Upsert(iss.ID, ContentHash{}, v); err != nil {
+			continue
+		}
+	}
+
+	neighbors := cfg.Neighbors
+	if neighbors <= 0 {
+		neighbors = 5
+	}
+	type pair struct {
+		a, b string
+		sim  float64
+	}
+	var candidates []pair
+	var nearest []float64
+	for _, id := range issueIdx.sortedIDs() {
+		hits, err := issueIdx.SearchTopK(vecs[id], neighbors+1)
+		if err != nil {
+			continue
+		}
+		first := true
+		for _, h := range hits {
+			if h.IssueID == id {
+				continue
+			}
+			if first {
+				nearest = append(nearest, h.Score)
+				first = false
+			}
+			if id < h.IssueID {
+				candidates = append(candidates, pair{id, h.IssueID, h.Score})
+			} else {
+				candidates = append(candidates, pair{h.IssueID, id, h.Score})
+			}
+		}
+	}
+
+	threshold := cfg.Threshold
+	if threshold <= 0 {
+		threshold = CalibrateDuplicateThreshold(nearest, cfg.Floor)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].sim != candidates[j].sim {
+			return candidates[i].sim > candidates[j].sim
+		}
+		if candidates[i].a != candidates[j].a {
+			return candidates[i].a < candidates[j].a
+		}
+		return candidates[i].b < candidates[j].b
+	})
+
+	compatible := func(a, b string) bool {
+		if !cfg.IgnoreClosedVsOpen {
+			return true
+		}
+		return isClosedLike(byID[a].Status) == isClosedLike(byID[b].Status)
+	}
+
+	// Agglomerate strongest pairs first; merging two clusters requires every
+	// cross pair to clear the threshold.
+	clusterOf := make(map[string]int)
+	var clusters [][]string
+	for _, p := range candidates {
+		if p.sim < threshold {
+			break
+		}
+		if !compatible(p.a, p.b) {
+			continue
+		}
+		ca, okA := clusterOf[p.a]
+		cb, okB := clusterOf[p.b]
+		if okA && okB && ca == cb {
+			continue
+		}
+		left, right := []string{p.a}, []string{p.b}
+		if okA {
+			left = clusters[ca]
+		}
+		if okB {
+			right = clusters[cb]
+		}
+		if !allPairsAbove(left, right, vecs, threshold) || !allCompatible(left, right, compatible) {
+			continue
+		}
+		merged := append(append([]string(nil), left...), right...)
+		target := len(clusters)
+		switch {
+		case okA:
+			target = ca
+			if okB {
+				clusters[cb] = nil
+			}
+		case okB:
+			target = cb
+		default:
+			clusters = append(clusters, nil)
+		}
+		clusters[target] = merged
+		for _, id := range merged {
+			clusterOf[id] = target
+		}
+	}
+
+	var groups []DuplicateGroup
+	for _, members := range clusters {
+		if len(members) < 2 {
+			continue
+		}
+		groups = append(groups, newDuplicateGroup(members, byID, vecs))
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if len(groups[i].Members) != len(groups[j].Members) {
+			return len(groups[i].Members) > len(groups[j].Members)
+		}
+		if groups[i].MinSimilarity != groups[j].MinSimilarity {
+			return groups[i].MinSimilarity > groups[j].MinSimilarity
+		}
+		return groups[i].Canonical < groups[j].Canonical
+	})
+	if cfg.MaxGroups > 0 && len(groups) > cfg.MaxGroups {
+		groups = groups[:cfg.MaxGroups]
+	}
+	return groups, threshold
+}
+
+func isClosedLike(s model.Status) bool {
+	return s == model.StatusClosed || s == model.StatusTombstone
+}
+
+func allPairsAbove(left, right []string, vecs map[string][]float32, threshold float64) bool {
+	for _, a := range left {
+		for _, b := range right {
+			if dotFloat32(vecs[a], vecs[b]) < threshold {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func allCompatible(left, right []string, ok func(a, b string) bool) bool {
+	for _, a := range left {
+		for _, b := range right {
+			if !ok(a, b) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// newDuplicateGroup picks the oldest issue as canonical; the others are
+// listed after it by ID.
+func newDuplicateGroup(members []string, byID map[string]*model.Issue, vecs map[string][]float32) DuplicateGroup {
+	sort.Slice(members, func(i, j int) bool {
+		a, b := byID[members[i]], byID[members[j]]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			if a.CreatedAt.IsZero() || b.CreatedAt.IsZero() {
+				return !a.CreatedAt.IsZero()
+			}
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID < b.ID
+	})
+	sort.Strings(members[1:])
+	g := DuplicateGroup{Canonical: members[0], Members: members, MinSimilarity: 1}
+	for i := range members {
+		for j := i + 1; j < len(members); j++ {
+			sim := dotFloat32(vecs[members[i]], vecs[members[j]])
+			g.MinSimilarity = math.Min(g.MinSimilarity, sim)
+			g.MaxSimilarity = math.Max(g.MaxSimilarity, sim)
+		}
+	}
+	return g
+}
+
+// DuplicateGroupSuggestions turns groups into potential-duplicate
+// suggestions, one per non-canonical member. Confidence rescales similarity
+// so the threshold maps to 0.5 and identical vectors to 1.
+func DuplicateGroupSuggestions(groups []DuplicateGroup, issues []model.Issue, threshold float64) []analysis.Suggestion {
+	byID := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+	var out []analysis.Suggestion
+	for gi, g := range groups {
+		for _, id := range g.Members[1:] {
+			confidence := 0.5
+			if threshold < 1 {
+				confidence = 0.5 + 0.5*(g.MinSimilarity-threshold)/(1-threshold)
+			}
+			confidence = math.Max(0, math.Min(1, confidence))
+
+			reason := fmt.Sprintf("%.0f%% embedding similarity", g.MinSimilarity*100)
+			if len(g.Members) > 2 {
+				reason += fmt.Sprintf("; group of %d: %s", len(g.Members), strings.Join(g.Members, ", "))
+			}
+			sug := analysis.NewSuggestion(
+				analysis.SuggestionPotentialDuplicate,
+				id,
+				fmt.Sprintf("Potential duplicate of %s", g.Canonical),
+				reason,
+				confidence,
+			).WithRelatedBead(g.Canonical).
+				WithMetadata("method", "semantic").
+				WithMetadata("similarity", g.MinSimilarity).
+				WithMetadata("threshold", threshold).
+				WithMetadata("group", gi+1).
+				WithMetadata("group_members", g.Members)
+
+			a, b := byID[id], byID[g.Canonical]
+			if a != nil && b != nil && !isClosedLike(a.Status) && !isClosedLike(b.Status) {
+				sug = sug.WithAction(fmt.Sprintf("br dep add %s %s --type=related", id, g.Canonical))
+			}
+			out = append(out, sug)
+		}
+	}
+	return out
+}
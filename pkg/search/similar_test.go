@@ -0,0 +1,155 @@
+package search
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// planeVec returns a unit vector at deg degrees in the plane of dims
+// (2*plane, 2*plane+1) of a 6-dim space, so vectors in different planes are
+// orthogonal and cos(a-b) is the similarity within a plane.
+func planeVec(plane int, deg float64) []float32 {
+	v := make([]float32, 6)
+	rad := deg * math.Pi / 180
+	v[2*plane] = float32(math.Cos(rad))
+	v[2*plane+1] = float32(math.Sin(rad))
+	return v
+}
+
+func TestSimilarIssues_UsesChunkCentroidAndExcludesSelf(t *testing.T) {
+	cs := NewChunkSet([]model.Issue{
+		{ID: "bv-1", Title: "a", Description: "b"},
+		{ID: "bv-2", Title: "c"},
+		{ID: "bv-3", Title: "d"},
+	})
+	idx := NewVectorIndex(6)
+	for key, vec := range map[string][]float32{
+		"bv-1#title.0":       planeVec(0, 0),
+		"bv-1#description.0": planeVec(0, 40),
+		"bv-2#title.0":       planeVec(0, 20), // nearest the bv-1 centroid
+		"bv-3#title.0":       planeVec(1, 0),
+	} {
+		if err := idx.Upsert(key, ContentHash{}, vec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := SimilarIssues(idx, cs, "bv-1", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].IssueID != "bv-2" || got[1].IssueID != "bv-3" {
+		t.Fatalf("SimilarIssues = %v", got)
+	}
+	if got[0].Score < 0.99 {
+		t.Fatalf("bv-2 should sit on the bv-1 centroid, score %v", got[0].Score)
+	}
+
+	if _, err := SimilarIssues(idx, cs, "bv-404", 5); err == nil {
+		t.Fatal("expected error for an issue missing from the index")
+	}
+}
+
+func TestCalibrateDuplicateThreshold(t *testing.T) {
+	if got := CalibrateDuplicateThreshold(nil, 0.8); got != 0.8 {
+		t.Fatalf("empty corpus threshold = %v, want floor", got)
+	}
+	// Tight spread around 0.5: the calibrated cut stays at the floor.
+	if got := CalibrateDuplicateThreshold([]float64{0.5, 0.51, 0.49, 0.5, 0.52}, 0.75); got != 0.75 {
+		t.Fatalf("threshold = %v, want floor 0.75", got)
+	}
+	// A corpus whose neighbours are all close pushes the cut above the floor.
+	got := CalibrateDuplicateThreshold([]float64{0.80, 0.84, 0.88, 0.92, 0.86}, 0.75)
+	if got <= 0.86 || got > duplicateCeiling {
+		t.Fatalf("threshold = %v, want above the median and at most %v", got, duplicateCeiling)
+	}
+}
+
+func TestDetectSemanticDuplicates_CompleteLinkageAndStatus(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "bv-a", Status: model.StatusOpen, CreatedAt: now.Add(-48 * time.Hour)},
+		{ID: "bv-b", Status: model.StatusOpen, CreatedAt: now.Add(-72 * time.Hour)},
+		{ID: "bv-c", Status: model.StatusOpen, CreatedAt: now},
+		{ID: "bv-d", Status: model.StatusOpen, CreatedAt: now},
+		{ID: "bv-e", Status: model.StatusClosed, CreatedAt: now},
+		{ID: "bv-f", Status: model.StatusOpen, CreatedAt: now},
+	}
+	idx := NewVectorIndex(6)
+	for id, vec := range map[string][]float32{
+		// a~b 0.990, b~c 0.982, a~c 0.946: c only chains through b.
+		"bv-a": planeVec(0, 0),
+		"bv-b": planeVec(0, 8),
+		"bv-c": planeVec(0, 19),
+		// d~e 0.996 but open vs closed.
+		"bv-d": planeVec(1, 0),
+		"bv-e": planeVec(1, 5),
+		"bv-f": planeVec(2, 0),
+	} {
+		// Bare IDs: an index built before chunking.
+		if err := idx.Upsert(id, ContentHash{}, vec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := DefaultSemanticDuplicateConfig(ProviderHash)
+	cfg.Threshold = 0.96
+	groups, threshold := DetectSemanticDuplicates(issues, idx, nil, cfg)
+	if threshold != 0.96 {
+		t.Fatalf("threshold = %v", threshold)
+	}
+	if len(groups) != 1 {
+		t.Fatalf("groups = %+v, want one", groups)
+	}
+	g := groups[0]
+	if g.Canonical != "bv-b" || strings.Join(g.Members, ",") != "bv-b,bv-a" {
+		t.Fatalf("group = %+v, want oldest bv-b canonical with bv-a", g)
+	}
+	if g.MinSimilarity < 0.98 || g.MinSimilarity > 0.995 {
+		t.Fatalf("MinSimilarity = %v", g.MinSimilarity)
+	}
+
+	cfg.IgnoreClosedVsOpen = false
+	groups, _ = DetectSemanticDuplicates(issues, idx, nil, cfg)
+	if len(groups) != 2 {
+		t.Fatalf("with closed-vs-open allowed, groups = %+v", groups)
+	}
+
+	// A looser threshold lets c join, because every pair now clears it.
+	cfg.Threshold = 0.94
+	groups, _ = DetectSemanticDuplicates(issues, idx, nil, cfg)
+	if len(groups[0].Members) != 3 {
+		t.Fatalf("groups = %+v, want a three-member group first", groups)
+	}
+}
+
+func TestDuplicateGroupSuggestions(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Status: model.StatusOpen},
+		{ID: "bv-2", Status: model.StatusOpen},
+		{ID: "bv-3", Status: model.StatusClosed},
+	}
+	groups := []DuplicateGroup{{Canonical: "bv-1", Members: []string{"bv-1", "bv-2", "bv-3"}, MinSimilarity: 0.9, MaxSimilarity: 0.95}}
+	sugs := DuplicateGroupSuggestions(groups, issues, 0.8)
+	if len(sugs) != 2 {
+		t.Fatalf("got %d suggestions, want one per non-canonical member", len(sugs))
+	}
+	s := sugs[0]
+	if s.Type != analysis.SuggestionPotentialDuplicate || s.TargetBead != "bv-2" || s.RelatedBead != "bv-1" {
+		t.Fatalf("suggestion = %+v", s)
+	}
+	if math.Abs(s.Confidence-0.75) > 1e-9 {
+		t.Fatalf("confidence = %v, want 0.75 halfway between threshold and 1", s.Confidence)
+	}
+	if s.Metadata["method"] != "semantic" || !strings.Contains(s.Reason, "group of 3") {
+		t.Fatalf("metadata=%v reason=%q", s.Metadata, s.Reason)
+	}
+	if s.ActionCommand == "" || sugs[1].ActionCommand != "" {
+		t.Fatalf("action only expected when both issues are open: %q / %q", s.ActionCommand, sugs[1].ActionCommand)
+	}
+}
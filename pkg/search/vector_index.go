@@ -2,6 +2,7 @@ package search
 
 import (
 	"bufio"
+	"bytes"
 	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
@@ -18,8 +19,15 @@ import (
 )
 
 const (
-	vectorIndexMagic   = "BVVI"
-	vectorIndexVersion = uint16(1)
+	vectorIndexMagic = "BVVI"
+	// Version 2 appends an optional HNSW graph section; version 1 files
+	// (entries only) still load and get a graph built on demand.
+	vectorIndexVersion = uint16(2)
+
+	// DefaultANNThreshold is the index size from which SearchTopK switches
+	// from exact scanning to the HNSW graph. Below it a brute-force scan is
+	// both exact and fast enough for search-as-you-type.
+	DefaultANNThreshold = 2048
 )
 
 type ContentHash [32]byte
@@ -57,6 +65,11 @@ type VectorIndex struct {
 	entries  map[string]VectorEntry
 	idsCache []string
 	idsDirty bool
+
+	// ann is built lazily once the index reaches annThreshold entries and is
+	// then kept in sync by Upsert/Remove.
+	ann          *hnswGraph
+	annThreshold int
 }
 
 func NewVectorIndex(dim int) *VectorIndex {
@@ -64,9 +77,47 @@ func NewVectorIndex(dim int) *VectorIndex {
 		dim = DefaultEmbeddingDim
 	}
 	return &VectorIndex{
-		Dim:      dim,
-		entries:  make(map[string]VectorEntry),
-		idsDirty: true,
+		Dim:          dim,
+		entries:      make(map[string]VectorEntry),
+		idsDirty:     true,
+		annThreshold: DefaultANNThreshold,
+	}
+}
+
+// SetANNThreshold changes the size at which approximate search kicks in.
+// A threshold <= 0 disables the HNSW graph entirely.
+func (idx *VectorIndex) SetANNThreshold(n int) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.annThreshold = n
+	if n <= 0 {
+		idx.ann = nil
+	}
+}
+
+// Approximate reports whether SearchTopK currently uses the HNSW graph.
+func (idx *VectorIndex) Approximate() bool {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.useANN()
+}
+
+func (idx *VectorIndex) useANN() bool {
+	return idx.annThreshold > 0 && len(idx.entries) >= idx.annThreshold
+}
+
+// ensureANN builds the graph if the index has grown past the threshold.
+func (idx *VectorIndex) ensureANN() {
+	idx.mu.RLock()
+	need := idx.ann == nil && idx.useANN()
+	idx.mu.RUnlock()
+	if !need {
+		return
+	}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if idx.ann == nil && idx.useANN() {
+		idx.ann = buildHNSW(idx.entries)
 	}
 }
 
@@ -91,7 +142,7 @@ func LoadVectorIndex(path string) (*VectorIndex, error) {
 	if err := binary.Read(r, binary.LittleEndian, &version); err != nil {
 		return nil, fmt.Errorf("read version: %w", err)
 	}
-	if version != vectorIndexVersion {
+	if version != 1 && version != vectorIndexVersion {
 		return nil, fmt.Errorf("unsupported version %d", version)
 	}
 
@@ -114,6 +165,7 @@ func LoadVectorIndex(path string) (*VectorIndex, error) {
 	}
 
 	idx := NewVectorIndex(int(dimU32))
+	order := make([]string, 0, count)
 	for i := uint32(0); i < count; i++ {
 		var idLen uint16
 		if err := binary.Read(r, binary.LittleEndian, &idLen); err != nil {
@@ -143,8 +195,28 @@ func LoadVectorIndex(path string) (*VectorIndex, error) {
 			vec[j] = math.Float32frombits(bits)
 		}
 
-		if err := idx.Upsert(issueID, ch, vec); err != nil {
-			return nil, err
+		if _, dup := idx.entries[issueID]; dup {
+			return nil, fmt.Errorf("duplicate issue id %q", issueID)
+		}
+		idx.entries[issueID] = VectorEntry{ContentHash: ch, Vector: vec}
+		order = append(order, issueID)
+	}
+
+	if version >= 2 {
+		var hasGraph uint8
+		if err := binary.Read(r, binary.LittleEndian, &hasGraph); err != nil {
+			return nil, fmt.Errorf("read graph flag: %w", err)
+		}
+		if hasGraph == 1 {
+			vecs := make([][]float32, len(order))
+			for i, id := range order {
+				vecs[i] = idx.entries[id].Vector
+			}
+			graph, err := readGraph(r, order, vecs)
+			if err != nil {
+				return nil, err
+			}
+			idx.ann = graph
 		}
 	}
 
@@ -152,6 +224,9 @@ func LoadVectorIndex(path string) (*VectorIndex, error) {
 }
 
 func (idx *VectorIndex) Save(path string) error {
+	// Persist the graph with the vectors so the next load skips the build.
+	idx.ensureANN()
+
 	// Acquire sorted IDs before locking to avoid deadlock (sortedIDs needs Write lock if dirty)
 	ids := idx.sortedIDs()
 
@@ -220,6 +295,10 @@ func (idx *VectorIndex) Save(path string) error {
 		}
 	}
 
+	if err := idx.writeGraphSection(w, ids); err != nil {
+		return err
+	}
+
 	if err := w.Flush(); err != nil {
 		return fmt.Errorf("flush: %w", err)
 	}
@@ -268,6 +347,9 @@ func (idx *VectorIndex) Upsert(issueID string, hash ContentHash, vec []float32)
 	if !exists {
 		idx.idsDirty = true
 	}
+	if idx.ann != nil {
+		idx.ann.upsert(issueID, cp)
+	}
 	return nil
 }
 
@@ -280,6 +362,9 @@ func (idx *VectorIndex) Remove(issueID string) {
 	}
 	delete(idx.entries, issueID)
 	idx.idsDirty = true
+	if idx.ann != nil {
+		idx.ann.remove(issueID)
+	}
 }
 
 func (idx *VectorIndex) Get(issueID string) (VectorEntry, bool) {
@@ -326,6 +411,9 @@ type SearchResult struct {
 	Score   float64 `json:"score"`
 }
 
+// SearchTopK returns the k entries most similar to query. Indexes at or above
+// the ANN threshold are searched through the HNSW graph (approximate); smaller
+// ones are scanned exactly.
 func (idx *VectorIndex) SearchTopK(query []float32, k int) ([]SearchResult, error) {
 	if k <= 0 {
 		return nil, nil
@@ -334,6 +422,27 @@ func (idx *VectorIndex) SearchTopK(query []float32, k int) ([]SearchResult, erro
 		return nil, fmt.Errorf("query dim mismatch: %d != %d", len(query), idx.Dim)
 	}
 
+	idx.ensureANN()
+	idx.mu.RLock()
+	if idx.ann != nil && idx.useANN() && k < len(idx.entries) {
+		defer idx.mu.RUnlock()
+		return idx.ann.search(query, k), nil
+	}
+	idx.mu.RUnlock()
+
+	return idx.SearchTopKExact(query, k)
+}
+
+// SearchTopKExact scores every entry. It is the reference that approximate
+// search is measured against.
+func (idx *VectorIndex) SearchTopKExact(query []float32, k int) ([]SearchResult, error) {
+	if k <= 0 {
+		return nil, nil
+	}
+	if len(query) != idx.Dim {
+		return nil, fmt.Errorf("query dim mismatch: %d != %d", len(query), idx.Dim)
+	}
+
 	// sortedIDs now handles its own locking safely
 	ids := idx.sortedIDs()
 
@@ -368,3 +477,27 @@ func dotFloat32(a, b []float32) float64 {
 	}
 	return sum
 }
+
+// writeGraphSection writes the graph flag and, when the graph matches the
+// saved entries, the graph itself. Caller holds at least a read lock.
+func (idx *VectorIndex) writeGraphSection(w io.Writer, ids []string) error {
+	if idx.ann == nil {
+		return binary.Write(w, binary.LittleEndian, uint8(0))
+	}
+	// Serialize to a buffer first so a mismatch can still fall back to "no graph".
+	var buf bytes.Buffer
+	ok, err := idx.ann.writeGraph(&buf, ids)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return binary.Write(w, binary.LittleEndian, uint8(0))
+	}
+	if err := binary.Write(w, binary.LittleEndian, uint8(1)); err != nil {
+		return fmt.Errorf("write graph flag: %w", err)
+	}
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("write graph: %w", err)
+	}
+	return nil
+}
@@ -227,8 +227,12 @@ type BackgroundWorker struct {
 	errorCount int          // Consecutive error count for backoff
 
 	// Components
-	watcher *watcher.Watcher
-	msgCh   chan tea.Msg
+	watcher    *watcher.Watcher
+	watchGroup *watcher.Group // beads file plus SQLite/git ref sources
+	msgCh      chan tea.Msg
+
+	// Changes observed since the last snapshot was sent (guarded by mu)
+	pendingChange watcher.ChangeEvent
 
 	// Lifecycle
 	ctx        context.Context
@@ -349,15 +353,18 @@ func NewBackgroundWorker(cfg WorkerConfig) (*BackgroundWorker, error) {
 	}
 	w.lastActivityUnixNano.Store(time.Now().UnixNano())
 
-	// Initialize file watcher
+	// Initialize file watcher. The beads file, the SQLite database/WAL and the
+	// beads git refs are grouped so a `git pull` coalesces into one change.
 	if cfg.BeadsPath != "" {
 		fw, err := watcher.NewWatcher(cfg.BeadsPath,
-			watcher.WithDebounceDuration(cfg.DebounceDelay),
+			watcher.WithDebounceDuration(min(cfg.DebounceDelay, watcher.SourceDebounce)),
 		)
 		if err != nil {
 			return nil, err
 		}
 		w.watcher = fw
+		w.watchGroup = watcher.NewGroup(cfg.DebounceDelay, watcher.WrapWatcher(watcher.ChangeFile, fw))
+		w.watchGroup.Add(watcher.AuxiliarySources(cfg.BeadsPath)...)
 	}
 
 	initialized = true
@@ -556,8 +563,8 @@ func (w *BackgroundWorker) Start() error {
 		idleGCGCPercent = 0
 	}
 
-	if w.watcher != nil {
-		if err := w.watcher.Start(); err != nil {
+	if w.watchGroup != nil {
+		if err := w.watchGroup.Start(); err != nil {
 			// Reset started flag so caller can retry or Stop() won't block
 			w.mu.Lock()
 			w.started = false
@@ -627,8 +634,8 @@ func (w *BackgroundWorker) Stop() {
 		loopCancel()
 	}
 
-	if w.watcher != nil {
-		w.watcher.Stop()
+	if w.watchGroup != nil {
+		w.watchGroup.Stop()
 	}
 
 	// Only wait for done if Start() was called
@@ -818,9 +825,9 @@ func (w *BackgroundWorker) attemptRecovery(reason string) {
 		}
 	}
 
-	if w.watcher != nil {
-		w.watcher.Stop()
-		if err := w.watcher.Start(); err != nil {
+	if w.watchGroup != nil {
+		w.watchGroup.Stop()
+		if err := w.watchGroup.Start(); err != nil {
 			w.send(SnapshotErrorMsg{
 				Err:         fmt.Errorf("background worker recovery failed (watcher start): %w", err),
 				Recoverable: false,
@@ -964,10 +971,10 @@ func (w *BackgroundWorker) processLoop(loopCtx context.Context, done chan struct
 
 	w.mu.RLock()
 	heartbeatInterval := w.heartbeatInterval
-	wch := w.watcher
+	group := w.watchGroup
 	w.mu.RUnlock()
 
-	if wch == nil {
+	if group == nil {
 		return
 	}
 
@@ -982,7 +989,10 @@ func (w *BackgroundWorker) processLoop(loopCtx context.Context, done chan struct
 		case <-heartbeatTicker.C:
 			w.recordHeartbeat(time.Now())
 
-		case <-wch.Changed():
+		case ev := <-group.Events():
+			w.mu.Lock()
+			w.pendingChange.Merge(ev)
+			w.mu.Unlock()
 			w.noteFileChange(time.Now())
 			w.TriggerRefresh()
 		}
@@ -1056,6 +1066,11 @@ func (w *BackgroundWorker) process() {
 			w.metrics.fullListCount.Add(1)
 		}
 	}
+	var change watcher.ChangeEvent
+	if snapshot != nil {
+		change = w.pendingChange
+		w.pendingChange = watcher.ChangeEvent{}
+	}
 	wasDirty := w.dirty
 	coalesced := w.coalesceCount.Load()
 	w.state = WorkerIdle
@@ -1099,6 +1114,7 @@ func (w *BackgroundWorker) process() {
 			SnapshotVer:   version,
 			QueueDepth:    queueDepth,
 			CoalesceCount: coalesced,
+			Change:        change,
 		})
 	}
 
@@ -1340,9 +1356,15 @@ func (w *BackgroundWorker) buildSnapshot() *DataSnapshot {
 	// Huge tier: default to open-only unless the recipe explicitly includes closed/tombstone.
 	loadOpenOnly := tier == datasetTierHuge && !recipeIncludesClosedStatuses(currentRecipe)
 
+	// Huge tier: decode graph fields only and load long-form text on demand
+	// for the detail pane, keeping memory bounded on very large archives.
+	loadSlim := tier == datasetTierHuge
+
 	// Load issues from file with panic recovery
 	var issues []model.Issue
 	var pooledRefs []*model.Issue
+	var details loader.DetailLoader
+	var sourceHash string
 	var loadWarnings []string
 	var loadStart time.Time
 	if profileSnapshot {
@@ -1362,6 +1384,15 @@ func (w *BackgroundWorker) buildSnapshot() *DataSnapshot {
 				return i.Status != model.StatusClosed && i.Status != model.StatusTombstone
 			}
 		}
+		if loadSlim {
+			slim, err := loader.LoadIssuesSlimFromFile(w.beadsPath, opts)
+			if err == nil {
+				issues = slim.Issues
+				details = slim.Details
+				sourceHash = slim.SourceHash
+			}
+			return err
+		}
 		loaded, err = loader.LoadIssuesFromFileWithOptionsPooled(w.beadsPath, opts)
 		if err == nil {
 			issues = loaded.Issues
@@ -1390,8 +1421,12 @@ func (w *BackgroundWorker) buildSnapshot() *DataSnapshot {
 
 	loadDuration := time.Since(start)
 
-	// Compute content hash for dedup
-	hash := analysis.ComputeDataHash(issues)
+	// Compute content hash for dedup. Slim loads skip long-form fields, so use
+	// the raw-record hash to still notice description-only edits.
+	hash := sourceHash
+	if hash == "" {
+		hash = analysis.ComputeDataHash(issues)
+	}
 
 	// Check if content is unchanged (dedup optimization)
 	w.mu.Lock()
@@ -1439,8 +1474,13 @@ func (w *BackgroundWorker) buildSnapshot() *DataSnapshot {
 	var snapshot *DataSnapshot
 	analyzeStart := time.Now()
 	analyzeErr := w.safeCompute("analyze_phase1", func() error {
+		var enums map[string][]string
+		if schema, err := loader.ProjectSchemaForJSONL(w.beadsPath); err == nil {
+			enums = schema.EnumValues()
+		}
 		builder := NewSnapshotBuilder(issues).
 			WithRecipe(currentRecipe).
+			WithCustomEnums(enums).
 			WithBuildConfig(snapshotBuildConfigForTier(tier))
 		if prevSnapshot != nil {
 			builder.WithPreviousSnapshot(prevSnapshot, diff)
@@ -1487,6 +1527,7 @@ func (w *BackgroundWorker) buildSnapshot() *DataSnapshot {
 		snapshot.RecipeName = recipeID
 		snapshot.RecipeHash = recipeHash
 		snapshot.pooledIssues = pooledRefs
+		snapshot.Details = details
 		snapshot.DatasetTier = tier
 		snapshot.SourceIssueCountHint = sourceLineCount
 		snapshot.LoadedOpenOnly = loadOpenOnly
@@ -1727,6 +1768,21 @@ type SnapshotReadyMsg struct {
 	SnapshotVer   uint64
 	QueueDepth    int64
 	CoalesceCount int64
+	Change        watcher.ChangeEvent // What triggered the rebuild (empty for forced refreshes)
+}
+
+// refMoveSummary describes git ref moves in a change event for the status
+// bar, e.g. " (synced: HEAD 1a2b3c4→5d6e7f8)". It is empty when no ref moved.
+func refMoveSummary(ev watcher.ChangeEvent) string {
+	moves := ev.RefMoves()
+	if len(moves) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(moves))
+	for _, c := range moves {
+		parts = append(parts, c.String())
+	}
+	return " (synced: " + strings.Join(parts, ", ") + ")"
 }
 
 // SnapshotErrorMsg is sent to the UI when snapshot building fails.
@@ -1766,10 +1822,10 @@ func (w *BackgroundWorker) send(msg tea.Msg) {
 // WatcherChanged returns the watcher's change notification channel.
 // This is useful for integration with existing code.
 func (w *BackgroundWorker) WatcherChanged() <-chan struct{} {
-	if w.watcher == nil {
+	if w.watchGroup == nil {
 		return nil
 	}
-	return w.watcher.Changed()
+	return w.watchGroup.Changed()
 }
 
 // LastHash returns the content hash from the last successful snapshot build.
@@ -15,6 +15,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/watcher"
 )
 
 func TestBackgroundWorker_NewWithoutPath(t *testing.T) {
@@ -543,7 +544,7 @@ func TestBackgroundWorker_HugeDatasetOpenOnly(t *testing.T) {
 		} else {
 			openCount++
 		}
-		line := fmt.Sprintf(`{"id":"issue-%d","title":"Issue %d","status":"%s","priority":1,"issue_type":"task"}`+"\n", i, i, status)
+		line := fmt.Sprintf(`{"id":"issue-%d","title":"Issue %d","description":"Details %d","status":"%s","priority":1,"issue_type":"task"}`+"\n", i, i, i, status)
 		if _, err := writer.WriteString(line); err != nil {
 			_ = f.Close()
 			t.Fatalf("Failed to write test file: %v", err)
@@ -599,6 +600,22 @@ func TestBackgroundWorker_HugeDatasetOpenOnly(t *testing.T) {
 	if !strings.Contains(snapshot.LargeDatasetWarning, "open-only") {
 		t.Fatalf("expected LargeDatasetWarning to mention open-only, got %q", snapshot.LargeDatasetWarning)
 	}
+
+	// Huge tier loads graph fields only; descriptions come from the detail loader.
+	if snapshot.Details == nil {
+		t.Fatal("expected Details loader for huge tier")
+	}
+	first := snapshot.Issues[0]
+	if first.Description != "" {
+		t.Fatalf("expected slim issue without description, got %q", first.Description)
+	}
+	full, err := snapshot.Details.LoadIssueDetails(first.ID)
+	if err != nil {
+		t.Fatalf("LoadIssueDetails failed: %v", err)
+	}
+	if !strings.HasPrefix(full.Description, "Details ") {
+		t.Fatalf("expected hydrated description, got %q", full.Description)
+	}
 }
 
 func TestBackgroundWorker_ResetHash(t *testing.T) {
@@ -1319,6 +1336,66 @@ not json
 	}
 }
 
+func TestBackgroundWorker_SnapshotCarriesGitRefMove(t *testing.T) {
+	t.Setenv("BEADS_SYNC_BRANCH", "")
+	repo := t.TempDir()
+	gitDir := filepath.Join(repo, ".git")
+	mainRef := filepath.Join(gitDir, "refs", "heads", "main")
+	if err := os.MkdirAll(filepath.Dir(mainRef), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(gitDir, "HEAD"), []byte("ref: refs/heads/main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(mainRef, []byte("1111111111111111111111111111111111111111\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	beadsDir := filepath.Join(repo, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	beadsPath := filepath.Join(beadsDir, "beads.jsonl")
+	if err := os.WriteFile(beadsPath, []byte(`{"id":"g-1","title":"One","status":"open","priority":1,"issue_type":"task"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	worker, err := NewBackgroundWorker(WorkerConfig{
+		BeadsPath:     beadsPath,
+		DebounceDelay: 50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewBackgroundWorker failed: %v", err)
+	}
+	defer worker.Stop()
+	if err := worker.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	// Simulate `git pull`: the ref moves and the beads file is rewritten.
+	if err := os.WriteFile(mainRef, []byte("2222222222222222222222222222222222222222\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(beadsPath, []byte(`{"id":"g-1","title":"One","status":"open","priority":1,"issue_type":"task"}`+"\n"+
+		`{"id":"g-2","title":"Two","status":"open","priority":2,"issue_type":"task"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var change watcher.ChangeEvent
+	waitForBackgroundWorkerMsg(t, worker, 3*time.Second, func(m tea.Msg) bool {
+		ready, ok := m.(SnapshotReadyMsg)
+		if !ok {
+			return false
+		}
+		change.Merge(ready.Change)
+		return change.Has(watcher.ChangeFile) && len(change.RefMoves()) > 0
+	})
+
+	if got, want := refMoveSummary(change), " (synced: HEAD 1111111→2222222)"; got != want {
+		t.Errorf("refMoveSummary = %q, want %q", got, want)
+	}
+}
+
 func TestBackgroundWorker_PreservesSnapshotOnPermissionErrorAndRecovers(t *testing.T) {
 	tmpDir := t.TempDir()
 	beadsPath := filepath.Join(tmpDir, "beads.jsonl")
@@ -23,8 +23,9 @@ type BoardModel struct {
 
 	// Swimlane grouping mode (bv-wjs0)
 	swimLaneMode SwimLaneMode
-	allIssues    []model.Issue // Store all issues for re-grouping on mode change
-	boardState   *BoardState   // Optional precomputed columns for all swimlane modes (bv-guxz)
+	allIssues    []model.Issue   // Store all issues for re-grouping on mode change
+	boardState   *BoardState     // Optional precomputed columns for all swimlane modes (bv-guxz)
+	customLane   *customSwimLane // Enum field from .bv/schema.yaml; enables SwimByCustom
 
 	// Reverse dependency index: maps issue ID -> slice of issue IDs it blocks (bv-1daf)
 	blocksIndex map[string][]string
@@ -80,11 +81,51 @@ const (
 	SwimByStatus   SwimLaneMode = iota // Default: Open | In Progress | Blocked | Closed
 	SwimByPriority                     // P0 Critical | P1 High | P2 Medium | P3+ Other
 	SwimByType                         // Bug | Feature | Task | Epic
+	SwimByCustom                       // Values of an enum custom field | Other
 )
 
-// SwimLaneModeCount is the total number of swimlane modes for cycling
+// SwimLaneModeCount is the number of built-in swimlane modes for cycling.
+// SwimByCustom joins the cycle only when a custom swimlane is configured.
 const SwimLaneModeCount = 3
 
+// customSwimLane groups cards by an enum custom field. The first three enum
+// values get their own column; later values and unset issues share the last.
+type customSwimLane struct {
+	field  string
+	values []string
+}
+
+// column returns the board column for an issue.
+func (l *customSwimLane) column(issue *model.Issue) int {
+	value := issue.CustomString(l.field)
+	for i, v := range l.values {
+		if i >= 3 {
+			break
+		}
+		if v == value {
+			return i
+		}
+	}
+	return 3
+}
+
+// headers returns column titles and icons for the custom lane.
+func (l *customSwimLane) headers() ([]string, []string) {
+	titles := make([]string, 4)
+	icons := []string{"🏷️", "🏷️", "🏷️", "❔"}
+	for i := 0; i < 3; i++ {
+		if i < len(l.values) {
+			titles[i] = strings.ToUpper(l.values[i])
+		}
+	}
+	if len(l.values) > 3 {
+		titles[3] = "OTHER"
+	} else {
+		titles[3] = "NO " + strings.ToUpper(l.field)
+	}
+	return titles, icons
+}
+
 // ColumnStats holds computed statistics for a board column (bv-nl8a)
 type ColumnStats struct {
 	Total        int           // Total issues in column
@@ -266,6 +307,27 @@ func buildBlocksIndex(issues []model.Issue) map[string][]string {
 	return index
 }
 
+// groupIssuesByCustom distributes issues into 4 columns by a custom enum field.
+func groupIssuesByCustom(issues []model.Issue, lane *customSwimLane) [4][]model.Issue {
+	var cols [4][]model.Issue
+	for i := range issues {
+		col := lane.column(&issues[i])
+		cols[col] = append(cols[col], issues[i])
+	}
+	for i := 0; i < 4; i++ {
+		sortIssuesByPriorityAndDate(cols[i])
+	}
+	return cols
+}
+
+// groupIssues distributes issues for the board's current swimlane mode.
+func (b *BoardModel) groupIssues(issues []model.Issue) [4][]model.Issue {
+	if b.swimLaneMode == SwimByCustom && b.customLane != nil {
+		return groupIssuesByCustom(issues, b.customLane)
+	}
+	return groupIssuesByMode(issues, b.swimLaneMode)
+}
+
 // groupIssuesByMode distributes issues into 4 columns based on swimlane mode (bv-wjs0)
 func groupIssuesByMode(issues []model.Issue, mode SwimLaneMode) [4][]model.Issue {
 	var cols [4][]model.Issue
@@ -334,6 +396,11 @@ func (b *BoardModel) GetSwimLaneModeName() string {
 		return "Priority"
 	case SwimByType:
 		return "Type"
+	case SwimByCustom:
+		if b.customLane != nil {
+			return b.customLane.field
+		}
+		return "Status"
 	default:
 		return "Status"
 	}
@@ -346,16 +413,49 @@ func (b *BoardModel) GetSwimLaneMode() SwimLaneMode {
 
 // CycleSwimLaneMode cycles to the next swimlane mode and regroups issues (bv-wjs0)
 func (b *BoardModel) CycleSwimLaneMode() {
-	b.swimLaneMode = SwimLaneMode((int(b.swimLaneMode) + 1) % SwimLaneModeCount)
+	count := SwimLaneModeCount
+	if b.customLane != nil {
+		count++
+	}
+	b.swimLaneMode = SwimLaneMode((int(b.swimLaneMode) + 1) % count)
 	b.regroupIssues()
 }
 
+// SetSwimLaneMode switches to mode and regroups issues. SwimByCustom is
+// ignored unless a custom swimlane is configured.
+func (b *BoardModel) SetSwimLaneMode(mode SwimLaneMode) {
+	if mode == SwimByCustom && b.customLane == nil {
+		return
+	}
+	if b.swimLaneMode != mode {
+		b.swimLaneMode = mode
+		b.regroupIssues()
+	}
+}
+
+// SetCustomSwimLane adds a swimlane mode that groups cards by an enum custom
+// field declared in .bv/schema.yaml. An empty field removes it.
+func (b *BoardModel) SetCustomSwimLane(field string, values []string) {
+	if field == "" || len(values) == 0 {
+		b.customLane = nil
+		if b.swimLaneMode == SwimByCustom {
+			b.swimLaneMode = SwimByStatus
+			b.regroupIssues()
+		}
+		return
+	}
+	b.customLane = &customSwimLane{field: field, values: append([]string(nil), values...)}
+	if b.swimLaneMode == SwimByCustom {
+		b.regroupIssues()
+	}
+}
+
 // regroupIssues rebuilds columns based on current swimlane mode (bv-wjs0)
 func (b *BoardModel) regroupIssues() {
-	if b.boardState != nil {
+	if b.boardState != nil && b.swimLaneMode != SwimByCustom {
 		b.columns = b.boardState.ColumnsForMode(b.swimLaneMode)
 	} else {
-		b.columns = groupIssuesByMode(b.allIssues, b.swimLaneMode)
+		b.columns = b.groupIssues(b.allIssues)
 	}
 
 	// Reset selection to avoid out-of-bounds
@@ -383,6 +483,12 @@ func (b *BoardModel) getColumnHeaders() ([]string, []string) {
 	case SwimByType:
 		return []string{"BUG", "FEATURE", "TASK", "EPIC"},
 			[]string{"🐛", "✨", "📋", "🎯"}
+	case SwimByCustom:
+		if b.customLane != nil {
+			return b.customLane.headers()
+		}
+		return []string{"OPEN", "IN PROGRESS", "BLOCKED", "CLOSED"},
+			[]string{"📋", "🔄", "🚫", "✅"}
 	default: // SwimByStatus
 		return []string{"OPEN", "IN PROGRESS", "BLOCKED", "CLOSED"},
 			[]string{"📋", "🔄", "🚫", "✅"}
@@ -429,7 +535,7 @@ func (b *BoardModel) SetIssues(issues []model.Issue) {
 	b.boardState = nil
 
 	// Group by current swimlane mode (bv-wjs0)
-	b.columns = groupIssuesByMode(issues, b.swimLaneMode)
+	b.columns = b.groupIssues(issues)
 
 	b.blocksIndex = buildBlocksIndex(issues) // Rebuild reverse dependency index (bv-1daf)
 
@@ -470,10 +576,10 @@ func (b *BoardModel) SetSnapshot(s *DataSnapshot) {
 	b.allIssues = s.Issues
 	b.boardState = s.BoardState
 
-	if b.boardState != nil {
+	if b.boardState != nil && b.swimLaneMode != SwimByCustom {
 		b.columns = b.boardState.ColumnsForMode(b.swimLaneMode)
 	} else {
-		b.columns = groupIssuesByMode(s.Issues, b.swimLaneMode)
+		b.columns = b.groupIssues(s.Issues)
 	}
 
 	// Prefer snapshot-precomputed reverse-dependency index when available.
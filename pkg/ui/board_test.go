@@ -1841,3 +1841,43 @@ func TestInlineCardExpansion_ShowsDescription(t *testing.T) {
 		t.Error("Expanded card should show description content")
 	}
 }
+
+// TestSwimLaneCustomField verifies grouping by an enum field from .bv/schema.yaml
+func TestSwimLaneCustomField(t *testing.T) {
+	theme := createTheme()
+	issues := []model.Issue{
+		{ID: "1", Status: model.StatusOpen, Custom: map[string]any{"severity": "low"}},
+		{ID: "2", Status: model.StatusOpen, Custom: map[string]any{"severity": "high"}},
+		{ID: "3", Status: model.StatusOpen, Custom: map[string]any{"severity": "critical"}},
+		{ID: "4", Status: model.StatusOpen},
+	}
+	b := ui.NewBoardModel(issues, theme)
+	b.SetCustomSwimLane("severity", []string{"low", "medium", "high", "critical"})
+
+	// Status -> Priority -> Type -> severity -> Status
+	modes := []string{"Status", "Priority", "Type", "severity", "Status"}
+	for i, expected := range modes {
+		if b.GetSwimLaneModeName() != expected {
+			t.Fatalf("Step %d: Expected %s mode, got %s", i, expected, b.GetSwimLaneModeName())
+		}
+		if expected == "severity" {
+			// low | medium | high | other (critical + unset)
+			want := []int{1, 0, 1, 2}
+			for col, n := range want {
+				if b.ColumnCount(col) != n {
+					t.Errorf("column %d: expected %d, got %d", col, n, b.ColumnCount(col))
+				}
+			}
+		}
+		b.CycleSwimLaneMode()
+	}
+
+	// Removing the lane while it is active falls back to status
+	for b.GetSwimLaneMode() != ui.SwimByCustom {
+		b.CycleSwimLaneMode()
+	}
+	b.SetCustomSwimLane("", nil)
+	if b.GetSwimLaneMode() != ui.SwimByStatus {
+		t.Errorf("Expected fallback to Status mode, got %s", b.GetSwimLaneModeName())
+	}
+}
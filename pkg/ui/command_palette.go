@@ -0,0 +1,607 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// PaletteEntryKind classifies command palette entries.
+type PaletteEntryKind int
+
+const (
+	PaletteView PaletteEntryKind = iota
+	PaletteAction
+	PaletteRecipe
+	PaletteIssue
+)
+
+func (k PaletteEntryKind) String() string {
+	switch k {
+	case PaletteView:
+		return "view"
+	case PaletteAction:
+		return "action"
+	case PaletteRecipe:
+		return "recipe"
+	default:
+		return "issue"
+	}
+}
+
+// PaletteEntry is one thing the command palette can jump to or run.
+type PaletteEntry struct {
+	Kind   PaletteEntryKind
+	Key    string   // stable identity, e.g. "issue:bv-12" or "view:board"
+	Title  string   // primary match target
+	Detail string   // shown dimmed after the title
+	Keys   string   // equivalent single-key binding, if any
+	Terms  []string // extra match targets (issue ID, labels)
+}
+
+// paletteRecentLimit bounds the visit history used for ranking.
+const paletteRecentLimit = 30
+
+// paletteRecencyBonus is added for the most recently visited entry and
+// decays linearly over the visit history.
+const paletteRecencyBonus = 300
+
+// rankPaletteEntries returns the entries matching query, best first. Match
+// quality comes from fuzzyScore over the title and terms; recently visited
+// entries get a bonus. An empty query lists recent entries first, then
+// everything else in its original order.
+func rankPaletteEntries(entries []PaletteEntry, query string, recent []string) []PaletteEntry {
+	query = strings.TrimSpace(query)
+	recency := make(map[string]int, len(recent))
+	for i, key := range recent {
+		recency[key] = (len(recent) - i) * paletteRecencyBonus / len(recent)
+	}
+
+	type scored struct {
+		entry PaletteEntry
+		score int
+		order int
+	}
+	var matches []scored
+	for i, e := range entries {
+		score := 0
+		if query != "" {
+			score = fuzzyScore(e.Title, query)
+			for _, term := range e.Terms {
+				if s := fuzzyScore(term, query); s > score {
+					score = s
+				}
+			}
+			if score == 0 {
+				continue
+			}
+		}
+		matches = append(matches, scored{entry: e, score: score + recency[e.Key], order: i})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].order < matches[j].order
+	})
+
+	out := make([]PaletteEntry, len(matches))
+	for i, m := range matches {
+		out[i] = m.entry
+	}
+	return out
+}
+
+// notePaletteVisit moves key to the front of the visit history.
+func notePaletteVisit(recent []string, key string) []string {
+	out := make([]string, 0, min(len(recent)+1, paletteRecentLimit))
+	out = append(out, key)
+	for _, k := range recent {
+		if k != key && len(out) < paletteRecentLimit {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// CommandPaletteModel is the ctrl+p fuzzy jump/command overlay.
+type CommandPaletteModel struct {
+	entries       []PaletteEntry
+	recent        []string
+	filtered      []PaletteEntry
+	input         textinput.Model
+	selectedIndex int
+	width         int
+	height        int
+	theme         Theme
+}
+
+// NewCommandPaletteModel creates a palette over entries, ranked with the
+// given visit history (most recent first).
+func NewCommandPaletteModel(entries []PaletteEntry, recent []string, theme Theme) CommandPaletteModel {
+	ti := textinput.New()
+	ti.Placeholder = "issue, view, action or recipe..."
+	ti.CharLimit = 80
+	ti.Width = 40
+	ti.Focus()
+
+	m := CommandPaletteModel{
+		entries: entries,
+		recent:  recent,
+		input:   ti,
+		theme:   theme,
+	}
+	m.filter()
+	return m
+}
+
+func (m *CommandPaletteModel) filter() {
+	m.filtered = rankPaletteEntries(m.entries, m.input.Value(), m.recent)
+	if m.selectedIndex >= len(m.filtered) {
+		m.selectedIndex = len(m.filtered) - 1
+	}
+	if m.selectedIndex < 0 {
+		m.selectedIndex = 0
+	}
+}
+
+// SetSize updates the palette dimensions
+func (m *CommandPaletteModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// MoveUp moves selection up
+func (m *CommandPaletteModel) MoveUp() {
+	if m.selectedIndex > 0 {
+		m.selectedIndex--
+	}
+}
+
+// MoveDown moves selection down
+func (m *CommandPaletteModel) MoveDown() {
+	if m.selectedIndex < len(m.filtered)-1 {
+		m.selectedIndex++
+	}
+}
+
+// SelectedEntry returns the currently selected entry
+func (m *CommandPaletteModel) SelectedEntry() *PaletteEntry {
+	if len(m.filtered) == 0 || m.selectedIndex >= len(m.filtered) {
+		return nil
+	}
+	return &m.filtered[m.selectedIndex]
+}
+
+// UpdateInput processes a key message for the text input
+func (m *CommandPaletteModel) UpdateInput(msg tea.Msg) {
+	m.input, _ = m.input.Update(msg)
+	m.selectedIndex = 0
+	m.filter()
+}
+
+// InputValue returns the current input value
+func (m *CommandPaletteModel) InputValue() string {
+	return m.input.Value()
+}
+
+// FilteredCount returns the number of matching entries
+func (m *CommandPaletteModel) FilteredCount() int {
+	return len(m.filtered)
+}
+
+// View renders the command palette overlay
+func (m *CommandPaletteModel) View() string {
+	if m.width == 0 {
+		m.width = 80
+	}
+	if m.height == 0 {
+		m.height = 24
+	}
+
+	t := m.theme
+
+	boxWidth := 72
+	if m.width < 82 {
+		boxWidth = m.width - 10
+	}
+	if boxWidth < 30 {
+		boxWidth = 30
+	}
+
+	maxVisible := 12
+	if m.height < 20 {
+		maxVisible = m.height - 8
+	}
+	if maxVisible < 3 {
+		maxVisible = 3
+	}
+
+	var lines []string
+
+	inputStyle := t.Renderer.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(t.Secondary).
+		Padding(0, 1).
+		Width(boxWidth - 6)
+	lines = append(lines, inputStyle.Render(m.input.View()))
+	lines = append(lines, "")
+
+	dimStyle := t.Renderer.NewStyle().
+		Foreground(t.Secondary)
+
+	if len(m.filtered) == 0 {
+		lines = append(lines, dimStyle.Italic(true).Render("  No matches"))
+	} else {
+		start := 0
+		if m.selectedIndex >= maxVisible {
+			start = m.selectedIndex - maxVisible + 1
+		}
+		end := min(start+maxVisible, len(m.filtered))
+
+		for i := start; i < end; i++ {
+			e := m.filtered[i]
+			isSelected := i == m.selectedIndex
+
+			itemStyle := t.Renderer.NewStyle()
+			if isSelected {
+				itemStyle = itemStyle.Foreground(t.Primary).Bold(true)
+			} else {
+				itemStyle = itemStyle.Foreground(t.Base.GetForeground())
+			}
+
+			prefix := "  "
+			if isSelected {
+				prefix = "> "
+			}
+			kind := fmt.Sprintf("%-7s", e.Kind)
+			suffix := truncateRunesHelper(e.Detail, boxWidth/3, "…")
+			if e.Keys != "" {
+				suffix = strings.TrimSpace(suffix + "  [" + e.Keys + "]")
+			}
+			maxTitle := boxWidth - 8 - len(kind) - lipgloss.Width(suffix) - 2
+			if maxTitle < 10 {
+				maxTitle = 10
+			}
+			title := truncateRunesHelper(e.Title, maxTitle, "…")
+			lines = append(lines, dimStyle.Render(prefix+kind)+itemStyle.Render(title)+dimStyle.Render("  "+suffix))
+		}
+
+		if len(m.filtered) > maxVisible {
+			lines = append(lines, "")
+			lines = append(lines, dimStyle.Italic(true).Render(
+				fmt.Sprintf("  (%d/%d)", m.selectedIndex+1, len(m.filtered)),
+			))
+		}
+	}
+
+	lines = append(lines, "")
+	footerStyle := t.Renderer.NewStyle().
+		Foreground(t.Secondary).
+		Italic(true)
+	lines = append(lines, footerStyle.Render("↑/↓: navigate • enter: go • esc: cancel"))
+
+	content := strings.Join(lines, "\n")
+
+	boxStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(boxWidth)
+
+	return lipgloss.Place(
+		m.width,
+		m.height,
+		lipgloss.Center,
+		lipgloss.Top,
+		boxStyle.Render(content),
+	)
+}
+
+// paletteCommand is a view or action offered by the command palette.
+type paletteCommand struct {
+	entry PaletteEntry
+	run   func(m Model) (Model, tea.Cmd)
+}
+
+func runeKey(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+// pressKey runs a global key binding through Update.
+func pressKey(k tea.KeyMsg) func(Model) (Model, tea.Cmd) {
+	return func(m Model) (Model, tea.Cmd) {
+		next, cmd := m.Update(k)
+		return next.(Model), cmd
+	}
+}
+
+// openView presses a view's toggle key unless the view is already showing.
+func openView(k tea.KeyMsg, active func(Model) bool) func(Model) (Model, tea.Cmd) {
+	return func(m Model) (Model, tea.Cmd) {
+		if active(m) {
+			return m, nil
+		}
+		return pressKey(k)(m)
+	}
+}
+
+// listKey returns to the issue list and runs a list-focused key binding.
+func listKey(k tea.KeyMsg) func(Model) (Model, tea.Cmd) {
+	return func(m Model) (Model, tea.Cmd) {
+		m.returnToList()
+		return m.handleListKeys(k), nil
+	}
+}
+
+// boardBy opens the board grouped by mode.
+func boardBy(mode SwimLaneMode) func(Model) (Model, tea.Cmd) {
+	return func(m Model) (Model, tea.Cmd) {
+		m, cmd := openView(runeKey("b"), func(m Model) bool { return m.isBoardView })(m)
+		m.board.SetSwimLaneMode(mode)
+		m.statusMsg = fmt.Sprintf("🔀 Swimlane: %s", m.board.GetSwimLaneModeName())
+		m.statusIsError = false
+		return m, cmd
+	}
+}
+
+func paletteCommands() []paletteCommand {
+	view := func(key, title, keys string, run func(Model) (Model, tea.Cmd)) paletteCommand {
+		return paletteCommand{entry: PaletteEntry{Kind: PaletteView, Key: "view:" + key, Title: title, Keys: keys}, run: run}
+	}
+	action := func(key, title, keys string, run func(Model) (Model, tea.Cmd)) paletteCommand {
+		return paletteCommand{entry: PaletteEntry{Kind: PaletteAction, Key: "action:" + key, Title: title, Keys: keys}, run: run}
+	}
+	return []paletteCommand{
+		view("list", "Issue list", "esc", func(m Model) (Model, tea.Cmd) {
+			m.returnToList()
+			return m, nil
+		}),
+		view("board", "Board", "b", openView(runeKey("b"), func(m Model) bool { return m.isBoardView })),
+		view("graph", "Dependency graph", "g", openView(runeKey("g"), func(m Model) bool { return m.isGraphView })),
+		view("tree", "Tree (epics and children)", "E", openView(runeKey("E"), func(m Model) bool { return m.focused == focusTree })),
+		view("insights", "Insights", "i", openView(runeKey("i"), func(m Model) bool { return m.focused == focusInsights && !m.showAttentionView })),
+		view("actionable", "Actionable plan", "a", openView(runeKey("a"), func(m Model) bool { return m.isActionableView })),
+		view("history", "History (bead ↔ commit)", "h", openView(runeKey("h"), func(m Model) bool { return m.isHistoryView })),
+		view("labels", "Label dashboard", "[", pressKey(runeKey("["))),
+		view("attention", "Attention (labels needing work)", "]", pressKey(runeKey("]"))),
+		view("flow", "Cross-label flow matrix", "f", pressKey(runeKey("f"))),
+		view("delivery", "Delivery metrics (DORA)", "D", openView(runeKey("D"), func(m Model) bool { return m.focused == focusDelivery })),
+		action("board-status", "Board by status", "", boardBy(SwimByStatus)),
+		action("board-priority", "Board by priority", "", boardBy(SwimByPriority)),
+		action("board-type", "Board by type", "", boardBy(SwimByType)),
+		action("filter-all", "Filter: all issues", "", listKey(runeKey("a"))),
+		action("filter-open", "Filter: open issues", "o", listKey(runeKey("o"))),
+		action("filter-closed", "Filter: closed issues", "c", listKey(runeKey("c"))),
+		action("filter-ready", "Filter: ready (no blockers)", "r", listKey(runeKey("r"))),
+		action("filter-label", "Filter by label", "l", pressKey(runeKey("l"))),
+		action("sort", "Cycle sort order", "s", listKey(runeKey("s"))),
+		action("triage", "Sort by triage score", "S", listKey(runeKey("S"))),
+		action("recipes", "Recipes", "'", pressKey(runeKey("'"))),
+		action("saved-searches", "Saved searches", "\"", pressKey(runeKey("\""))),
+		action("semantic", "Toggle semantic search", "ctrl+s", func(m Model) (Model, tea.Cmd) {
+			m.returnToList()
+			return pressKey(tea.KeyMsg{Type: tea.KeyCtrlS})(m)
+		}),
+		action("hybrid", "Toggle hybrid search ranking", "H", func(m Model) (Model, tea.Cmd) {
+			m.returnToList()
+			return pressKey(runeKey("H"))(m)
+		}),
+		action("similar", "Similar issues (more like this)", "M", func(m Model) (Model, tea.Cmd) {
+			m.returnToList()
+			return m.showSimilarIssues()
+		}),
+		action("facets", "Refine search results by facet", "F", func(m Model) (Model, tea.Cmd) {
+			m.returnToList()
+			return m.openFacetPicker(), nil
+		}),
+		action("priority-hints", "Toggle priority hints", "p", pressKey(runeKey("p"))),
+		action("alerts", "Alerts", "!", pressKey(runeKey("!"))),
+		action("time-travel", "Time travel to revision", "t", listKey(runeKey("t"))),
+		action("export-md", "Export Markdown report", "x", pressKey(runeKey("x"))),
+		action("export-graph", "Export graph (interactive HTML)", "", func(m Model) (Model, tea.Cmd) {
+			m.exportInteractiveGraph()
+			return m, nil
+		}),
+		action("copy", "Copy issue as Markdown", "C", listKey(runeKey("C"))),
+		action("copy-id", "Copy issue ID", "y", listKey(runeKey("y"))),
+		action("editor", "Open beads file in editor", "O", listKey(runeKey("O"))),
+		action("refresh", "Refresh data", "ctrl+r", pressKey(tea.KeyMsg{Type: tea.KeyCtrlR})),
+		action("shortcuts", "Toggle shortcuts sidebar", ";", pressKey(runeKey(";"))),
+		action("help", "Help", "?", pressKey(runeKey("?"))),
+		action("tutorial", "Tutorial", "`", pressKey(runeKey("`"))),
+		action("quit", "Quit", "q", func(m Model) (Model, tea.Cmd) {
+			return m, tea.Quit
+		}),
+	}
+}
+
+// paletteEntries lists everything the palette offers: views and actions,
+// recipes, then issues.
+func (m Model) paletteEntries() []PaletteEntry {
+	cmds := paletteCommands()
+	entries := make([]PaletteEntry, 0, len(cmds)+len(m.issues)+16)
+	for _, c := range cmds {
+		entries = append(entries, c.entry)
+	}
+	if m.recipeLoader != nil {
+		for _, r := range m.recipeLoader.List() {
+			entries = append(entries, PaletteEntry{
+				Kind:   PaletteRecipe,
+				Key:    "recipe:" + r.Name,
+				Title:  "Recipe: " + r.Name,
+				Detail: r.Description,
+				Terms:  []string{r.Name},
+			})
+		}
+	}
+	for _, iss := range m.issues {
+		terms := append([]string{iss.ID}, iss.Labels...)
+		entries = append(entries, PaletteEntry{
+			Kind:   PaletteIssue,
+			Key:    "issue:" + iss.ID,
+			Title:  iss.Title,
+			Detail: iss.ID,
+			Terms:  terms,
+		})
+	}
+	return entries
+}
+
+// openCommandPalette shows the ctrl+p palette.
+func (m Model) openCommandPalette() Model {
+	m.commandPalette = NewCommandPaletteModel(m.paletteEntries(), m.paletteRecent, m.theme)
+	m.commandPalette.SetSize(m.width, m.height-1)
+	m.showCommandPalette = true
+	m.focusBeforePalette = m.focused
+	m.focused = focusCommandPalette
+	return m
+}
+
+// handleCommandPaletteKeys handles keyboard input when the palette is open.
+func (m Model) handleCommandPaletteKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+p":
+		m.showCommandPalette = false
+		m.focused = m.focusBeforePalette
+	case "up", "ctrl+k":
+		m.commandPalette.MoveUp()
+	case "down", "ctrl+j", "ctrl+n":
+		m.commandPalette.MoveDown()
+	case "enter":
+		m.showCommandPalette = false
+		m.focused = m.focusBeforePalette
+		if e := m.commandPalette.SelectedEntry(); e != nil {
+			return m.runPaletteEntry(*e)
+		}
+	default:
+		m.commandPalette.UpdateInput(msg)
+	}
+	return m, nil
+}
+
+// runPaletteEntry jumps to or runs a palette entry and records the visit.
+func (m Model) runPaletteEntry(e PaletteEntry) (Model, tea.Cmd) {
+	m.paletteRecent = notePaletteVisit(m.paletteRecent, e.Key)
+	switch e.Kind {
+	case PaletteIssue:
+		return m.jumpToIssue(strings.TrimPrefix(e.Key, "issue:")), nil
+	case PaletteRecipe:
+		if r := m.recipeLoader.Get(strings.TrimPrefix(e.Key, "recipe:")); r != nil {
+			m.returnToList()
+			m.setActiveRecipe(r)
+			m.applyRecipe(r)
+		}
+		return m, nil
+	}
+	for _, c := range paletteCommands() {
+		if c.entry.Key == e.Key {
+			return c.run(m)
+		}
+	}
+	return m, nil
+}
+
+// jumpToIssue selects id in the focused board, graph or tree view, and
+// otherwise in the issue list, clearing filters that hide it.
+func (m Model) jumpToIssue(id string) Model {
+	m.statusIsError = false
+	m.statusMsg = "Jumped to " + id
+	switch m.focused {
+	case focusBoard:
+		if m.board.SelectIssueByID(id) {
+			return m
+		}
+	case focusGraph:
+		if m.graphView.SelectByID(id) {
+			return m
+		}
+	case focusTree:
+		if m.tree.SelectByID(id) {
+			return m
+		}
+	}
+
+	m.returnToList()
+	idx := listIndexOf(m.list.Items(), id)
+	if idx < 0 && m.hasActiveFilters() {
+		m.clearAllFilters()
+		idx = listIndexOf(m.list.Items(), id)
+		m.statusMsg = "Jumped to " + id + " (filters cleared)"
+	}
+	if idx < 0 {
+		m.statusMsg = fmt.Sprintf("%s is not in the current view", id)
+		m.statusIsError = true
+		return m
+	}
+	m.list.Select(idx)
+	if !m.isSplitView {
+		m.showDetails = true
+		m.focused = focusDetail
+		m.viewport.GotoTop()
+	}
+	m.updateViewportContent()
+	return m
+}
+
+func listIndexOf(items []list.Item, id string) int {
+	for i, item := range items {
+		if it, ok := item.(IssueItem); ok && it.Issue.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// returnToList closes full-screen views and focuses the issue list.
+func (m *Model) returnToList() {
+	m.clearAttentionOverlay()
+	m.isBoardView = false
+	m.isGraphView = false
+	m.isActionableView = false
+	m.isHistoryView = false
+	if !m.isSplitView {
+		m.showDetails = false
+	}
+	m.focused = focusList
+}
+
+// exportInteractiveGraph writes the dependency graph as a self-contained
+// HTML file, like --export-graph.
+func (m *Model) exportInteractiveGraph() {
+	if len(m.issues) == 0 {
+		m.statusMsg = "❌ No issues to export"
+		m.statusIsError = true
+		return
+	}
+	projectName := "beads"
+	if cwd, err := os.Getwd(); err == nil {
+		projectName = filepath.Base(cwd)
+	}
+	triage := analysis.ComputeTriageFromAnalyzer(m.analyzer, m.analysis, m.issues, analysis.TriageOptions{}, time.Now())
+	path, err := export.GenerateInteractiveGraphHTML(export.InteractiveGraphOptions{
+		Issues:      m.issues,
+		Stats:       m.analysis,
+		Triage:      &triage,
+		Title:       projectName,
+		ProjectName: projectName,
+	})
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Graph export failed: %v", err)
+		m.statusIsError = true
+		return
+	}
+	m.statusMsg = fmt.Sprintf("✅ Exported graph of %d issues to %s", len(m.issues), path)
+	m.statusIsError = false
+}
@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRankPaletteEntries(t *testing.T) {
+	entries := []PaletteEntry{
+		{Kind: PaletteView, Key: "view:board", Title: "Board"},
+		{Kind: PaletteAction, Key: "action:board-priority", Title: "Board by priority"},
+		{Kind: PaletteIssue, Key: "issue:bv-7", Title: "Login page crashes", Terms: []string{"bv-7", "auth"}},
+		{Kind: PaletteIssue, Key: "issue:bv-8", Title: "Logout button", Terms: []string{"bv-8"}},
+	}
+
+	got := rankPaletteEntries(entries, "bv-7", nil)
+	if len(got) == 0 || got[0].Key != "issue:bv-7" {
+		t.Fatalf("exact ID should rank first: %+v", got)
+	}
+	if got := rankPaletteEntries(entries, "auth", nil); len(got) != 1 || got[0].Key != "issue:bv-7" {
+		t.Fatalf("labels should match: %+v", got)
+	}
+	if got := rankPaletteEntries(entries, "bbp", nil); len(got) == 0 || got[0].Key != "action:board-priority" {
+		t.Fatalf("fuzzy subsequence should match actions: %+v", got)
+	}
+
+	// Recency breaks ties between comparable matches.
+	got = rankPaletteEntries(entries, "log", []string{"issue:bv-8"})
+	if len(got) < 2 || got[0].Key != "issue:bv-8" {
+		t.Fatalf("recently visited entry should rank first: %+v", got)
+	}
+
+	// Empty query: recent entries first, then original order.
+	got = rankPaletteEntries(entries, "", []string{"issue:bv-8"})
+	if len(got) != len(entries) || got[0].Key != "issue:bv-8" || got[1].Key != "view:board" {
+		t.Fatalf("empty query order: %+v", got)
+	}
+}
+
+func TestNotePaletteVisit(t *testing.T) {
+	var recent []string
+	for i := 0; i < paletteRecentLimit+5; i++ {
+		recent = notePaletteVisit(recent, "issue:"+itoa(i))
+	}
+	recent = notePaletteVisit(recent, "issue:"+itoa(paletteRecentLimit))
+	if len(recent) != paletteRecentLimit || recent[0] != "issue:"+itoa(paletteRecentLimit) || recent[1] != "issue:"+itoa(paletteRecentLimit+4) {
+		t.Fatalf("recent = %v", recent)
+	}
+}
+
+func typePalette(t *testing.T, m Model, query string) Model {
+	t.Helper()
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	m = updated.(Model)
+	if !m.showCommandPalette || m.focused != focusCommandPalette {
+		t.Fatal("ctrl+p should open the command palette")
+	}
+	for _, r := range query {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(Model)
+	}
+	return m
+}
+
+func TestCommandPaletteJumpsAndRunsActions(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Alpha task", Status: model.StatusOpen, Priority: 2},
+		{ID: "bv-2", Title: "Beta bug", Status: model.StatusOpen, Priority: 0},
+		{ID: "bv-3", Title: "Gamma closed", Status: model.StatusClosed, Priority: 1},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+
+	// Typing "b" must go to the palette input, not toggle the board.
+	m = typePalette(t, m, "board by prio")
+	if m.isBoardView {
+		t.Fatal("keys typed into the palette leaked to global bindings")
+	}
+	if !strings.Contains(m.commandPalette.View(), "Board by priority") {
+		t.Fatalf("palette view missing action:\n%s", m.commandPalette.View())
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.showCommandPalette || !m.isBoardView || m.focused != focusBoard || m.board.GetSwimLaneMode() != SwimByPriority {
+		t.Fatalf("expected board by priority, got board=%v focus=%v mode=%v", m.isBoardView, m.focused, m.board.GetSwimLaneMode())
+	}
+
+	// Jumping to an issue in the board selects it there.
+	m = typePalette(t, m, "bv-2")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if sel := m.board.SelectedIssue(); sel == nil || sel.ID != "bv-2" || m.focused != focusBoard {
+		t.Fatalf("expected bv-2 selected on board, got %+v", sel)
+	}
+
+	// From the list, a jump clears filters hiding the issue.
+	m.returnToList()
+	m.currentFilter = "open"
+	m.applyFilter()
+	m = typePalette(t, m, "gamma")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	sel, ok := m.list.SelectedItem().(IssueItem)
+	if !ok || sel.Issue.ID != "bv-3" || m.currentFilter != "all" {
+		t.Fatalf("expected bv-3 selected with filters cleared, got %+v filter=%q", sel.Issue.ID, m.currentFilter)
+	}
+	if m.paletteRecent[0] != "issue:bv-3" {
+		t.Fatalf("visit not recorded: %v", m.paletteRecent)
+	}
+
+	// Esc closes without running anything.
+	m = typePalette(t, m, "quit")
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.showCommandPalette || cmd != nil {
+		t.Fatal("esc should close the palette without a command")
+	}
+}
@@ -11,6 +11,9 @@ const (
 	// Overlays (highest priority)
 	ContextLabelPicker        Context = "label-picker"
 	ContextRecipePicker       Context = "recipe-picker"
+	ContextSavedSearchPicker  Context = "saved-search-picker"
+	ContextCommandPalette     Context = "command-palette"
+	ContextFacetPicker        Context = "facet-picker"
 	ContextHelp               Context = "help"
 	ContextQuitConfirm        Context = "quit-confirm"
 	ContextLabelHealthDetail  Context = "label-health-detail"
@@ -25,6 +28,7 @@ const (
 	// Views
 	ContextInsights       Context = "insights"
 	ContextFlowMatrix     Context = "flow-matrix"
+	ContextDelivery       Context = "delivery"
 	ContextGraph          Context = "graph"
 	ContextBoard          Context = "board"
 	ContextActionable     Context = "actionable"
@@ -81,6 +85,21 @@ func (m Model) CurrentContext() Context {
 		return ContextRecipePicker
 	}
 
+	// Command palette overlay
+	if m.showCommandPalette {
+		return ContextCommandPalette
+	}
+
+	// Saved search picker overlay
+	if m.showSavedSearchPicker {
+		return ContextSavedSearchPicker
+	}
+
+	// Facet picker overlay
+	if m.showFacetPicker {
+		return ContextFacetPicker
+	}
+
 	// Label health detail modal
 	if m.showLabelHealthDetail {
 		return ContextLabelHealthDetail
@@ -127,6 +146,11 @@ func (m Model) CurrentContext() Context {
 		return ContextFlowMatrix
 	}
 
+	// Delivery metrics view
+	if m.focused == focusDelivery {
+		return ContextDelivery
+	}
+
 	// Label dashboard
 	if m.focused == focusLabelDashboard {
 		return ContextLabelDashboard
@@ -191,6 +215,9 @@ func (c Context) Description() string {
 	descriptions := map[Context]string{
 		ContextLabelPicker:        "Label picker",
 		ContextRecipePicker:       "Recipe picker",
+		ContextSavedSearchPicker:  "Saved search picker",
+		ContextCommandPalette:     "Command palette",
+		ContextFacetPicker:        "Search facet picker",
 		ContextHelp:               "Help overlay",
 		ContextQuitConfirm:        "Quit confirmation",
 		ContextLabelHealthDetail:  "Label health detail",
@@ -203,6 +230,7 @@ func (c Context) Description() string {
 		ContextCassSession:        "Cass session preview",
 		ContextInsights:           "Insights panel",
 		ContextFlowMatrix:         "Flow matrix",
+		ContextDelivery:           "Delivery metrics",
 		ContextGraph:              "Dependency graph",
 		ContextBoard:              "Kanban board",
 		ContextActionable:         "Actionable view",
@@ -225,7 +253,7 @@ func (c Context) Description() string {
 // IsOverlay returns true if the context is an overlay (modal/popup)
 func (c Context) IsOverlay() bool {
 	switch c {
-	case ContextLabelPicker, ContextRecipePicker, ContextHelp, ContextQuitConfirm,
+	case ContextLabelPicker, ContextRecipePicker, ContextSavedSearchPicker, ContextCommandPalette, ContextFacetPicker, ContextHelp, ContextQuitConfirm,
 		ContextLabelHealthDetail, ContextLabelDrilldown, ContextLabelGraphAnalysis,
 		ContextTimeTravelInput, ContextAlerts, ContextRepoPicker, ContextAgentPrompt,
 		ContextCassSession:
@@ -237,7 +265,7 @@ func (c Context) IsOverlay() bool {
 // IsView returns true if the context is a full view (not overlay or default list)
 func (c Context) IsView() bool {
 	switch c {
-	case ContextInsights, ContextFlowMatrix, ContextGraph, ContextBoard,
+	case ContextInsights, ContextFlowMatrix, ContextDelivery, ContextGraph, ContextBoard,
 		ContextActionable, ContextHistory, ContextSprint, ContextLabelDashboard,
 		ContextAttention, ContextSplit, ContextDetail, ContextTimeTravel:
 		return true
@@ -262,12 +290,16 @@ func (c Context) TutorialPages() []int {
 		ContextTimeTravel:         {10},      // Time-Travel
 		ContextLabelDashboard:     {11},      // Labels
 		ContextFlowMatrix:         {11, 12},  // Labels, Advanced
+		ContextDelivery:           {8},       // History View
 		ContextHelp:               {13},      // Keyboard Reference
 		ContextSprint:             {14},      // Sprints
 		ContextAttention:          {7},       // Insights (attention is part of insights)
 		ContextAlerts:             {15},      // Alerts
 		ContextLabelPicker:        {11, 3},   // Labels, Filtering
 		ContextRecipePicker:       {3, 12},   // Filtering, Advanced
+		ContextSavedSearchPicker:  {3},       // Filtering
+		ContextCommandPalette:     {1},       // Navigation basics
+		ContextFacetPicker:        {3},       // Filtering
 		ContextRepoPicker:         {12},      // Advanced (workspace)
 		ContextAgentPrompt:        {16},      // AI Agent Integration
 		ContextLabelHealthDetail:  {11},      // Labels
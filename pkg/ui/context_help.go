@@ -94,6 +94,7 @@ const contextHelpList = `## List View
   j/k       Move up/down
   Enter     View issue details
   g/G       Jump to top/bottom
+  Ctrl+P    Command palette (jump to ID/title)
 
 **Filtering**
   o         Open issues only
@@ -103,6 +104,8 @@ const contextHelpList = `## List View
   Ctrl+S    Semantic search (AI)
   H         Hybrid ranking
   Alt+H     Hybrid preset
+  F         Refine semantic results by facet
+  "         Saved searches
 
 **Switch Views**
   a         Actionable view
@@ -110,10 +113,12 @@ const contextHelpList = `## List View
   g         Graph view
   i         Insights panel
   h         History view
+  D         Delivery metrics
 
 **Actions**
   U         Self-update bv
-  V         Preview cass sessions`
+  V         Preview cass sessions
+  M         Similar issues (semantic)`
 
 const contextHelpGraph = `## Graph View
 
@@ -199,6 +204,7 @@ const contextHelpHistory = `## History View
 **View Modes**
   v         Toggle Bead/Git mode
   f         Toggle file tree panel
+  b         Blame selected file (in file tree)
   /         Search commits/beads
   c         Cycle confidence filter
 
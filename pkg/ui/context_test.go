@@ -119,6 +119,11 @@ func TestCurrentContext_Views(t *testing.T) {
 			setup:    func(m *Model) { m.focused = focusFlowMatrix },
 			expected: ContextFlowMatrix,
 		},
+		{
+			name:     "delivery metrics",
+			setup:    func(m *Model) { m.focused = focusDelivery },
+			expected: ContextDelivery,
+		},
 		{
 			name:     "label dashboard",
 			setup:    func(m *Model) { m.focused = focusLabelDashboard },
@@ -279,7 +284,7 @@ func TestContext_IsOverlay(t *testing.T) {
 
 func TestContext_IsView(t *testing.T) {
 	views := []Context{
-		ContextInsights, ContextFlowMatrix, ContextGraph, ContextBoard,
+		ContextInsights, ContextFlowMatrix, ContextDelivery, ContextGraph, ContextBoard,
 		ContextActionable, ContextHistory, ContextSprint, ContextLabelDashboard,
 		ContextAttention, ContextSplit, ContextDetail, ContextTimeTravel,
 	}
@@ -1305,3 +1305,40 @@ func TestHelpOverlayScroll(t *testing.T) {
 		t.Fatalf("expected helpScroll=0 after Space, got %d", m.helpScroll)
 	}
 }
+
+// countingDetailLoader counts detail reads for slim snapshots.
+type countingDetailLoader struct {
+	loads int
+}
+
+func (l *countingDetailLoader) LoadIssueDetails(id string) (*model.Issue, error) {
+	l.loads++
+	return &model.Issue{ID: id, Description: "full text"}, nil
+}
+
+func TestHydrateIssueDetails_CachesUntilSnapshotSwap(t *testing.T) {
+	issues := []model.Issue{{ID: "A", Title: "Alpha", Status: model.StatusOpen, IssueType: model.TypeTask}}
+	m := NewModel(issues, nil, "")
+	details := &countingDetailLoader{}
+	m.snapshot = NewSnapshotBuilder(issues).Build()
+	m.snapshot.Details = details
+
+	for i := 0; i < 3; i++ {
+		if got := m.hydrateIssueDetails(issues[0]); got.Description != "full text" {
+			t.Fatalf("expected hydrated description, got %q", got.Description)
+		}
+	}
+	if details.loads != 1 {
+		t.Fatalf("expected one detail read, got %d", details.loads)
+	}
+
+	snap := NewSnapshotBuilder(issues).Build()
+	snap.Details = details
+	modelAny, _ := m.Update(SnapshotReadyMsg{Snapshot: snap})
+	m = modelAny.(Model)
+	loads := details.loads
+	m.hydrateIssueDetails(issues[0])
+	if details.loads != loads+1 {
+		t.Fatalf("expected a fresh read after reload, got %d reads (was %d)", details.loads, loads)
+	}
+}
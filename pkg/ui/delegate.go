@@ -17,8 +17,9 @@ type IssueDelegate struct {
 	Theme             Theme
 	ShowPriorityHints bool
 	PriorityHints     map[string]*analysis.PriorityRecommendation
-	WorkspaceMode     bool // When true, shows repo prefix badges
-	ShowSearchScores  bool // Show semantic/hybrid score badge when search is active
+	WorkspaceMode     bool                // When true, shows repo prefix badges
+	ShowSearchScores  bool                // Show semantic/hybrid score badge when search is active
+	SavedSearchAlerts map[string][]string // Issue ID -> saved searches it recently entered
 }
 
 func (d IssueDelegate) Height() int {
@@ -170,6 +171,13 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 		leftFixedWidth += lipgloss.Width(badge) + 1
 	}
 
+	// Saved search alert badge
+	var alertBadge string
+	if names := d.SavedSearchAlerts[i.Issue.ID]; len(names) > 0 {
+		alertBadge = "🔔"
+		leftFixedWidth += lipgloss.Width(alertBadge) + 1
+	}
+
 	// Title gets everything in between
 	titleWidth := width - leftFixedWidth - rightWidth - 2
 	if titleWidth < 5 {
@@ -263,6 +271,12 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 		leftSide.WriteString(" ")
 	}
 
+	// Saved search alert badge
+	if alertBadge != "" {
+		leftSide.WriteString(alertBadge)
+		leftSide.WriteString(" ")
+	}
+
 	// Title with emphasis when selected
 	titleStyle := t.Renderer.NewStyle()
 	if isSelected {
@@ -76,6 +76,26 @@ func TestIssueDelegate_RenderWorkspaceWithPriorityHints(t *testing.T) {
 	}
 }
 
+func TestIssueDelegate_RenderSavedSearchAlertBadge(t *testing.T) {
+	item := newTestIssueItem("bv-7")
+	theme := DefaultTheme(lipgloss.NewRenderer(os.Stdout))
+	render := func(delegate IssueDelegate) string {
+		l := list.New([]list.Item{item}, delegate, 0, 0)
+		l.SetWidth(120)
+		var buf bytes.Buffer
+		delegate.Render(&buf, l, 0, item)
+		return buf.String()
+	}
+
+	if out := render(IssueDelegate{Theme: theme}); strings.Contains(out, "🔔") {
+		t.Fatalf("unexpected alert badge: %q", out)
+	}
+	out := render(IssueDelegate{Theme: theme, SavedSearchAlerts: map[string][]string{"bv-7": {"sync"}}})
+	if !strings.Contains(out, "bv-7 🔔") {
+		t.Fatalf("render output missing alert badge after the id: %q", out)
+	}
+}
+
 func TestIssueDelegate_RenderFallsBackWidthAndNoPanic(t *testing.T) {
 	item := newTestIssueItem("TASK-1")
 	theme := DefaultTheme(lipgloss.NewRenderer(os.Stdout))
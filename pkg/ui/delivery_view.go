@@ -0,0 +1,255 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// deliveryChartHeight is the number of rows per weekly bar chart.
+const deliveryChartHeight = 3
+
+// barEighths renders partial bar heights, from 1/8 to a full cell.
+var barEighths = []string{"▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+
+// DeliveryMetricsLoadedMsg is sent when background delivery metrics finish.
+type DeliveryMetricsLoadedMsg struct {
+	Metrics *correlation.DeliveryMetrics
+	Error   error
+}
+
+// LoadDeliveryMetricsCmd computes delivery metrics in the background from
+// the loaded history report and the repository's release tags.
+func LoadDeliveryMetricsCmd(issues []model.Issue, report *correlation.HistoryReport, beadsPath string) tea.Cmd {
+	return func() tea.Msg {
+		repoPath, err := historyRepoPath(beadsPath)
+		if err != nil {
+			return DeliveryMetricsLoadedMsg{Error: err}
+		}
+		tags, err := correlation.ListReleaseTags(repoPath, correlation.DefaultReleaseTagPattern)
+		if err != nil {
+			return DeliveryMetricsLoadedMsg{Error: err}
+		}
+		released, err := correlation.FirstReleases(repoPath, tags)
+		if err != nil {
+			return DeliveryMetricsLoadedMsg{Error: err}
+		}
+		metrics := correlation.ComputeDeliveryMetrics(issues, report, tags, released, correlation.DeliveryOptions{})
+		return DeliveryMetricsLoadedMsg{Metrics: metrics}
+	}
+}
+
+// DeliveryMetricsModel charts DORA-style delivery metrics as weekly bars.
+type DeliveryMetricsModel struct {
+	metrics *correlation.DeliveryMetrics
+	err     error
+	loading bool
+	width   int
+	height  int
+	theme   Theme
+}
+
+// NewDeliveryMetricsModel creates a delivery metrics view waiting for data.
+func NewDeliveryMetricsModel(theme Theme) DeliveryMetricsModel {
+	return DeliveryMetricsModel{theme: theme, loading: true}
+}
+
+// SetSize updates the view dimensions
+func (m *DeliveryMetricsModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// SetMetrics stores computed metrics or the error computing them.
+func (m *DeliveryMetricsModel) SetMetrics(metrics *correlation.DeliveryMetrics, err error) {
+	m.metrics = metrics
+	m.err = err
+	m.loading = false
+}
+
+// View renders the delivery metrics charts
+func (m *DeliveryMetricsModel) View() string {
+	t := m.theme
+	r := t.Renderer
+
+	titleStyle := r.NewStyle().Foreground(t.Primary).Bold(true)
+	mutedStyle := r.NewStyle().Foreground(t.Subtext)
+
+	var lines []string
+	switch {
+	case m.loading:
+		lines = append(lines, titleStyle.Render("Delivery Metrics"), "", mutedStyle.Render("Computing from git history and release tags…"))
+	case m.err != nil:
+		lines = append(lines, titleStyle.Render("Delivery Metrics"), "", r.NewStyle().Foreground(t.Blocked).Render("Error: "+m.err.Error()))
+	case m.metrics != nil:
+		lines = m.renderCharts()
+	}
+
+	return r.NewStyle().Padding(1, 2).Width(m.width).Height(m.height).Render(strings.Join(lines, "\n"))
+}
+
+func (m *DeliveryMetricsModel) renderCharts() []string {
+	t := m.theme
+	r := t.Renderer
+	dm := m.metrics
+	s := dm.Summary
+
+	titleStyle := r.NewStyle().Foreground(t.Primary).Bold(true)
+	labelStyle := r.NewStyle().Foreground(t.Secondary).Bold(true)
+	mutedStyle := r.NewStyle().Foreground(t.Subtext)
+
+	// Fit one column per week, two cells wide when there is room.
+	colWidth := 2
+	if len(dm.Weeks)*(colWidth+1) > m.width-8 {
+		colWidth = 1
+	}
+
+	var lines []string
+	lines = append(lines, titleStyle.Render(fmt.Sprintf("Delivery Metrics · last %d weeks", len(dm.Weeks))))
+	basis := "created → release"
+	if s.LeadTimeBasis == correlation.LeadTimeToClose {
+		basis = "created → closed (no release tags match " + s.ReleaseTagPattern + ")"
+	}
+	lines = append(lines, mutedStyle.Render("Lead time: "+basis))
+	lines = append(lines, "")
+
+	chart := func(title, summary string, color lipgloss.TerminalColor, value func(w correlation.DeliveryWeek) (float64, bool)) {
+		values := make([]float64, len(dm.Weeks))
+		present := make([]bool, len(dm.Weeks))
+		for i, w := range dm.Weeks {
+			values[i], present[i] = value(w)
+		}
+		lines = append(lines, labelStyle.Render(title)+"  "+mutedStyle.Render(summary))
+		barStyle := r.NewStyle().Foreground(color)
+		for _, row := range renderWeeklyBars(values, present, deliveryChartHeight, colWidth) {
+			lines = append(lines, "  "+barStyle.Render(row))
+		}
+		lines = append(lines, "")
+	}
+
+	chart("Deployment frequency",
+		fmt.Sprintf("%d releases · %.1f/week", s.Deployments, s.DeploymentsPerWeek),
+		t.Open,
+		func(w correlation.DeliveryWeek) (float64, bool) { return float64(w.Deployments), true })
+	chart("Lead time for changes",
+		"median "+formatHoursPtr(s.LeadTimeHours)+fmt.Sprintf(" · %d beads", s.LeadTimes),
+		t.InProgress,
+		func(w correlation.DeliveryWeek) (float64, bool) { return derefValue(w.LeadTimeHours) })
+	chart("Change failure rate",
+		formatRatePtr(s.ChangeFailureRate)+fmt.Sprintf(" · %d of %d changes", s.FailedChanges, s.Changes),
+		t.Blocked,
+		func(w correlation.DeliveryWeek) (float64, bool) { return derefValue(w.ChangeFailureRate) })
+	chart("Time to restore (P0 bugs)",
+		"median "+formatHoursPtr(s.TimeToRestoreHours)+fmt.Sprintf(" · %d restores", s.Restores),
+		t.Closed,
+		func(w correlation.DeliveryWeek) (float64, bool) { return derefValue(w.TimeToRestoreHours) })
+
+	if len(dm.Weeks) > 0 {
+		first := dm.Weeks[0].WeekStart.Format("Jan 02")
+		last := dm.Weeks[len(dm.Weeks)-1].WeekStart.Format("Jan 02")
+		span := len(dm.Weeks)*(colWidth+1) - 1
+		gap := span - len(first) - len(last)
+		if gap < 1 {
+			gap = 1
+		}
+		lines = append(lines, "  "+mutedStyle.Render(first+strings.Repeat(" ", gap)+last))
+	}
+	return lines
+}
+
+// renderWeeklyBars draws one bar per value, scaled to the largest, as
+// height rows top to bottom. Missing values show a dot on the baseline.
+func renderWeeklyBars(values []float64, present []bool, height, colWidth int) []string {
+	maxVal := 0.0
+	for i, v := range values {
+		if present[i] && v > maxVal {
+			maxVal = v
+		}
+	}
+
+	rows := make([]string, height)
+	for row := 0; row < height; row++ {
+		level := height - 1 - row // 0 = baseline
+		var b strings.Builder
+		for i, v := range values {
+			if i > 0 {
+				b.WriteString(" ")
+			}
+			cell := " "
+			if !present[i] {
+				if level == 0 {
+					cell = "·"
+				}
+			} else if maxVal > 0 {
+				units := int(math.Round(v / maxVal * float64(height*8)))
+				if v > 0 && units == 0 {
+					units = 1 // Keep small non-zero values visible
+				}
+				switch {
+				case units >= (level+1)*8:
+					cell = "█"
+				case units > level*8:
+					cell = barEighths[units-level*8-1]
+				}
+			}
+			b.WriteString(strings.Repeat(cell, colWidth))
+		}
+		rows[row] = b.String()
+	}
+	return rows
+}
+
+func derefValue(v *float64) (float64, bool) {
+	if v == nil {
+		return 0, false
+	}
+	return *v, true
+}
+
+func formatHoursPtr(h *float64) string {
+	if h == nil {
+		return "n/a"
+	}
+	return formatDuration(time.Duration(*h * float64(time.Hour)))
+}
+
+func formatRatePtr(rate *float64) string {
+	if rate == nil {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.0f%%", *rate*100)
+}
+
+// openDeliveryView shows delivery metrics, computing them in the background.
+func (m Model) openDeliveryView() (Model, tea.Cmd) {
+	m.clearAttentionOverlay()
+	if m.historyLoading {
+		m.statusMsg = "Delivery metrics need git history; still loading…"
+		m.statusIsError = false
+		return m, nil
+	}
+	m.isGraphView = false
+	m.isBoardView = false
+	m.isActionableView = false
+	m.isHistoryView = false
+	m.focused = focusDelivery
+	m.deliveryView = NewDeliveryMetricsModel(m.theme)
+	m.deliveryView.SetSize(m.width, m.height-1)
+	return m, LoadDeliveryMetricsCmd(m.issuesForAsync(), m.historyView.report, m.beadsPath)
+}
+
+// handleDeliveryKeys handles keyboard input when the delivery view is focused
+func (m Model) handleDeliveryKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "D", "q", "esc":
+		m.focused = focusList
+	}
+	return m
+}
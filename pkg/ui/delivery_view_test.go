@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRenderWeeklyBars(t *testing.T) {
+	rows := renderWeeklyBars([]float64{4, 2, 0, 0}, []bool{true, true, true, false}, 2, 1)
+	if len(rows) != 2 {
+		t.Fatalf("rows = %q", rows)
+	}
+	// The half-height bar fills exactly the baseline row.
+	if rows[0] != "█      " {
+		t.Fatalf("top row = %q", rows[0])
+	}
+	if rows[1] != "█ █   ·" {
+		t.Fatalf("baseline = %q", rows[1])
+	}
+}
+
+func TestDeliveryViewOpensAndCloses(t *testing.T) {
+	issues := []model.Issue{{ID: "bv-1", Title: "Alpha", Status: model.StatusOpen, CreatedAt: time.Now()}}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+
+	m.historyLoading = true
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	m = updated.(Model)
+	if m.focused == focusDelivery || !strings.Contains(m.statusMsg, "still loading") {
+		t.Fatalf("expected loading notice, got focus=%v status=%q", m.focused, m.statusMsg)
+	}
+
+	m.historyLoading = false
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	m = updated.(Model)
+	if m.focused != focusDelivery || cmd == nil {
+		t.Fatalf("expected delivery focus with load command, got focus=%v", m.focused)
+	}
+
+	week := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	lead := 36.0
+	metrics := &correlation.DeliveryMetrics{
+		Summary: correlation.DeliverySummary{Deployments: 3, DeploymentsPerWeek: 1.5, LeadTimeHours: &lead, LeadTimes: 2, LeadTimeBasis: correlation.LeadTimeToRelease},
+		Weeks:   []correlation.DeliveryWeek{{WeekStart: week.AddDate(0, 0, -7), Deployments: 1}, {WeekStart: week, Deployments: 2, LeadTimeHours: &lead}},
+	}
+	updated, _ = m.Update(DeliveryMetricsLoadedMsg{Metrics: metrics})
+	m = updated.(Model)
+	view := m.View()
+	for _, want := range []string{"Delivery Metrics", "Deployment frequency", "3 releases", "Change failure rate", "Jun 10"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.focused != focusList {
+		t.Fatalf("expected list focus after esc, got %v", m.focused)
+	}
+}
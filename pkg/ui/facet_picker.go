@@ -0,0 +1,332 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// facetPickerMaxValues caps the values listed per facet; selected values
+// are always listed.
+const facetPickerMaxValues = 8
+
+type facetRow struct {
+	field search.FacetField
+	value string
+	count int
+}
+
+// FacetPickerModel lists facet counts over the current semantic search
+// results and toggles refinements.
+type FacetPickerModel struct {
+	rows          []facetRow
+	filter        search.FacetFilter
+	query         string
+	total         int // results before refinement
+	matching      int // results after refinement
+	issues        []*model.Issue
+	selectedIndex int
+	width         int
+	height        int
+	theme         Theme
+}
+
+// NewFacetPickerModel creates a picker over the issues a query returned.
+func NewFacetPickerModel(query string, issues []*model.Issue, filter search.FacetFilter, theme Theme) FacetPickerModel {
+	m := FacetPickerModel{
+		filter: search.FacetFilter{},
+		query:  query,
+		total:  len(issues),
+		issues: issues,
+		theme:  theme,
+	}
+	for field, values := range filter {
+		m.filter[field] = append([]string(nil), values...)
+	}
+
+	facets := search.ComputeFacets(issues)
+	for _, field := range search.FacetFields {
+		buckets := facets[field]
+		for i, b := range buckets {
+			if i >= facetPickerMaxValues && !m.filter.Has(field, b.Value) {
+				continue
+			}
+			m.rows = append(m.rows, facetRow{field: field, value: b.Value, count: b.Count})
+		}
+		// Selected values absent from these results still need a row to
+		// be toggled off.
+		for _, v := range m.filter[field] {
+			found := false
+			for _, b := range buckets {
+				if b.Value == v {
+					found = true
+					break
+				}
+			}
+			if !found {
+				m.rows = append(m.rows, facetRow{field: field, value: v})
+			}
+		}
+	}
+	m.recount()
+	return m
+}
+
+func (m *FacetPickerModel) recount() {
+	m.matching = 0
+	for _, issue := range m.issues {
+		if m.filter.Match(issue) {
+			m.matching++
+		}
+	}
+}
+
+// SetSize updates the picker dimensions
+func (m *FacetPickerModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// MoveUp moves selection up
+func (m *FacetPickerModel) MoveUp() {
+	if m.selectedIndex > 0 {
+		m.selectedIndex--
+	}
+}
+
+// MoveDown moves selection down
+func (m *FacetPickerModel) MoveDown() {
+	if m.selectedIndex < len(m.rows)-1 {
+		m.selectedIndex++
+	}
+}
+
+// Toggle selects or deselects the value under the cursor.
+func (m *FacetPickerModel) Toggle() {
+	if m.selectedIndex >= len(m.rows) {
+		return
+	}
+	row := m.rows[m.selectedIndex]
+	m.filter.Toggle(row.field, row.value)
+	m.recount()
+}
+
+// Clear removes every refinement.
+func (m *FacetPickerModel) Clear() {
+	m.filter = search.FacetFilter{}
+	m.recount()
+}
+
+// Filter returns the refinement being edited.
+func (m *FacetPickerModel) Filter() search.FacetFilter {
+	return m.filter
+}
+
+// View renders the facet picker overlay
+func (m *FacetPickerModel) View() string {
+	if m.width == 0 {
+		m.width = 60
+	}
+	if m.height == 0 {
+		m.height = 20
+	}
+
+	t := m.theme
+
+	boxWidth := 50
+	if m.width < 60 {
+		boxWidth = m.width - 10
+	}
+	if boxWidth < 30 {
+		boxWidth = 30
+	}
+
+	var lines []string
+
+	titleStyle := t.Renderer.NewStyle().
+		Foreground(t.Primary).
+		Bold(true)
+	lines = append(lines, titleStyle.Render("Refine Results"))
+
+	descStyle := t.Renderer.NewStyle().
+		Foreground(t.Secondary).
+		Italic(true)
+	lines = append(lines, descStyle.Render(truncateRunesHelper(fmt.Sprintf("%q · %d of %d results", m.query, m.matching, m.total), boxWidth-6, "…")))
+	lines = append(lines, "")
+
+	if len(m.rows) == 0 {
+		lines = append(lines, descStyle.Render("No results to refine"))
+	}
+
+	// Keep the cursor visible when the facets outgrow the screen.
+	visible := m.height - 12
+	if visible < 5 {
+		visible = 5
+	}
+	start := 0
+	if m.selectedIndex >= visible {
+		start = m.selectedIndex - visible + 1
+	}
+	end := start + visible
+	if end > len(m.rows) {
+		end = len(m.rows)
+	}
+
+	headerStyle := t.Renderer.NewStyle().
+		Foreground(t.Secondary).
+		Bold(true)
+	var lastField search.FacetField
+	for i := start; i < end; i++ {
+		row := m.rows[i]
+		if row.field != lastField {
+			if lastField != "" {
+				lines = append(lines, "")
+			}
+			lines = append(lines, headerStyle.Render(strings.ToUpper(string(row.field))))
+			lastField = row.field
+		}
+
+		isSelected := i == m.selectedIndex
+		style := t.Renderer.NewStyle()
+		if isSelected {
+			style = style.Foreground(t.Primary).Bold(true)
+		} else {
+			style = style.Foreground(t.Base.GetForeground())
+		}
+		prefix := "  "
+		if isSelected {
+			prefix = "▸ "
+		}
+		check := "[ ]"
+		if m.filter.Has(row.field, row.value) {
+			check = "[x]"
+		}
+		count := fmt.Sprintf("%d", row.count)
+		label := truncateRunesHelper(row.value, boxWidth-len(count)-14, "…")
+		gap := boxWidth - 6 - len(prefix) - len(check) - 1 - lipgloss.Width(label) - len(count)
+		if gap < 1 {
+			gap = 1
+		}
+		lines = append(lines, style.Render(prefix+check+" "+label+strings.Repeat(" ", gap)+count))
+	}
+
+	lines = append(lines, "")
+	footerStyle := t.Renderer.NewStyle().
+		Foreground(t.Secondary).
+		Italic(true)
+	lines = append(lines, footerStyle.Render("space: toggle • c: clear • enter/esc: done"))
+
+	content := strings.Join(lines, "\n")
+
+	boxStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(boxWidth)
+
+	return lipgloss.Place(
+		m.width,
+		m.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		boxStyle.Render(content),
+	)
+}
+
+// openFacetPicker shows facet counts over the current semantic search results.
+func (m Model) openFacetPicker() Model {
+	term := m.list.FilterInput.Value()
+	if !m.semanticSearchEnabled || m.semanticSearch == nil || term == "" || m.list.FilterState() == list.Unfiltered {
+		m.statusMsg = "Facets refine semantic search results (ctrl+s, then / to search)"
+		m.statusIsError = false
+		return m
+	}
+	ids, ok := m.semanticSearch.ResultIDs(term)
+	if !ok {
+		m.statusMsg = "Semantic results still computing…"
+		m.statusIsError = false
+		return m
+	}
+	issues := make([]*model.Issue, 0, len(ids))
+	for _, id := range ids {
+		if issue, ok := m.issueMap[id]; ok {
+			issues = append(issues, issue)
+		}
+	}
+	m.facetPicker = NewFacetPickerModel(term, issues, m.searchFacets, m.theme)
+	m.facetPicker.SetSize(m.width, m.height-1)
+	m.showFacetPicker = true
+	m.focused = focusFacetPicker
+	return m
+}
+
+// handleFacetPickerKeys handles keyboard input when the facet picker is focused.
+// Each toggle refines the list immediately.
+func (m Model) handleFacetPickerKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "j", "down":
+		m.facetPicker.MoveDown()
+	case "k", "up":
+		m.facetPicker.MoveUp()
+	case " ", "space", "x":
+		m.facetPicker.Toggle()
+		m.setSearchFacets(m.facetPicker.Filter())
+	case "c":
+		m.facetPicker.Clear()
+		m.setSearchFacets(nil)
+	case "enter", "esc", "q", "F":
+		m.showFacetPicker = false
+		m.focused = focusList
+		if m.searchFacets.Empty() {
+			m.statusMsg = ""
+		} else {
+			m.statusMsg = "Refined by " + m.searchFacets.String() + " (F to change)"
+		}
+		m.statusIsError = false
+	}
+	return m
+}
+
+// setSearchFacets applies a facet refinement to the semantic results and
+// refreshes the filtered list.
+func (m *Model) setSearchFacets(f search.FacetFilter) {
+	m.searchFacets = nil
+	if !f.Empty() {
+		m.searchFacets = search.FacetFilter{}
+		for field, values := range f {
+			m.searchFacets[field] = append([]string(nil), values...)
+		}
+	}
+	m.syncFacetAllow()
+
+	if prevState := m.list.FilterState(); prevState != list.Unfiltered {
+		m.list.SetFilterText(m.list.FilterInput.Value())
+		if prevState == list.Filtering {
+			m.list.SetFilterState(list.Filtering)
+		}
+	}
+}
+
+// syncFacetAllow recomputes which issues the facet refinement keeps.
+func (m *Model) syncFacetAllow() {
+	if m.semanticSearch == nil {
+		return
+	}
+	if m.searchFacets.Empty() {
+		m.semanticSearch.SetFacetAllow(nil)
+		return
+	}
+	allow := make(map[string]struct{})
+	for i := range m.issues {
+		if m.searchFacets.Match(&m.issues[i]) {
+			allow[m.issues[i].ID] = struct{}{}
+		}
+	}
+	m.semanticSearch.SetFacetAllow(allow)
+}
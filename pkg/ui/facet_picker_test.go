@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSemanticSearchFacetAllow(t *testing.T) {
+	ss := NewSemanticSearch()
+	ss.SetIndex(search.NewVectorIndex(3), &mockEmbedder{dim: 3})
+	ss.SetIDs([]string{"a", "b", "c"})
+	ss.SetCachedResults("q", []list.Rank{{Index: 2}, {Index: 0}, {Index: 1}})
+	targets := []string{"a", "b", "c"}
+
+	if ids, ok := ss.ResultIDs("q"); !ok || strings.Join(ids, ",") != "c,a,b" {
+		t.Fatalf("ResultIDs = %v, %v", ids, ok)
+	}
+	if _, ok := ss.ResultIDs("other"); ok {
+		t.Fatal("ResultIDs should miss uncached terms")
+	}
+
+	ss.SetFacetAllow(map[string]struct{}{"a": {}, "c": {}})
+	if got := ss.Filter("q", targets); len(got) != 2 || got[0].Index != 2 || got[1].Index != 0 {
+		t.Fatalf("restricted ranks = %+v", got)
+	}
+	if ids, _ := ss.ResultIDs("q"); len(ids) != 3 {
+		t.Fatalf("ResultIDs should ignore the refinement: %v", ids)
+	}
+
+	ss.SetFacetAllow(nil)
+	if got := ss.Filter("q", targets); len(got) != 3 {
+		t.Fatalf("unrestricted ranks = %+v", got)
+	}
+}
+
+func TestFacetPickerToggleAndClear(t *testing.T) {
+	issues := []*model.Issue{
+		{ID: "a", Status: model.StatusOpen, IssueType: model.TypeBug, Labels: []string{"api"}},
+		{ID: "b", Status: model.StatusClosed, IssueType: model.TypeBug},
+		{ID: "c", Status: model.StatusOpen, IssueType: model.TypeTask},
+	}
+	preset := search.FacetFilter{search.FacetLabel: {"gone"}}
+	p := NewFacetPickerModel("q", issues, preset, DefaultTheme(nil))
+	if p.matching != 0 || p.total != 3 {
+		t.Fatalf("matching=%d total=%d", p.matching, p.total)
+	}
+	if p.rows[0].field != search.FacetStatus || p.rows[0].value != "open" || p.rows[0].count != 2 {
+		t.Fatalf("first row = %+v", p.rows[0])
+	}
+	found := false
+	for _, r := range p.rows {
+		if r.field == search.FacetLabel && r.value == "gone" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("selected value missing from results should still be listed")
+	}
+
+	p.Clear()
+	p.Toggle() // status=open
+	if p.matching != 2 || !p.Filter().Has(search.FacetStatus, "open") {
+		t.Fatalf("after toggle matching=%d filter=%v", p.matching, p.Filter())
+	}
+	preset[search.FacetStatus] = []string{"closed"}
+	if p.Filter().Has(search.FacetStatus, "closed") {
+		t.Fatal("picker should not alias the caller's filter")
+	}
+	if !strings.Contains(p.View(), "2 of 3 results") {
+		t.Fatalf("view missing counts:\n%s", p.View())
+	}
+}
+
+func TestFacetPickerRefinesSemanticResults(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Auth token bug", Status: model.StatusOpen, IssueType: model.TypeBug},
+		{ID: "bv-2", Title: "Auth docs", Status: model.StatusClosed, IssueType: model.TypeTask},
+		{ID: "bv-3", Title: "Auth refresh", Status: model.StatusOpen, IssueType: model.TypeTask},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+
+	// Without an active semantic query, F only explains itself.
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+	m = updated.(Model)
+	if m.showFacetPicker {
+		t.Fatal("facet picker needs semantic results")
+	}
+
+	m.semanticSearch.SetIndex(search.NewVectorIndex(3), &mockEmbedder{dim: 3})
+	m.semanticSearchEnabled = true
+	m.list.Filter = m.semanticSearch.Filter
+	ids := m.semanticSearch.Snapshot().IDs
+	ranks := make([]list.Rank, len(ids))
+	for i := range ids {
+		ranks[i] = list.Rank{Index: i}
+	}
+	m.semanticSearch.SetCachedResults("auth", ranks)
+	m.list.SetFilterText("auth")
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("F")})
+	m = updated.(Model)
+	if !m.showFacetPicker || m.focused != focusFacetPicker {
+		t.Fatalf("F should open the facet picker (status %q)", m.statusMsg)
+	}
+
+	// Rows start with status: open (2), closed (1). Select closed.
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")})
+	m = updated.(Model)
+	visible := m.list.VisibleItems()
+	if len(visible) != 1 || visible[0].(IssueItem).Issue.ID != "bv-2" {
+		t.Fatalf("refined list = %d items", len(visible))
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.showFacetPicker || m.focused != focusList || !strings.Contains(m.statusMsg, "status=closed") {
+		t.Fatalf("enter should close the picker and report the refinement (status %q)", m.statusMsg)
+	}
+
+	// Turning semantic search off drops the refinement.
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	m = updated.(Model)
+	if !m.searchFacets.Empty() {
+		t.Fatalf("facets should clear with semantic search: %v", m.searchFacets)
+	}
+}
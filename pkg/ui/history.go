@@ -158,6 +158,13 @@ type HistoryModel struct {
 	fileFilter      string          // Current file filter (empty = no filter)
 	fileTreeFocus   bool            // True when file tree has focus
 
+	// Blame viewer state
+	blamePath   string                 // File being blamed (empty = viewer closed)
+	blame       *correlation.FileBlame // Loaded blame (nil while loading)
+	blameErr    error                  // Error from git blame
+	blameCursor int                    // Index into blame.Lines
+	blameScroll int                    // First visible line index
+
 	// Cass session integration state (bv-pr1l)
 	sessionCache map[string][]cass.ScoredResult // Cached sessions per bead ID
 
@@ -1197,6 +1204,21 @@ func (h *HistoryModel) SelectedBeadID() string {
 	return ""
 }
 
+// SelectBead selects beadID in the bead list, switching to bead mode.
+// Returns false if current filters hide the bead.
+func (h *HistoryModel) SelectBead(beadID string) bool {
+	for i, id := range h.beadIDs {
+		if id == beadID {
+			h.viewMode = historyModeBead
+			h.selectedBead = i
+			h.selectedCommit = 0
+			h.ensureBeadVisible()
+			return true
+		}
+	}
+	return false
+}
+
 // SelectedHistory returns the currently selected bead history
 func (h *HistoryModel) SelectedHistory() *correlation.BeadHistory {
 	if h.selectedBead < len(h.histories) {
@@ -1258,6 +1280,9 @@ func (h *HistoryModel) View() string {
 	if h.report == nil {
 		return h.renderEmpty("No history data loaded")
 	}
+	if h.IsBlameOpen() {
+		return h.renderBlameView()
+	}
 
 	// In git mode, check commit list; in bead mode, check histories
 	if h.viewMode == historyModeGit {
@@ -1407,6 +1432,24 @@ func (h *HistoryModel) buildTimeline(hist correlation.BeadHistory) []TimelineEnt
 			EventType: "closed",
 		})
 	}
+	if hist.Milestones.InReview != nil {
+		entries = append(entries, TimelineEntry{
+			Timestamp: hist.Milestones.InReview.Timestamp,
+			EntryType: timelineEntryEvent,
+			Label:     "◐ In review",
+			Detail:    hist.Milestones.InReview.CommitMsg,
+			EventType: "in_review",
+		})
+	}
+	if hist.Milestones.Merged != nil {
+		entries = append(entries, TimelineEntry{
+			Timestamp: hist.Milestones.Merged.Timestamp,
+			EntryType: timelineEntryEvent,
+			Label:     "⑂ Merged",
+			Detail:    hist.Milestones.Merged.CommitMsg,
+			EventType: "merged",
+		})
+	}
 
 	// Add commits
 	for _, commit := range hist.Commits {
@@ -1563,9 +1606,9 @@ func (h *HistoryModel) renderTimelinePanel(width, height int) string {
 				switch entry.EventType {
 				case "created":
 					eventColor = t.Secondary
-				case "claimed":
+				case "claimed", "in_review":
 					eventColor = t.InProgress
-				case "closed":
+				case "closed", "merged":
 					eventColor = t.Closed
 				case "reopened":
 					eventColor = t.Open
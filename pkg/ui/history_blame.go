@@ -0,0 +1,302 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// blameGutterIDWidth caps the bead ID column of the blame gutter.
+const blameGutterIDWidth = 12
+
+// BlameLoadedMsg is sent when git blame for a history file finishes.
+type BlameLoadedMsg struct {
+	Path  string
+	Blame *correlation.FileBlame
+	Error error
+}
+
+// LoadBlameCmd blames path in the background and maps each line's commit to
+// beads through the history report.
+func LoadBlameCmd(report *correlation.HistoryReport, beadsPath, path string) tea.Cmd {
+	return func() tea.Msg {
+		repoPath, err := historyRepoPath(beadsPath)
+		if err != nil {
+			return BlameLoadedMsg{Path: path, Error: err}
+		}
+		lines, err := correlation.BlameFile(repoPath, path, 0, 0)
+		if err != nil {
+			return BlameLoadedMsg{Path: path, Error: err}
+		}
+		return BlameLoadedMsg{Path: path, Blame: report.AnnotateBlame(path, lines)}
+	}
+}
+
+// OpenBlame shows the blame viewer for path while it loads.
+func (h *HistoryModel) OpenBlame(path string) {
+	h.blamePath = path
+	h.blame = nil
+	h.blameErr = nil
+	h.blameCursor = 0
+	h.blameScroll = 0
+}
+
+// SetBlame stores a loaded blame; results for a file no longer open are dropped.
+func (h *HistoryModel) SetBlame(path string, blame *correlation.FileBlame, err error) {
+	if path != h.blamePath {
+		return
+	}
+	h.blame = blame
+	h.blameErr = err
+}
+
+// CloseBlame closes the blame viewer
+func (h *HistoryModel) CloseBlame() {
+	h.blamePath = ""
+	h.blame = nil
+	h.blameErr = nil
+}
+
+// IsBlameOpen returns whether the blame viewer is showing
+func (h *HistoryModel) IsBlameOpen() bool {
+	return h.blamePath != ""
+}
+
+// MoveBlameCursor moves the blame cursor by delta lines, clamped to the file.
+func (h *HistoryModel) MoveBlameCursor(delta int) {
+	if h.blame == nil || len(h.blame.Lines) == 0 {
+		return
+	}
+	h.blameCursor += delta
+	if h.blameCursor < 0 {
+		h.blameCursor = 0
+	}
+	if h.blameCursor >= len(h.blame.Lines) {
+		h.blameCursor = len(h.blame.Lines) - 1
+	}
+}
+
+// JumpBlameRange moves the cursor to the start of the next (dir > 0) or
+// previous (dir < 0) range explained by a bead.
+func (h *HistoryModel) JumpBlameRange(dir int) {
+	if h.blame == nil || len(h.blame.Lines) == 0 {
+		return
+	}
+	line := h.blame.Lines[h.blameCursor].Line
+	ranges := h.blame.Ranges
+	if dir > 0 {
+		for _, r := range ranges {
+			if r.StartLine > line && len(r.Beads) > 0 {
+				h.MoveBlameCursor(r.StartLine - line)
+				return
+			}
+		}
+		return
+	}
+	for i := len(ranges) - 1; i >= 0; i-- {
+		if r := ranges[i]; r.EndLine < line && len(r.Beads) > 0 {
+			h.MoveBlameCursor(r.StartLine - line)
+			return
+		}
+	}
+}
+
+// BlameCursorRange returns the blame range under the cursor
+func (h *HistoryModel) BlameCursorRange() *correlation.BlameRange {
+	if h.blame == nil || h.blameCursor >= len(h.blame.Lines) {
+		return nil
+	}
+	line := h.blame.Lines[h.blameCursor].Line
+	for i := range h.blame.Ranges {
+		if r := &h.blame.Ranges[i]; line >= r.StartLine && line <= r.EndLine {
+			return r
+		}
+	}
+	return nil
+}
+
+// renderBlameView renders the file with a gutter naming the bead that
+// explains each run of lines.
+func (h *HistoryModel) renderBlameView() string {
+	t := h.theme
+	r := t.Renderer
+
+	titleStyle := r.NewStyle().Bold(true).Foreground(t.Primary)
+	mutedStyle := r.NewStyle().Foreground(t.Subtext)
+
+	header := titleStyle.Render("BLAME ") + r.NewStyle().Bold(true).Render(h.blamePath)
+	footer := mutedStyle.Render("j/k move • ^d/^u page • n/N next/prev bead • enter select bead • esc close")
+
+	var body []string
+	switch {
+	case h.blameErr != nil:
+		body = []string{r.NewStyle().Foreground(t.Blocked).Render("Error: " + h.blameErr.Error())}
+	case h.blame == nil:
+		body = []string{mutedStyle.Render("Running git blame…")}
+	default:
+		s := h.blame.Stats
+		header += mutedStyle.Render(fmt.Sprintf("  %d lines • %.0f%% explained by %d beads",
+			s.TotalLines, s.AttributionRatio*100, len(h.blame.Beads)))
+		body = h.renderBlameLines(h.height - 4)
+	}
+
+	for len(body) < h.height-4 {
+		body = append(body, "")
+	}
+	detail := ""
+	if h.blame != nil {
+		detail = h.blameCursorDetail()
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, header, strings.Repeat("─", max(h.width, 1)),
+		strings.Join(body, "\n"), r.NewStyle().Foreground(t.Secondary).Render(detail), footer)
+}
+
+// renderBlameLines renders the visible window of blamed lines.
+func (h *HistoryModel) renderBlameLines(height int) []string {
+	t := h.theme
+	r := t.Renderer
+	lines := h.blame.Lines
+	if height < 1 {
+		height = 1
+	}
+
+	if h.blameCursor < h.blameScroll {
+		h.blameScroll = h.blameCursor
+	}
+	if h.blameCursor >= h.blameScroll+height {
+		h.blameScroll = h.blameCursor - height + 1
+	}
+
+	rangeAt := make(map[int]*correlation.BlameRange, len(h.blame.Ranges))
+	for i := range h.blame.Ranges {
+		rangeAt[h.blame.Ranges[i].StartLine] = &h.blame.Ranges[i]
+	}
+	numWidth := len(fmt.Sprint(h.blame.EndLine))
+	mutedStyle := r.NewStyle().Foreground(t.Subtext)
+
+	var out []string
+	var current *correlation.BlameRange
+	for i := 0; i < len(lines) && i < h.blameScroll+height; i++ {
+		if rg, ok := rangeAt[lines[i].Line]; ok {
+			current = rg
+		}
+		if i < h.blameScroll {
+			continue
+		}
+
+		// Name the bead and commit on a range's first visible line only.
+		first := current != nil && (current.StartLine == lines[i].Line || i == h.blameScroll)
+		id, sha := "", ""
+		color := t.Subtext
+		if current != nil {
+			if len(current.Beads) > 0 {
+				color = t.GetStatusColor(current.Beads[0].Status)
+				if first {
+					id = current.Beads[0].ID
+					if len(current.Beads) > 1 {
+						id += fmt.Sprintf("+%d", len(current.Beads)-1)
+					}
+				}
+			} else if first {
+				id = "·"
+			}
+			if first {
+				sha = current.ShortSHA
+			}
+		}
+		gutter := r.NewStyle().Foreground(color).Render("▌"+padRight(truncate(id, blameGutterIDWidth), blameGutterIDWidth)) +
+			" " + mutedStyle.Render(padRight(sha, 7)) +
+			" " + mutedStyle.Render(fmt.Sprintf("%*d", numWidth, lines[i].Line)) + " │ "
+
+		content := strings.ReplaceAll(lines[i].Content, "\t", "    ")
+		avail := h.width - lipgloss.Width(gutter)
+		if avail < 1 {
+			avail = 1
+		}
+		row := gutter + truncate(content, avail)
+		if i == h.blameCursor {
+			row = r.NewStyle().Background(t.Highlight).Render(padRight(row, h.width))
+		}
+		out = append(out, row)
+	}
+
+	return out
+}
+
+// blameCursorDetail describes the commit and bead under the cursor.
+func (h *HistoryModel) blameCursorDetail() string {
+	rg := h.BlameCursorRange()
+	if rg == nil {
+		return ""
+	}
+	detail := fmt.Sprintf("%s %s — %s", rg.ShortSHA, rg.Summary, rg.Author)
+	if len(rg.Beads) > 0 {
+		b := rg.Beads[0]
+		detail = fmt.Sprintf("%s %s [%s] • %s", b.ID, b.Title, b.Status, detail)
+	}
+	return truncate(detail, h.width)
+}
+
+// openHistoryBlame opens the blame viewer for the file selected in the
+// history file tree.
+func (m Model) openHistoryBlame() (Model, tea.Cmd) {
+	node := m.historyView.SelectedFileNode()
+	if node == nil || node.IsDir {
+		m.statusMsg = "Select a file to blame"
+		m.statusIsError = true
+		return m, nil
+	}
+	m.historyView.OpenBlame(node.Path)
+	m.statusMsg = fmt.Sprintf("Blaming %s…", node.Path)
+	m.statusIsError = false
+	return m, LoadBlameCmd(m.historyView.report, m.beadsPath, node.Path)
+}
+
+// handleHistoryBlameKeys handles keyboard input while the blame viewer is open
+func (m Model) handleHistoryBlameKeys(msg tea.KeyMsg) Model {
+	page := m.historyView.height / 2
+	switch msg.String() {
+	case "j", "down":
+		m.historyView.MoveBlameCursor(1)
+	case "k", "up":
+		m.historyView.MoveBlameCursor(-1)
+	case "ctrl+d", "pgdown":
+		m.historyView.MoveBlameCursor(page)
+	case "ctrl+u", "pgup":
+		m.historyView.MoveBlameCursor(-page)
+	case "g", "home":
+		m.historyView.MoveBlameCursor(-m.historyView.blameCursor)
+	case "G", "end":
+		if m.historyView.blame != nil {
+			m.historyView.MoveBlameCursor(len(m.historyView.blame.Lines))
+		}
+	case "n":
+		m.historyView.JumpBlameRange(1)
+	case "N":
+		m.historyView.JumpBlameRange(-1)
+	case "enter":
+		rg := m.historyView.BlameCursorRange()
+		if rg == nil || len(rg.Beads) == 0 {
+			m.statusMsg = "No bead explains this line"
+			m.statusIsError = true
+			return m
+		}
+		id := rg.Beads[0].ID
+		m.historyView.CloseBlame()
+		m.historyView.SetFileTreeFocus(false)
+		if m.historyView.SelectBead(id) {
+			m.statusMsg = fmt.Sprintf("Selected %s", id)
+			m.statusIsError = false
+		} else {
+			m.statusMsg = fmt.Sprintf("%s is hidden by the current history filters", id)
+			m.statusIsError = true
+		}
+	case "esc", "q", "b":
+		m.historyView.CloseBlame()
+	}
+	return m
+}
@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func pressBlameKey(t *testing.T, m Model, key string) (Model, tea.Cmd) {
+	t.Helper()
+	var msg tea.KeyMsg
+	switch key {
+	case "enter":
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		msg = tea.KeyMsg{Type: tea.KeyEsc}
+	default:
+		msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	}
+	updated, cmd := m.Update(msg)
+	return updated.(Model), cmd
+}
+
+func TestHistoryBlameViewer(t *testing.T) {
+	m := NewModel([]model.Issue{{ID: "bv-1", Title: "Fix authentication bug", Status: model.StatusClosed}}, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+	m.historyLoading = false
+	m.historyView = NewHistoryModel(createTestHistoryReportWithFiles(), m.theme)
+	m.historyView.SetSize(140, 39)
+	m.isHistoryView = true
+	m.focused = focusHistory
+
+	// f toggles the history file tree rather than opening the flow matrix.
+	m, _ = pressBlameKey(t, m, "f")
+	if m.focused != focusHistory || !m.historyView.IsFileTreeVisible() {
+		t.Fatalf("expected history file tree, got focus=%v visible=%v", m.focused, m.historyView.IsFileTreeVisible())
+	}
+	m.historyView.SetFileTreeFocus(true)
+
+	for i := 0; i < 20; i++ {
+		node := m.historyView.SelectedFileNode()
+		if node == nil || !node.IsDir {
+			break
+		}
+		if !node.Expanded {
+			m, _ = pressBlameKey(t, m, "l")
+		} else {
+			m, _ = pressBlameKey(t, m, "j")
+		}
+	}
+	node := m.historyView.SelectedFileNode()
+	if node == nil || node.IsDir {
+		t.Fatalf("expected a file selected, got %+v", node)
+	}
+
+	m, cmd := pressBlameKey(t, m, "b")
+	if !m.historyView.IsBlameOpen() || cmd == nil || m.isBoardView {
+		t.Fatalf("expected blame to open with a load command, board=%v", m.isBoardView)
+	}
+	if !strings.Contains(m.historyView.View(), "Running git blame") {
+		t.Fatalf("expected loading state:\n%s", m.historyView.View())
+	}
+
+	bead := []correlation.BlameBead{{ID: "bv-1", Title: "Fix authentication bug", Status: "closed", Confidence: 0.95}}
+	lines := []correlation.BlameLine{
+		{Line: 1, SHA: "aaa", Content: "package auth"},
+		{Line: 2, SHA: "abc123", Content: "func Token() {}"},
+		{Line: 3, SHA: "abc123", Content: "func Refresh() {}"},
+	}
+	blame := &correlation.FileBlame{
+		Path: node.Path, StartLine: 1, EndLine: 3, Lines: lines,
+		Ranges: []correlation.BlameRange{
+			{StartLine: 1, EndLine: 1, SHA: "aaa", ShortSHA: "aaa", Summary: "init", Beads: []correlation.BlameBead{}},
+			{StartLine: 2, EndLine: 3, SHA: "abc123", ShortSHA: "abc123", Summary: "fix: auth bug", Beads: bead},
+		},
+		Beads: []correlation.BlameBeadSummary{{ID: "bv-1", Lines: 2}},
+		Stats: correlation.BlameStats{TotalLines: 3, AttributedLines: 2, AttributionRatio: 2.0 / 3},
+	}
+	updated, _ = m.Update(BlameLoadedMsg{Path: "some/other.go", Blame: &correlation.FileBlame{}})
+	m = updated.(Model)
+	updated, _ = m.Update(BlameLoadedMsg{Path: node.Path, Blame: blame})
+	m = updated.(Model)
+
+	view := m.historyView.View()
+	for _, want := range []string{"BLAME", node.Path, "67% explained by 1 beads", "bv-1", "func Refresh() {}"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("blame view missing %q:\n%s", want, view)
+		}
+	}
+
+	// Keys go to the viewer: n jumps to the bead range instead of anything global.
+	m, _ = pressBlameKey(t, m, "n")
+	if rg := m.historyView.BlameCursorRange(); rg == nil || rg.StartLine != 2 {
+		t.Fatalf("expected cursor on bead range, got %+v", rg)
+	}
+	m, _ = pressBlameKey(t, m, "enter")
+	if m.historyView.IsBlameOpen() || m.historyView.SelectedBeadID() != "bv-1" || m.historyView.FileTreeHasFocus() {
+		t.Fatalf("expected bv-1 selected after enter, got %q open=%v", m.historyView.SelectedBeadID(), m.historyView.IsBlameOpen())
+	}
+}
+
+func TestHistoryBlameViewer_EscCloses(t *testing.T) {
+	h := NewHistoryModel(createTestHistoryReportWithFiles(), DefaultTheme(nil))
+	h.SetSize(100, 30)
+	h.OpenBlame("pkg/auth/token.go")
+	h.SetBlame("pkg/auth/token.go", nil, errBlameTest)
+	if !strings.Contains(h.View(), "Error: no such path") {
+		t.Fatalf("expected error view:\n%s", h.View())
+	}
+
+	m := Model{historyView: h}
+	m = m.handleHistoryBlameKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.historyView.IsBlameOpen() {
+		t.Fatal("esc should close the blame viewer")
+	}
+}
+
+var errBlameTest = &blameTestError{}
+
+type blameTestError struct{}
+
+func (*blameTestError) Error() string { return "no such path" }
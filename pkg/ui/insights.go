@@ -24,8 +24,9 @@ const (
 	PanelArticulation
 	PanelSlack
 	PanelCycles
-	PanelPriority // Agent-first priority recommendations
-	PanelCount    // Sentinel for wrapping
+	PanelPriority  // Agent-first priority recommendations
+	PanelBusFactor // Knowledge silos from commit authorship (shown once history loads)
+	PanelCount     // Sentinel for wrapping
 )
 
 // MetricInfo contains explanation for each metric
@@ -130,6 +131,15 @@ var metricDescriptions = map[MetricPanel]MetricInfo{
 		HowToUse:    "**Work top to bottom.** High scores = high impact. Check unblocks count.",
 		FormulaHint: "`Score = Σ(PageRank + Betweenness + BlockerRatio + ...)`",
 	},
+	PanelBusFactor: {
+		Icon:        "🚌",
+		Title:       "Bus Factor",
+		ShortDesc:   "Knowledge Silos",
+		WhatIs:      "Labels, epics and hot files where **one contributor** authored most of the linked commits.",
+		WhyUseful:   "Open work in a silo *waits on one person*. If they are away, the critical path stalls.",
+		HowToUse:    "**Pair or hand off** open items in high-risk silos before they become blockers.",
+		FormulaHint: "`Risk = TopShare × log2(1 + Open + CriticalOpen)`",
+	},
 }
 
 // InsightsModel is an interactive insights dashboard
@@ -144,6 +154,9 @@ type InsightsModel struct {
 	// Priority triage data (bv-91)
 	topPicks []analysis.TopPick
 
+	// Knowledge silos; nil until history is available
+	busFactor *analysis.BusFactorReport
+
 	// Priority radar data (bv-93) - full recommendations with breakdown
 	recommendations   []analysis.Recommendation
 	recommendationMap map[string]*analysis.Recommendation // ID -> Recommendation for quick lookup
@@ -371,6 +384,9 @@ func (m *InsightsModel) renderMarkdownExplanation(text string, width int) string
 
 func (m *InsightsModel) NextPanel() {
 	m.focusedPanel = (m.focusedPanel + 1) % PanelCount
+	if m.focusedPanel == PanelBusFactor && m.busFactor == nil {
+		m.focusedPanel = (m.focusedPanel + 1) % PanelCount
+	}
 	m.updateDetailContent()
 }
 
@@ -380,6 +396,9 @@ func (m *InsightsModel) PrevPanel() {
 	} else {
 		m.focusedPanel--
 	}
+	if m.focusedPanel == PanelBusFactor && m.busFactor == nil {
+		m.focusedPanel--
+	}
 	m.updateDetailContent()
 }
 
@@ -569,6 +588,11 @@ func (m *InsightsModel) currentPanelItemCount() int {
 		return len(m.insights.Cycles)
 	case PanelPriority:
 		return len(m.topPicks)
+	case PanelBusFactor:
+		if m.busFactor == nil {
+			return 0
+		}
+		return len(m.busFactor.Areas)
 	default:
 		return 0
 	}
@@ -622,6 +646,15 @@ func (m *InsightsModel) SelectedIssueID() string {
 		return ""
 	}
 
+	// For bus factor panel, return the selected silo's most critical open issue
+	if m.focusedPanel == PanelBusFactor {
+		idx := m.selectedIndex[PanelBusFactor]
+		if m.busFactor != nil && idx >= 0 && idx < len(m.busFactor.Areas) && len(m.busFactor.Areas[idx].OpenIssues) > 0 {
+			return m.busFactor.Areas[idx].OpenIssues[0]
+		}
+		return ""
+	}
+
 	// For other panels, return selected item's ID
 	items := m.getPanelItems(m.focusedPanel)
 	idx := m.selectedIndex[m.focusedPanel]
@@ -678,8 +711,12 @@ func (m *InsightsModel) View() string {
 		colWidth = 25
 	}
 
-	// With 4 rows, reduce individual row height
-	rowHeight := (m.height - 8) / 4
+	// With 4 rows (5 with bus factor), reduce individual row height
+	rows := 4
+	if m.busFactor != nil {
+		rows = 5
+	}
+	rowHeight := (m.height - 8) / rows
 	if rowHeight < 6 {
 		rowHeight = 6
 	}
@@ -709,6 +746,9 @@ func (m *InsightsModel) View() string {
 	}
 
 	mainContent := lipgloss.JoinVertical(lipgloss.Left, row1, row2, row3, row4)
+	if m.busFactor != nil {
+		mainContent = lipgloss.JoinVertical(lipgloss.Left, mainContent, m.renderBusFactorPanel(mainWidth-2, rowHeight, t))
+	}
 
 	// Add detail panel if enabled
 	if detailWidth > 0 {
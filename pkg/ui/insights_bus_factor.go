@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SetBusFactor sets the knowledge-silo report; nil hides the panel
+func (m *InsightsModel) SetBusFactor(report *analysis.BusFactorReport) {
+	m.busFactor = report
+	if report == nil && m.focusedPanel == PanelBusFactor {
+		m.focusedPanel = PanelPriority
+	}
+}
+
+// renderBusFactorPanel lists knowledge silos, riskiest first
+func (m *InsightsModel) renderBusFactorPanel(width, height int, t Theme) string {
+	info := metricDescriptions[PanelBusFactor]
+	isFocused := m.focusedPanel == PanelBusFactor
+	areas := m.busFactor.Areas
+
+	borderColor := t.Secondary
+	if isFocused {
+		borderColor = t.Primary
+	}
+
+	panelStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borderColor).
+		Width(width).
+		Height(height).
+		Padding(0, 1)
+
+	titleStyle := t.Renderer.NewStyle().Bold(true)
+	if isFocused {
+		titleStyle = titleStyle.Foreground(t.Primary)
+	} else {
+		titleStyle = titleStyle.Foreground(t.Secondary)
+	}
+	subtitleStyle := t.Renderer.NewStyle().Foreground(t.Subtext).Italic(true)
+
+	var lines []string
+	headerLine := fmt.Sprintf("%s %s (%d)", info.Icon, info.Title, len(areas))
+	lines = append(lines, titleStyle.Render(headerLine)+"  "+subtitleStyle.Render(info.ShortDesc))
+
+	if len(areas) == 0 {
+		healthyStyle := t.Renderer.NewStyle().Foreground(t.Open).Bold(true)
+		lines = append(lines, healthyStyle.Render("✓ No area is dominated by a single contributor"))
+		return panelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	}
+
+	selectedIdx := m.selectedIndex[PanelBusFactor]
+	visibleRows := height - 2
+	if visibleRows < 1 {
+		visibleRows = 1
+	}
+
+	startIdx := m.scrollOffset[PanelBusFactor]
+	if selectedIdx >= startIdx+visibleRows {
+		startIdx = selectedIdx - visibleRows + 1
+	}
+	if selectedIdx < startIdx {
+		startIdx = selectedIdx
+	}
+	m.scrollOffset[PanelBusFactor] = startIdx
+
+	endIdx := min(startIdx+visibleRows, len(areas))
+	for i := startIdx; i < endIdx; i++ {
+		area := areas[i]
+		isSelected := isFocused && i == selectedIdx
+		prefix := "  "
+		if isSelected {
+			prefix = t.Renderer.NewStyle().Foreground(t.Primary).Bold(true).Render("▸ ")
+		}
+
+		// Color by the open work waiting on the silo
+		rowStyle := t.Renderer.NewStyle().Foreground(t.Subtext)
+		open := fmt.Sprintf("%d open", area.OpenCount)
+		switch {
+		case area.CriticalOpen > 0:
+			rowStyle = rowStyle.Foreground(t.Blocked)
+			open += fmt.Sprintf(" (%d critical)", area.CriticalOpen)
+		case area.OpenCount > 0:
+			rowStyle = rowStyle.Foreground(t.InProgress)
+		}
+		if isSelected {
+			rowStyle = rowStyle.Bold(true)
+		}
+
+		name := area.Key
+		if area.Title != "" {
+			name += " " + area.Title
+		}
+		row := fmt.Sprintf("%s %s • %s %.0f%% of %d commits • %s • risk %.2f",
+			padRight(area.Kind, 5), truncate(name, max(width/3, 10)), area.TopAuthor,
+			area.TopShare*100, area.TotalCommits, open, area.Risk)
+		lines = append(lines, prefix+rowStyle.Render(truncate(row, max(width-6, 10))))
+	}
+
+	return panelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// computeBusFactor builds the knowledge-silo report from the loaded history,
+// or returns nil while history is unavailable
+func (m Model) computeBusFactor() *analysis.BusFactorReport {
+	if m.historyView.report == nil {
+		return nil
+	}
+	opts := analysis.DefaultBusFactorOptions()
+	if m.analysis != nil {
+		opts.CriticalPath = m.analysis.CriticalPathScore()
+	}
+	return analysis.ComputeBusFactor(m.issues, m.historyView.report, opts)
+}
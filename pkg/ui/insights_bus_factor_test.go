@@ -0,0 +1,47 @@
+package ui_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
+)
+
+func TestInsightsBusFactorPanel(t *testing.T) {
+	m := ui.NewInsightsModel(createTestInsights(), createTestIssueMap(), createTheme())
+	m.SetSize(160, 60)
+
+	// Without history the panel is skipped: Priority wraps to Bottlenecks.
+	for i := 0; i < int(ui.PanelPriority); i++ {
+		m.NextPanel()
+	}
+	m.NextPanel()
+	if id := m.SelectedIssueID(); id != "bottleneck-1" {
+		t.Fatalf("expected wrap to bottleneck-1 without bus factor, got %q", id)
+	}
+	if strings.Contains(m.View(), "Bus Factor") {
+		t.Fatal("bus factor panel shown without history")
+	}
+
+	m.SetBusFactor(&analysis.BusFactorReport{Areas: []analysis.BusFactorArea{
+		{Kind: analysis.BusFactorLabel, Key: "auth", TopAuthor: "alice", TopShare: 0.9, TotalCommits: 10,
+			OpenCount: 2, CriticalOpen: 1, OpenIssues: []string{"keystone-1", "hub-2"}, Risk: 1.4},
+		{Kind: analysis.BusFactorFile, Key: "pkg/db/store.go", TopAuthor: "bob", TopShare: 0.8, TotalCommits: 5},
+	}})
+	m.PrevPanel()
+	if id := m.SelectedIssueID(); id != "keystone-1" {
+		t.Fatalf("expected most critical open issue of the top silo, got %q", id)
+	}
+	m.MoveDown()
+	if id := m.SelectedIssueID(); id != "" {
+		t.Fatalf("silo without open work should select nothing, got %q", id)
+	}
+
+	view := m.View()
+	for _, want := range []string{"Bus Factor (2)", "auth", "alice 90% of 10 commits", "1 critical", "pkg/db/store.go"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
 )
 
 // DiffStatus represents the diff state of an issue in time-travel mode
@@ -44,6 +45,7 @@ type IssueItem struct {
 	SearchTextScore  float64
 	SearchComponents map[string]float64
 	SearchScoreSet   bool
+	SearchMatch      *search.ChunkMatch // Best-matching field/comment chunk, if any
 
 	// Triage insights (bv-151)
 	TriageScore   float64  // Unified triage score (0-1)
@@ -68,6 +68,10 @@ const (
 	focusTutorial    // Interactive tutorial (bv-8y31)
 	focusCassModal   // Cass session preview modal (bv-5bqh)
 	focusUpdateModal // Self-update modal (bv-182)
+	focusSavedSearchPicker
+	focusCommandPalette
+	focusFacetPicker
+	focusDelivery // Delivery metrics charts
 )
 
 // SortMode represents the current list sorting mode (bv-3ita)
@@ -261,33 +265,33 @@ func CheckAgentFileCmd(workDir string) tea.Cmd {
 	}
 }
 
-// LoadHistoryCmd returns a command that loads history data in the background
-func LoadHistoryCmd(issues []model.Issue, beadsPath string) tea.Cmd {
-	return func() tea.Msg {
-		var repoPath string
-		var err error
-
-		if beadsPath != "" {
-			// If beadsPath is provided (single-repo mode), derive repo root from it.
-			// Try to resolve absolute path first.
-			if absPath, e := filepath.Abs(beadsPath); e == nil {
-				dir := filepath.Dir(absPath)
-				// Standard layout: <repo_root>/.beads/<file.jsonl>
-				if filepath.Base(dir) == ".beads" {
-					repoPath = filepath.Dir(dir)
-				} else {
-					// Legacy/Flat layout: <repo_root>/<file.jsonl>
-					repoPath = dir
-				}
+// historyRepoPath derives the git repository root from the beads file path,
+// falling back to the working directory.
+func historyRepoPath(beadsPath string) (string, error) {
+	if beadsPath != "" {
+		// If beadsPath is provided (single-repo mode), derive repo root from it.
+		// Try to resolve absolute path first.
+		if absPath, e := filepath.Abs(beadsPath); e == nil {
+			dir := filepath.Dir(absPath)
+			// Standard layout: <repo_root>/.beads/<file.jsonl>
+			if filepath.Base(dir) == ".beads" {
+				return filepath.Dir(dir), nil
 			}
+			// Legacy/Flat layout: <repo_root>/<file.jsonl>
+			return dir, nil
 		}
+	}
 
-		// Fallback to CWD if beadsPath is empty (workspace mode) or Abs failed
-		if repoPath == "" {
-			repoPath, err = os.Getwd()
-			if err != nil {
-				return HistoryLoadedMsg{Error: err}
-			}
+	// Fallback to CWD if beadsPath is empty (workspace mode) or Abs failed
+	return os.Getwd()
+}
+
+// LoadHistoryCmd returns a command that loads history data in the background
+func LoadHistoryCmd(issues []model.Issue, beadsPath string, historyDB bool) tea.Cmd {
+	return func() tea.Msg {
+		repoPath, err := historyRepoPath(beadsPath)
+		if err != nil {
+			return HistoryLoadedMsg{Error: err}
 		}
 
 		// Convert model.Issue to correlation.BeadInfo
@@ -300,7 +304,7 @@ func LoadHistoryCmd(issues []model.Issue, beadsPath string) tea.Cmd {
 			}
 		}
 
-		correlator := correlation.NewCorrelator(repoPath, beadsPath)
+		correlator := correlation.NewHistoryReporter(repoPath, historyDB, beadsPath)
 		opts := correlation.CorrelatorOptions{
 			Limit: 500, // Reasonable limit for TUI performance
 		}
@@ -330,6 +334,7 @@ type Model struct {
 	analyzer     *analysis.Analyzer
 	analysis     *analysis.GraphStats
 	beadsPath    string           // Path to beads.jsonl for reloading
+	historyDB    bool             // Read history through .bv/history.db
 	watcher      *watcher.Watcher // File watcher for live reload
 	instanceLock *instance.Lock   // Multi-instance coordination lock
 
@@ -338,6 +343,10 @@ type Model struct {
 	// Access is safe without locks because Bubble Tea ensures Update() and View()
 	// don't run concurrently. When nil, the UI uses legacy m.issues/m.issueMap fields.
 	snapshot *DataSnapshot
+	// hydratedDetails caches full issues loaded for a slim snapshot, keyed by
+	// issue ID, so the detail pane does not re-read them on every render.
+	// Cleared whenever the snapshot is swapped.
+	hydratedDetails map[string]*model.Issue
 	// snapshotInitPending is true until we receive the first BackgroundWorker snapshot
 	// (or an error), allowing a polished cold-start loading screen (bv-tspo).
 	snapshotInitPending bool
@@ -346,6 +355,23 @@ type Model struct {
 	workerSpinnerIdx int // Spinner frame for background worker activity (bv-9nfy)
 	lastForceRefresh time.Time
 
+	// Persistent snapshot cache. snapshotCache is the cache the issues were
+	// loaded from (nil on a cold start) and is validated in the background;
+	// snapshotSources fingerprints the source files at the last load so the
+	// next cache write is keyed to the data it actually holds.
+	snapshotCache   *SnapshotCache
+	snapshotSources []snapshotSourceStamp
+
+	// Warm analysis shared with other bv instances on the same project (see
+	// shared_analysis.go). analysisDataHash keys the current analysis;
+	// analysisCancel stops local Phase 2 when the primary's result arrives.
+	sharedAnalysis    *instance.SharedAnalysis
+	analysisDataHash  string
+	analysisCancel    context.CancelFunc
+	sharedAnalysisKey string // dataHash|configHash last published or adopted
+	sharedWaitCancel  context.CancelFunc
+	sharedWaitCmd     tea.Cmd // startup wait, issued from Init
+
 	// UI Components
 	list               list.Model
 	viewport           viewport.Model
@@ -358,6 +384,7 @@ type Model struct {
 	tree               TreeModel // Hierarchical tree view (bv-gllx)
 	insightsPanel      InsightsModel
 	flowMatrix         FlowMatrixModel // Cross-label flow matrix
+	deliveryView       DeliveryMetricsModel
 	theme              Theme
 
 	// Update State
@@ -410,6 +437,7 @@ type Model struct {
 	sortMode               SortMode // bv-3ita: current sort mode
 	semanticSearchEnabled  bool
 	semanticIndexBuilding  bool
+	semanticIndexPath      string
 	semanticSearch         *SemanticSearch
 	semanticHybridEnabled  bool
 	semanticHybridPreset   search.PresetName
@@ -417,6 +445,11 @@ type Model struct {
 	semanticHybridReady    bool
 	lastSearchTerm         string
 
+	// More like this (M): neighbours of similarIssueID, shown in its details
+	similarIssueID   string
+	similarIssues    []search.SearchResult
+	similarPendingID string // waiting for the semantic index to build
+
 	// Stats (cached)
 	countOpen    int
 	countReady   int
@@ -439,6 +472,25 @@ type Model struct {
 	recipePicker     RecipePickerModel
 	activeRecipe     *recipe.Recipe
 	recipeLoader     *recipe.Loader
+	customEnums      map[string][]string // Enum field -> declared values (.bv/schema.yaml)
+
+	// Saved searches (.bv/searches.yaml)
+	showSavedSearchPicker bool
+	savedSearchPicker     SavedSearchPickerModel
+	savedSearchResults    []search.SavedSearchResult
+	savedSearchAlerts     map[string][]string // issue ID -> saved searches it recently entered
+	savedSearchChecking   bool
+
+	// Command palette (ctrl+p)
+	showCommandPalette bool
+	commandPalette     CommandPaletteModel
+	focusBeforePalette focus
+	paletteRecent      []string // palette entry keys, most recently visited first
+
+	// Facet refinement of semantic search results (F)
+	showFacetPicker bool
+	facetPicker     FacetPickerModel
+	searchFacets    search.FacetFilter
 
 	// Label picker (bv-126)
 	showLabelPicker bool
@@ -614,6 +666,7 @@ func (m *Model) updateSemanticIDs(items []list.Item) {
 	}
 	m.semanticSearch.SetIDs(ids)
 	m.semanticSearch.SetDocs(docs)
+	m.syncFacetAllow()
 }
 
 func (m *Model) shouldShowSearchScores() bool {
@@ -636,6 +689,7 @@ func (m *Model) updateListDelegate() {
 		PriorityHints:     m.priorityHints,
 		WorkspaceMode:     m.workspaceMode,
 		ShowSearchScores:  m.shouldShowSearchScores(),
+		SavedSearchAlerts: m.savedSearchAlerts,
 	})
 }
 
@@ -657,11 +711,13 @@ func (m *Model) applySemanticScores(term string) {
 			issueItem.SearchScore = score.Score
 			issueItem.SearchTextScore = score.TextScore
 			issueItem.SearchComponents = score.Components
+			issueItem.SearchMatch = score.Match
 			issueItem.SearchScoreSet = true
 		} else {
 			issueItem.SearchScore = 0
 			issueItem.SearchTextScore = 0
 			issueItem.SearchComponents = nil
+			issueItem.SearchMatch = nil
 			issueItem.SearchScoreSet = false
 		}
 		items[i] = issueItem
@@ -676,10 +732,11 @@ func (m *Model) clearSemanticScores() {
 		if !ok {
 			continue
 		}
-		if issueItem.SearchScoreSet || issueItem.SearchComponents != nil {
+		if issueItem.SearchScoreSet || issueItem.SearchComponents != nil || issueItem.SearchMatch != nil {
 			issueItem.SearchScore = 0
 			issueItem.SearchTextScore = 0
 			issueItem.SearchComponents = nil
+			issueItem.SearchMatch = nil
 			issueItem.SearchScoreSet = false
 			items[i] = issueItem
 			changed = true
@@ -710,9 +767,54 @@ func (m *Model) issuesForAsync() []model.Issue {
 // NewModel creates a new Model from the given issues
 // beadsPath is the path to the beads.jsonl file for live reload support
 func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath string) Model {
+	return NewModelWithSnapshotCache(issues, activeRecipe, beadsPath, nil)
+}
+
+// NewModelWithSnapshotCache is NewModel for a startup that loaded issues from
+// the on-disk snapshot cache (see OpenSnapshotCache). When the cache matches
+// the issues and recipe, its graph metrics and triage results are reused so
+// startup skips analysis entirely; the source file is re-read in the
+// background and the view reloads if the cache turns out to be stale.
+func NewModelWithSnapshotCache(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath string, cache *SnapshotCache) Model {
+	sources := snapshotSourceStamps(beadsPath)
+	if cache != nil && len(cache.sources) > 0 {
+		sources = cache.sources
+	}
+	configHash := SnapshotConfigHash(activeRecipe)
+	var cached *SnapshotCache
+	if cache.matches(issues, configHash) {
+		cached = cache
+	}
+
+	// Another instance may already have analyzed this exact data.
+	shared := openSharedAnalysis(beadsPath)
+	var dataHash string
+	if shared != nil {
+		dataHash = analysis.ComputeDataHash(issues)
+		if cached == nil {
+			cached = lookupSharedAnalysis(shared, dataHash, configHash, len(issues))
+		}
+	}
+
 	// Graph Analysis - Phase 1 is instant, Phase 2 runs in background
 	analyzer := analysis.NewAnalyzer(issues)
-	graphStats := analyzer.AnalyzeAsync(context.Background())
+	var graphStats *analysis.GraphStats
+	var analysisCancel context.CancelFunc
+	if cached != nil {
+		graphStats = cached.GraphStats()
+	} else {
+		var analysisCtx context.Context
+		analysisCtx, analysisCancel = context.WithCancel(context.Background())
+		graphStats = analyzer.AnalyzeAsync(analysisCtx)
+	}
+
+	// Custom fields from .bv/schema.yaml: enum value order for sorting and
+	// an extra swimlane mode
+	var schema *loader.Schema
+	if beadsPath != "" {
+		schema, _ = loader.ProjectSchemaForJSONL(beadsPath)
+	}
+	customEnums := schema.EnumValues()
 
 	// Sort issues
 	if activeRecipe != nil && activeRecipe.Sort.Field != "" {
@@ -733,7 +835,12 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 			case "pagerank":
 				less = graphStats.GetPageRankScore(issues[i].ID) < graphStats.GetPageRankScore(issues[j].ID)
 			default:
-				less = issues[i].Priority < issues[j].Priority
+				if name, ok := model.CustomFieldName(r.Sort.Field); ok {
+					// Already directed: missing values stay last either way
+					return model.CompareCustom(&issues[i], &issues[j], name, customEnums[name], descending) < 0
+				} else {
+					less = issues[i].Priority < issues[j].Priority
+				}
 			}
 			if descending {
 				return !less
@@ -842,6 +949,9 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 
 	// Initialize sub-components
 	board := NewBoardModel(issues, theme)
+	if lane, ok := schema.SwimlaneField(); ok {
+		board.SetCustomSwimLane(lane.Name, lane.Values)
+	}
 	labelDashboard := NewLabelDashboardModel(theme)
 	labelDashboard.SetSize(defaultWidth, defaultHeight-1)
 	velocityComparison := NewVelocityComparisonModel(theme) // bv-125
@@ -856,29 +966,44 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 	priorityHints := make(map[string]*analysis.PriorityRecommendation)
 
 	// Compute triage insights (bv-151) - reuse existing analyzer/stats (bv-runn.12)
-	triageResult := analysis.ComputeTriageFromAnalyzer(analyzer, graphStats, issues, analysis.TriageOptions{}, time.Now())
-	triageScores := make(map[string]float64, len(triageResult.Recommendations))
-	triageReasons := make(map[string]analysis.TriageReasons, len(triageResult.Recommendations))
-	quickWinSet := make(map[string]bool, len(triageResult.QuickWins))
-	blockerSet := make(map[string]bool, len(triageResult.BlockersToClear))
-	unblocksMap := make(map[string][]string, len(triageResult.Recommendations))
-
-	for _, rec := range triageResult.Recommendations {
-		triageScores[rec.ID] = rec.Score
-		if len(rec.Reasons) > 0 {
-			triageReasons[rec.ID] = analysis.TriageReasons{
-				Primary:    rec.Reasons[0],
-				All:        rec.Reasons,
-				ActionHint: rec.Action,
+	var (
+		triageScores  map[string]float64
+		triageReasons map[string]analysis.TriageReasons
+		quickWinSet   map[string]bool
+		blockerSet    map[string]bool
+		unblocksMap   map[string][]string
+	)
+	if cached != nil {
+		triageScores = cached.body.TriageScores
+		triageReasons = cached.body.TriageReasons
+		quickWinSet = cached.body.QuickWinSet
+		blockerSet = cached.body.BlockerSet
+		unblocksMap = cached.body.UnblocksMap
+	} else {
+		triageResult := analysis.ComputeTriageFromAnalyzer(analyzer, graphStats, issues, analysis.TriageOptions{}, time.Now())
+		triageScores = make(map[string]float64, len(triageResult.Recommendations))
+		triageReasons = make(map[string]analysis.TriageReasons, len(triageResult.Recommendations))
+		quickWinSet = make(map[string]bool, len(triageResult.QuickWins))
+		blockerSet = make(map[string]bool, len(triageResult.BlockersToClear))
+		unblocksMap = make(map[string][]string, len(triageResult.Recommendations))
+
+		for _, rec := range triageResult.Recommendations {
+			triageScores[rec.ID] = rec.Score
+			if len(rec.Reasons) > 0 {
+				triageReasons[rec.ID] = analysis.TriageReasons{
+					Primary:    rec.Reasons[0],
+					All:        rec.Reasons,
+					ActionHint: rec.Action,
+				}
 			}
+			unblocksMap[rec.ID] = rec.UnblocksIDs
+		}
+		for _, qw := range triageResult.QuickWins {
+			quickWinSet[qw.ID] = true
+		}
+		for _, bl := range triageResult.BlockersToClear {
+			blockerSet[bl.ID] = true
 		}
-		unblocksMap[rec.ID] = rec.UnblocksIDs
-	}
-	for _, qw := range triageResult.QuickWins {
-		quickWinSet[qw.ID] = true
-	}
-	for _, bl := range triageResult.BlockersToClear {
-		blockerSet[bl.ID] = true
 	}
 
 	// Update items with triage data
@@ -966,6 +1091,13 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 		// Lock creation failure is non-fatal - we just won't have coordination
 	}
 
+	// A secondary instance waits for the primary's analysis while computing its own.
+	var sharedWaitCmd tea.Cmd
+	var sharedWaitCancel context.CancelFunc
+	if backgroundWorker == nil {
+		sharedWaitCmd, sharedWaitCancel = awaitSharedAnalysisCmd(shared, instLock, graphStats, dataHash, configHash, len(issues))
+	}
+
 	// Semantic search (bv-9gf.3): initialized lazily on first toggle.
 	semanticSearch := NewSemanticSearch()
 	semanticIDs := make([]string, 0, len(items))
@@ -1017,6 +1149,14 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 		watcher:                fileWatcher,
 		snapshotInitPending:    backgroundWorker != nil,
 		backgroundWorker:       backgroundWorker,
+		snapshotCache:          cache,
+		snapshotSources:        sources,
+		semanticIndexPath:      cache.searchIndexPath(),
+		sharedAnalysis:         shared,
+		analysisDataHash:       dataHash,
+		analysisCancel:         analysisCancel,
+		sharedWaitCmd:          sharedWaitCmd,
+		sharedWaitCancel:       sharedWaitCancel,
 		instanceLock:           instLock,
 		list:                   l,
 		viewport:               vp,
@@ -1056,6 +1196,7 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 		recipeLoader:        recipeLoader,
 		recipePicker:        recipePicker,
 		activeRecipe:        activeRecipe,
+		customEnums:         customEnums,
 		labelPicker:         labelPicker,
 		labelDrilldownCache: make(map[string][]model.Issue),
 		timeTravelInput:     ti,
@@ -1099,14 +1240,25 @@ func (m Model) Init() tea.Cmd {
 	} else if m.watcher != nil {
 		cmds = append(cmds, WatchFileCmd(m.watcher))
 	}
+	// Issues came from the snapshot cache: confirm against the source off-thread.
+	// In background mode the worker's first build already re-reads the source.
+	if m.snapshotCache != nil && m.backgroundWorker == nil && m.beadsPath != "" {
+		cmds = append(cmds, validateSnapshotCacheCmd(m.beadsPath, m.snapshotCache.DataHash))
+	}
+	if m.sharedWaitCmd != nil {
+		cmds = append(cmds, m.sharedWaitCmd)
+	}
 	// Start loading history in background
 	if len(m.issues) > 0 {
-		cmds = append(cmds, LoadHistoryCmd(m.issuesForAsync(), m.beadsPath))
+		cmds = append(cmds, LoadHistoryCmd(m.issuesForAsync(), m.beadsPath, m.historyDB))
 	}
 	// Check for AGENTS.md integration prompt (bv-i8dk)
 	if m.workDir != "" && !m.workspaceMode {
 		cmds = append(cmds, CheckAgentFileCmd(m.workDir))
 	}
+	if len(m.issues) > 0 {
+		cmds = append(cmds, CheckSavedSearchesCmd(m.issuesForAsync()))
+	}
 	return tea.Batch(cmds...)
 }
 
@@ -1158,6 +1310,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case SemanticIndexReadyMsg:
 		m.semanticIndexBuilding = false
 		if msg.Error != nil {
+			m.similarPendingID = ""
 			// If indexing fails, revert to fuzzy mode for predictable behavior.
 			m.semanticSearchEnabled = false
 			m.list.Filter = list.DefaultFilter
@@ -1167,7 +1320,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		if m.semanticSearch != nil {
 			m.semanticSearch.SetIndex(msg.Index, msg.Embedder)
+			m.semanticSearch.SetLexicalIndex(msg.Lexical)
+			m.semanticSearch.SetChunks(msg.Chunks)
+			if m.similarPendingID != "" {
+				cmds = append(cmds, ComputeSimilarIssuesCmd(m.semanticSearch, m.similarPendingID, similarIssuesLimit))
+				m.similarPendingID = ""
+			}
 		}
+		m.semanticIndexPath = msg.IndexPath
 		if !msg.Loaded {
 			m.statusMsg = fmt.Sprintf("Semantic index built (%d embedded)", msg.Stats.Embedded)
 		} else if msg.Stats.Changed() {
@@ -1216,6 +1376,44 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case SavedSearchesCheckedMsg:
+		m.savedSearchChecking = false
+		if msg.Error != nil {
+			m.statusMsg = fmt.Sprintf("Saved searches: %v", msg.Error)
+			m.statusIsError = true
+			break
+		}
+		prevAlerts := len(m.savedSearchAlerts)
+		m.savedSearchResults = msg.Results
+		m.savedSearchAlerts = search.SavedSearchAlerts(msg.Results)
+		if n := len(m.savedSearchAlerts); n > prevAlerts {
+			m.statusMsg = fmt.Sprintf("🔔 %d issues entered saved searches (\" to browse)", n)
+			m.statusIsError = false
+		}
+		m.updateListDelegate()
+		if m.isSplitView || m.showDetails {
+			m.updateViewportContent()
+		}
+
+	case SimilarIssuesMsg:
+		if msg.Error != nil {
+			m.statusMsg = fmt.Sprintf("Similar issues unavailable: %v", msg.Error)
+			m.statusIsError = true
+			break
+		}
+		m.similarIssueID = msg.IssueID
+		m.similarIssues = msg.Results
+		m.statusMsg = fmt.Sprintf("🧭 %d issues similar to %s", len(msg.Results), msg.IssueID)
+		m.statusIsError = false
+		if sel, ok := m.list.SelectedItem().(IssueItem); ok && sel.Issue.ID == msg.IssueID {
+			if !m.isSplitView && m.focused == focusList {
+				m.showDetails = true
+				m.focused = focusDetail
+				m.viewport.GotoTop()
+			}
+			m.updateViewportContent()
+		}
+
 	case SemanticFilterResultMsg:
 		// Async semantic filter results arrived - cache and refresh list
 		if m.semanticSearch != nil && msg.Results != nil {
@@ -1255,6 +1453,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case snapshotCacheValidatedMsg:
+		if msg.Err != nil || !msg.Stale || m.snapshotCache == nil {
+			return m, nil
+		}
+		// The cache matched on size/mtime but not on content: drop it and
+		// reload through the normal live-reload path. The watcher is already
+		// armed, so detach it while reloading to avoid a second waiter.
+		_ = os.Remove(m.snapshotCache.Path)
+		m.snapshotCache = nil
+		w := m.watcher
+		m.watcher = nil
+		updated, cmd := m.Update(FileChangedMsg{})
+		nm := updated.(Model)
+		if nm.backgroundWorker != nil {
+			// A slow reload auto-enabled background mode, which owns watching now.
+			if w != nil {
+				w.Stop()
+			}
+		} else {
+			nm.watcher = w
+		}
+		return nm, cmd
+
+	case sharedAnalysisMsg:
+		return m.handleSharedAnalysis(msg)
+
 	case Phase2ReadyMsg:
 		// Ignore stale Phase2 completions (from before a file reload)
 		if msg.Stats != m.analysis {
@@ -1319,6 +1543,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.blockerSet = blockerSet
 		m.unblocksMap = unblocksMap
 
+		// Persist the completed snapshot so the next cold start can skip analysis,
+		// and share it with other instances running on this project.
+		if cmd := m.saveSnapshotCache(); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+		if cmd := m.publishSharedAnalysis(); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
 		m.insightsPanel.SetTopPicks(triage.QuickRef.TopPicks)
 
 		// Set full recommendations with breakdown for priority radar (bv-93)
@@ -1432,12 +1665,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else if msg.Report != nil {
 			m.historyView = NewHistoryModel(msg.Report, m.theme)
 			m.historyView.SetSize(m.width, m.height-1)
+			if m.focused == focusInsights {
+				m.insightsPanel.SetBusFactor(m.computeBusFactor())
+			}
 			// Refresh detail pane if visible
 			if m.isSplitView || m.showDetails {
 				m.updateViewportContent()
 			}
 		}
 
+	case DeliveryMetricsLoadedMsg:
+		m.deliveryView.SetMetrics(msg.Metrics, msg.Error)
+
+	case BlameLoadedMsg:
+		m.historyView.SetBlame(msg.Path, msg.Blame, msg.Error)
+		if msg.Error == nil && msg.Path == m.historyView.blamePath {
+			m.statusMsg = ""
+		}
+
 	case AgentFileCheckMsg:
 		// AGENTS.md integration check (bv-i8dk)
 		if msg.ShouldPrompt && msg.FilePath != "" {
@@ -1492,6 +1737,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Swap snapshot pointer
 		m.snapshot = msg.Snapshot
+		m.hydratedDetails = nil
 		if m.backgroundWorker != nil {
 			latencyStart := msg.FileChangeAt
 			if latencyStart.IsZero() {
@@ -1725,6 +1971,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, BuildSemanticIndexCmd(m.issuesForAsync()))
 		}
 
+		// Re-evaluate saved searches for issues new to their top-N.
+		if !m.savedSearchChecking {
+			m.savedSearchChecking = true
+			cmds = append(cmds, CheckSavedSearchesCmd(m.issuesForAsync()))
+		}
+
 		// Reload sprints (bv-161)
 		if m.beadsPath != "" {
 			beadsDir := filepath.Dir(m.beadsPath)
@@ -1761,6 +2013,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.statusMsg = fmt.Sprintf("Reloaded %d issues", len(m.issues))
 		}
+		if !firstSnapshot {
+			m.statusMsg += refMoveSummary(msg.Change)
+		}
 		m.statusIsError = false
 
 		// Wait for Phase 2 if not ready
@@ -1846,6 +2101,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if profileRefresh {
 			loadStart = time.Now()
 		}
+		// Stamp the source before reading so a concurrent write can only make
+		// the next snapshot cache key stale, never wrong.
+		m.snapshotSources = snapshotSourceStamps(m.beadsPath)
+		m.snapshotCache = nil
 		loadedIssues, err := loader.LoadIssuesFromFileWithOptionsPooled(m.beadsPath, loader.ParseOptions{
 			WarningHandler: func(msg string) {
 				reloadWarnings = append(reloadWarnings, msg)
@@ -1906,7 +2165,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		cachedAnalyzer := analysis.NewCachedAnalyzer(newIssues, nil)
 		m.analyzer = cachedAnalyzer.Analyzer
-		m.analysis = cachedAnalyzer.AnalyzeAsync(context.Background())
+		if m.analysisCancel != nil {
+			m.analysisCancel()
+			m.analysisCancel = nil
+		}
+		m.analysisDataHash = cachedAnalyzer.DataHash()
+		if shared := lookupSharedAnalysis(m.sharedAnalysis, m.analysisDataHash, SnapshotConfigHash(m.activeRecipe), len(newIssues)); shared != nil {
+			m.analysis = shared.GraphStats()
+			m.sharedAnalysisKey = shared.DataHash + "|" + shared.ConfigHash
+		} else {
+			analysisCtx, cancel := context.WithCancel(context.Background())
+			m.analysisCancel = cancel
+			m.analysis = cachedAnalyzer.AnalyzeAsync(analysisCtx)
+		}
 		cacheHit := cachedAnalyzer.WasCacheHit()
 		if profileRefresh {
 			recordTiming("phase1_setup", time.Since(analysisStart))
@@ -2127,6 +2398,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, BuildSemanticIndexCmd(m.issuesForAsync()))
 		}
 
+		// Re-evaluate saved searches for issues new to their top-N.
+		if !m.savedSearchChecking {
+			m.savedSearchChecking = true
+			cmds = append(cmds, CheckSavedSearchesCmd(m.issuesForAsync()))
+		}
+
 		if cacheHit {
 			m.statusMsg = fmt.Sprintf("Reloaded %d issues (cached)", len(newIssues))
 		} else {
@@ -2207,6 +2484,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, WatchFileCmd(m.watcher))
 		}
 		cmds = append(cmds, WaitForPhase2Cmd(m.analysis))
+		if cmd := m.awaitSharedAnalysis(); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
 		return m, tea.Batch(cmds...)
 
 	case tea.KeyMsg:
@@ -2468,6 +2748,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Handle saved search picker overlay before global keys (esc/q/etc.)
+		if m.showSavedSearchPicker {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m.handleSavedSearchPickerKeys(msg)
+		}
+
+		// Handle facet picker overlay before global keys (esc/q/etc.)
+		if m.showFacetPicker {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m.handleFacetPickerKeys(msg), nil
+		}
+
+		// Handle command palette overlay before global keys (esc/q/etc.)
+		if m.showCommandPalette {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m.handleCommandPaletteKeys(msg)
+		}
+
 		// Handle recipe picker overlay before global keys (esc/q/etc.)
 		if m.showRecipePicker {
 			if msg.String() == "ctrl+c" {
@@ -2489,6 +2793,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Open the command palette (ctrl+p) from anywhere but text inputs
+		if msg.String() == "ctrl+p" && m.list.FilterState() != list.Filtering &&
+			!m.showLabelPicker && !m.showTutorial && m.focused != focusTimeTravelInput {
+			if m.showHelp {
+				m.showHelp = false
+				m.focused = m.restoreFocusFromHelp()
+			}
+			return m.openCommandPalette(), nil
+		}
+
 		// Handle help overlay toggle (? or F1)
 		if (msg.String() == "?" || msg.String() == "f1") && m.list.FilterState() != list.Filtering {
 			m.showHelp = !m.showHelp
@@ -2601,7 +2915,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, tea.Batch(cmds...)
 			case "alt+h", "alt+H":
 				m.statusIsError = false
-				m.semanticHybridPreset = nextHybridPreset(m.semanticHybridPreset)
+				m.semanticHybridPreset = nextHybridPreset(m.semanticHybridPreset, hasLearnedSearchPreset())
 				if m.semanticSearch != nil {
 					m.semanticSearch.SetHybridConfig(m.semanticHybridEnabled, m.semanticHybridPreset)
 					m.semanticSearch.ResetCache()
@@ -2653,6 +2967,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.list.Filter = list.DefaultFilter
 				m.statusMsg = "Fuzzy search enabled"
 				m.clearSemanticScores()
+				m.searchFacets = nil
+				m.syncFacetAllow()
 			}
 
 			// Refresh the current list filter results immediately.
@@ -2698,6 +3014,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// The history file tree and blame viewer own keys that are otherwise
+		// global view toggles (f would open the flow matrix, h close history).
+		if m.focused == focusHistory && msg.String() != "ctrl+c" {
+			switch {
+			case m.historyView.IsBlameOpen():
+				m = m.handleHistoryBlameKeys(msg)
+				return m, nil
+			case m.historyView.FileTreeHasFocus() && msg.String() == "b":
+				return m.openHistoryBlame()
+			case m.historyView.FileTreeHasFocus(), msg.String() == "f", msg.String() == "F":
+				m = m.handleHistoryKeys(msg)
+				return m, nil
+			}
+		}
+
 		// Handle keys when not filtering
 		if m.list.FilterState() != list.Filtering {
 			switch msg.String() {
@@ -2723,6 +3054,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.focused = focusList
 					return m, nil
 				}
+				if m.focused == focusDelivery {
+					m.focused = focusList
+					return m, nil
+				}
 				if m.isGraphView {
 					m.isGraphView = false
 					m.focused = focusList
@@ -2754,6 +3089,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.focused = focusList
 					return m, nil
 				}
+				if m.focused == focusDelivery {
+					m.focused = focusList
+					return m, nil
+				}
 				if m.isGraphView {
 					m.isGraphView = false
 					m.focused = focusList
@@ -2921,6 +3260,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						// Set full recommendations with breakdown for priority radar (bv-93)
 						dataHash := fmt.Sprintf("v%s@%s#%d", triage.Meta.Version, triage.Meta.GeneratedAt.Format("15:04:05"), triage.Meta.IssueCount)
 						m.insightsPanel.SetRecommendations(triage.Recommendations, dataHash)
+						m.insightsPanel.SetBusFactor(m.computeBusFactor())
 						panelHeight := m.height - 2
 						if panelHeight < 3 {
 							panelHeight = 3
@@ -3031,6 +3371,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.flowMatrix.SetSize(m.width, panelHeight)
 				return m, nil
 
+			case "D":
+				// Delivery metrics (DORA-style weekly charts)
+				if m.focused == focusDelivery {
+					m.focused = focusList
+					return m, nil
+				}
+				return m.openDeliveryView()
+
 			case "!":
 				// Toggle alerts panel (bv-168)
 				// Only show if there are active alerts
@@ -3060,6 +3408,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, nil
 
+			case "\"":
+				// Open saved search picker (.bv/searches.yaml)
+				return m.openSavedSearchPicker(), nil
+
 			case "w":
 				// Toggle repo picker overlay (workspace mode)
 				if !m.workspaceMode || len(m.availableRepos) == 0 {
@@ -3167,8 +3519,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case focusFlowMatrix:
 				m = m.handleFlowMatrixKeys(msg)
 
+			case focusDelivery:
+				m = m.handleDeliveryKeys(msg)
+
 			case focusList:
-				m = m.handleListKeys(msg)
+				if msg.String() == "M" {
+					var similarCmd tea.Cmd
+					m, similarCmd = m.showSimilarIssues()
+					cmds = append(cmds, similarCmd)
+				} else if msg.String() == "F" {
+					m = m.openFacetPicker()
+				} else {
+					if msg.String() == "enter" && !m.isSplitView {
+						if ev, ok := m.searchClickEvent(); ok {
+							cmds = append(cmds, RecordSearchClickCmd(ev))
+						}
+					}
+					m = m.handleListKeys(msg)
+				}
 
 			case focusDetail:
 				m.viewport, cmd = m.viewport.Update(msg)
@@ -3854,7 +4222,7 @@ func (m Model) handleHistoryKeys(msg tea.KeyMsg) Model {
 		// Toggle file tree panel (bv-190l)
 		m.historyView.ToggleFileTree()
 		if m.historyView.IsFileTreeVisible() {
-			m.statusMsg = "📁 File tree: j/k navigate, Enter select, Esc close"
+			m.statusMsg = "📁 File tree: j/k navigate, Enter select, b blame, Esc close"
 		} else {
 			m.statusMsg = "📁 File tree hidden"
 		}
@@ -4067,6 +4435,97 @@ func (m Model) handleRecipePickerKeys(msg tea.KeyMsg) Model {
 	return m
 }
 
+// openSavedSearchPicker (re)loads .bv/searches.yaml and shows the picker.
+func (m Model) openSavedSearchPicker() Model {
+	projectDir, err := os.Getwd()
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Saved searches: %v", err)
+		m.statusIsError = true
+		return m
+	}
+	searches, err := search.LoadSavedSearches(projectDir)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Saved searches: %v", err)
+		m.statusIsError = true
+		return m
+	}
+	if len(searches) == 0 {
+		m.statusMsg = "No saved searches (define them in .bv/searches.yaml)"
+		m.statusIsError = false
+		return m
+	}
+	m.savedSearchPicker = NewSavedSearchPickerModel(searches, m.savedSearchResults, m.theme)
+	m.savedSearchPicker.SetSize(m.width, m.height-1)
+	m.showSavedSearchPicker = true
+	m.focused = focusSavedSearchPicker
+	return m
+}
+
+// handleSavedSearchPickerKeys handles keyboard input when the saved search picker is focused
+func (m Model) handleSavedSearchPickerKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		m.savedSearchPicker.MoveDown()
+	case "k", "up":
+		m.savedSearchPicker.MoveUp()
+	case "esc", "q":
+		m.showSavedSearchPicker = false
+		m.focused = focusList
+	case "enter":
+		m.showSavedSearchPicker = false
+		m.focused = focusList
+		if selected := m.savedSearchPicker.SelectedSearch(); selected != nil {
+			return m.runSavedSearch(*selected)
+		}
+	}
+	return m, nil
+}
+
+// runSavedSearch applies a saved search's filters as an ad-hoc recipe and
+// runs its query through semantic search (hybrid with its preset when the
+// search asks for it). Custom weights are not supported in the TUI; the
+// preset is used instead.
+func (m Model) runSavedSearch(s search.SavedSearch) (Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	r := &recipe.Recipe{Name: "search:" + s.Name, Description: s.Description, Filters: s.Filters}
+	m.setActiveRecipe(r)
+	m.applyRecipe(r)
+
+	m.statusIsError = false
+	if m.semanticSearch == nil {
+		m.list.SetFilterText(s.Query)
+		m.statusMsg = fmt.Sprintf("Saved search %s (fuzzy: semantic search unavailable)", s.Name)
+		return m, nil
+	}
+
+	m.semanticSearchEnabled = true
+	m.list.Filter = m.semanticSearch.Filter
+	m.semanticHybridEnabled = s.SearchMode() == search.SearchModeHybrid
+	if s.Preset != "" {
+		m.semanticHybridPreset = s.Preset
+	}
+	m.semanticSearch.SetHybridConfig(m.semanticHybridEnabled, m.semanticHybridPreset)
+	m.semanticSearch.ResetCache()
+	m.clearSemanticScores()
+	if !m.semanticSearch.Snapshot().Ready && !m.semanticIndexBuilding {
+		m.semanticIndexBuilding = true
+		cmds = append(cmds, BuildSemanticIndexCmd(m.issuesForAsync()))
+	}
+	if m.semanticHybridEnabled && !m.semanticHybridReady && !m.semanticHybridBuilding {
+		m.semanticHybridBuilding = true
+		cmds = append(cmds, BuildHybridMetricsCmd(m.issuesForAsync()))
+	}
+
+	m.list.ResetSelected()
+	m.list.SetFilterText(s.Query)
+	if m.semanticSearch.Snapshot().Ready && !m.semanticHybridBuilding {
+		cmds = append(cmds, ComputeSemanticFilterCmd(m.semanticSearch, s.Query))
+	}
+	m.updateListDelegate()
+	m.statusMsg = fmt.Sprintf("Saved search: %s", s.Name)
+	return m, tea.Batch(cmds...)
+}
+
 // handleRepoPickerKeys handles keyboard input when repo picker is focused (workspace mode).
 func (m Model) handleRepoPickerKeys(msg tea.KeyMsg) Model {
 	switch msg.String() {
@@ -4195,6 +4654,9 @@ func (m Model) handleListKeys(msg tea.KeyMsg) Model {
 			m.viewport.GotoTop() // Reset scroll position for new issue
 			m.updateViewportContent()
 		}
+		if sel, ok := m.list.SelectedItem().(IssueItem); ok {
+			m.paletteRecent = notePaletteVisit(m.paletteRecent, "issue:"+sel.Issue.ID)
+		}
 	case "home":
 		m.list.Select(0)
 	case "G", "end":
@@ -4297,6 +4759,38 @@ func (m Model) handleListKeys(msg tea.KeyMsg) Model {
 	return m
 }
 
+// similarIssuesLimit is how many neighbours "more like this" lists.
+const similarIssuesLimit = 8
+
+// showSimilarIssues finds the selected issue's semantic nearest neighbours
+// for the detail pane, building the semantic index first if needed.
+func (m Model) showSimilarIssues() (Model, tea.Cmd) {
+	issueItem, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		m.statusMsg = "❌ No issue selected"
+		m.statusIsError = true
+		return m, nil
+	}
+	if m.semanticSearch == nil {
+		m.statusMsg = "Similar issues unavailable"
+		m.statusIsError = true
+		return m, nil
+	}
+	m.statusIsError = false
+	id := issueItem.Issue.ID
+	if !m.semanticSearch.Snapshot().Ready {
+		m.similarPendingID = id
+		m.statusMsg = "Similar issues: building semantic index…"
+		if m.semanticIndexBuilding {
+			return m, nil
+		}
+		m.semanticIndexBuilding = true
+		return m, BuildSemanticIndexCmd(m.issuesForAsync())
+	}
+	m.statusMsg = fmt.Sprintf("Finding issues similar to %s…", id)
+	return m, ComputeSimilarIssuesCmd(m.semanticSearch, id, similarIssuesLimit)
+}
+
 // handleTimeTravelInputKeys handles keyboard input for the time-travel revision prompt
 func (m Model) handleTimeTravelInputKeys(msg tea.KeyMsg) Model {
 	switch msg.String() {
@@ -4465,6 +4959,12 @@ func (m Model) View() string {
 		body = m.renderTimeTravelPrompt()
 	} else if m.showRecipePicker {
 		body = m.recipePicker.View()
+	} else if m.showCommandPalette {
+		body = m.commandPalette.View()
+	} else if m.showSavedSearchPicker {
+		body = m.savedSearchPicker.View()
+	} else if m.showFacetPicker {
+		body = m.facetPicker.View()
 	} else if m.showRepoPicker {
 		body = m.repoPicker.View()
 	} else if m.showLabelPicker {
@@ -4482,6 +4982,9 @@ func (m Model) View() string {
 	} else if m.focused == focusFlowMatrix {
 		m.flowMatrix.SetSize(m.width, m.height-1)
 		body = m.flowMatrix.View()
+	} else if m.focused == focusDelivery {
+		m.deliveryView.SetSize(m.width, m.height-1)
+		body = m.deliveryView.View()
 	} else if m.focused == focusTree {
 		// Hierarchical tree view (bv-gllx)
 		m.tree.SetSize(m.width, m.height-1)
@@ -4821,15 +5324,18 @@ func (m *Model) renderHelpOverlay() string {
 		{"h", "History view"},
 		{"a", "Actionable"},
 		{"f", "Flow matrix"},
+		{"D", "Delivery metrics"},
 		{"[", "Label dashboard"},
 		{"]", "Attention view"},
 	}
 
 	globalSection := []struct{ key, desc string }{
 		{"?", "This help"},
+		{"Ctrl+P", "Command palette"},
 		{";", "Shortcuts bar"},
 		{"!", "Alerts panel"},
 		{"'", "Recipes"},
+		{"\"", "Saved searches"},
 		{"w", "Repo picker"},
 		{"q", "Back / Quit"},
 		{"Ctrl+c", "Force quit"},
@@ -4838,6 +5344,7 @@ func (m *Model) renderHelpOverlay() string {
 	filterSection := []struct{ key, desc string }{
 		{"/", "Fuzzy search"},
 		{"Ctrl+S", "Semantic search"},
+		{"F", "Refine by facet"},
 		{"H", "Hybrid ranking"},
 		{"Alt+H", "Hybrid preset"},
 		{"o", "Open issues"},
@@ -5904,6 +6411,12 @@ func (m *Model) renderFooter() string {
 		keyHints = append(keyHints, "Press any key to close")
 	} else if m.showRecipePicker {
 		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" apply", keyStyle.Render("esc")+" cancel")
+	} else if m.showCommandPalette {
+		keyHints = append(keyHints, "type to search", keyStyle.Render("↑/↓")+" nav", keyStyle.Render("⏎")+" go", keyStyle.Render("esc")+" cancel")
+	} else if m.showSavedSearchPicker {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" run", keyStyle.Render("esc")+" cancel")
+	} else if m.showFacetPicker {
+		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("space")+" toggle", keyStyle.Render("c")+" clear", keyStyle.Render("⏎")+" done")
 	} else if m.showRepoPicker {
 		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("space")+" toggle", keyStyle.Render("⏎")+" apply", keyStyle.Render("esc")+" cancel")
 	} else if m.showLabelPicker {
@@ -5913,6 +6426,8 @@ func (m *Model) renderFooter() string {
 		keyHints = append(keyHints, keyStyle.Render("A")+" attention", keyStyle.Render("F")+" flow")
 	} else if m.focused == focusFlowMatrix {
 		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("tab")+" panel", keyStyle.Render("⏎")+" drill", keyStyle.Render("esc")+" back", keyStyle.Render("f")+" close")
+	} else if m.focused == focusDelivery {
+		keyHints = append(keyHints, keyStyle.Render("esc")+" back", keyStyle.Render("D")+" close")
 	} else if m.isGraphView {
 		keyHints = append(keyHints, keyStyle.Render("hjkl")+" nav", keyStyle.Render("H/L")+" scroll", keyStyle.Render("⏎")+" view", keyStyle.Render("g")+" list")
 	} else if m.isBoardView {
@@ -6057,8 +6572,11 @@ func (m *Model) renderFooter() string {
 	return lipgloss.JoinHorizontal(lipgloss.Bottom, parts...)
 }
 
-func nextHybridPreset(current search.PresetName) search.PresetName {
+func nextHybridPreset(current search.PresetName, learned bool) search.PresetName {
 	presets := search.ListPresets()
+	if learned {
+		presets = append(presets, search.PresetLearned)
+	}
 	if len(presets) == 0 {
 		return search.PresetDefault
 	}
@@ -6175,7 +6693,7 @@ func (m *Model) filteredIssuesForActiveView() []model.Issue {
 				filtered = append(filtered, issue)
 			}
 		}
-		sortIssuesByRecipe(filtered, m.analysis, m.activeRecipe)
+		sortIssuesByRecipe(filtered, m.analysis, m.activeRecipe, m.customEnums)
 		return filtered
 	}
 	for _, issue := range m.issues {
@@ -6473,6 +6991,11 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 			include = !isBlocked
 		}
 
+		// Apply custom field filters (.bv/schema.yaml)
+		if include && !r.Filters.MatchesCustom(&issue) {
+			include = false
+		}
+
 		if include {
 			item := IssueItem{
 				Issue:      issue,
@@ -6498,6 +7021,8 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 	// Apply sort
 	field := r.Sort.Field
 	descending := r.Sort.Direction == "desc"
+	// CompareCustom applies the direction itself so missing values stay last
+	_, customSort := model.CustomFieldName(field)
 	if field != "" {
 		compare := func(a, b model.Issue) int {
 			switch field {
@@ -6571,6 +7096,9 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 					return 0
 				}
 			default:
+				if name, ok := model.CustomFieldName(field); ok {
+					return model.CompareCustom(&a, &b, name, m.customEnums[name], descending)
+				}
 				switch {
 				case a.Priority < b.Priority:
 					return -1
@@ -6590,7 +7118,7 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 			if cmp == 0 {
 				return iItem.Issue.ID < jItem.Issue.ID
 			}
-			if descending {
+			if descending && !customSort {
 				return cmp > 0
 			}
 			return cmp < 0
@@ -6605,7 +7133,7 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 			if cmp == 0 {
 				return ii.ID < jj.ID
 			}
-			if descending {
+			if descending && !customSort {
 				return cmp > 0
 			}
 			return cmp < 0
@@ -6660,6 +7188,28 @@ func (m *Model) recalculateSplitPaneSizes() {
 	m.updateViewportContent()
 }
 
+// hydrateIssueDetails fills in long-form fields for issues from a slim
+// (graph-only) snapshot. Other issues are returned unchanged.
+func (m *Model) hydrateIssueDetails(issue model.Issue) model.Issue {
+	if m.snapshot == nil || m.snapshot.Details == nil {
+		return issue
+	}
+	full, ok := m.hydratedDetails[issue.ID]
+	if !ok {
+		loaded, err := m.snapshot.Details.LoadIssueDetails(issue.ID)
+		if err != nil {
+			return issue
+		}
+		if m.hydratedDetails == nil {
+			m.hydratedDetails = make(map[string]*model.Issue)
+		}
+		m.hydratedDetails[issue.ID] = loaded
+		full = loaded
+	}
+	loader.HydrateIssue(&issue, full)
+	return issue
+}
+
 func (m *Model) updateViewportContent() {
 	selectedItem := m.list.SelectedItem()
 	if selectedItem == nil {
@@ -6673,7 +7223,7 @@ func (m *Model) updateViewportContent() {
 		m.viewport.SetContent("Error: invalid item type")
 		return
 	}
-	item := issueItem.Issue
+	item := m.hydrateIssueDetails(issueItem.Issue)
 
 	var sb strings.Builder
 
@@ -6758,6 +7308,34 @@ func (m *Model) updateViewportContent() {
 		sb.WriteString("\n")
 	}
 
+	// More like this (M)
+	if m.similarIssueID == item.ID && len(m.similarIssues) > 0 {
+		sb.WriteString("### 🧭 Similar Issues\n")
+		for _, r := range m.similarIssues {
+			title := ""
+			if iss, ok := m.issueMap[r.IssueID]; ok {
+				title = iss.Title
+			}
+			sb.WriteString(fmt.Sprintf("- **%s** %s _(%.2f)_\n", r.IssueID, title, r.Score))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Saved searches this issue recently entered the top-N of
+	if names := m.savedSearchAlerts[item.ID]; len(names) > 0 {
+		sb.WriteString("### 🔔 New in Saved Searches\n")
+		for _, name := range names {
+			sb.WriteString(fmt.Sprintf("- **%s**\n", name))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Which part of a long issue the semantic query matched
+	if m.semanticSearchEnabled && issueItem.SearchMatch != nil && m.list.FilterState() != list.Unfiltered {
+		sb.WriteString(fmt.Sprintf("### 🔎 Search Match (%s)\n", issueItem.SearchMatch.Label()))
+		sb.WriteString("> " + issueItem.SearchMatch.Highlighted("**", "**") + "\n\n")
+	}
+
 	// Graph Analysis (using thread-safe accessors)
 	pr := m.analysis.GetPageRankScore(item.ID)
 	bt := m.analysis.GetBetweennessScore(item.ID)
@@ -6959,6 +7537,12 @@ func (m Model) FilteredIssues() []model.Issue {
 	return issues
 }
 
+// SetHistoryDB makes history views read through the persistent history
+// database (.bv/history.db) instead of walking git.
+func (m *Model) SetHistoryDB(enabled bool) {
+	m.historyDB = enabled
+}
+
 // EnableWorkspaceMode configures the model for workspace (multi-repo) view
 func (m *Model) EnableWorkspaceMode(info WorkspaceInfo) {
 	m.workspaceMode = info.Enabled
@@ -7002,7 +7586,7 @@ func (m *Model) enterHistoryView() {
 	}
 
 	// Load correlation data
-	correlator := correlation.NewCorrelator(cwd, m.beadsPath)
+	correlator := correlation.NewHistoryReporter(cwd, m.historyDB, m.beadsPath)
 	opts := correlation.CorrelatorOptions{
 		Limit: 500, // Reasonable limit for TUI performance
 	}
@@ -7150,6 +7734,12 @@ func (m Model) FocusState() string {
 		return "actionable"
 	case focusRecipePicker:
 		return "recipe_picker"
+	case focusSavedSearchPicker:
+		return "saved_search_picker"
+	case focusCommandPalette:
+		return "command_palette"
+	case focusFacetPicker:
+		return "facet_picker"
 	case focusRepoPicker:
 		return "repo_picker"
 	case focusHelp:
@@ -7170,6 +7760,8 @@ func (m Model) FocusState() string {
 		return "agent_prompt"
 	case focusFlowMatrix:
 		return "flow_matrix"
+	case focusDelivery:
+		return "delivery"
 	case focusTutorial:
 		return "tutorial"
 	case focusCassModal:
@@ -7832,6 +8424,12 @@ func (m *Model) openInEditor() {
 // Stop cleans up resources (file watcher, instance lock, background worker, etc.)
 // Should be called when the program exits
 func (m *Model) Stop() {
+	if m.sharedWaitCancel != nil {
+		m.sharedWaitCancel()
+	}
+	if m.analysisCancel != nil {
+		m.analysisCancel()
+	}
 	if m.backgroundWorker != nil {
 		m.backgroundWorker.Stop()
 	}
@@ -0,0 +1,188 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SavedSearchesCheckedMsg carries the result of evaluating .bv/searches.yaml.
+type SavedSearchesCheckedMsg struct {
+	Results []search.SavedSearchResult
+	Error   error
+}
+
+// CheckSavedSearchesCmd evaluates the project's saved searches against issues
+// and records which issues entered each search's top-N.
+func CheckSavedSearchesCmd(issues []model.Issue) tea.Cmd {
+	return func() tea.Msg {
+		projectDir, err := os.Getwd()
+		if err != nil {
+			return SavedSearchesCheckedMsg{Error: err}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		defer cancel()
+
+		now := time.Now()
+		results, err := search.CheckSavedSearches(ctx, projectDir, issues, now, now.Add(-search.DefaultSavedSearchAlertWindow))
+		return SavedSearchesCheckedMsg{Results: results, Error: err}
+	}
+}
+
+// SavedSearchPickerModel represents the saved search picker overlay
+type SavedSearchPickerModel struct {
+	searches      []search.SavedSearch
+	newCounts     map[string]int // search name -> issues that recently entered its top-N
+	selectedIndex int
+	width         int
+	height        int
+	theme         Theme
+}
+
+// NewSavedSearchPickerModel creates a new saved search picker
+func NewSavedSearchPickerModel(searches []search.SavedSearch, results []search.SavedSearchResult, theme Theme) SavedSearchPickerModel {
+	counts := make(map[string]int, len(results))
+	for _, res := range results {
+		counts[res.Name] = len(res.New)
+	}
+	return SavedSearchPickerModel{
+		searches:  searches,
+		newCounts: counts,
+		theme:     theme,
+	}
+}
+
+// SetSize updates the picker dimensions
+func (m *SavedSearchPickerModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// MoveUp moves selection up
+func (m *SavedSearchPickerModel) MoveUp() {
+	if m.selectedIndex > 0 {
+		m.selectedIndex--
+	}
+}
+
+// MoveDown moves selection down
+func (m *SavedSearchPickerModel) MoveDown() {
+	if m.selectedIndex < len(m.searches)-1 {
+		m.selectedIndex++
+	}
+}
+
+// SelectedSearch returns the currently selected saved search
+func (m *SavedSearchPickerModel) SelectedSearch() *search.SavedSearch {
+	if len(m.searches) == 0 || m.selectedIndex >= len(m.searches) {
+		return nil
+	}
+	return &m.searches[m.selectedIndex]
+}
+
+// SearchCount returns the number of saved searches
+func (m *SavedSearchPickerModel) SearchCount() int {
+	return len(m.searches)
+}
+
+// View renders the saved search picker overlay
+func (m *SavedSearchPickerModel) View() string {
+	if m.width == 0 {
+		m.width = 60
+	}
+	if m.height == 0 {
+		m.height = 20
+	}
+
+	t := m.theme
+
+	boxWidth := 56
+	if m.width < 66 {
+		boxWidth = m.width - 10
+	}
+	if boxWidth < 30 {
+		boxWidth = 30
+	}
+
+	var lines []string
+
+	titleStyle := t.Renderer.NewStyle().
+		Foreground(t.Primary).
+		Bold(true).
+		MarginBottom(1)
+	lines = append(lines, titleStyle.Render("Saved Searches"))
+	lines = append(lines, "")
+
+	descStyle := t.Renderer.NewStyle().
+		Foreground(t.Secondary).
+		Italic(true)
+
+	if len(m.searches) == 0 {
+		lines = append(lines, descStyle.Render("No searches in .bv/searches.yaml"))
+	}
+
+	for i, s := range m.searches {
+		isSelected := i == m.selectedIndex
+
+		nameStyle := t.Renderer.NewStyle()
+		if isSelected {
+			nameStyle = nameStyle.Foreground(t.Primary).Bold(true)
+		} else {
+			nameStyle = nameStyle.Foreground(t.Base.GetForeground())
+		}
+
+		prefix := "  "
+		if isSelected {
+			prefix = "▸ "
+		}
+		name := prefix + s.Name
+		if n := m.newCounts[s.Name]; n > 0 {
+			name += fmt.Sprintf("  🔔%d", n)
+		}
+		lines = append(lines, nameStyle.Render(name))
+
+		detail := fmt.Sprintf("%q", s.Query)
+		if s.SearchMode() == search.SearchModeHybrid {
+			detail += " · hybrid"
+		}
+		if s.Description != "" {
+			detail = s.Description + " — " + detail
+		}
+		lines = append(lines, descStyle.Render("    "+truncateRunesHelper(detail, boxWidth-8, "…")))
+
+		if i < len(m.searches)-1 {
+			lines = append(lines, "")
+		}
+	}
+
+	lines = append(lines, "")
+	footerStyle := t.Renderer.NewStyle().
+		Foreground(t.Secondary).
+		Italic(true)
+	lines = append(lines, footerStyle.Render("j/k: navigate • enter: run • esc: cancel"))
+
+	content := strings.Join(lines, "\n")
+
+	boxStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(boxWidth)
+
+	return lipgloss.Place(
+		m.width,
+		m.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		boxStyle.Render(content),
+	)
+}
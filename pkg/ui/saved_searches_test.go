@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestSavedSearchPickerSelectionAndView(t *testing.T) {
+	searches := []search.SavedSearch{
+		{Name: "auth", Query: "login token"},
+		{Name: "sync", Query: "sync conflict", Mode: search.SearchModeHybrid, Description: "Open sync bugs"},
+	}
+	results := []search.SavedSearchResult{
+		{SavedSearch: searches[1], New: []search.SavedSearchHit{{IssueID: "bv-9"}, {IssueID: "bv-10"}}},
+	}
+	m := NewSavedSearchPickerModel(searches, results, DefaultTheme(lipgloss.NewRenderer(nil)))
+	m.SetSize(80, 24)
+
+	if sel := m.SelectedSearch(); sel == nil || sel.Name != "auth" {
+		t.Fatalf("expected initial selection auth, got %+v", sel)
+	}
+	m.MoveDown()
+	m.MoveDown()
+	if sel := m.SelectedSearch(); sel == nil || sel.Name != "sync" {
+		t.Fatalf("expected selection to stop at sync, got %+v", sel)
+	}
+
+	out := m.View()
+	for _, want := range []string{"Saved Searches", "auth", "🔔2", "Open sync bugs", "hybrid"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("view missing %q:\n%s", want, out)
+		}
+	}
+
+	empty := NewSavedSearchPickerModel(nil, nil, DefaultTheme(lipgloss.NewRenderer(nil)))
+	if empty.SelectedSearch() != nil || !strings.Contains(empty.View(), ".bv/searches.yaml") {
+		t.Fatal("empty picker should select nothing and point at the config file")
+	}
+}
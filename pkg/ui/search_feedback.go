@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"os"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// searchFeedbackShownLimit caps how many visible results are logged per click.
+const searchFeedbackShownLimit = 20
+
+// RecordSearchClickCmd appends a search click to the project's feedback log.
+// Logging is best-effort and never surfaces errors in the UI.
+func RecordSearchClickCmd(ev search.SearchFeedbackEvent) tea.Cmd {
+	return func() tea.Msg {
+		if projectDir, err := os.Getwd(); err == nil {
+			_ = search.AppendSearchFeedback(projectDir, ev)
+		}
+		return nil
+	}
+}
+
+// searchClickEvent builds a feedback event when the selected issue is opened
+// from a semantic search result list, or returns false if no search is active.
+func (m Model) searchClickEvent() (search.SearchFeedbackEvent, bool) {
+	if !m.semanticSearchEnabled || m.list.FilterState() == list.Unfiltered {
+		return search.SearchFeedbackEvent{}, false
+	}
+	term := m.list.FilterInput.Value()
+	sel, ok := m.list.SelectedItem().(IssueItem)
+	if term == "" || !ok {
+		return search.SearchFeedbackEvent{}, false
+	}
+	var shown []string
+	for _, item := range m.list.VisibleItems() {
+		if len(shown) >= searchFeedbackShownLimit {
+			break
+		}
+		if it, ok := item.(IssueItem); ok {
+			shown = append(shown, it.Issue.ID)
+		}
+	}
+	mode := search.SearchModeText
+	if m.semanticHybridEnabled {
+		mode = search.SearchModeHybrid
+	}
+	return search.NewSearchFeedbackEvent(search.FeedbackSourceTUI, term, mode, shown, sel.Issue.ID, time.Now()), true
+}
+
+// hasLearnedSearchPreset reports whether the project has fitted a learned preset.
+func hasLearnedSearchPreset() bool {
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return false
+	}
+	lw, err := search.LoadLearnedWeights(projectDir)
+	return err == nil && lw != nil
+}
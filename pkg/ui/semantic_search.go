@@ -20,6 +20,8 @@ type semanticSearchSnapshot struct {
 	Ready    bool
 	Index    *search.VectorIndex
 	Embedder search.Embedder
+	Lexical  *search.LexicalIndex
+	Chunks   *search.ChunkSet
 	IDs      []string
 	Docs     map[string]string
 }
@@ -46,11 +48,19 @@ type metricsCacheHolder struct {
 	cache search.MetricsCache
 }
 
+// facetAllowHolder holds the IDs a facet refinement keeps; nil keeps all.
+type facetAllowHolder struct {
+	ids map[string]struct{}
+}
+
 // SemanticScore captures semantic/hybrid scoring details for a single issue.
 type SemanticScore struct {
 	Score      float64
 	TextScore  float64
 	Components map[string]float64
+	// Match is the best-matching field or comment chunk, set for the
+	// displayed results when the index holds per-chunk vectors.
+	Match *search.ChunkMatch
 }
 
 type SemanticSearch struct {
@@ -59,6 +69,7 @@ type SemanticSearch struct {
 	scores       atomic.Value // *semanticScoreCache
 	hybridConfig atomic.Value // semanticHybridConfig
 	metricsCache atomic.Value // *metricsCacheHolder
+	facetAllow   atomic.Value // *facetAllowHolder
 }
 
 func NewSemanticSearch() *SemanticSearch {
@@ -138,7 +149,8 @@ func (s *SemanticSearch) getHybridConfig() semanticHybridConfig {
 
 // SetHybridConfig updates hybrid scoring configuration.
 func (s *SemanticSearch) SetHybridConfig(enabled bool, preset search.PresetName) {
-	weights, err := search.GetPreset(preset)
+	projectDir, _ := os.Getwd()
+	weights, err := search.ResolvePreset(preset, projectDir)
 	if err != nil {
 		weights, _ = search.GetPreset(search.PresetDefault)
 		preset = search.PresetDefault
@@ -229,6 +241,22 @@ func (s *SemanticSearch) SetIndex(idx *search.VectorIndex, embedder search.Embed
 	s.snapshot.Store(snap)
 }
 
+// SetLexicalIndex installs the BM25 index fused with vector similarity.
+// Without one, results fall back to the short-query lexical boost.
+func (s *SemanticSearch) SetLexicalIndex(idx *search.LexicalIndex) {
+	snap := s.Snapshot()
+	snap.Lexical = idx
+	s.snapshot.Store(snap)
+}
+
+// SetChunks installs the chunk layout the vector index was built from; when
+// set, an issue scores as the max similarity over its chunks.
+func (s *SemanticSearch) SetChunks(cs *search.ChunkSet) {
+	snap := s.Snapshot()
+	snap.Chunks = cs
+	s.snapshot.Store(snap)
+}
+
 func (s *SemanticSearch) SetIDs(ids []string) {
 	snap := s.Snapshot()
 	cp := make([]string, len(ids))
@@ -273,7 +301,7 @@ func (s *SemanticSearch) Filter(term string, targets []string) []list.Rank {
 	// Check cache first - return immediately if we have cached results
 	c := s.getCache()
 	if cached, ok := c.results[term]; ok {
-		return cached
+		return s.restrictToFacets(cached, snap.IDs)
 	}
 
 	// No cached results - mark as pending and return fuzzy results
@@ -286,7 +314,47 @@ func (s *SemanticSearch) Filter(term string, targets []string) []list.Rank {
 	s.cache.Store(newCache)
 
 	// Return fuzzy results immediately so UI stays responsive
-	return list.DefaultFilter(term, targets)
+	return s.restrictToFacets(list.DefaultFilter(term, targets), snap.IDs)
+}
+
+// SetFacetAllow restricts filter results to ids (a facet refinement of the
+// semantic results); nil lifts the restriction.
+func (s *SemanticSearch) SetFacetAllow(ids map[string]struct{}) {
+	s.facetAllow.Store(&facetAllowHolder{ids: ids})
+}
+
+func (s *SemanticSearch) restrictToFacets(ranks []list.Rank, ids []string) []list.Rank {
+	v := s.facetAllow.Load()
+	if v == nil || v.(*facetAllowHolder).ids == nil {
+		return ranks
+	}
+	allow := v.(*facetAllowHolder).ids
+	out := make([]list.Rank, 0, len(ranks))
+	for _, r := range ranks {
+		if r.Index < len(ids) {
+			if _, ok := allow[ids[r.Index]]; ok {
+				out = append(out, r)
+			}
+		}
+	}
+	return out
+}
+
+// ResultIDs returns the issue IDs of the cached semantic results for term,
+// best first and before any facet refinement.
+func (s *SemanticSearch) ResultIDs(term string) ([]string, bool) {
+	cached, ok := s.getCache().results[term]
+	if !ok {
+		return nil, false
+	}
+	ids := s.Snapshot().IDs
+	out := make([]string, 0, len(cached))
+	for _, r := range cached {
+		if r.Index < len(ids) {
+			out = append(out, ids[r.Index])
+		}
+	}
+	return out, true
 }
 
 // ComputeSemanticResults computes semantic similarity results synchronously.
@@ -320,13 +388,58 @@ func (s *SemanticSearch) ComputeSemanticResults(term string) []list.Rank {
 		id        string
 		score     float64
 		textScore float64
-		hasVector bool
+		hasVector bool // ranked by at least one retriever
+	}
+
+	limit := 75
+
+	// Large indexes answer from the ANN graph instead of scoring every item;
+	// indexed items outside the candidate set rank below all candidates.
+	var annScores map[string]float64
+	bestChunk := make(map[string]string)
+	if snap.Index.Approximate() {
+		fetch := search.HybridCandidateLimit(limit, len(snap.IDs), term)
+		var results []search.SearchResult
+		var err error
+		if snap.Chunks != nil {
+			results, bestChunk, err = search.SearchChunks(snap.Index, q, fetch)
+		} else {
+			results, err = snap.Index.SearchTopK(q, fetch)
+		}
+		if err == nil {
+			annScores = make(map[string]float64, len(results))
+			for _, r := range results {
+				annScores[r.IssueID] = r.Score
+			}
+		}
 	}
 
 	scoredItems := make([]scored, len(snap.IDs))
 	scoreMap := make(map[string]SemanticScore, len(snap.IDs))
 	for i, id := range snap.IDs {
-		entry, ok := snap.Index.Get(id)
+		var sim float64
+		var ok bool
+		switch {
+		case annScores != nil:
+			// The ANN search already scored its candidates and picked their
+			// best chunks; only index membership is needed here.
+			if snap.Chunks != nil {
+				ok = snap.Chunks.Indexed(snap.Index, id)
+			} else {
+				_, ok = snap.Index.Get(id)
+			}
+		case snap.Chunks != nil:
+			var key string
+			sim, key, ok = snap.Chunks.MaxSim(snap.Index, q, id)
+			if ok {
+				bestChunk[id] = key
+			}
+		default:
+			var entry search.VectorEntry
+			if entry, ok = snap.Index.Get(id); ok {
+				sim = dotFloat32(q, entry.Vector)
+			}
+		}
 		textScore := 0.0
 		score := 0.0
 		if !ok {
@@ -334,9 +447,15 @@ func (s *SemanticSearch) ComputeSemanticResults(term string) []list.Rank {
 			// Assign lowest possible score to keep it in the list but at the bottom.
 			score = -2.0
 			textScore = score
+		} else if annScores != nil {
+			textScore = -1.0
+			if sim, hit := annScores[id]; hit {
+				textScore = sim
+			}
+			score = textScore
 		} else {
-			textScore = dotFloat32(q, entry.Vector)
-			if doc, ok := snap.Docs[id]; ok {
+			textScore = sim
+			if doc, ok := snap.Docs[id]; ok && snap.Lexical == nil {
 				textScore += search.ShortQueryLexicalBoost(term, doc)
 			}
 			score = textScore
@@ -354,7 +473,60 @@ func (s *SemanticSearch) ComputeSemanticResults(term string) []list.Rank {
 		}
 	}
 
-	limit := 75
+	if snap.Lexical != nil {
+		// Fuse the vector ranking with BM25 by rank; the fused score becomes the
+		// text relevance that hybrid scoring builds on.
+		fetch := search.HybridCandidateLimit(limit, len(scoredItems), term)
+		vectorRanked := make([]search.SearchResult, 0, len(scoredItems))
+		visible := make(map[string]struct{}, len(scoredItems))
+		for _, item := range scoredItems {
+			visible[item.id] = struct{}{}
+			if item.hasVector {
+				vectorRanked = append(vectorRanked, search.SearchResult{IssueID: item.id, Score: item.textScore})
+			}
+		}
+		sort.Slice(vectorRanked, func(i, j int) bool {
+			if vectorRanked[i].Score == vectorRanked[j].Score {
+				return vectorRanked[i].IssueID < vectorRanked[j].IssueID
+			}
+			return vectorRanked[i].Score > vectorRanked[j].Score
+		})
+		if len(vectorRanked) > fetch {
+			vectorRanked = vectorRanked[:fetch]
+		}
+		// Fetch only the candidate pool, as the robot path does, widening
+		// it when list filters hide too many of the top hits.
+		var lexicalRanked []search.SearchResult
+		for retrieve := fetch; ; retrieve *= 2 {
+			results := snap.Lexical.Search(term, retrieve)
+			lexicalRanked = make([]search.SearchResult, 0, fetch)
+			for _, r := range results {
+				if _, ok := visible[r.IssueID]; ok {
+					lexicalRanked = append(lexicalRanked, r)
+					if len(lexicalRanked) == fetch {
+						break
+					}
+				}
+			}
+			if len(lexicalRanked) == fetch || len(results) < retrieve || retrieve >= snap.Lexical.Size() {
+				break
+			}
+		}
+
+		fused := make(map[string]float64, fetch)
+		for _, r := range search.FuseRRF(search.DefaultRRFK, vectorRanked, lexicalRanked) {
+			fused[r.IssueID] = r.Score
+		}
+		for i := range scoredItems {
+			item := &scoredItems[i]
+			if f, ok := fused[item.id]; ok {
+				item.textScore, item.score, item.hasVector = f, f, true
+			} else if item.hasVector {
+				item.textScore, item.score = 0, 0
+			}
+			scoreMap[item.id] = SemanticScore{Score: item.score, TextScore: item.textScore}
+		}
+	}
 	if scorer != nil {
 		candidateLimit := search.HybridCandidateLimit(limit, len(scoredItems), term)
 		var candidateIDs map[string]struct{}
@@ -414,15 +586,49 @@ func (s *SemanticSearch) ComputeSemanticResults(term string) []list.Rank {
 	out := make([]list.Rank, 0, len(scoredItems))
 	for _, it := range scoredItems {
 		out = append(out, list.Rank{Index: it.index})
+		if snap.Chunks == nil {
+			continue
+		}
+		if m := snap.Chunks.MatchFor(it.id, bestChunk[it.id], term); m != nil {
+			sc := scoreMap[it.id]
+			sc.Match = m
+			scoreMap[it.id] = sc
+		}
 	}
 	s.SetScores(term, scoreMap)
 	return out
 }
 
+// Similar returns the k issues nearest to issueID ("more like this").
+func (s *SemanticSearch) Similar(issueID string, k int) ([]search.SearchResult, error) {
+	snap := s.Snapshot()
+	if !snap.Ready || snap.Index == nil {
+		return nil, fmt.Errorf("semantic index not ready")
+	}
+	return search.SimilarIssues(snap.Index, snap.Chunks, issueID, k)
+}
+
+// SimilarIssuesMsg carries the nearest neighbours of one issue.
+type SimilarIssuesMsg struct {
+	IssueID string
+	Results []search.SearchResult
+	Error   error
+}
+
+// ComputeSimilarIssuesCmd finds an issue's nearest neighbours asynchronously.
+func ComputeSimilarIssuesCmd(s *SemanticSearch, issueID string, k int) tea.Cmd {
+	return func() tea.Msg {
+		results, err := s.Similar(issueID, k)
+		return SimilarIssuesMsg{IssueID: issueID, Results: results, Error: err}
+	}
+}
+
 // SemanticIndexReadyMsg is emitted when the semantic index build/update completes.
 type SemanticIndexReadyMsg struct {
 	Embedder  search.Embedder
 	Index     *search.VectorIndex
+	Lexical   *search.LexicalIndex
+	Chunks    *search.ChunkSet
 	IndexPath string
 	Loaded    bool
 	Stats     search.IndexSyncStats
@@ -479,43 +685,27 @@ func BuildHybridMetricsCmd(issues []model.Issue) tea.Cmd {
 // BuildSemanticIndexCmd builds or updates the semantic index for the given issues.
 func BuildSemanticIndexCmd(issues []model.Issue) tea.Cmd {
 	return func() tea.Msg {
-		cfg := search.EmbeddingConfigFromEnv()
-		embedder, err := search.NewEmbedderFromConfig(cfg)
-		if err != nil {
-			return SemanticIndexReadyMsg{Error: err}
-		}
-
 		projectDir, err := os.Getwd()
 		if err != nil {
 			return SemanticIndexReadyMsg{Error: err}
 		}
 
-		indexPath := search.DefaultIndexPath(projectDir, cfg)
-		idx, loaded, err := search.LoadOrNewVectorIndex(indexPath, embedder.Dim())
-		if err != nil {
-			return SemanticIndexReadyMsg{Error: err}
-		}
-
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		docs := search.DocumentsFromIssues(issues)
-		stats, err := search.SyncVectorIndex(ctx, idx, embedder, docs, 64)
+		pi, err := search.OpenProjectIndex(ctx, projectDir, issues, nil)
 		if err != nil {
 			return SemanticIndexReadyMsg{Error: err}
 		}
-		if !loaded || stats.Changed() {
-			if err := idx.Save(indexPath); err != nil {
-				return SemanticIndexReadyMsg{Error: fmt.Errorf("save semantic index: %w", err)}
-			}
-		}
 
 		return SemanticIndexReadyMsg{
-			Embedder:  embedder,
-			Index:     idx,
-			IndexPath: indexPath,
-			Loaded:    loaded,
-			Stats:     stats,
+			Embedder:  pi.Embedder,
+			Index:     pi.Index,
+			Lexical:   search.NewLexicalIndex(issues),
+			Chunks:    pi.Chunks,
+			IndexPath: pi.Path,
+			Loaded:    pi.Loaded,
+			Stats:     pi.Stats,
 		}
 	}
 }
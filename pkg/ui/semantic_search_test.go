@@ -756,6 +756,27 @@ func BenchmarkSemanticSearchFilter(b *testing.B) {
 	}
 }
 
+func TestSemanticSearchSimilar(t *testing.T) {
+	ss := NewSemanticSearch()
+	if _, err := ss.Similar("a", 3); err == nil {
+		t.Fatal("Similar should fail before the index is ready")
+	}
+
+	idx := search.NewVectorIndex(3)
+	idx.Upsert("a", search.ContentHash{}, []float32{1, 0, 0})
+	idx.Upsert("b", search.ContentHash{}, []float32{0.8, 0.6, 0})
+	idx.Upsert("c", search.ContentHash{}, []float32{0, 0, 1})
+	ss.SetIndex(idx, &mockEmbedder{dim: 3})
+
+	msg := ComputeSimilarIssuesCmd(ss, "a", 1)().(SimilarIssuesMsg)
+	if msg.Error != nil {
+		t.Fatalf("unexpected error: %v", msg.Error)
+	}
+	if msg.IssueID != "a" || len(msg.Results) != 1 || msg.Results[0].IssueID != "b" {
+		t.Fatalf("Similar(a) = %+v", msg)
+	}
+}
+
 func BenchmarkDotFloat32(b *testing.B) {
 	a := make([]float32, 384)
 	bVec := make([]float32, 384)
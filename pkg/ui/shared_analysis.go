@@ -0,0 +1,228 @@
+// Package ui provides the terminal user interface for beads_viewer.
+// This file implements warm analysis sharing between concurrent bv instances
+// on the same project: the primary instance (the holder of the instance lock)
+// publishes Phase 2 metrics and triage to .bv/shared/, and other instances
+// reuse them instead of recomputing the same analysis.
+package ui
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/instance"
+)
+
+// sharedAnalysisWaitTimeout bounds how long a secondary instance waits for the
+// primary to publish a data version before giving up (it keeps computing
+// locally in the meantime, so this only limits the background wait).
+const sharedAnalysisWaitTimeout = 10 * time.Minute
+
+// SharedAnalysisEnabled reports whether analysis is shared between instances.
+// Set BV_SHARED_ANALYSIS=0 to disable it.
+func SharedAnalysisEnabled() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("BV_SHARED_ANALYSIS"))) {
+	case "0", "false", "no", "off":
+		return false
+	}
+	return true
+}
+
+// openSharedAnalysis opens the shared analysis store for a beads file, or
+// returns nil when sharing is disabled or unavailable.
+func openSharedAnalysis(beadsPath string) *instance.SharedAnalysis {
+	if beadsPath == "" || !SharedAnalysisEnabled() {
+		return nil
+	}
+	store, err := instance.OpenSharedAnalysis(filepath.Dir(beadsPath))
+	if err != nil {
+		return nil
+	}
+	return store
+}
+
+// encodeSharedAnalysis serializes stats and triage in the snapshot cache body
+// layout (without issues: every instance parses those itself).
+func encodeSharedAnalysis(stats *analysis.GraphStats, body snapshotCacheBody) ([]byte, error) {
+	var statsBuf bytes.Buffer
+	if err := analysis.EncodeGraphStats(&statsBuf, stats); err != nil {
+		return nil, err
+	}
+	body.Issues = nil
+	body.SearchIndexPath = ""
+	body.Stats = statsBuf.Bytes()
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(body); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeSharedAnalysis decodes a published payload into a SnapshotCache so it
+// can be consumed exactly like an on-disk snapshot cache hit.
+func decodeSharedAnalysis(payload []byte, dataHash, configHash string, issueCount int) (*SnapshotCache, error) {
+	var body snapshotCacheBody
+	if err := gob.NewDecoder(bytes.NewReader(payload)).Decode(&body); err != nil {
+		return nil, err
+	}
+	stats, err := analysis.DecodeGraphStats(bytes.NewReader(body.Stats))
+	if err != nil {
+		return nil, err
+	}
+	body.Stats = nil
+	return &SnapshotCache{
+		DataHash:   dataHash,
+		ConfigHash: configHash,
+		IssueCount: issueCount,
+		CreatedAt:  time.Now(),
+		body:       body,
+		stats:      stats,
+	}, nil
+}
+
+// lookupSharedAnalysis returns the published analysis for the given data and
+// config, or nil when none is available.
+func lookupSharedAnalysis(store *instance.SharedAnalysis, dataHash, configHash string, issueCount int) *SnapshotCache {
+	if store == nil || dataHash == "" {
+		return nil
+	}
+	payload, err := store.Lookup(dataHash, configHash)
+	if err != nil {
+		return nil
+	}
+	cache, err := decodeSharedAnalysis(payload, dataHash, configHash, issueCount)
+	if err != nil {
+		return nil
+	}
+	return cache
+}
+
+// sharedAnalysisMsg delivers analysis published by the primary instance, or
+// reports why waiting for it ended.
+type sharedAnalysisMsg struct {
+	DataHash   string
+	ConfigHash string
+	Cache      *SnapshotCache
+	Err        error
+}
+
+// awaitSharedAnalysisCmd waits for the lock holder to publish analysis for
+// dataHash. It returns nil when this instance is the primary (or has no
+// lock), when the holder is gone, or when stats are already complete.
+func awaitSharedAnalysisCmd(store *instance.SharedAnalysis, lock *instance.Lock, stats *analysis.GraphStats, dataHash, configHash string, issueCount int) (tea.Cmd, context.CancelFunc) {
+	if store == nil || lock == nil || lock.IsFirstInstance() || !lock.HolderAlive() || dataHash == "" {
+		return nil, nil
+	}
+	if stats == nil || stats.IsPhase2Ready() {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), sharedAnalysisWaitTimeout)
+	holderPID := lock.HolderPID()
+	return func() tea.Msg {
+		defer cancel()
+		payload, err := store.Wait(ctx, holderPID, dataHash, configHash)
+		if err != nil {
+			return sharedAnalysisMsg{DataHash: dataHash, ConfigHash: configHash, Err: err}
+		}
+		cache, err := decodeSharedAnalysis(payload, dataHash, configHash, issueCount)
+		return sharedAnalysisMsg{DataHash: dataHash, ConfigHash: configHash, Cache: cache, Err: err}
+	}, cancel
+}
+
+// sharedAnalysisEligible reports whether the model's current data is a plain
+// load of beadsPath that other instances would compute identically.
+func (m *Model) sharedAnalysisEligible() bool {
+	return m.sharedAnalysis != nil && !m.workspaceMode && !m.timeTravelMode && m.snapshot == nil && m.analysisDataHash != ""
+}
+
+// awaitSharedAnalysis starts waiting for the primary to publish the current
+// data version, cancelling any wait for a previous version.
+func (m *Model) awaitSharedAnalysis() tea.Cmd {
+	if m.sharedWaitCancel != nil {
+		m.sharedWaitCancel()
+		m.sharedWaitCancel = nil
+	}
+	if !m.sharedAnalysisEligible() {
+		return nil
+	}
+	cmd, cancel := awaitSharedAnalysisCmd(m.sharedAnalysis, m.instanceLock, m.analysis, m.analysisDataHash, SnapshotConfigHash(m.activeRecipe), len(m.issues))
+	m.sharedWaitCancel = cancel
+	return cmd
+}
+
+// publishSharedAnalysis returns a command publishing the completed analysis
+// for other instances, or nil unless this is the primary instance with new
+// Phase 2 results. A secondary whose primary has exited takes over here.
+func (m *Model) publishSharedAnalysis() tea.Cmd {
+	if !m.sharedAnalysisEligible() || m.instanceLock == nil {
+		return nil
+	}
+	if m.analysis == nil || !m.analysis.IsPhase2Ready() {
+		return nil
+	}
+	configHash := SnapshotConfigHash(m.activeRecipe)
+	key := m.analysisDataHash + "|" + configHash
+	if key == m.sharedAnalysisKey {
+		return nil
+	}
+	if !m.instanceLock.IsFirstInstance() && !m.instanceLock.TryPromote() {
+		return nil
+	}
+	m.sharedAnalysisKey = key
+
+	store, stats, dataHash := m.sharedAnalysis, m.analysis, m.analysisDataHash
+	body := snapshotCacheBody{
+		TriageScores:  m.triageScores,
+		TriageReasons: m.triageReasons,
+		QuickWinSet:   m.quickWinSet,
+		BlockerSet:    m.blockerSet,
+		UnblocksMap:   m.unblocksMap,
+	}
+	return func() tea.Msg {
+		// Publishing is an optimization for other instances; failures are silent.
+		if payload, err := encodeSharedAnalysis(stats, body); err == nil {
+			_ = store.Publish(dataHash, configHash, payload)
+		}
+		return nil
+	}
+}
+
+// handleSharedAnalysis adopts analysis published by the primary if it still
+// matches the current data and local Phase 2 hasn't finished first.
+func (m Model) handleSharedAnalysis(msg sharedAnalysisMsg) (Model, tea.Cmd) {
+	if errors.Is(msg.Err, instance.ErrHolderGone) {
+		m.sharedWaitCancel = nil
+		if m.instanceLock != nil && m.instanceLock.TryPromote() {
+			m.statusMsg = "Primary bv exited; analysis is now computed and shared here"
+			m.statusIsError = false
+		}
+		return m, nil
+	}
+	if msg.Err != nil || msg.Cache == nil {
+		return m, nil
+	}
+	if msg.DataHash != m.analysisDataHash || msg.ConfigHash != SnapshotConfigHash(m.activeRecipe) {
+		return m, nil
+	}
+	if m.analysis == nil || m.analysis.IsPhase2Ready() {
+		return m, nil
+	}
+
+	m.sharedWaitCancel = nil
+	if m.analysisCancel != nil {
+		m.analysisCancel()
+		m.analysisCancel = nil
+	}
+	m.analysis = msg.Cache.GraphStats()
+	m.sharedAnalysisKey = msg.DataHash + "|" + msg.ConfigHash
+	return m, WaitForPhase2Cmd(m.analysis)
+}
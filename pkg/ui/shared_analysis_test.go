@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/instance"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+func TestSharedAnalysis_SecondaryReusesPrimaryAnalysis(t *testing.T) {
+	t.Setenv("BV_BACKGROUND_MODE", "0")
+	t.Setenv("BV_SNAPSHOT_CACHE", "0")
+	beadsPath := writeSnapshotCacheProject(t, snapshotCacheFixture)
+	issues, err := loader.LoadIssuesFromFile(beadsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	primary := NewModel(issues, nil, beadsPath)
+	defer primary.Stop()
+	if primary.instanceLock == nil || !primary.instanceLock.IsFirstInstance() {
+		t.Fatal("first model should hold the instance lock")
+	}
+	if primary.sharedWaitCmd != nil {
+		t.Error("primary should not wait for shared analysis")
+	}
+	primary.analysis.WaitForPhase2()
+	primary.triageScores = map[string]float64{"S-1": 0.5}
+
+	cmd := primary.publishSharedAnalysis()
+	if cmd == nil {
+		t.Fatal("primary should publish after Phase 2")
+	}
+	cmd()
+	if primary.publishSharedAnalysis() != nil {
+		t.Error("unchanged analysis should not be republished")
+	}
+
+	issues2, _ := loader.LoadIssuesFromFile(beadsPath)
+	secondary := NewModel(issues2, nil, beadsPath)
+	defer secondary.Stop()
+	if secondary.instanceLock.IsFirstInstance() {
+		t.Fatal("second model should be a secondary instance")
+	}
+	if !secondary.analysis.IsPhase2Ready() || secondary.analysis == primary.analysis {
+		t.Error("secondary should start from the published analysis")
+	}
+	if secondary.triageScores["S-1"] != 0.5 {
+		t.Errorf("secondary should reuse published triage, got %v", secondary.triageScores)
+	}
+	if secondary.publishSharedAnalysis() != nil {
+		t.Error("secondary must not publish while the primary is alive")
+	}
+}
+
+func TestSharedAnalysis_SecondaryWaitsForPrimary(t *testing.T) {
+	t.Setenv("BV_BACKGROUND_MODE", "0")
+	t.Setenv("BV_SNAPSHOT_CACHE", "0")
+	beadsPath := writeSnapshotCacheProject(t, snapshotCacheFixture)
+	issues, err := loader.LoadIssuesFromFile(beadsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	primary := NewModel(issues, nil, beadsPath)
+	defer primary.Stop()
+	primary.analysis.WaitForPhase2()
+
+	dataHash := analysis.ComputeDataHash(issues)
+	configHash := SnapshotConfigHash(nil)
+	secondaryLock, err := instance.NewLock(filepath.Dir(primary.instanceLock.Path()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer secondaryLock.Release()
+
+	// Stats whose Phase 2 hasn't completed stand in for a secondary still computing.
+	pending := &analysis.GraphStats{}
+	cmd, cancel := awaitSharedAnalysisCmd(primary.sharedAnalysis, secondaryLock, pending, dataHash, configHash, len(issues))
+	if cmd == nil {
+		t.Fatal("secondary should wait for the primary")
+	}
+	defer cancel()
+
+	primary.publishSharedAnalysis()()
+	msg := cmd().(sharedAnalysisMsg)
+	if msg.Err != nil || msg.Cache == nil || !msg.Cache.GraphStats().IsPhase2Ready() {
+		t.Fatalf("expected published analysis, got %+v", msg)
+	}
+	if msg.DataHash != dataHash || msg.ConfigHash != configHash {
+		t.Errorf("unexpected key %s|%s", msg.DataHash, msg.ConfigHash)
+	}
+
+	// A secondary still computing adopts the published stats.
+	secondary := primary
+	secondary.analysis = pending
+	secondary.analysisCancel = nil
+	adopted, next := secondary.handleSharedAnalysis(msg)
+	if adopted.analysis != msg.Cache.GraphStats() || next == nil {
+		t.Error("secondary should adopt published analysis and wait for Phase 2 on it")
+	}
+}
+
+func TestSharedAnalysis_HolderGonePromotes(t *testing.T) {
+	t.Setenv("BV_BACKGROUND_MODE", "0")
+	t.Setenv("BV_SNAPSHOT_CACHE", "0")
+	beadsPath := writeSnapshotCacheProject(t, snapshotCacheFixture)
+	issues, err := loader.LoadIssuesFromFile(beadsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	primary := NewModel(issues, nil, beadsPath)
+	issues2, _ := loader.LoadIssuesFromFile(beadsPath)
+	secondary := NewModel(issues2, nil, beadsPath)
+	defer secondary.Stop()
+	primary.Stop()
+
+	updated, _ := secondary.handleSharedAnalysis(sharedAnalysisMsg{Err: instance.ErrHolderGone})
+	if !updated.instanceLock.IsFirstInstance() {
+		t.Fatal("secondary should take over when the primary is gone")
+	}
+
+	// Stale results for other data are ignored.
+	before := updated.analysis
+	updated, cmd := updated.handleSharedAnalysis(sharedAnalysisMsg{DataHash: "other", ConfigHash: SnapshotConfigHash(nil), Cache: &SnapshotCache{}})
+	if cmd != nil || updated.analysis != before {
+		t.Error("shared analysis for different data must be ignored")
+	}
+}
+
+func TestSharedAnalysisEnabled(t *testing.T) {
+	t.Setenv("BV_SHARED_ANALYSIS", "")
+	if !SharedAnalysisEnabled() {
+		t.Error("sharing should be enabled by default")
+	}
+	t.Setenv("BV_SHARED_ANALYSIS", "0")
+	if SharedAnalysisEnabled() || openSharedAnalysis("/tmp/x/.beads/beads.jsonl") != nil {
+		t.Error("BV_SHARED_ANALYSIS=0 should disable sharing")
+	}
+}
@@ -105,12 +105,14 @@ func (s *ShortcutsSidebar) allSections() []shortcutSection {
 			items: []shortcutItem{
 				{"a", "Actionable"},
 				{"b", "Board"},
+				{"D", "Delivery"},
 				{"g", "Graph"},
 				{"h", "History"},
 				{"i", "Insights"},
 				{"?", "Help"},
 				{";", "This sidebar"},
 				{"p", "Priority hints"},
+				{"^P", "Command palette"},
 			},
 		},
 		{
@@ -184,8 +186,11 @@ func (s *ShortcutsSidebar) allSections() []shortcutSection {
 				{"C", "Copy"},
 				{"O", "Open in $EDITOR"},
 				{"'", "Recipe picker"},
+				{"\"", "Saved searches"},
 				{"U", "Self-update"},
 				{"V", "Cass sessions"},
+				{"M", "Similar issues"},
+				{"F", "Search facets"},
 			},
 		},
 	}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
 )
@@ -183,6 +184,9 @@ type DataSnapshot struct {
 	TruncatedCount int
 	// LargeDatasetWarning is a short, user-facing warning to show in the footer.
 	LargeDatasetWarning string
+	// Details loads long-form fields (description, notes, comments) on demand.
+	// It is non-nil only when Issues were loaded with graph fields only (huge tier).
+	Details loader.DetailLoader
 	// LoadWarningCount is the number of non-fatal parse warnings encountered while loading.
 	// In TUI mode, warnings must not be printed to stderr during render.
 	LoadWarningCount int
@@ -272,6 +276,7 @@ type SnapshotBuilder struct {
 	analyzer *analysis.Analyzer
 	analysis *analysis.GraphStats
 	recipe   *recipe.Recipe
+	enums    map[string][]string
 	cfg      snapshotBuildConfig
 
 	prevSnapshot *DataSnapshot
@@ -299,6 +304,13 @@ func (b *SnapshotBuilder) WithRecipe(r *recipe.Recipe) *SnapshotBuilder {
 	return b
 }
 
+// WithCustomEnums sets the declared value order of custom enum fields, used
+// when the recipe sorts by one of them.
+func (b *SnapshotBuilder) WithCustomEnums(enums map[string][]string) *SnapshotBuilder {
+	b.enums = enums
+	return b
+}
+
 func (b *SnapshotBuilder) WithBuildConfig(cfg snapshotBuildConfig) *SnapshotBuilder {
 	b.cfg = cfg
 	return b
@@ -397,7 +409,7 @@ func (b *SnapshotBuilder) Build() *DataSnapshot {
 				viewIssues = append(viewIssues, issues[i])
 			}
 		}
-		sortIssuesByRecipe(viewIssues, graphStats, b.recipe)
+		sortIssuesByRecipe(viewIssues, graphStats, b.recipe, b.enums)
 	}
 
 	// Build list items with graph scores (respecting recipe filtering/sorting when present).
@@ -692,10 +704,11 @@ func issueMatchesRecipe(issue model.Issue, issueMap map[string]*model.Issue, r *
 		}
 	}
 
-	return true
+	// Custom field filters (.bv/schema.yaml)
+	return r.Filters.MatchesCustom(&issue)
 }
 
-func sortIssuesByRecipe(issues []model.Issue, stats *analysis.GraphStats, r *recipe.Recipe) {
+func sortIssuesByRecipe(issues []model.Issue, stats *analysis.GraphStats, r *recipe.Recipe, enums map[string][]string) {
 	if r == nil || r.Sort.Field == "" {
 		return
 	}
@@ -767,6 +780,13 @@ func sortIssuesByRecipe(issues []model.Issue, stats *analysis.GraphStats, r *rec
 				cmp = 1
 			}
 		default:
+			if name, ok := model.CustomFieldName(field); ok {
+				// Already directed: missing values stay last either way
+				if cmp := model.CompareCustom(&ii, &jj, name, enums[name], desc); cmp != 0 {
+					return cmp < 0
+				}
+				return ii.ID < jj.ID
+			}
 			switch {
 			case ii.Priority < jj.Priority:
 				cmp = -1
@@ -0,0 +1,460 @@
+// Package ui provides the terminal user interface for beads_viewer.
+// This file implements the persistent on-disk snapshot cache that makes cold
+// TUI startup fast: parsed issues, Phase 2 graph metrics and triage results are
+// written to .bv/snapshot-cache.bin once analysis completes, and reloaded on the
+// next launch when the source files are unchanged.
+package ui
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
+)
+
+const (
+	// snapshotCacheVersion must be bumped whenever the encoded layout or the
+	// semantics of cached fields change; older files are treated as misses.
+	snapshotCacheVersion  = 2
+	snapshotCacheFileName = "snapshot-cache.bin"
+)
+
+var snapshotCacheMagic = [4]byte{'B', 'V', 'S', 'C'}
+
+func init() {
+	// Issue.Custom holds dates from .bv/schema.yaml as time.Time interface values.
+	gob.Register(time.Time{})
+}
+
+// ErrSnapshotCacheMiss is returned when no usable snapshot cache exists for the
+// current source files and configuration.
+var ErrSnapshotCacheMiss = errors.New("snapshot cache miss")
+
+// snapshotSourceStamp fingerprints a source file cheaply (without reading it).
+type snapshotSourceStamp struct {
+	Path    string
+	Size    int64
+	ModTime int64
+}
+
+// snapshotCacheHeader is decoded first so key checks never pay for the body.
+type snapshotCacheHeader struct {
+	Version    int
+	Sources    []snapshotSourceStamp
+	DataHash   string
+	ConfigHash string
+	IssueCount int
+	CreatedAt  time.Time
+}
+
+type snapshotCacheBody struct {
+	Issues        []model.Issue
+	Stats         []byte // analysis.EncodeGraphStats
+	TriageScores  map[string]float64
+	TriageReasons map[string]analysis.TriageReasons
+	QuickWinSet   map[string]bool
+	BlockerSet    map[string]bool
+	UnblocksMap   map[string][]string
+	// SearchIndexPath points at the semantic index last synced for this data.
+	SearchIndexPath string
+}
+
+// SnapshotCache is a decoded on-disk TUI snapshot.
+type SnapshotCache struct {
+	Path         string
+	DataHash     string
+	ConfigHash   string
+	IssueCount   int
+	CreatedAt    time.Time
+	LoadDuration time.Duration
+
+	sources []snapshotSourceStamp
+	body    snapshotCacheBody
+	stats   *analysis.GraphStats
+}
+
+// SnapshotCacheEnabled reports whether the snapshot cache is enabled.
+// Set BV_SNAPSHOT_CACHE=0 to disable it.
+func SnapshotCacheEnabled() bool {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("BV_SNAPSHOT_CACHE"))) {
+	case "0", "false", "no", "off":
+		return false
+	}
+	return true
+}
+
+// SnapshotCachePath returns the cache location for a beads file.
+// beadsPath is like /path/to/project/.beads/beads.jsonl; the cache lives in
+// /path/to/project/.bv/ alongside other project-local bv state.
+func SnapshotCachePath(beadsPath string) string {
+	if beadsPath == "" {
+		return ""
+	}
+	projectDir := filepath.Dir(filepath.Dir(beadsPath))
+	return filepath.Join(projectDir, ".bv", snapshotCacheFileName)
+}
+
+// SnapshotConfigHash fingerprints the view configuration that affects cached
+// results (currently the active recipe).
+func SnapshotConfigHash(r *recipe.Recipe) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("v%d|%s", snapshotCacheVersion, recipeFingerprint(r))))
+	return fmt.Sprintf("%x", sum[:8])
+}
+
+// snapshotSourceStamps fingerprints the beads file and the SQLite database next
+// to it (if any), since either may be the load source.
+func snapshotSourceStamps(beadsPath string) []snapshotSourceStamp {
+	if beadsPath == "" {
+		return nil
+	}
+	paths := []string{
+		beadsPath,
+		filepath.Join(filepath.Dir(beadsPath), "beads.db"),
+		loader.SchemaPath(filepath.Dir(filepath.Dir(beadsPath))), // field mapping changes parsed issues
+	}
+	stamps := make([]snapshotSourceStamp, 0, len(paths))
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			continue
+		}
+		stamps = append(stamps, snapshotSourceStamp{Path: p, Size: info.Size(), ModTime: info.ModTime().UnixNano()})
+	}
+	return stamps
+}
+
+func sameSourceStamps(a, b []snapshotSourceStamp) bool {
+	if len(a) == 0 || len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func readSnapshotCacheHeader(r io.Reader, dec **gob.Decoder) (snapshotCacheHeader, error) {
+	var magic [4]byte
+	var version uint32
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return snapshotCacheHeader{}, err
+	}
+	if err := binary.Read(r, binary.LittleEndian, &version); err != nil {
+		return snapshotCacheHeader{}, err
+	}
+	if magic != snapshotCacheMagic || version != snapshotCacheVersion {
+		return snapshotCacheHeader{}, ErrSnapshotCacheMiss
+	}
+	*dec = gob.NewDecoder(r)
+	var hdr snapshotCacheHeader
+	if err := (*dec).Decode(&hdr); err != nil {
+		return snapshotCacheHeader{}, err
+	}
+	if hdr.Version != snapshotCacheVersion {
+		return snapshotCacheHeader{}, ErrSnapshotCacheMiss
+	}
+	return hdr, nil
+}
+
+// OpenSnapshotCache loads the cache for beadsPath if the source files are
+// unchanged since it was written and it was built with configHash.
+// Any mismatch or decode failure is reported as ErrSnapshotCacheMiss (wrapped).
+func OpenSnapshotCache(beadsPath, configHash string) (*SnapshotCache, error) {
+	start := time.Now()
+	path := SnapshotCachePath(beadsPath)
+	if path == "" {
+		return nil, ErrSnapshotCacheMiss
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: no cache yet", ErrSnapshotCacheMiss)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrSnapshotCacheMiss, err)
+	}
+	defer f.Close()
+
+	var dec *gob.Decoder
+	hdr, err := readSnapshotCacheHeader(bufio.NewReaderSize(f, 1<<20), &dec)
+	if err != nil {
+		if errors.Is(err, ErrSnapshotCacheMiss) {
+			return nil, fmt.Errorf("%w: version mismatch", ErrSnapshotCacheMiss)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrSnapshotCacheMiss, err)
+	}
+	if hdr.ConfigHash != configHash {
+		return nil, fmt.Errorf("%w: config changed", ErrSnapshotCacheMiss)
+	}
+	if !sameSourceStamps(hdr.Sources, snapshotSourceStamps(beadsPath)) {
+		return nil, fmt.Errorf("%w: source changed", ErrSnapshotCacheMiss)
+	}
+
+	var body snapshotCacheBody
+	if err := dec.Decode(&body); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSnapshotCacheMiss, err)
+	}
+	stats, err := analysis.DecodeGraphStats(bytes.NewReader(body.Stats))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSnapshotCacheMiss, err)
+	}
+	body.Stats = nil
+
+	return &SnapshotCache{
+		Path:         path,
+		DataHash:     hdr.DataHash,
+		ConfigHash:   hdr.ConfigHash,
+		IssueCount:   hdr.IssueCount,
+		CreatedAt:    hdr.CreatedAt,
+		LoadDuration: time.Since(start),
+		sources:      hdr.Sources,
+		body:         body,
+		stats:        stats,
+	}, nil
+}
+
+// Issues returns the cached issues. The slice is owned by the caller.
+func (c *SnapshotCache) Issues() []model.Issue {
+	if c == nil {
+		return nil
+	}
+	out := make([]model.Issue, len(c.body.Issues))
+	copy(out, c.body.Issues)
+	return out
+}
+
+// GraphStats returns the cached Phase 2-complete graph metrics.
+func (c *SnapshotCache) GraphStats() *analysis.GraphStats {
+	if c == nil {
+		return nil
+	}
+	return c.stats
+}
+
+func (c *SnapshotCache) searchIndexPath() string {
+	if c == nil {
+		return ""
+	}
+	return c.body.SearchIndexPath
+}
+
+// matches reports whether the cached analysis applies to issues under configHash.
+func (c *SnapshotCache) matches(issues []model.Issue, configHash string) bool {
+	if c == nil || c.stats == nil || c.ConfigHash != configHash || c.IssueCount != len(issues) {
+		return false
+	}
+	return analysis.ComputeDataHash(issues) == c.DataHash
+}
+
+// snapshotCacheEntry is everything needed to write a cache file.
+type snapshotCacheEntry struct {
+	Sources         []snapshotSourceStamp
+	ConfigHash      string
+	Issues          []model.Issue
+	Stats           *analysis.GraphStats
+	TriageScores    map[string]float64
+	TriageReasons   map[string]analysis.TriageReasons
+	QuickWinSet     map[string]bool
+	BlockerSet      map[string]bool
+	UnblocksMap     map[string][]string
+	SearchIndexPath string
+}
+
+// saveSnapshotCache writes entry to path atomically. It is a no-op when the file
+// already holds the same data under the same key, so repeated Phase 2
+// completions for unchanged data don't rewrite the cache.
+func saveSnapshotCache(path string, entry snapshotCacheEntry) error {
+	if path == "" || len(entry.Sources) == 0 {
+		return nil
+	}
+	dataHash := analysis.ComputeDataHash(entry.Issues)
+
+	if f, err := os.Open(path); err == nil {
+		var dec *gob.Decoder
+		hdr, err := readSnapshotCacheHeader(bufio.NewReader(f), &dec)
+		f.Close()
+		if err == nil && hdr.DataHash == dataHash && hdr.ConfigHash == entry.ConfigHash && sameSourceStamps(hdr.Sources, entry.Sources) {
+			return nil
+		}
+	}
+
+	var stats bytes.Buffer
+	if err := analysis.EncodeGraphStats(&stats, entry.Stats); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), snapshotCacheFileName+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op after a successful rename
+
+	w := bufio.NewWriterSize(tmp, 1<<20)
+	if _, err := w.Write(snapshotCacheMagic[:]); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, uint32(snapshotCacheVersion)); err != nil {
+		tmp.Close()
+		return err
+	}
+	enc := gob.NewEncoder(w)
+	hdr := snapshotCacheHeader{
+		Version:    snapshotCacheVersion,
+		Sources:    entry.Sources,
+		DataHash:   dataHash,
+		ConfigHash: entry.ConfigHash,
+		IssueCount: len(entry.Issues),
+		CreatedAt:  time.Now().UTC(),
+	}
+	body := snapshotCacheBody{
+		Issues:          gobSafeIssues(entry.Issues),
+		Stats:           stats.Bytes(),
+		TriageScores:    entry.TriageScores,
+		TriageReasons:   entry.TriageReasons,
+		QuickWinSet:     entry.QuickWinSet,
+		BlockerSet:      entry.BlockerSet,
+		UnblocksMap:     entry.UnblocksMap,
+		SearchIndexPath: entry.SearchIndexPath,
+	}
+	if err := enc.Encode(hdr); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := enc.Encode(body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// gobSafeIssues drops nil dependency/comment entries, which gob cannot encode.
+// The input is returned unchanged (no copy) in the common case.
+func gobSafeIssues(issues []model.Issue) []model.Issue {
+	var out []model.Issue
+	for i := range issues {
+		if !hasNilRefs(&issues[i]) {
+			continue
+		}
+		if out == nil {
+			out = make([]model.Issue, len(issues))
+			copy(out, issues)
+		}
+		deps := make([]*model.Dependency, 0, len(out[i].Dependencies))
+		for _, d := range out[i].Dependencies {
+			if d != nil {
+				deps = append(deps, d)
+			}
+		}
+		comments := make([]*model.Comment, 0, len(out[i].Comments))
+		for _, c := range out[i].Comments {
+			if c != nil {
+				comments = append(comments, c)
+			}
+		}
+		out[i].Dependencies = deps
+		out[i].Comments = comments
+	}
+	if out == nil {
+		return issues
+	}
+	return out
+}
+
+func hasNilRefs(issue *model.Issue) bool {
+	for _, d := range issue.Dependencies {
+		if d == nil {
+			return true
+		}
+	}
+	for _, c := range issue.Comments {
+		if c == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// saveSnapshotCacheCmd persists the cache off the UI thread. Failures are
+// silent: the cache is an optimization and the next launch simply misses.
+func saveSnapshotCacheCmd(path string, entry snapshotCacheEntry) tea.Cmd {
+	return func() tea.Msg {
+		_ = saveSnapshotCache(path, entry)
+		return nil
+	}
+}
+
+// snapshotCacheValidatedMsg reports the result of re-reading the source after a
+// startup that used cached issues.
+type snapshotCacheValidatedMsg struct {
+	Stale bool
+	Err   error
+}
+
+// validateSnapshotCacheCmd re-parses the beads file in the background and
+// compares its data hash against the cache the UI started from. The stat-based
+// key catches almost every change; this guards against edits that preserve
+// size and mtime (and against clocks with coarse mtime resolution).
+func validateSnapshotCacheCmd(beadsPath, dataHash string) tea.Cmd {
+	return func() tea.Msg {
+		issues, err := loader.LoadIssuesFromFileWithOptions(beadsPath, loader.ParseOptions{
+			WarningHandler: func(string) {},
+			BufferSize:     envMaxLineSizeBytes(),
+		})
+		if err != nil {
+			return snapshotCacheValidatedMsg{Err: err}
+		}
+		return snapshotCacheValidatedMsg{Stale: analysis.ComputeDataHash(issues) != dataHash}
+	}
+}
+
+// saveSnapshotCache returns a command that persists the model's current data,
+// or nil when the data isn't a plain load of beadsPath (workspace, time-travel,
+// or background-worker snapshots) or the cache is disabled.
+func (m *Model) saveSnapshotCache() tea.Cmd {
+	if !SnapshotCacheEnabled() || m.beadsPath == "" || m.workspaceMode || m.timeTravelMode || m.snapshot != nil {
+		return nil
+	}
+	if m.analysis == nil || !m.analysis.IsPhase2Ready() || len(m.snapshotSources) == 0 {
+		return nil
+	}
+	return saveSnapshotCacheCmd(SnapshotCachePath(m.beadsPath), snapshotCacheEntry{
+		Sources:         m.snapshotSources,
+		ConfigHash:      SnapshotConfigHash(m.activeRecipe),
+		Issues:          m.issuesForAsync(),
+		Stats:           m.analysis,
+		TriageScores:    m.triageScores,
+		TriageReasons:   m.triageReasons,
+		QuickWinSet:     m.quickWinSet,
+		BlockerSet:      m.blockerSet,
+		UnblocksMap:     m.unblocksMap,
+		SearchIndexPath: m.semanticIndexPath,
+	})
+}
@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
+)
+
+const snapshotCacheFixture = `{"id":"S-1","title":"Root","status":"open","priority":1,"issue_type":"task"}
+{"id":"S-2","title":"Child","description":"details","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"S-2","depends_on_id":"S-1","type":"blocks"}]}
+{"id":"S-3","title":"Done","status":"closed","priority":3,"issue_type":"bug"}
+`
+
+// writeSnapshotCacheProject creates <dir>/.beads/beads.jsonl and returns its path.
+func writeSnapshotCacheProject(t *testing.T, content string) string {
+	t.Helper()
+	beadsDir := filepath.Join(t.TempDir(), ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(beadsDir, "beads.jsonl")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// saveTestSnapshotCache writes a cache for the file's current contents.
+func saveTestSnapshotCache(t *testing.T, beadsPath string, r *recipe.Recipe) []model.Issue {
+	t.Helper()
+	issues, err := loader.LoadIssuesFromFile(beadsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats := analysis.NewAnalyzer(issues).AnalyzeAsync(t.Context())
+	stats.WaitForPhase2()
+	err = saveSnapshotCache(SnapshotCachePath(beadsPath), snapshotCacheEntry{
+		Sources:      snapshotSourceStamps(beadsPath),
+		ConfigHash:   SnapshotConfigHash(r),
+		Issues:       issues,
+		Stats:        stats,
+		TriageScores: map[string]float64{"S-1": 0.75},
+		QuickWinSet:  map[string]bool{"S-1": true},
+	})
+	if err != nil {
+		t.Fatalf("saveSnapshotCache: %v", err)
+	}
+	return issues
+}
+
+func TestSnapshotCache_RoundTrip(t *testing.T) {
+	beadsPath := writeSnapshotCacheProject(t, snapshotCacheFixture)
+	issues := saveTestSnapshotCache(t, beadsPath, nil)
+
+	cache, err := OpenSnapshotCache(beadsPath, SnapshotConfigHash(nil))
+	if err != nil {
+		t.Fatalf("OpenSnapshotCache: %v", err)
+	}
+	if cache.Path != filepath.Join(filepath.Dir(filepath.Dir(beadsPath)), ".bv", snapshotCacheFileName) {
+		t.Errorf("unexpected cache path %s", cache.Path)
+	}
+	got := cache.Issues()
+	if len(got) != len(issues) || cache.IssueCount != len(issues) {
+		t.Fatalf("expected %d issues, got %d", len(issues), len(got))
+	}
+	if analysis.ComputeDataHash(got) != cache.DataHash {
+		t.Error("data hash should match the cached issues")
+	}
+	if !cache.GraphStats().IsPhase2Ready() {
+		t.Error("cached stats should be Phase 2 ready")
+	}
+	if cache.body.TriageScores["S-1"] != 0.75 || !cache.body.QuickWinSet["S-1"] {
+		t.Errorf("triage data not restored: %+v", cache.body)
+	}
+}
+
+func TestSnapshotCache_Misses(t *testing.T) {
+	beadsPath := writeSnapshotCacheProject(t, snapshotCacheFixture)
+
+	if _, err := OpenSnapshotCache(beadsPath, SnapshotConfigHash(nil)); !errors.Is(err, ErrSnapshotCacheMiss) {
+		t.Fatalf("expected miss without a cache file, got %v", err)
+	}
+
+	saveTestSnapshotCache(t, beadsPath, nil)
+	if _, err := OpenSnapshotCache(beadsPath, SnapshotConfigHash(&recipe.Recipe{Name: "other"})); !errors.Is(err, ErrSnapshotCacheMiss) {
+		t.Errorf("expected miss for a different config, got %v", err)
+	}
+
+	later := time.Now().Add(2 * time.Second)
+	if err := os.Chtimes(beadsPath, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenSnapshotCache(beadsPath, SnapshotConfigHash(nil)); !errors.Is(err, ErrSnapshotCacheMiss) {
+		t.Errorf("expected miss after the source changed, got %v", err)
+	}
+
+	if err := os.WriteFile(SnapshotCachePath(beadsPath), []byte("garbage"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenSnapshotCache(beadsPath, SnapshotConfigHash(nil)); !errors.Is(err, ErrSnapshotCacheMiss) {
+		t.Errorf("expected miss for a corrupt cache, got %v", err)
+	}
+}
+
+func TestNewModelWithSnapshotCache_ReusesCachedAnalysis(t *testing.T) {
+	t.Setenv("BV_BACKGROUND_MODE", "0")
+	beadsPath := writeSnapshotCacheProject(t, snapshotCacheFixture)
+	saveTestSnapshotCache(t, beadsPath, nil)
+
+	cache, err := OpenSnapshotCache(beadsPath, SnapshotConfigHash(nil))
+	if err != nil {
+		t.Fatalf("OpenSnapshotCache: %v", err)
+	}
+	m := NewModelWithSnapshotCache(cache.Issues(), nil, beadsPath, cache)
+	defer m.Stop()
+
+	if m.analysis != cache.GraphStats() {
+		t.Error("model should reuse cached graph stats")
+	}
+	if m.triageScores["S-1"] != 0.75 {
+		t.Errorf("model should reuse cached triage scores, got %v", m.triageScores)
+	}
+
+	// A recipe changes the config hash, so the cached analysis is not reused.
+	m2 := NewModelWithSnapshotCache(cache.Issues(), &recipe.Recipe{Name: "other"}, beadsPath, cache)
+	defer m2.Stop()
+	if m2.analysis == cache.GraphStats() {
+		t.Error("cached analysis must not be reused under a different config")
+	}
+}
+
+func TestModel_SavesSnapshotCacheOnPhase2(t *testing.T) {
+	t.Setenv("BV_BACKGROUND_MODE", "0")
+	beadsPath := writeSnapshotCacheProject(t, snapshotCacheFixture)
+	issues, err := loader.LoadIssuesFromFile(beadsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(issues, nil, beadsPath)
+	defer m.Stop()
+	m.analysis.WaitForPhase2()
+
+	cmd := m.saveSnapshotCache()
+	if cmd == nil {
+		t.Fatal("expected a save command after Phase 2")
+	}
+	cmd()
+
+	cache, err := OpenSnapshotCache(beadsPath, SnapshotConfigHash(nil))
+	if err != nil {
+		t.Fatalf("expected cache after save: %v", err)
+	}
+	if len(cache.Issues()) != len(issues) {
+		t.Errorf("expected %d cached issues, got %d", len(issues), len(cache.Issues()))
+	}
+
+	t.Setenv("BV_SNAPSHOT_CACHE", "0")
+	if m.saveSnapshotCache() != nil {
+		t.Error("BV_SNAPSHOT_CACHE=0 should disable saving")
+	}
+}
+
+func TestValidateSnapshotCacheCmd(t *testing.T) {
+	beadsPath := writeSnapshotCacheProject(t, snapshotCacheFixture)
+	issues, err := loader.LoadIssuesFromFile(beadsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := validateSnapshotCacheCmd(beadsPath, analysis.ComputeDataHash(issues))().(snapshotCacheValidatedMsg)
+	if msg.Err != nil || msg.Stale {
+		t.Errorf("expected fresh cache, got %+v", msg)
+	}
+	msg = validateSnapshotCacheCmd(beadsPath, "not-the-hash")().(snapshotCacheValidatedMsg)
+	if !msg.Stale {
+		t.Error("expected stale result for a mismatched data hash")
+	}
+}
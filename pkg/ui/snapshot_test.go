@@ -603,7 +603,7 @@ func TestSortIssuesByRecipe_PriorityAsc(t *testing.T) {
 	}
 
 	r := &recipe.Recipe{Sort: recipe.SortConfig{Field: "priority", Direction: "asc"}}
-	sortIssuesByRecipe(issues, nil, r)
+	sortIssuesByRecipe(issues, nil, r, nil)
 
 	if issues[0].ID != "Z" || issues[1].ID != "A" {
 		t.Fatalf("expected Z then A, got %s then %s", issues[0].ID, issues[1].ID)
@@ -617,7 +617,7 @@ func TestSortIssuesByRecipe_PriorityDesc_TieBreakByID(t *testing.T) {
 	}
 
 	r := &recipe.Recipe{Sort: recipe.SortConfig{Field: "priority", Direction: "desc"}}
-	sortIssuesByRecipe(issues, nil, r)
+	sortIssuesByRecipe(issues, nil, r, nil)
 
 	if issues[0].ID != "A" || issues[1].ID != "B" {
 		t.Fatalf("expected A then B, got %s then %s", issues[0].ID, issues[1].ID)
@@ -526,6 +526,7 @@ func structuredTutorialPages() []StructuredTutorialPage {
 					{Key: "j / k", Desc: "Navigate timeline"},
 					{Key: "v", Desc: "Toggle Bead/Git mode"},
 					{Key: "f", Desc: "Toggle file tree panel"},
+					{Key: "b", Desc: "Blame selected file by bead"},
 					{Key: "Tab", Desc: "Cycle focus"},
 				}},
 				Spacer{Lines: 1},
@@ -0,0 +1,354 @@
+package watcher
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNotGitRepo is returned when no git directory can be found.
+var ErrNotGitRepo = errors.New("not a git repository")
+
+// FindGitDir walks up from start looking for a .git directory (or a .git file
+// pointing at one, as used by worktrees and submodules).
+func FindGitDir(start string) (string, error) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return "", err
+	}
+	for {
+		candidate := filepath.Join(dir, ".git")
+		info, err := os.Stat(candidate)
+		if err == nil {
+			if info.IsDir() {
+				return candidate, nil
+			}
+			data, err := os.ReadFile(candidate)
+			if err != nil {
+				return "", err
+			}
+			line := strings.TrimSpace(string(data))
+			if target, ok := strings.CutPrefix(line, "gitdir:"); ok {
+				target = strings.TrimSpace(target)
+				if !filepath.IsAbs(target) {
+					target = filepath.Join(dir, target)
+				}
+				return filepath.Clean(target), nil
+			}
+			return "", fmt.Errorf("%w: malformed %s", ErrNotGitRepo, candidate)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ErrNotGitRepo
+		}
+		dir = parent
+	}
+}
+
+// gitCommonDir returns the directory holding shared refs. For linked worktrees
+// this differs from the per-worktree git dir (which only holds HEAD).
+func gitCommonDir(gitDir string) string {
+	data, err := os.ReadFile(filepath.Join(gitDir, "commondir"))
+	if err != nil {
+		return gitDir
+	}
+	common := strings.TrimSpace(string(data))
+	if !filepath.IsAbs(common) {
+		common = filepath.Join(gitDir, common)
+	}
+	return filepath.Clean(common)
+}
+
+// ResolveRef reads a ref ("HEAD", "refs/heads/main", ...) directly from the
+// git directory without invoking git. Symbolic refs are followed; packed refs
+// are consulted when no loose ref exists. An unborn or missing ref resolves
+// to "" without error.
+func ResolveRef(gitDir, ref string) (string, error) {
+	commonDir := gitCommonDir(gitDir)
+	for depth := 0; depth < 5; depth++ {
+		dir := commonDir
+		if ref == "HEAD" {
+			dir = gitDir
+		}
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(ref)))
+		if err == nil {
+			value := strings.TrimSpace(string(data))
+			if target, ok := strings.CutPrefix(value, "ref:"); ok {
+				ref = strings.TrimSpace(target)
+				continue
+			}
+			return value, nil
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+		return lookupPackedRef(commonDir, ref)
+	}
+	return "", fmt.Errorf("symbolic ref loop resolving %s", ref)
+}
+
+func lookupPackedRef(commonDir, ref string) (string, error) {
+	f, err := os.Open(filepath.Join(commonDir, "packed-refs"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || line[0] == '#' || line[0] == '^' {
+			continue
+		}
+		hash, name, ok := strings.Cut(line, " ")
+		if ok && name == ref {
+			return hash, nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+// GitRefSource reports when any of a set of git refs moves. It watches the
+// files a ref update touches (HEAD, the loose ref files and packed-refs) and
+// re-resolves the refs whenever one of them changes, so a `git pull` that
+// rewrites the beads file and moves refs is seen as ref changes too. When
+// HEAD switches branches, the watch follows it to the new branch's file.
+type GitRefSource struct {
+	gitDir string
+	refs   []string
+	opts   []WatcherOption
+
+	mu      sync.Mutex
+	last    map[string]string
+	files   map[string]*FileSource // Keyed by watched path
+	emit    func(Change)
+	started bool
+}
+
+// NewGitRefSource watches refs in the repository containing repoDir.
+// If refs is empty, only HEAD is watched.
+func NewGitRefSource(repoDir string, refs []string, opts ...WatcherOption) (*GitRefSource, error) {
+	gitDir, err := FindGitDir(repoDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(refs) == 0 {
+		refs = []string{"HEAD"}
+	}
+	return &GitRefSource{gitDir: gitDir, refs: refs, opts: opts}, nil
+}
+
+// Name returns "git:<refs>".
+func (s *GitRefSource) Name() string {
+	return "git:" + strings.Join(s.refs, ",")
+}
+
+// GitDir returns the resolved git directory.
+func (s *GitRefSource) GitDir() string {
+	return s.gitDir
+}
+
+// Refs returns the watched ref names.
+func (s *GitRefSource) Refs() []string {
+	return append([]string(nil), s.refs...)
+}
+
+// watchedFiles lists the files whose modification can move a watched ref.
+func (s *GitRefSource) watchedFiles() []string {
+	commonDir := gitCommonDir(s.gitDir)
+	seen := make(map[string]bool)
+	var files []string
+	add := func(p string) {
+		if !seen[p] {
+			seen[p] = true
+			files = append(files, p)
+		}
+	}
+	for _, ref := range s.refs {
+		if ref == "HEAD" {
+			add(filepath.Join(s.gitDir, "HEAD"))
+			// HEAD usually points at a branch; watch that branch's file too.
+			if data, err := os.ReadFile(filepath.Join(s.gitDir, "HEAD")); err == nil {
+				if target, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "ref:"); ok {
+					add(filepath.Join(commonDir, filepath.FromSlash(strings.TrimSpace(target))))
+				}
+			}
+			continue
+		}
+		add(filepath.Join(commonDir, filepath.FromSlash(ref)))
+	}
+	add(filepath.Join(commonDir, "packed-refs"))
+	return files
+}
+
+// Start resolves the current ref values and begins watching.
+func (s *GitRefSource) Start(emit func(Change)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return ErrAlreadyStarted
+	}
+
+	s.last = make(map[string]string, len(s.refs))
+	for _, ref := range s.refs {
+		hash, _ := ResolveRef(s.gitDir, ref)
+		s.last[ref] = hash
+	}
+	s.emit = emit
+
+	if err := s.syncFilesLocked(); err != nil {
+		s.stopFilesLocked()
+		return err
+	}
+	s.started = true
+	return nil
+}
+
+// syncFilesLocked watches exactly the files watchedFiles lists, so a branch
+// switch moves the watch from the old branch's ref file to the new one.
+func (s *GitRefSource) syncFilesLocked() error {
+	want := s.watchedFiles()
+	keep := make(map[string]bool, len(want))
+	for _, path := range want {
+		keep[path] = true
+	}
+	for path, fs := range s.files {
+		if !keep[path] {
+			fs.Stop()
+			delete(s.files, path)
+		}
+	}
+
+	opts := append([]WatcherOption{WithDebounceDuration(SourceDebounce)}, s.opts...)
+	for _, path := range want {
+		if s.files[path] != nil {
+			continue
+		}
+		fs, err := NewFileSource(ChangeGitRef, path, opts...)
+		if err != nil {
+			return err
+		}
+		if err := fs.Start(func(Change) { s.check() }); err != nil {
+			return err
+		}
+		if s.files == nil {
+			s.files = make(map[string]*FileSource)
+		}
+		s.files[path] = fs
+	}
+	return nil
+}
+
+// check re-resolves every ref and emits one Change per ref that moved. It
+// also re-reads HEAD, following a branch switch to the new branch's file.
+func (s *GitRefSource) check() {
+	s.mu.Lock()
+	if !s.started {
+		s.mu.Unlock()
+		return
+	}
+	now := time.Now()
+	var moved []Change
+	for _, ref := range s.refs {
+		hash, err := ResolveRef(s.gitDir, ref)
+		if err != nil {
+			continue // mid-update (lock held); the rename will notify again
+		}
+		if old := s.last[ref]; old != hash {
+			s.last[ref] = hash
+			moved = append(moved, Change{Kind: ChangeGitRef, Source: s.Name(), Ref: ref, OldHash: old, NewHash: hash, At: now})
+		}
+	}
+	_ = s.syncFilesLocked() // best effort; retried on the next change
+	emit := s.emit
+	s.mu.Unlock()
+
+	for _, c := range moved {
+		emit(c)
+	}
+}
+
+// Stop stops watching.
+func (s *GitRefSource) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopFilesLocked()
+	s.started = false
+}
+
+func (s *GitRefSource) stopFilesLocked() {
+	for _, fs := range s.files {
+		fs.Stop()
+	}
+	s.files = nil
+}
+
+// SyncBranch returns the branch bd commits beads to, from BEADS_SYNC_BRANCH or
+// the sync.branch setting in <beadsDir>/config.yaml. It returns "" when no
+// sync branch is configured.
+func SyncBranch(beadsDir string) string {
+	if branch := strings.TrimSpace(os.Getenv("BEADS_SYNC_BRANCH")); branch != "" {
+		return branch
+	}
+	data, err := os.ReadFile(filepath.Join(beadsDir, "config.yaml"))
+	if err != nil {
+		return ""
+	}
+	var cfg map[string]any
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return ""
+	}
+	for _, key := range []string{"sync.branch", "sync-branch"} {
+		if branch, ok := cfg[key].(string); ok && branch != "" {
+			return branch
+		}
+	}
+	if sync, ok := cfg["sync"].(map[string]any); ok {
+		if branch, ok := sync["branch"].(string); ok {
+			return branch
+		}
+	}
+	return ""
+}
+
+// BeadsRefs returns the refs whose movement means beads may have been synced:
+// HEAD plus the local and origin sync branch when one is configured.
+func BeadsRefs(beadsDir string) []string {
+	refs := []string{"HEAD"}
+	if branch := SyncBranch(beadsDir); branch != "" {
+		refs = append(refs, "refs/heads/"+branch, "refs/remotes/origin/"+branch)
+	}
+	return refs
+}
+
+// AuxiliarySources returns the sources that accompany a beads data file: the
+// SQLite database and WAL when beads.db exists next to it, and the beads git
+// refs when the project is a git repository. Missing backends are skipped.
+func AuxiliarySources(beadsPath string, opts ...WatcherOption) []Source {
+	beadsDir := filepath.Dir(beadsPath)
+	var sources []Source
+
+	dbPath := filepath.Join(beadsDir, "beads.db")
+	if dbPath != beadsPath {
+		if _, err := os.Stat(dbPath); err == nil {
+			if src, err := NewSQLiteSource(dbPath, opts...); err == nil {
+				sources = append(sources, src)
+			}
+		}
+	}
+
+	if src, err := NewGitRefSource(beadsDir, BeadsRefs(beadsDir), opts...); err == nil {
+		sources = append(sources, src)
+	}
+	return sources
+}
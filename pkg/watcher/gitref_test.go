@@ -0,0 +1,198 @@
+package watcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const (
+	hashA = "1111111111111111111111111111111111111111"
+	hashB = "2222222222222222222222222222222222222222"
+	hashC = "3333333333333333333333333333333333333333"
+)
+
+// writeGitDir lays out a minimal .git directory with HEAD on main.
+func writeGitDir(t *testing.T) (repo, gitDir string) {
+	t.Helper()
+	repo = t.TempDir()
+	gitDir = filepath.Join(repo, ".git")
+	writeFile(t, filepath.Join(gitDir, "HEAD"), "ref: refs/heads/main\n")
+	writeFile(t, filepath.Join(gitDir, "refs", "heads", "main"), hashA+"\n")
+	return repo, gitDir
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFindGitDir(t *testing.T) {
+	repo, gitDir := writeGitDir(t)
+	nested := filepath.Join(repo, ".beads")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	got, err := FindGitDir(nested)
+	if err != nil || got != gitDir {
+		t.Fatalf("FindGitDir = %q, %v; want %q", got, err, gitDir)
+	}
+
+	// Worktrees use a .git file pointing at the real git dir.
+	wt := t.TempDir()
+	writeFile(t, filepath.Join(wt, ".git"), "gitdir: "+gitDir+"\n")
+	if got, err := FindGitDir(wt); err != nil || got != gitDir {
+		t.Errorf("FindGitDir(worktree) = %q, %v", got, err)
+	}
+}
+
+func TestResolveRef(t *testing.T) {
+	_, gitDir := writeGitDir(t)
+	writeFile(t, filepath.Join(gitDir, "packed-refs"),
+		"# pack-refs with: peeled fully-peeled sorted\n"+hashB+" refs/remotes/origin/beads-sync\n^"+hashC+"\n")
+
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{"HEAD", hashA},
+		{"refs/heads/main", hashA},
+		{"refs/remotes/origin/beads-sync", hashB},
+		{"refs/heads/missing", ""},
+	}
+	for _, tt := range tests {
+		got, err := ResolveRef(gitDir, tt.ref)
+		if err != nil || got != tt.want {
+			t.Errorf("ResolveRef(%s) = %q, %v; want %q", tt.ref, got, err, tt.want)
+		}
+	}
+}
+
+func TestSyncBranchAndBeadsRefs(t *testing.T) {
+	t.Setenv("BEADS_SYNC_BRANCH", "")
+	dir := t.TempDir()
+	if got := BeadsRefs(dir); len(got) != 1 || got[0] != "HEAD" {
+		t.Errorf("expected only HEAD without a sync branch, got %v", got)
+	}
+
+	writeFile(t, filepath.Join(dir, "config.yaml"), "sync:\n  branch: beads-sync\n")
+	if got := SyncBranch(dir); got != "beads-sync" {
+		t.Errorf("SyncBranch from nested config = %q", got)
+	}
+	writeFile(t, filepath.Join(dir, "config.yaml"), "sync.branch: flat\n")
+	if got := SyncBranch(dir); got != "flat" {
+		t.Errorf("SyncBranch from flat key = %q", got)
+	}
+
+	t.Setenv("BEADS_SYNC_BRANCH", "from-env")
+	refs := BeadsRefs(dir)
+	want := []string{"HEAD", "refs/heads/from-env", "refs/remotes/origin/from-env"}
+	if len(refs) != len(want) {
+		t.Fatalf("BeadsRefs = %v, want %v", refs, want)
+	}
+	for i := range want {
+		if refs[i] != want[i] {
+			t.Errorf("BeadsRefs[%d] = %q, want %q", i, refs[i], want[i])
+		}
+	}
+}
+
+func TestGitRefSource_ReportsRefMoves(t *testing.T) {
+	repo, gitDir := writeGitDir(t)
+	src, err := NewGitRefSource(repo, []string{"HEAD", "refs/remotes/origin/beads-sync"}, WithPollInterval(20*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGroup(50*time.Millisecond, src)
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer g.Stop()
+
+	time.Sleep(50 * time.Millisecond)
+	writeFile(t, filepath.Join(gitDir, "refs", "heads", "main"), hashB+"\n")
+	writeFile(t, filepath.Join(gitDir, "refs", "remotes", "origin", "beads-sync"), hashC+"\n")
+
+	deadline := time.After(3 * time.Second)
+	moves := make(map[string]Change)
+	for len(moves) < 2 {
+		select {
+		case ev := <-g.Events():
+			for _, c := range ev.RefMoves() {
+				moves[c.Ref] = c
+			}
+		case <-deadline:
+			t.Fatalf("timed out; got moves %+v", moves)
+		}
+	}
+	if c := moves["HEAD"]; c.OldHash != hashA || c.NewHash != hashB {
+		t.Errorf("HEAD move = %+v", c)
+	}
+	if c := moves["refs/remotes/origin/beads-sync"]; c.OldHash != "" || c.NewHash != hashC {
+		t.Errorf("sync branch move = %+v", c)
+	}
+}
+
+func TestGitRefSource_FollowsBranchSwitch(t *testing.T) {
+	repo, gitDir := writeGitDir(t)
+	writeFile(t, filepath.Join(gitDir, "refs", "heads", "feature"), hashB+"\n")
+	src, err := NewGitRefSource(repo, nil, WithPollInterval(20*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGroup(50*time.Millisecond, src)
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer g.Stop()
+
+	waitMove := func(want string) {
+		t.Helper()
+		deadline := time.After(3 * time.Second)
+		for {
+			select {
+			case ev := <-g.Events():
+				for _, c := range ev.RefMoves() {
+					if c.Ref == "HEAD" && c.NewHash == want {
+						return
+					}
+				}
+			case <-deadline:
+				t.Fatalf("timed out waiting for HEAD to move to %s", want)
+			}
+		}
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	writeFile(t, filepath.Join(gitDir, "HEAD"), "ref: refs/heads/feature\n")
+	waitMove(hashB)
+
+	// A commit on the new branch only touches its ref file.
+	time.Sleep(50 * time.Millisecond)
+	writeFile(t, filepath.Join(gitDir, "refs", "heads", "feature"), hashC+"\n")
+	waitMove(hashC)
+}
+
+func TestAuxiliarySources(t *testing.T) {
+	t.Setenv("BEADS_SYNC_BRANCH", "")
+	repo, _ := writeGitDir(t)
+	beadsPath := filepath.Join(repo, ".beads", "beads.jsonl")
+	writeFile(t, beadsPath, "")
+
+	sources := AuxiliarySources(beadsPath)
+	if len(sources) != 1 || sources[0].Name() != "git:HEAD" {
+		t.Fatalf("expected only the git source without beads.db, got %d", len(sources))
+	}
+
+	writeFile(t, filepath.Join(repo, ".beads", "beads.db"), "")
+	sources = AuxiliarySources(beadsPath)
+	if len(sources) != 2 || sources[0].Name() != "sqlite:beads.db" {
+		t.Errorf("expected sqlite and git sources, got %d", len(sources))
+	}
+}
@@ -0,0 +1,134 @@
+package watcher
+
+import (
+	"sync"
+	"time"
+)
+
+// Group fans in several Sources and coalesces everything they report within
+// a window into a single ChangeEvent. A `git pull` that rewrites the beads
+// file, touches the SQLite WAL and moves refs therefore produces one event
+// describing all of it instead of a reload per file.
+type Group struct {
+	sources   []Source
+	debouncer *Debouncer
+
+	mu      sync.Mutex
+	pending ChangeEvent
+	started bool
+
+	deliverMu sync.Mutex
+	events    chan ChangeEvent
+	changed   chan struct{}
+}
+
+// NewGroup creates a Group that coalesces changes within window.
+// If window is 0, DefaultDebounceDuration is used.
+func NewGroup(window time.Duration, sources ...Source) *Group {
+	return &Group{
+		sources:   append([]Source(nil), sources...),
+		debouncer: NewDebouncer(window),
+		events:    make(chan ChangeEvent, 1),
+		changed:   make(chan struct{}, 1),
+	}
+}
+
+// Add registers another source. It must be called before Start.
+func (g *Group) Add(sources ...Source) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.sources = append(g.sources, sources...)
+}
+
+// Sources returns the names of the registered sources.
+func (g *Group) Sources() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	names := make([]string, 0, len(g.sources))
+	for _, s := range g.sources {
+		names = append(names, s.Name())
+	}
+	return names
+}
+
+// Start starts every source. If one fails, the ones already started are
+// stopped again and the error is returned.
+func (g *Group) Start() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.started {
+		return ErrAlreadyStarted
+	}
+	for i, s := range g.sources {
+		if err := s.Start(g.emit); err != nil {
+			for _, started := range g.sources[:i] {
+				started.Stop()
+			}
+			return err
+		}
+	}
+	g.started = true
+	return nil
+}
+
+// Stop stops every source and drops any change not yet delivered.
+func (g *Group) Stop() {
+	g.mu.Lock()
+	sources := g.sources
+	g.started = false
+	g.pending = ChangeEvent{}
+	g.mu.Unlock()
+
+	for _, s := range sources {
+		s.Stop()
+	}
+	g.debouncer.Cancel()
+}
+
+// Events returns the channel of coalesced change events. If the previous
+// event has not been received yet, new changes are merged into it.
+func (g *Group) Events() <-chan ChangeEvent {
+	return g.events
+}
+
+// Changed returns a channel signalled once per delivered event, for callers
+// that only need to know that something changed (mirrors Watcher.Changed).
+func (g *Group) Changed() <-chan struct{} {
+	return g.changed
+}
+
+func (g *Group) emit(c Change) {
+	g.mu.Lock()
+	if !g.started {
+		g.mu.Unlock()
+		return
+	}
+	g.pending.add(c)
+	g.mu.Unlock()
+	g.debouncer.Trigger(g.flush)
+}
+
+func (g *Group) flush() {
+	g.mu.Lock()
+	ev := g.pending
+	g.pending = ChangeEvent{}
+	g.mu.Unlock()
+	if len(ev.Changes) == 0 {
+		return
+	}
+
+	g.deliverMu.Lock()
+	defer g.deliverMu.Unlock()
+	select {
+	case prev := <-g.events:
+		prev.Merge(ev)
+		ev = prev
+	default:
+	}
+	g.events <- ev
+
+	select {
+	case g.changed <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,151 @@
+package watcher
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeSource is a Source driven directly by the test.
+type fakeSource struct {
+	name     string
+	startErr error
+	emit     func(Change)
+	stopped  int
+}
+
+func (f *fakeSource) Name() string { return f.name }
+
+func (f *fakeSource) Start(emit func(Change)) error {
+	if f.startErr != nil {
+		return f.startErr
+	}
+	f.emit = emit
+	return nil
+}
+
+func (f *fakeSource) Stop() { f.stopped++ }
+
+func TestGroup_CoalescesChangesIntoOneEvent(t *testing.T) {
+	file := &fakeSource{name: "file:beads.jsonl"}
+	ref := &fakeSource{name: "git:HEAD"}
+	g := NewGroup(50*time.Millisecond, file, ref)
+	if err := g.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer g.Stop()
+
+	now := time.Now()
+	file.emit(Change{Kind: ChangeFile, Path: "/p/beads.jsonl", At: now})
+	ref.emit(Change{Kind: ChangeGitRef, Ref: "HEAD", OldHash: "aaaaaaaaaa", NewHash: "bbbbbbbbbb", At: now})
+	file.emit(Change{Kind: ChangeFile, Path: "/p/beads.jsonl", At: now.Add(time.Millisecond)})
+	ref.emit(Change{Kind: ChangeGitRef, Ref: "HEAD", OldHash: "bbbbbbbbbb", NewHash: "cccccccccc", At: now.Add(time.Millisecond)})
+
+	select {
+	case ev := <-g.Events():
+		if len(ev.Changes) != 2 {
+			t.Fatalf("expected 2 coalesced changes, got %+v", ev.Changes)
+		}
+		moves := ev.RefMoves()
+		if len(moves) != 1 || moves[0].OldHash != "aaaaaaaaaa" || moves[0].NewHash != "cccccccccc" {
+			t.Errorf("expected merged ref move a→c, got %+v", moves)
+		}
+		if !ev.Has(ChangeFile) || ev.Has(ChangeSQLite) {
+			t.Errorf("unexpected kinds in %+v", ev)
+		}
+		if got := ev.String(); got != "beads.jsonl, HEAD aaaaaaa→ccccccc" {
+			t.Errorf("unexpected summary %q", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+
+	select {
+	case <-g.Changed():
+	case <-time.After(time.Second):
+		t.Fatal("Changed should be signalled alongside Events")
+	}
+}
+
+func TestGroup_MergesIntoUnconsumedEvent(t *testing.T) {
+	src := &fakeSource{name: "file"}
+	g := NewGroup(10*time.Millisecond, src)
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer g.Stop()
+
+	src.emit(Change{Kind: ChangeFile, Path: "/a", At: time.Now()})
+	time.Sleep(50 * time.Millisecond)
+	src.emit(Change{Kind: ChangeFile, Path: "/b", At: time.Now()})
+	time.Sleep(50 * time.Millisecond)
+
+	ev := <-g.Events()
+	if len(ev.Changes) != 2 {
+		t.Errorf("expected both changes in the pending event, got %+v", ev.Changes)
+	}
+}
+
+func TestGroup_StartFailureStopsStartedSources(t *testing.T) {
+	ok := &fakeSource{name: "ok"}
+	bad := &fakeSource{name: "bad", startErr: errors.New("boom")}
+	g := NewGroup(0, ok, bad)
+	if err := g.Start(); err == nil {
+		t.Fatal("expected start error")
+	}
+	if ok.stopped != 1 {
+		t.Errorf("started source should be stopped on failure, stopped=%d", ok.stopped)
+	}
+	if names := g.Sources(); len(names) != 2 || names[0] != "ok" {
+		t.Errorf("unexpected sources %v", names)
+	}
+}
+
+func TestGroup_FileSourceEndToEnd(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "beads.jsonl")
+	if err := os.WriteFile(path, []byte("{}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	src, err := NewFileSource(ChangeFile, path, WithPollInterval(20*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGroup(30*time.Millisecond, src)
+	if err := g.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer g.Stop()
+
+	time.Sleep(50 * time.Millisecond)
+	if err := os.WriteFile(path, []byte("{}\n{}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case ev := <-g.Events():
+		if len(ev.Changes) != 1 || ev.Changes[0].Path != src.Watcher().Path() || ev.Changes[0].Kind != ChangeFile {
+			t.Errorf("unexpected event %+v", ev)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for file change")
+	}
+}
+
+func TestChange_String(t *testing.T) {
+	tests := []struct {
+		c    Change
+		want string
+	}{
+		{Change{Kind: ChangeFile, Path: "/p/.beads/beads.jsonl"}, "beads.jsonl"},
+		{Change{Kind: ChangeGitRef, Ref: "refs/remotes/origin/beads-sync", NewHash: "abcdef0123"}, "origin/beads-sync ∅→abcdef0"},
+		{Change{Kind: ChangeGitRef, Ref: "refs/heads/main", OldHash: "abc", NewHash: "def"}, "main abc→def"},
+	}
+	for _, tt := range tests {
+		if got := tt.c.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
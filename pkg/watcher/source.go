@@ -0,0 +1,261 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SourceDebounce is the debounce applied by individual sources inside a Group.
+// It only absorbs the burst of events a single write produces; cross-source
+// coalescing is done by the Group's window.
+const SourceDebounce = 25 * time.Millisecond
+
+// ChangeKind classifies what a Change refers to.
+type ChangeKind string
+
+const (
+	ChangeFile   ChangeKind = "file"    // A watched data file (e.g. beads.jsonl) changed
+	ChangeSQLite ChangeKind = "sqlite"  // The SQLite database or its WAL changed
+	ChangeGitRef ChangeKind = "git_ref" // A git ref moved (OldHash → NewHash)
+)
+
+// Change describes a single observed change from a Source.
+type Change struct {
+	Kind    ChangeKind `json:"kind"`
+	Source  string     `json:"source"`
+	Path    string     `json:"path,omitempty"`
+	Ref     string     `json:"ref,omitempty"`
+	OldHash string     `json:"old,omitempty"`
+	NewHash string     `json:"new,omitempty"`
+	At      time.Time  `json:"at"`
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case ChangeGitRef:
+		return fmt.Sprintf("%s %s→%s", shortRef(c.Ref), shortHash(c.OldHash), shortHash(c.NewHash))
+	default:
+		return filepath.Base(c.Path)
+	}
+}
+
+// shortRef trims the refs/heads/ and refs/remotes/ prefixes like git does.
+func shortRef(ref string) string {
+	for _, prefix := range []string{"refs/heads/", "refs/remotes/"} {
+		if short, ok := strings.CutPrefix(ref, prefix); ok {
+			return short
+		}
+	}
+	return ref
+}
+
+func shortHash(h string) string {
+	if h == "" {
+		return "∅"
+	}
+	if len(h) > 7 {
+		return h[:7]
+	}
+	return h
+}
+
+// ChangeEvent is a coalesced batch of changes delivered by a Group.
+// File changes are de-duplicated by path; ref changes are merged per ref so
+// OldHash is the value before the batch and NewHash the value after it.
+type ChangeEvent struct {
+	Changes []Change  `json:"changes"`
+	First   time.Time `json:"first"`
+	Last    time.Time `json:"last"`
+}
+
+// Has reports whether the event includes a change of the given kind.
+func (e ChangeEvent) Has(kind ChangeKind) bool {
+	for _, c := range e.Changes {
+		if c.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// RefMoves returns the ref changes in the event.
+func (e ChangeEvent) RefMoves() []Change {
+	var out []Change
+	for _, c := range e.Changes {
+		if c.Kind == ChangeGitRef {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// String summarizes the event, e.g. "beads.jsonl, HEAD 1a2b3c4→5d6e7f8".
+func (e ChangeEvent) String() string {
+	parts := make([]string, 0, len(e.Changes))
+	for _, c := range e.Changes {
+		parts = append(parts, c.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (e *ChangeEvent) add(c Change) {
+	if e.First.IsZero() || c.At.Before(e.First) {
+		e.First = c.At
+	}
+	if c.At.After(e.Last) {
+		e.Last = c.At
+	}
+	for i := range e.Changes {
+		existing := &e.Changes[i]
+		if existing.Kind != c.Kind {
+			continue
+		}
+		switch {
+		case c.Kind == ChangeGitRef && existing.Ref == c.Ref:
+			existing.NewHash = c.NewHash
+			existing.At = c.At
+			return
+		case c.Kind != ChangeGitRef && existing.Path == c.Path:
+			existing.At = c.At
+			return
+		}
+	}
+	e.Changes = append(e.Changes, c)
+}
+
+// Merge folds other into e, keeping per-path and per-ref de-duplication.
+func (e *ChangeEvent) Merge(other ChangeEvent) {
+	for _, c := range other.Changes {
+		e.add(c)
+	}
+}
+
+// Source is a pluggable change backend for a Group.
+type Source interface {
+	// Name identifies the source in change events and logs.
+	Name() string
+	// Start begins watching; changes are reported through emit until Stop.
+	Start(emit func(Change)) error
+	// Stop releases resources. It is safe to call more than once, and a
+	// stopped source may be started again.
+	Stop()
+}
+
+// FileSource adapts a Watcher to the Source interface.
+type FileSource struct {
+	kind ChangeKind
+	w    *Watcher
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+// NewFileSource watches path and reports changes of the given kind.
+func NewFileSource(kind ChangeKind, path string, opts ...WatcherOption) (*FileSource, error) {
+	opts = append([]WatcherOption{WithDebounceDuration(SourceDebounce)}, opts...)
+	w, err := NewWatcher(path, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return WrapWatcher(kind, w), nil
+}
+
+// WrapWatcher adapts an existing (not yet started) Watcher. The source owns
+// the watcher's lifecycle and consumes its Changed channel.
+func WrapWatcher(kind ChangeKind, w *Watcher) *FileSource {
+	return &FileSource{kind: kind, w: w}
+}
+
+// Name returns "<kind>:<file name>".
+func (s *FileSource) Name() string {
+	return string(s.kind) + ":" + filepath.Base(s.w.Path())
+}
+
+// Watcher returns the underlying file watcher.
+func (s *FileSource) Watcher() *Watcher {
+	return s.w
+}
+
+// Start starts the underlying watcher and forwards its notifications.
+func (s *FileSource) Start(emit func(Change)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return ErrAlreadyStarted
+	}
+	if err := s.w.Start(); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	changed := s.w.Changed()
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-changed:
+				emit(Change{Kind: s.kind, Source: s.Name(), Path: s.w.Path(), At: time.Now()})
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops the underlying watcher.
+func (s *FileSource) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.cancel = nil
+	s.w.Stop()
+}
+
+// multiSource groups several sources under one name, e.g. the SQLite database
+// and its WAL file.
+type multiSource struct {
+	name    string
+	sources []Source
+}
+
+func (m *multiSource) Name() string { return m.name }
+
+func (m *multiSource) Start(emit func(Change)) error {
+	for i, s := range m.sources {
+		if err := s.Start(emit); err != nil {
+			for _, started := range m.sources[:i] {
+				started.Stop()
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *multiSource) Stop() {
+	for _, s := range m.sources {
+		s.Stop()
+	}
+}
+
+// NewSQLiteSource watches a SQLite database and its write-ahead log. With WAL
+// journaling most commits only touch the -wal file, so watching the main
+// database alone misses writes until the next checkpoint.
+func NewSQLiteSource(dbPath string, opts ...WatcherOption) (Source, error) {
+	db, err := NewFileSource(ChangeSQLite, dbPath, opts...)
+	if err != nil {
+		return nil, err
+	}
+	wal, err := NewFileSource(ChangeSQLite, dbPath+"-wal", opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &multiSource{name: "sqlite:" + filepath.Base(dbPath), sources: []Source{db, wal}}, nil
+}